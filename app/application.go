@@ -7,6 +7,7 @@ import (
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
 	"github.com/linnoxlewis/trade-bot/internal/heartbeat"
 	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/eventbus"
 	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
 	"github.com/linnoxlewis/trade-bot/internal/pkg/telegram"
 	"github.com/linnoxlewis/trade-bot/internal/repository"
@@ -24,6 +25,9 @@ import (
 	"time"
 )
 
+// reliableEventTimeout bounds how long an order event waits for the risk, strategy and price feed listeners.
+const reliableEventTimeout = 5 * time.Second
+
 func Run(ctx context.Context) {
 	sgn := make(chan os.Signal, 1)
 	cfg := config.NewConfig()
@@ -41,83 +45,201 @@ func Run(ctx context.Context) {
 	database := db.StartDB(cfg, logger)
 	defer db.CloseDB(ctx, database, logger)
 
-	binanceQueue := domain.NewOrderQueue(consts.Binance)
-	limitBinanceQueue := domain.NewOrderQueue(consts.Binance)
+	orderEvents := eventbus.New(logger)
 
-	exchangePkg := exchanger.NewExchanger(cfg.GetTestnet())
+	tpSlQueues := domain.NewExchangeQueues(exchanger.ExchangeList)
+	limitQueues := domain.NewExchangeQueues(exchanger.ExchangeList)
 
 	apiKeysRepo := repository.NewApiKeyRepo(database)
 	userRepo := repository.NewUserRepository(database)
 	orderRepo := repository.NewOrderRepository(database)
 	symbolsRepo := repository.NewSymbolRepository(database)
+	paperRepo := repository.NewPaperRepository(database)
+	dealRepo := repository.NewDealRepository(database)
+	riskLimitsRepo := repository.NewRiskLimitsRepository(database)
+	strategyRepo := repository.NewStrategyRepository(database)
+	alertRepo := repository.NewPriceAlertRepository(database)
+
+	priceSource := exchanger.NewCachePriceSource(keyDb)
+	exchangePkg := exchanger.NewPaperExchanger(exchanger.NewExchanger(cfg.GetTestnet()),
+		paperRepo,
+		priceSource,
+		cfg.GetPaperInitialBalance())
+	symbolFilters := exchanger.NewSymbolFilterCache(exchangePkg, cfg.GetSymbolFiltersTtl())
+	priceFeed := heartbeat.NewPriceFeed(priceSource, heartbeat.ExchangeTradeStreamOpener(), logger, tpSlQueues, limitQueues)
+	defer priceFeed.Close()
+
+	tg := tgCli.New(cfg.GetTgToken())
+	notifier := telegram.NewNotifier(tg, i18n, logger)
 
 	userSrv := service.NewUserService(cfg, userRepo, i18n, logger)
+	riskSrv := service.NewRiskService(cfg, riskLimitsRepo, dealRepo, priceSource, userSrv, notifier, i18n, logger)
 	apiKeySrv := service.NewApiKeysService(cfg, apiKeysRepo, userRepo, i18n, logger)
-	orderSrv := service.NewOrder(cfg, exchangePkg, apiKeysRepo, orderRepo, binanceQueue, limitBinanceQueue, keyDb, i18n, logger)
+	orderSrv := service.NewOrder(cfg,
+		exchangePkg,
+		symbolFilters,
+		riskSrv,
+		apiKeysRepo,
+		orderRepo,
+		tpSlQueues,
+		limitQueues,
+		keyDb,
+		orderEvents,
+		priceFeed,
+		i18n,
+		logger)
 	symbolSrv := service.NewSymbolService(orderRepo, symbolsRepo, logger)
-	accountSrv := service.NewAccountService(cfg, apiKeysRepo, exchangePkg, i18n, logger)
+	accountSrv := service.NewAccountService(cfg, apiKeysRepo, exchangePkg, priceSource, i18n, logger)
+	dealSrv := service.NewDealService(cfg, dealRepo, i18n, logger)
+	pnlSrv := service.NewPnlService(cfg, dealRepo, priceSource, i18n, logger)
+	strategySrv := service.NewStrategyService(cfg, strategyRepo, orderSrv, priceSource, i18n, logger)
+	alertSrv := service.NewAlertService(cfg, alertRepo, priceSource, priceFeed, notifier, i18n, logger)
+	if err := alertSrv.LoadAlerts(ctx); err != nil {
+		logger.ErrorLog.Println("cant load price alerts: ", err)
+	}
+	priceFeed.AddDemand(alertSrv)
 
 	admins, err := userSrv.GetAdmins(ctx)
 	if err != nil {
 		//panic("cant get admin list")
 	}
 
-	tg := tgCli.New(cfg.GetTgToken())
-	tgBot := telegram.New(tg, userSrv, orderSrv, accountSrv, i18n, admins, logger, 100)
+	tgBot := telegram.New(tg,
+		userSrv,
+		orderSrv,
+		accountSrv,
+		dealSrv,
+		pnlSrv,
+		strategySrv,
+		alertSrv,
+		i18n,
+		admins,
+		cfg.GetJwtSecret(),
+		cfg.GetJwtTtl(),
+		logger,
+		100)
 	go func() {
 		if err := tgBot.Start(ctx); err != nil {
 			panic("service is stopped " + err.Error())
 		}
 	}()
 
-	grpcSrv := grpcServer.NewGrpc(cfg.GetGrpcPort(), *logger)
+	tgEvents, unsubscribeTg := orderEvents.Subscribe(100)
+	defer unsubscribeTg()
+	go notifier.Listen(ctx, tgEvents)
+
+	riskEvents, unsubscribeRisk := orderEvents.SubscribeReliable(100, reliableEventTimeout)
+	defer unsubscribeRisk()
+	go riskSrv.Listen(ctx, riskEvents, time.Minute)
+
+	strategyEvents, unsubscribeStrategy := orderEvents.SubscribeReliable(100, reliableEventTimeout)
+	defer unsubscribeStrategy()
+	go strategySrv.Listen(ctx, strategyEvents, time.Minute)
+
+	feedEvents, unsubscribeFeed := orderEvents.SubscribeReliable(100, reliableEventTimeout)
+	defer unsubscribeFeed()
+	go priceFeed.Listen(ctx, feedEvents)
+
+	grpcSrv, err := grpcServer.NewGrpc(cfg.GetGrpcPort(),
+		cfg.GetJwtSecret(),
+		orderSrv,
+		accountSrv,
+		pnlSrv,
+		orderEvents,
+		*logger)
+	if err != nil {
+		panic("cant start grpc server: " + err.Error())
+	}
 	go grpcSrv.StartServer()
 	defer grpcSrv.StopServer()
 
-	restSrv := restServer.NewApiServer(cfg.GetApiPort(), cfg.GetApiServerMode(), apiKeySrv, logger)
+	restSrv, err := restServer.NewApiServer(cfg.GetApiPort(),
+		cfg.GetApiServerMode(),
+		cfg.GetJwtSecret(),
+		apiKeySrv,
+		orderSrv,
+		accountSrv,
+		dealSrv,
+		pnlSrv,
+		riskSrv,
+		logger)
+	if err != nil {
+		panic("cant start rest server: " + err.Error())
+	}
 	go restSrv.StartServer()
 	defer restSrv.StopServer()
 
-	symbols, _ := symbolSrv.GetActiveSymbols(ctx)
-	if symbols.IsEmpty() {
-		var err error
-		symbols, err = symbolSrv.GetDefaultSymbols(ctx)
-		if err != nil || symbols == nil {
+	symbols := make(map[string][]string)
+	activeSymbols, _ := symbolSrv.GetActiveSymbols(ctx)
+	for _, v := range activeSymbols {
+		symbols[v.Exchange] = append(symbols[v.Exchange], v.Symbol)
+	}
+	if len(activeSymbols) == 0 {
+		defaultSymbols, err := symbolSrv.GetDefaultSymbols(ctx)
+		if err != nil || defaultSymbols == nil {
 			panic("cant get symbols for ticker")
 		}
+		for _, exchange := range exchanger.ExchangeList {
+			for _, v := range defaultSymbols {
+				symbols[exchange] = append(symbols[exchange], string(v))
+			}
+		}
+	}
+
+	alertSymbols, _ := alertSrv.GetAlertSymbols(ctx)
+	for _, v := range alertSymbols {
+		if err := priceFeed.Subscribe(v.Exchange, v.Symbol); err != nil {
+			logger.ErrorLog.Println("cant start alert price ticker: ", v.Exchange, v.Symbol, err)
+		}
 	}
 
 	for _, v := range exchanger.ExchangeList {
 		exchange := v
-		for _, symbolVal := range symbols {
-			priceTicker, err := heartbeat.NewTradePriceTicker(cfg,
-				keyDb,
-				exchange,
-				string(symbolVal),
-				logger)
-			if err != nil {
-				continue
+		for _, symbolVal := range symbols[exchange] {
+			if err := priceFeed.Subscribe(exchange, symbolVal); err != nil {
+				logger.ErrorLog.Println("cant start price ticker: ", exchange, symbolVal, err)
 			}
-			go priceTicker.HandleWebSocketData(ctx)
 		}
 
+		alertTicker := heartbeat.NewAlertTicker(cfg,
+			alertSrv,
+			priceSource,
+			logger,
+			time.Second,
+			exchange)
+		go alertTicker.Tick(ctx, sgn)
+
 		tpSlTicker := heartbeat.NewTpSlTicker(cfg,
 			orderSrv,
-			keyDb,
+			priceSource,
+			userSrv,
+			notifier,
 			logger,
-			tg,
 			time.Second,
-			binanceQueue,
-			v,
-			i18n,
+			tpSlQueues.Get(exchange),
+			exchange,
 			true)
+		priceFeed.AddListener(tpSlTicker)
 		go tpSlTicker.Tick(ctx, sgn)
 
+		var userStreams heartbeat.UserStreamStatus
+		if exchange == consts.Binance {
+			binanceStreams := heartbeat.NewUserDataStreams(orderSrv,
+				limitQueues.Get(exchange),
+				heartbeat.BinanceUserStreamOpener(cfg.GetTestnet()),
+				logger,
+				exchange,
+				time.Second*5)
+			go binanceStreams.Tick(ctx, sgn)
+			userStreams = binanceStreams
+		}
+
 		checkLimitOrders := heartbeat.NewLimitOrderTicker(cfg,
 			orderSrv,
-			limitBinanceQueue,
+			limitQueues.Get(exchange),
+			userStreams,
 			logger,
-			v,
+			exchange,
 			time.Second*5)
 		go checkLimitOrders.Tick(ctx, sgn)
 	}
@@ -0,0 +1,77 @@
+package cmd
+
+import (
+	"errors"
+	"os"
+
+	"github.com/linnoxlewis/trade-bot/internal/backtest"
+	"github.com/spf13/cobra"
+)
+
+const (
+	backtestFormatTable = "table"
+	backtestFormatJson  = "json"
+)
+
+var (
+	backtestDataPath   string
+	backtestSymbol     string
+	backtestOrdersPath string
+	backtestFormat     string
+	backtestKlinePath  string
+)
+
+var errBacktestFormat = errors.New("err unknown backtest output format")
+
+var backtestCmd = &cobra.Command{
+	Use:   "backtest",
+	Short: "Replay historical trades/klines through the TP/SL engine",
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if backtestFormat != backtestFormatTable && backtestFormat != backtestFormatJson {
+			return errBacktestFormat
+		}
+
+		dataFile, err := os.Open(backtestDataPath)
+		if err != nil {
+			return err
+		}
+		defer dataFile.Close()
+
+		ticks, err := backtest.LoadTicks(dataFile, backtest.KlinePath(backtestKlinePath))
+		if err != nil {
+			return err
+		}
+
+		ordersFile, err := os.Open(backtestOrdersPath)
+		if err != nil {
+			return err
+		}
+		defer ordersFile.Close()
+
+		orders, err := backtest.LoadOrders(ordersFile, backtestSymbol)
+		if err != nil {
+			return err
+		}
+
+		reports := backtest.Run(ticks, orders)
+		if backtestFormat == backtestFormatJson {
+			return backtest.WriteJSON(cmd.OutOrStdout(), reports)
+		}
+
+		return backtest.WriteTable(cmd.OutOrStdout(), reports)
+	},
+}
+
+func init() {
+	backtestCmd.Flags().StringVarP(&backtestDataPath, "data", "d", "", "CSV with trades (time,price) or klines (open_time,open,high,low,close,...)")
+	backtestCmd.Flags().StringVarP(&backtestSymbol, "symbol", "s", "", "symbol of the data, orders for other symbols are rejected")
+	backtestCmd.Flags().StringVarP(&backtestOrdersPath, "orders", "o", "", "JSON array of orders in the telegram create format")
+	backtestCmd.Flags().StringVarP(&backtestFormat, "format", "f", backtestFormatTable, "output format: table or json")
+	backtestCmd.Flags().StringVar(&backtestKlinePath, "kline-path", string(backtest.KlinePathAuto),
+		"order of kline high and low: auto (bullish low first, bearish high first), high-first or low-first")
+	_ = backtestCmd.MarkFlagRequired("data")
+	_ = backtestCmd.MarkFlagRequired("symbol")
+	_ = backtestCmd.MarkFlagRequired("orders")
+
+	rootCmd.AddCommand(backtestCmd)
+}
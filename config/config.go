@@ -2,12 +2,18 @@ package config
 
 import (
 	"github.com/spf13/viper"
+	"time"
 )
 
 type Config struct{}
 
 func NewConfig() *Config {
 	viper.AutomaticEnv()
+	viper.SetDefault("JWT_TTL", time.Hour)
+	viper.SetDefault("TRADE_FEE_PERCENT", "0.1")
+	viper.SetDefault("BALANCE_DUST_USDT", "1")
+	viper.SetDefault("SYMBOL_FILTERS_TTL", time.Hour)
+	viper.SetDefault("PRICE_STALE_THRESHOLD", time.Minute)
 
 	return &Config{}
 }
@@ -79,3 +85,31 @@ func (c *Config) GetTgToken() string {
 func (c *Config) GetTestnet() bool {
 	return viper.GetBool("TESTNET")
 }
+
+func (c *Config) GetPaperInitialBalance() string {
+	return viper.GetString("PAPER_INITIAL_BALANCE")
+}
+
+func (c *Config) GetJwtSecret() string {
+	return viper.GetString("JWT_SECRET")
+}
+
+func (c *Config) GetJwtTtl() time.Duration {
+	return viper.GetDuration("JWT_TTL")
+}
+
+func (c *Config) GetTradeFeePercent() string {
+	return viper.GetString("TRADE_FEE_PERCENT")
+}
+
+func (c *Config) GetBalanceDustValue() string {
+	return viper.GetString("BALANCE_DUST_USDT")
+}
+
+func (c *Config) GetSymbolFiltersTtl() time.Duration {
+	return viper.GetDuration("SYMBOL_FILTERS_TTL")
+}
+
+func (c *Config) GetPriceStaleThreshold() time.Duration {
+	return viper.GetDuration("PRICE_STALE_THRESHOLD")
+}
@@ -0,0 +1,475 @@
+package backtest
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+)
+
+const (
+	StatusClosed    = "closed"
+	StatusOpen      = "open"
+	StatusNotFilled = "not_filled"
+
+	klineMinColumns = 5
+	decimalPlaces   = 8
+)
+
+// KlinePath is the assumed order in which a kline visits its high and low, klines hide the real one.
+type KlinePath string
+
+const (
+	// KlinePathAuto walks a bullish kline open, low, high, close and a bearish one open, high, low, close.
+	KlinePathAuto      KlinePath = "auto"
+	KlinePathHighFirst KlinePath = "high-first"
+	KlinePathLowFirst  KlinePath = "low-first"
+)
+
+var (
+	errEmptyData  = errors.New("err backtest data is empty")
+	errTickFormat = errors.New("err invalid backtest data row")
+	errKlinePath  = errors.New("err unknown kline path")
+	errSymbol     = errors.New("err order symbol differs from backtest data symbol")
+)
+
+type Tick struct {
+	Time  time.Time
+	Price string
+}
+
+type Report struct {
+	Index         int        `json:"index"`
+	Symbol        string     `json:"symbol"`
+	Side          string     `json:"side"`
+	OrderType     string     `json:"orderType"`
+	Quantity      string     `json:"quantity"`
+	Status        string     `json:"status"`
+	EntryTime     *time.Time `json:"entryTime,omitempty"`
+	EntryPrice    string     `json:"entryPrice,omitempty"`
+	TpPrice       string     `json:"tpPrice,omitempty"`
+	SlPrice       string     `json:"slPrice,omitempty"`
+	ExitTime      *time.Time `json:"exitTime,omitempty"`
+	ExitPrice     string     `json:"exitPrice,omitempty"`
+	Leg           string     `json:"leg,omitempty"`
+	TrailingMoves int        `json:"trailingMoves"`
+	PnL           string     `json:"pnl"`
+	PnLPercent    string     `json:"pnlPercent"`
+	MAE           string     `json:"mae"`
+	MAEPercent    string     `json:"maePercent"`
+}
+
+// LoadTicks reads trades as they are and expands every kline into four ticks along the path.
+func LoadTicks(r io.Reader, path KlinePath) ([]Tick, error) {
+	if path != KlinePathAuto && path != KlinePathHighFirst && path != KlinePathLowFirst {
+		return nil, errKlinePath
+	}
+
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	ticks := make([]Tick, 0)
+	for line := 1; ; line++ {
+		record, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		rowTicks, err := parseRecord(record, path)
+		if err != nil {
+			if line == 1 {
+				continue
+			}
+
+			return nil, fmt.Errorf("%w: line %d", err, line)
+		}
+		ticks = append(ticks, rowTicks...)
+	}
+	if len(ticks) == 0 {
+		return nil, errEmptyData
+	}
+
+	return ticks, nil
+}
+
+// LoadOrders reads the orders to replay, ticks carry no symbol so every order must be for the symbol of the data.
+func LoadOrders(r io.Reader, symbol string) ([]*dto.Order, error) {
+	var orders []*dto.Order
+	if err := json.NewDecoder(r).Decode(&orders); err != nil {
+		return nil, err
+	}
+
+	symbol = strings.TrimSpace(strings.ToUpper(symbol))
+	for i, order := range orders {
+		order.Side = strings.ToUpper(order.Side)
+		order.OrderType = strings.ToUpper(order.OrderType)
+		order.Symbol = strings.TrimSpace(strings.ToUpper(order.Symbol))
+		if order.Exchange == "" {
+			order.Exchange = consts.Binance
+		}
+		if err := order.Validate(); err != nil {
+			return nil, fmt.Errorf("order %d: %w", i, err)
+		}
+		if order.Symbol != symbol {
+			return nil, fmt.Errorf("order %d: %w: %s", i, errSymbol, order.Symbol)
+		}
+	}
+
+	return orders, nil
+}
+
+func Run(ticks []Tick, orders []*dto.Order) []*Report {
+	reports := make([]*Report, 0, len(orders))
+	for i, order := range orders {
+		reports = append(reports, replay(i, ticks, order))
+	}
+
+	return reports
+}
+
+func WriteJSON(w io.Writer, reports []*Report) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(reports)
+}
+
+func WriteTable(w io.Writer, reports []*Report) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "#\tSYMBOL\tSIDE\tTYPE\tQTY\tSTATUS\tENTRY\tEXIT\tLEG\tPNL\tPNL %\tMAE\tMAE %")
+
+	total := new(big.Float)
+	for _, r := range reports {
+		fmt.Fprintf(tw, "%d\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\t%s\n",
+			r.Index,
+			r.Symbol,
+			r.Side,
+			r.OrderType,
+			r.Quantity,
+			r.Status,
+			dash(r.EntryPrice),
+			dash(r.ExitPrice),
+			dash(r.Leg),
+			r.PnL,
+			r.PnLPercent,
+			r.MAE,
+			r.MAEPercent)
+		if pnl := helper.StringToBigFloat(r.PnL); pnl != nil {
+			total.Add(total, pnl)
+		}
+	}
+	fmt.Fprintf(tw, "\t\t\t\t\t\t\t\tTOTAL\t%s\t\t\t\n", formatDecimal(total))
+
+	return tw.Flush()
+}
+
+func replay(index int, ticks []Tick, order *dto.Order) *Report {
+	report := &Report{
+		Index:      index,
+		Symbol:     order.Symbol,
+		Side:       order.Side,
+		OrderType:  order.OrderType,
+		Quantity:   order.Quantity,
+		Status:     StatusNotFilled,
+		PnL:        "0",
+		PnLPercent: "0",
+		MAE:        "0",
+		MAEPercent: "0",
+	}
+
+	entryIdx, entryPrice := findEntry(ticks, order)
+	if entryIdx < 0 {
+		return report
+	}
+	entryTime := ticks[entryIdx].Time
+	report.EntryTime = &entryTime
+	report.EntryPrice = entryPrice
+	report.Status = StatusOpen
+
+	baseOrder := &domain.Order{
+		Symbol:    order.Symbol,
+		Side:      order.Side,
+		OrderType: order.OrderType,
+		Quantity:  order.Quantity,
+		Price:     entryPrice,
+		TpSl:      consts.BaseOrderType,
+	}
+	settings := &domain.Settings{
+		TpPercent:   order.TpPercent,
+		SlPercent:   order.SlPercent,
+		TpPrice:     order.TpPrice,
+		SlPrice:     order.SlPrice,
+		Ts:          order.Ts,
+		SlBreakeven: order.SlBreakeven,
+	}
+	for _, v := range order.TpLevels {
+		settings.TpLevels = append(settings.TpLevels, domain.TpLevel{Percent: v.Percent, Price: v.Price, Share: v.Share})
+	}
+	tpOrders, slOrder := newTpSlOrders(baseOrder, settings)
+	tpPrices := make([]string, 0, len(tpOrders))
+	for _, v := range tpOrders {
+		tpPrices = append(tpPrices, v.Price)
+	}
+	report.TpPrice = strings.Join(tpPrices, ",")
+	if slOrder != nil {
+		report.SlPrice = slOrder.Price
+	}
+
+	entry := helper.StringToBigFloat(entryPrice)
+	worst := new(big.Float).Copy(entry)
+	remaining := helper.StringToBigFloat(order.Quantity)
+	pnl := new(big.Float)
+	exitValue := new(big.Float)
+	exitIdx := len(ticks) - 1
+
+	exit := func(i int, tpSlOrder *domain.Order, price, quantity *big.Float) {
+		pnl.Add(pnl, profit(order.Side, entry, price, quantity))
+		exitValue.Add(exitValue, new(big.Float).Mul(price, quantity))
+		remaining.Sub(remaining, quantity)
+		exitIdx = i
+		report.Leg = tpSlOrder.TpSl
+	}
+
+	for i := entryIdx + 1; i < len(ticks) && remaining.Sign() > 0; i++ {
+		price := helper.StringToBigFloat(ticks[i].Price)
+		if price == nil {
+			continue
+		}
+		if isAdverse(order.Side, price, worst) {
+			worst.Copy(price)
+		}
+
+		if slOrder != nil && slOrder.IsTrailingStop() {
+			stopPrice, isWatermark, moved := slOrder.NextTrailingStop(price)
+			if isWatermark {
+				slOrder.TsPrice = price.String()
+			}
+			if moved {
+				slOrder.Price = stopPrice.String()
+				report.TrailingMoves++
+			}
+		}
+
+		// legs fill at the tick that crossed them like the live market orders, a gap through a stop is paid in full
+		for len(tpOrders) > 0 && tpOrders[0].IsTpSlTriggered(price) {
+			exit(i, tpOrders[0], price, helper.StringToBigFloat(tpOrders[0].Quantity))
+			tpOrders = tpOrders[1:]
+			if slOrder != nil && settings.SlBreakeven && slOrder.IsTighterStop(entry) {
+				slOrder.Price = entry.String()
+			}
+		}
+		if remaining.Sign() > 0 && slOrder != nil && slOrder.IsTpSlTriggered(price) {
+			exit(i, slOrder, price, new(big.Float).Copy(remaining))
+		}
+	}
+
+	exitTime := ticks[exitIdx].Time
+	report.ExitTime = &exitTime
+	if remaining.Sign() > 0 {
+		last := helper.StringToBigFloat(ticks[len(ticks)-1].Price)
+		pnl.Add(pnl, profit(order.Side, entry, last, remaining))
+		exitValue.Add(exitValue, new(big.Float).Mul(last, remaining))
+		report.Leg = ""
+	} else {
+		report.Status = StatusClosed
+	}
+
+	qty := helper.StringToBigFloat(order.Quantity)
+	report.ExitPrice = formatDecimal(new(big.Float).Quo(exitValue, qty))
+	report.PnL = formatDecimal(pnl)
+	report.PnLPercent = formatDecimal(percentOf(pnl, new(big.Float).Mul(entry, qty)))
+
+	mae := new(big.Float).Sub(entry, worst)
+	if order.Side == consts.OrderSideSell {
+		mae.Neg(mae)
+	}
+	report.MAE = formatDecimal(mae)
+	report.MAEPercent = formatDecimal(percentOf(mae, entry))
+
+	return report
+}
+
+func findEntry(ticks []Tick, order *dto.Order) (int, string) {
+	for i, tick := range ticks {
+		price := helper.StringToBigFloat(tick.Price)
+		if price == nil {
+			continue
+		}
+
+		switch order.OrderType {
+		case consts.OrderTypeMarket:
+			return i, price.String()
+		case consts.OrderTypeLimit:
+			if domain.IsTakeProfitTriggered(order.Side, helper.StringToBigFloat(order.Price), price) {
+				return i, order.Price
+			}
+		case consts.OrderTypeStopLossLimit:
+			if domain.IsStopLossTriggered(order.Side, helper.StringToBigFloat(order.StopPrice), price) {
+				if order.Price != "" {
+					return i, order.Price
+				}
+
+				return i, order.StopPrice
+			}
+		}
+	}
+
+	return -1, ""
+}
+
+// newTpSlOrders builds the take profit ladder sorted from the nearest level and the stop loss for the filled order.
+func newTpSlOrders(baseOrder *domain.Order, settings *domain.Settings) ([]*domain.Order, *domain.Order) {
+	var slOrder *domain.Order
+	tpOrders := make([]*domain.Order, 0, len(settings.TpLevels)+1)
+	for _, leg := range settings.GetTpSlLegs(baseOrder) {
+		order := &domain.Order{
+			Symbol:   baseOrder.Symbol,
+			Side:     domain.GetTpSlSide(baseOrder.Side),
+			Quantity: leg.Quantity,
+			Price:    leg.Price,
+			TpSl:     leg.TpSl,
+			Status:   consts.OrderStatusActive,
+		}
+		if leg.TpSl == consts.TpOrderType {
+			tpOrders = append(tpOrders, order)
+
+			continue
+		}
+		if settings.Ts != "" {
+			order.Ts = settings.Ts
+			order.TsPrice = baseOrder.Price
+		}
+		slOrder = order
+	}
+
+	sort.SliceStable(tpOrders, func(i, j int) bool {
+		cmp := helper.StringToBigFloat(tpOrders[i].Price).Cmp(helper.StringToBigFloat(tpOrders[j].Price))
+		if baseOrder.Side == consts.OrderSideSell {
+			return cmp > 0
+		}
+
+		return cmp < 0
+	})
+
+	return tpOrders, slOrder
+}
+
+func profit(side string, entry, exit, quantity *big.Float) *big.Float {
+	diff := new(big.Float).Sub(exit, entry)
+	if side == consts.OrderSideSell {
+		diff.Neg(diff)
+	}
+
+	return diff.Mul(diff, quantity)
+}
+
+func parseRecord(record []string, klinePath KlinePath) ([]Tick, error) {
+	if len(record) < 2 {
+		return nil, errTickFormat
+	}
+
+	tickTime, err := parseTime(strings.TrimSpace(record[0]))
+	if err != nil {
+		return nil, errTickFormat
+	}
+
+	if len(record) < klineMinColumns {
+		price := strings.TrimSpace(record[1])
+		if helper.StringToBigFloat(price) == nil {
+			return nil, errTickFormat
+		}
+
+		return []Tick{{Time: tickTime, Price: price}}, nil
+	}
+
+	prices := make([]string, 4)
+	for i := range prices {
+		prices[i] = strings.TrimSpace(record[i+1])
+		if helper.StringToBigFloat(prices[i]) == nil {
+			return nil, errTickFormat
+		}
+	}
+	open, high, low, closePrice := prices[0], prices[1], prices[2], prices[3]
+
+	path := []string{open, high, low, closePrice}
+	bullish := helper.StringToBigFloat(closePrice).Cmp(helper.StringToBigFloat(open)) >= 0
+	if klinePath == KlinePathLowFirst || (klinePath == KlinePathAuto && bullish) {
+		path = []string{open, low, high, closePrice}
+	}
+
+	ticks := make([]Tick, 0, len(path))
+	for _, price := range path {
+		ticks = append(ticks, Tick{Time: tickTime, Price: price})
+	}
+
+	return ticks, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if ts, err := strconv.ParseInt(value, 10, 64); err == nil {
+		switch {
+		case ts > 1e15:
+			return time.UnixMicro(ts).UTC(), nil
+		case ts > 1e12:
+			return time.UnixMilli(ts).UTC(), nil
+		default:
+			return time.Unix(ts, 0).UTC(), nil
+		}
+	}
+
+	return time.Parse(time.RFC3339, value)
+}
+
+func isAdverse(side string, price, worst *big.Float) bool {
+	if side == consts.OrderSideSell {
+		return price.Cmp(worst) > 0
+	}
+
+	return price.Cmp(worst) < 0
+}
+
+func percentOf(value, base *big.Float) *big.Float {
+	if base.Sign() == 0 {
+		return new(big.Float)
+	}
+
+	result := new(big.Float).Quo(value, base)
+
+	return result.Mul(result, big.NewFloat(100))
+}
+
+func formatDecimal(value *big.Float) string {
+	result := value.Text('f', decimalPlaces)
+	if strings.Contains(result, ".") {
+		result = strings.TrimRight(strings.TrimRight(result, "0"), ".")
+	}
+	if result == "-0" {
+		return "0"
+	}
+
+	return result
+}
+
+func dash(value string) string {
+	if value == "" {
+		return "-"
+	}
+
+	return value
+}
@@ -0,0 +1,244 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/backtest"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func ticks(prices ...string) []backtest.Tick {
+	result := make([]backtest.Tick, 0, len(prices))
+	start := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	for i, price := range prices {
+		result = append(result, backtest.Tick{Time: start.Add(time.Duration(i) * time.Minute), Price: price})
+	}
+
+	return result
+}
+
+func TestLoadTicks(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     string
+		path     backtest.KlinePath
+		expected []string
+		err      bool
+	}{
+		{
+			name:     "trades with header",
+			data:     "time,price,qty\n1698796800000,100,1\n1698796801000,101.5,2\n",
+			expected: []string{"100", "101.5"},
+		},
+		{
+			name:     "bullish kline goes through low first",
+			data:     "1698796800000,100,110,95,105,12,1698796859999\n",
+			expected: []string{"100", "95", "110", "105"},
+		},
+		{
+			name:     "bullish kline with high first path",
+			data:     "1698796800000,100,110,95,105,12,1698796859999\n",
+			path:     backtest.KlinePathHighFirst,
+			expected: []string{"100", "110", "95", "105"},
+		},
+		{
+			name:     "bearish kline with low first path",
+			data:     "2023-11-01T00:00:00Z,100,110,95,97,12\n",
+			path:     backtest.KlinePathLowFirst,
+			expected: []string{"100", "95", "110", "97"},
+		},
+		{
+			name: "unknown kline path",
+			data: "1698796800000,100\n",
+			path: "random",
+			err:  true,
+		},
+		{
+			name:     "bearish kline goes through high first",
+			data:     "2023-11-01T00:00:00Z,100,110,95,97,12\n",
+			expected: []string{"100", "110", "95", "97"},
+		},
+		{
+			name: "broken row",
+			data: "1698796800000,100\n1698796801000,abc\n",
+			err:  true,
+		},
+		{
+			name: "empty",
+			data: "time,price\n",
+			err:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := tt.path
+			if path == "" {
+				path = backtest.KlinePathAuto
+			}
+			result, err := backtest.LoadTicks(strings.NewReader(tt.data), path)
+			if tt.err {
+				assert.Error(t, err)
+
+				return
+			}
+
+			require.NoError(t, err)
+			prices := make([]string, 0, len(result))
+			for _, v := range result {
+				prices = append(prices, v.Price)
+			}
+			assert.Equal(t, tt.expected, prices)
+			assert.Equal(t, time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC), result[0].Time)
+		})
+	}
+}
+
+func TestLoadOrders(t *testing.T) {
+	orders, err := backtest.LoadOrders(strings.NewReader(
+		`[{"ccy":"btcusdt","type":"market","side":"buy","qty":"1","tp_percent":"10","sl_percent":"5"}]`), "btcusdt")
+
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, consts.Binance, orders[0].Exchange)
+	assert.Equal(t, "BTCUSDT", orders[0].Symbol)
+	assert.Equal(t, consts.OrderTypeMarket, orders[0].OrderType)
+
+	_, err = backtest.LoadOrders(strings.NewReader(`[{"ccy":"BTCUSDT","type":"MARKET","side":"BUY","qty":"1"}]`), "BTCUSDT")
+	assert.ErrorContains(t, err, "order 0")
+
+	_, err = backtest.LoadOrders(strings.NewReader(
+		`[{"ccy":"BTCUSDT","type":"MARKET","side":"BUY","qty":"1","tp_percent":"10","sl_percent":"5"},
+		{"ccy":"ETHUSDT","type":"MARKET","side":"BUY","qty":"1","tp_percent":"10","sl_percent":"5"}]`), "BTCUSDT")
+	assert.ErrorContains(t, err, "order 1")
+	assert.ErrorContains(t, err, "ETHUSDT")
+}
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name     string
+		ticks    []backtest.Tick
+		order    *dto.Order
+		expected backtest.Report
+	}{
+		{
+			name:  "market buy hits take profit",
+			ticks: ticks("100", "97", "104", "111"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "2", TpPercent: "10", SlPercent: "5"},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "100", TpPrice: "110", SlPrice: "95",
+				ExitPrice: "111", Leg: consts.TpOrderType, PnL: "22", PnLPercent: "11", MAE: "3", MAEPercent: "3"},
+		},
+		{
+			name:  "market buy gaps through stop loss",
+			ticks: ticks("100", "103", "94"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "1", TpPercent: "10", SlPercent: "5"},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "100", TpPrice: "110", SlPrice: "95",
+				ExitPrice: "94", Leg: consts.SlOrderType, PnL: "-6", PnLPercent: "-6", MAE: "6", MAEPercent: "6"},
+		},
+		{
+			name:  "limit sell fills and hits take profit",
+			ticks: ticks("190", "200", "205", "179"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideSell, OrderType: consts.OrderTypeLimit,
+				Quantity: "1", Price: "200", TpPrice: "180", SlPrice: "210"},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "200", TpPrice: "180", SlPrice: "210",
+				ExitPrice: "179", Leg: consts.TpOrderType, PnL: "21", PnLPercent: "10.5", MAE: "5", MAEPercent: "2.5"},
+		},
+		{
+			name:  "trailing stop locks profit",
+			ticks: ticks("100", "105", "108", "106", "102"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "1", TpPercent: "50", SlPercent: "5", Ts: "2"},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "100", TpPrice: "150", SlPrice: "95",
+				ExitPrice: "102", Leg: consts.SlOrderType, TrailingMoves: 2, PnL: "2", PnLPercent: "2", MAE: "0", MAEPercent: "0"},
+		},
+		{
+			name:  "take profit ladder fills every level",
+			ticks: ticks("100", "103", "106", "111"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "2", SlPercent: "5", TpLevels: []dto.TpLevel{{Percent: "10", Share: "50"}, {Percent: "5", Share: "50"}}},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "100", TpPrice: "105,110", SlPrice: "95",
+				ExitPrice: "108.5", Leg: consts.TpOrderType, PnL: "17", PnLPercent: "8.5", MAE: "0", MAEPercent: "0"},
+		},
+		{
+			name:  "take profit ladder moves stop to breakeven",
+			ticks: ticks("100", "106", "99"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "2", SlPercent: "5", SlBreakeven: true,
+				TpLevels: []dto.TpLevel{{Percent: "5", Share: "50"}, {Percent: "10", Share: "50"}}},
+			expected: backtest.Report{Status: backtest.StatusClosed, EntryPrice: "100", TpPrice: "105,110", SlPrice: "95",
+				ExitPrice: "102.5", Leg: consts.SlOrderType, PnL: "5", PnLPercent: "2.5", MAE: "1", MAEPercent: "1"},
+		},
+		{
+			name:  "take profit ladder partially filled stays open",
+			ticks: ticks("100", "106", "104"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "2", SlPercent: "5", TpLevels: []dto.TpLevel{{Percent: "5", Share: "50"}, {Percent: "10", Share: "50"}}},
+			expected: backtest.Report{Status: backtest.StatusOpen, EntryPrice: "100", TpPrice: "105,110", SlPrice: "95",
+				ExitPrice: "105", PnL: "10", PnLPercent: "5", MAE: "0", MAEPercent: "0"},
+		},
+		{
+			name:  "position still open",
+			ticks: ticks("100", "101", "99"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+				Quantity: "1", TpPercent: "10", SlPercent: "5"},
+			expected: backtest.Report{Status: backtest.StatusOpen, EntryPrice: "100", TpPrice: "110", SlPrice: "95",
+				ExitPrice: "99", PnL: "-1", PnLPercent: "-1", MAE: "1", MAEPercent: "1"},
+		},
+		{
+			name:  "limit never filled",
+			ticks: ticks("100", "101"),
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+				Quantity: "1", Price: "90", TpPercent: "10", SlPercent: "5"},
+			expected: backtest.Report{Status: backtest.StatusNotFilled, PnL: "0", PnLPercent: "0", MAE: "0", MAEPercent: "0"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reports := backtest.Run(tt.ticks, []*dto.Order{tt.order})
+			require.Len(t, reports, 1)
+
+			result := *reports[0]
+			result.EntryTime, result.ExitTime = nil, nil
+			tt.expected.Symbol = tt.order.Symbol
+			tt.expected.Side = tt.order.Side
+			tt.expected.OrderType = tt.order.OrderType
+			tt.expected.Quantity = tt.order.Quantity
+			assert.Equal(t, tt.expected, result)
+		})
+	}
+}
+
+func TestWriteReports(t *testing.T) {
+	reports := backtest.Run(ticks("100", "111"), []*dto.Order{
+		{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+			Quantity: "1", TpPercent: "10", SlPercent: "5"},
+		{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket,
+			Quantity: "2", TpPercent: "10", SlPercent: "5"},
+	})
+
+	var table bytes.Buffer
+	require.NoError(t, backtest.WriteTable(&table, reports))
+	lines := strings.Split(strings.TrimSpace(table.String()), "\n")
+	require.Len(t, lines, 4)
+	assert.Contains(t, lines[0], "PNL")
+	assert.Contains(t, lines[1], "closed")
+	assert.Contains(t, lines[3], "TOTAL  33")
+
+	var out bytes.Buffer
+	require.NoError(t, backtest.WriteJSON(&out, reports))
+	var decoded []backtest.Report
+	require.NoError(t, json.Unmarshal(out.Bytes(), &decoded))
+	require.Len(t, decoded, 2)
+	assert.Equal(t, "22", decoded[1].PnL)
+	assert.Equal(t, consts.TpOrderType, decoded[1].Leg)
+}
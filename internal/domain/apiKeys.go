@@ -38,5 +38,5 @@ func (a *ApiKeys) DecodePrivKey(secret string) {
 }
 
 func (a *ApiKeys) DecodePassKey(secret string) {
-	a.PrivKey, _ = helper.DecryptMessage(a.Passphrase, secret)
+	a.Passphrase, _ = helper.DecryptMessage(a.Passphrase, secret)
 }
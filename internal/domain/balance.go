@@ -1,15 +1,34 @@
 package domain
 
+import "math/big"
+
 type BalanceSymbol struct {
 	Symbol   string `json:"symbol"`
 	Quantity string `json:"quantity"`
+	Locked   string `json:"locked,omitempty"`
 }
 
-func NewBalanceSymbol(symbol, quantity string) BalanceSymbol {
+func NewBalanceSymbol(symbol, quantity, locked string) BalanceSymbol {
 	return BalanceSymbol{
 		Symbol:   symbol,
 		Quantity: quantity,
+		Locked:   locked,
 	}
 }
 
+func (b BalanceSymbol) IsEmpty() bool {
+	return new(big.Rat).Add(ratOrZero(b.Quantity), ratOrZero(b.Locked)).Sign() <= 0
+}
+
 type Balance []BalanceSymbol
+
+// GetFree returns the free amount of asset or an empty string when the balance has no such asset.
+func (b Balance) GetFree(asset string) string {
+	for _, v := range b {
+		if v.Symbol == asset {
+			return v.Quantity
+		}
+	}
+
+	return ""
+}
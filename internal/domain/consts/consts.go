@@ -13,6 +13,11 @@ const (
 	OrderTypeLimit         = "LIMIT"
 	OrderTypeMarket        = "MARKET"
 	OrderTypeStopLossLimit = "STOP_LOSS_LIMIT"
+	OrderTypeStopLoss      = "STOP_LOSS"
+	OrderTypeLimitMaker    = "LIMIT_MAKER"
+
+	ProtectionVirtual = "virtual"
+	ProtectionOco     = "oco"
 
 	ErrServer         = "server_error"
 	ErrUnauthorized   = "unauthorized"
@@ -20,10 +25,12 @@ const (
 	ErrWrongInputJson = "wrong_json_format_or_params_type"
 	ErrConversion     = "conversion_err"
 
-	TradePriceCacheKey = "pairPrice_"
-	Binance            = "binance"
-	Kucoin             = "kucoin"
-	Okx                = "okx"
+	TradePriceCacheKey     = "pairPrice_"
+	TradePriceTimeCacheKey = "pairPriceTime_"
+	Binance                = "binance"
+	Kucoin                 = "kucoin"
+	Okx                    = "okx"
+	Paper                  = "paper"
 
 	OrderStatusActive       = "active"
 	OrderStatusPartFilled   = "part_filled"
@@ -31,11 +38,54 @@ const (
 	OrderStatusFilled       = "filled"
 	OrderStatusTpSlInactive = "inactive"
 
+	DealStatusPending  = "pending"
+	DealStatusOpen     = "open"
+	DealStatusClosed   = "closed"
+	DealStatusCanceled = "canceled"
+
+	PnlPeriodDay   = "day"
+	PnlPeriodWeek  = "week"
+	PnlPeriodMonth = "month"
+
 	TpOrderType   = "tp"
 	SlOrderType   = "sl"
 	BaseOrderType = "base"
 
+	OrderEventCreated       = "created"
+	OrderEventFilled        = "filled"
+	OrderEventTpExecuted    = "tp_executed"
+	OrderEventSlExecuted    = "sl_executed"
+	OrderEventExecuteFailed = "execute_failed"
+	OrderEventTrailingMoved = "trailing_moved"
+	OrderEventCanceled      = "canceled"
+	OrderEventOcoFailed     = "oco_failed"
+	OrderEventPartFilled    = "part_filled"
+	OrderEventSlBreakeven   = "sl_breakeven"
+
+	StrategyTypeDca  = "dca"
+	StrategyTypeGrid = "grid"
+
+	StrategyStatusActive    = "active"
+	StrategyStatusStopped   = "stopped"
+	StrategyStatusCompleted = "completed"
+	StrategyStatusFailed    = "failed"
+
+	StrategyOrderBase   = "base"
+	StrategyOrderSafety = "safety"
+	StrategyOrderTp     = "tp"
+	StrategyOrderGrid   = "grid"
+
+	AlertConditionAbove   = "above"
+	AlertConditionBelow   = "below"
+	AlertConditionCrosses = "crosses"
+	AlertConditionMove    = "move"
+
+	AlertStatusActive    = "active"
+	AlertStatusTriggered = "triggered"
+	AlertStatusDeleted   = "deleted"
+
 	TgCreateOrderCommand = "create"
 	TgCancelOrderCommand = "cancel"
 	TgUpdateTpSLCommand  = "updateTpsl"
+	TgStrategyCommand    = "strategy"
 )
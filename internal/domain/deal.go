@@ -1,8 +1,192 @@
 package domain
 
+import (
+	"math/big"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
 type Deal struct {
-	BaseOrder *Order
-	TpOrder   *Order
-	SlOrder   *Order
-	Settings  *Settings
+	BaseOrder   *Order    `json:"baseOrder"`
+	TpOrders    []*Order  `json:"tpOrders"`
+	SlOrder     *Order    `json:"slOrder"`
+	Settings    *Settings `json:"settings"`
+	Status      string    `json:"status"`
+	EntryPrice  string    `json:"entryPrice"`
+	ExitPrice   string    `json:"exitPrice"`
+	EntryQty    string    `json:"entryQty"`
+	ClosedQty   string    `json:"closedQty"`
+	Pnl         string    `json:"pnl"`
+	Fee         string    `json:"fee"`
+	NetPnl      string    `json:"netPnl"`
+	OpenedAt    time.Time `json:"openedAt"`
+	ClosedAt    time.Time `json:"closedAt"`
+	DurationSec int64     `json:"durationSec"`
+}
+
+type DealPage struct {
+	Deals []*Deal `json:"deals"`
+	Page  int     `json:"page"`
+	Pages int     `json:"pages"`
+	Total int     `json:"total"`
+}
+
+func NewDeal(baseOrder *Order, tpSlOrders []*Order, settings *Settings) *Deal {
+	deal := &Deal{
+		BaseOrder: baseOrder,
+		TpOrders:  make([]*Order, 0, len(tpSlOrders)),
+		Settings:  settings,
+	}
+	for _, v := range tpSlOrders {
+		if v.TpSl == consts.SlOrderType {
+			deal.SlOrder = v
+		} else {
+			deal.TpOrders = append(deal.TpOrders, v)
+		}
+	}
+
+	return deal
+}
+
+func (d *Deal) ExitOrders() []*Order {
+	orders := make([]*Order, 0, len(d.TpOrders)+1)
+	for _, v := range append(d.TpOrders, d.SlOrder) {
+		if v != nil && v.Status == consts.OrderStatusFilled {
+			orders = append(orders, v)
+		}
+	}
+
+	return orders
+}
+
+func (d *Deal) Calculate(feePercent string, now time.Time) {
+	entryPrice := ratOrZero(orderPrice(d.BaseOrder))
+	entryQty := new(big.Rat)
+	if d.BaseOrder.HasExecutedQty() {
+		entryQty = ratOrZero(d.BaseOrder.ExecutedQty)
+	} else if d.BaseOrder.Status == consts.OrderStatusFilled {
+		entryQty = ratOrZero(d.BaseOrder.Quantity)
+	}
+
+	closedQty := new(big.Rat)
+	exitValue := new(big.Rat)
+	for _, v := range d.ExitOrders() {
+		qty := ratOrZero(v.Quantity)
+		if v.HasExecutedQty() {
+			qty = ratOrZero(v.ExecutedQty)
+		}
+		closedQty.Add(closedQty, qty)
+		exitValue.Add(exitValue, new(big.Rat).Mul(qty, ratOrZero(orderPrice(v))))
+		if v.UpdatedAt.After(d.ClosedAt) {
+			d.ClosedAt = v.UpdatedAt
+		}
+	}
+
+	pnl := new(big.Rat).Sub(exitValue, new(big.Rat).Mul(entryPrice, closedQty))
+	if d.BaseOrder.Side == consts.OrderSideSell {
+		pnl.Neg(pnl)
+	}
+	fee := new(big.Rat).Add(new(big.Rat).Mul(entryPrice, entryQty), exitValue)
+	fee.Mul(fee, ratOrZero(feePercent))
+	fee.Quo(fee, big.NewRat(100, 1))
+
+	d.EntryPrice = formatQuantity(entryPrice)
+	d.EntryQty = formatQuantity(entryQty)
+	d.ClosedQty = formatQuantity(closedQty)
+	d.ExitPrice = ""
+	if closedQty.Sign() > 0 {
+		d.ExitPrice = formatQuantity(new(big.Rat).Quo(exitValue, closedQty))
+	}
+	d.Pnl = formatQuantity(pnl)
+	d.Fee = formatQuantity(fee)
+	d.NetPnl = formatQuantity(new(big.Rat).Sub(pnl, fee))
+	d.OpenedAt = d.BaseOrder.CreatedAt
+
+	switch {
+	case entryQty.Sign() == 0 && d.BaseOrder.Status == consts.OrderStatusCanceled:
+		d.Status = consts.DealStatusCanceled
+	case entryQty.Sign() == 0:
+		d.Status = consts.DealStatusPending
+	case closedQty.Cmp(entryQty) >= 0:
+		d.Status = consts.DealStatusClosed
+	default:
+		d.Status = consts.DealStatusOpen
+	}
+
+	end := now
+	if d.Status == consts.DealStatusClosed && !d.ClosedAt.IsZero() {
+		end = d.ClosedAt
+	} else {
+		d.ClosedAt = time.Time{}
+	}
+	if !d.OpenedAt.IsZero() && end.After(d.OpenedAt) {
+		d.DurationSec = int64(end.Sub(d.OpenedAt) / time.Second)
+	}
+}
+
+func orderPrice(order *Order) string {
+	if order.AvgPrice != "" {
+		return order.AvgPrice
+	}
+
+	return order.Price
+}
+
+func ratOrZero(val string) *big.Rat {
+	result, ok := new(big.Rat).SetString(val)
+	if !ok {
+		return new(big.Rat)
+	}
+
+	return result
+}
+
+func (d *Deal) OpenQty() *big.Rat {
+	return new(big.Rat).Sub(ratOrZero(d.EntryQty), ratOrZero(d.ClosedQty))
+}
+
+func (d *Deal) UnrealizedPnl(markPrice string) string {
+	price, ok := new(big.Rat).SetString(markPrice)
+	if !ok || d.Status != consts.DealStatusOpen {
+		return "0"
+	}
+
+	pnl := new(big.Rat).Sub(price, ratOrZero(d.EntryPrice))
+	pnl.Mul(pnl, d.OpenQty())
+	if d.BaseOrder.Side == consts.OrderSideSell {
+		pnl.Neg(pnl)
+	}
+
+	return formatQuantity(pnl)
+}
+
+func (d *Deal) StopPrice() string {
+	if d.Settings != nil && d.Settings.SlPrice != "" {
+		return d.Settings.SlPrice
+	}
+	if d.Settings != nil && d.Settings.SlPercent != "" {
+		return percentPrice(d.EntryPrice, d.Settings.SlPercent, d.BaseOrder.Side, consts.SlOrderType)
+	}
+	if d.SlOrder != nil {
+		return d.SlOrder.Price
+	}
+
+	return ""
+}
+
+func (d *Deal) RMultiple() (string, bool) {
+	stop, ok := new(big.Rat).SetString(d.StopPrice())
+	if !ok || d.Status != consts.DealStatusClosed {
+		return "", false
+	}
+
+	risk := new(big.Rat).Sub(ratOrZero(d.EntryPrice), stop)
+	risk.Abs(risk)
+	risk.Mul(risk, ratOrZero(d.EntryQty))
+	if risk.Sign() == 0 {
+		return "", false
+	}
+
+	return formatQuantity(new(big.Rat).Quo(ratOrZero(d.NetPnl), risk)), true
 }
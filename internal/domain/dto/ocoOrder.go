@@ -0,0 +1,21 @@
+package dto
+
+type OcoOrder struct {
+	Exchange  string `json:"exchange"`
+	Symbol    string `json:"symbol"`
+	Side      string `json:"side"`
+	Quantity  string `json:"quantity"`
+	Price     string `json:"price"`
+	StopPrice string `json:"stopPrice"`
+}
+
+func NewOcoOrder(exchange, symbol, side, quantity, price, stopPrice string) *OcoOrder {
+	return &OcoOrder{
+		Exchange:  exchange,
+		Symbol:    symbol,
+		Side:      side,
+		Quantity:  quantity,
+		Price:     price,
+		StopPrice: stopPrice,
+	}
+}
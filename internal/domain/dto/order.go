@@ -4,6 +4,7 @@ import (
 	"errors"
 	validation "github.com/go-ozzo/ozzo-validation/v4"
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"math/big"
 	"regexp"
 )
 
@@ -22,23 +23,27 @@ var timeInForce = []interface{}{
 }
 
 type Order struct {
-	Exchange    string `json:"exchange"`
-	Symbol      string `json:"ccy"`
-	OrderType   string `json:"type"`
-	Side        string `json:"side"`
-	Quantity    string `json:"qty"`
-	Price       string `json:"price"`
-	TpPercent   string `json:"tp_percent"`
-	SlPercent   string `json:"sl_percent"`
-	TpPrice     string `json:"tp_price"`
-	SlPrice     string `json:"sl_price"`
-	TpType      string `json:"tp_type"`
-	SlType      string `json:"sl_type"`
-	Ts          string `json:"ts"`
-	TimeInForce string `json:"tif"`
-	StopPercent string `json:"stopPercent"`
-	StopPrice   string `json:"stopPrice"`
-	IcebergQty  string `json:"icebergQty"`
+	Exchange    string    `json:"exchange"`
+	Symbol      string    `json:"ccy"`
+	OrderType   string    `json:"type"`
+	Side        string    `json:"side"`
+	Quantity    string    `json:"qty"`
+	RiskPercent string    `json:"risk_percent"`
+	Price       string    `json:"price"`
+	TpPercent   string    `json:"tp_percent"`
+	SlPercent   string    `json:"sl_percent"`
+	TpPrice     string    `json:"tp_price"`
+	SlPrice     string    `json:"sl_price"`
+	TpType      string    `json:"tp_type"`
+	SlType      string    `json:"sl_type"`
+	Ts          string    `json:"ts"`
+	TpLevels    []TpLevel `json:"tp_levels"`
+	SlBreakeven bool      `json:"sl_breakeven"`
+	TimeInForce string    `json:"tif"`
+	StopPercent string    `json:"stopPercent"`
+	StopPrice   string    `json:"stopPrice"`
+	IcebergQty  string    `json:"icebergQty"`
+	Protection  string    `json:"protection"`
 }
 
 func (o *Order) Validate() error {
@@ -57,9 +62,17 @@ func (o *Order) Validate() error {
 				validation.By(zeroString),
 			),
 		),
-		validation.Field(&o.Quantity, validation.Required,
+		validation.Field(&o.Quantity,
+			validation.When(!o.IsRiskSized(), validation.Required,
+				validation.Match(intRegexp),
+				validation.By(zeroString),
+			),
+			validation.When(o.IsRiskSized(), validation.Empty),
+		),
+		validation.Field(&o.RiskPercent,
 			validation.Match(intRegexp),
 			validation.By(zeroString),
+			validation.By(maxPercent),
 		),
 		validation.Field(&o.TimeInForce, validation.When(
 			o.OrderType == consts.OrderTypeLimit, validation.Required),
@@ -76,11 +89,12 @@ func (o *Order) Validate() error {
 			),
 		),
 		validation.Field(&o.TpPercent,
-			validation.When(o.TpPrice == "",
+			validation.When(o.TpPrice == "" && !o.HasTpLadder(),
 				validation.Required,
 				validation.Match(intRegexp),
 				validation.By(zeroString),
 			),
+			validation.When(o.HasTpLadder(), validation.Empty),
 		),
 		validation.Field(&o.SlPercent,
 			validation.When(o.SlPrice == "",
@@ -90,11 +104,20 @@ func (o *Order) Validate() error {
 			),
 		),
 		validation.Field(&o.TpPrice,
-			validation.When(o.TpPercent == "",
+			validation.When(o.TpPercent == "" && !o.HasTpLadder(),
 				validation.Required,
 				validation.Match(intRegexp),
 				validation.By(zeroString),
 			),
+			validation.When(o.HasTpLadder(), validation.Empty),
+		),
+		validation.Field(&o.TpLevels,
+			validation.Length(0, maxTpLevels),
+			validation.By(tpShares),
+			validation.When(o.Protection == consts.ProtectionOco, validation.Empty),
+		),
+		validation.Field(&o.SlBreakeven,
+			validation.When(!o.HasTpLadder(), validation.Empty),
 		),
 		validation.Field(&o.SlPrice,
 			validation.When(o.SlPercent == "",
@@ -106,6 +129,19 @@ func (o *Order) Validate() error {
 		validation.Field(&o.Ts,
 			validation.Match(intRegexp),
 			validation.By(zeroString),
+			validation.When(o.Protection == consts.ProtectionOco, validation.Empty),
+		),
+		validation.Field(&o.Protection,
+			validation.In(consts.ProtectionVirtual, consts.ProtectionOco),
+		),
+		validation.Field(&o.Exchange,
+			validation.When(o.Protection == consts.ProtectionOco, validation.In(consts.Binance)),
+		),
+		validation.Field(&o.TpType,
+			validation.When(o.Protection == consts.ProtectionOco, validation.Empty),
+		),
+		validation.Field(&o.SlType,
+			validation.When(o.Protection == consts.ProtectionOco, validation.Empty),
 		),
 	)
 }
@@ -124,9 +160,40 @@ func zeroString(value interface{}) error {
 	return nil
 }
 
+func maxPercent(value interface{}) error {
+	val, ok := value.(string)
+	if !ok {
+		return errInvalidFormat
+	}
+	if val == "" {
+		return nil
+	}
+
+	percent, ok := new(big.Rat).SetString(val)
+	if !ok || percent.Cmp(big.NewRat(100, 1)) > 0 {
+		return errInvalidFormat
+	}
+
+	return nil
+}
+
+func (o *Order) IsOco() bool {
+	return o.Protection == consts.ProtectionOco
+}
+
 func (o *Order) IsEmptyTpSl() bool {
 	return o.TpPercent == "" &&
 		o.SlPercent == "" &&
 		o.TpPrice == "" &&
-		o.SlPrice == ""
+		o.SlPrice == "" &&
+		!o.HasTpLadder()
+}
+
+// IsRiskSized reports that quantity has to be calculated from the balance, risk percent and stop loss.
+func (o *Order) IsRiskSized() bool {
+	return o.RiskPercent != ""
+}
+
+func (o *Order) HasTpLadder() bool {
+	return len(o.TpLevels) != 0
 }
@@ -0,0 +1,39 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+type PriceAlert struct {
+	Exchange  string `json:"exchange"`
+	Symbol    string `json:"ccy"`
+	Condition string `json:"condition"`
+	Value     string `json:"value"`
+	Recurring bool   `json:"recurring"`
+}
+
+func (a *PriceAlert) Validate() error {
+	return validation.ValidateStruct(a,
+		validation.Field(&a.Exchange,
+			validation.Required),
+
+		validation.Field(&a.Symbol,
+			validation.Required,
+			validation.Length(4, 20),
+			validation.Match(symbolRegexp)),
+
+		validation.Field(&a.Condition,
+			validation.Required,
+			validation.In(consts.AlertConditionAbove,
+				consts.AlertConditionBelow,
+				consts.AlertConditionCrosses,
+				consts.AlertConditionMove)),
+
+		validation.Field(&a.Value,
+			validation.Required,
+			validation.Match(intRegexp),
+			validation.By(zeroString),
+			validation.When(a.Condition == consts.AlertConditionMove, validation.By(maxPercent))),
+	)
+}
@@ -0,0 +1,32 @@
+package dto
+
+import (
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var maxAllowedSymbols = 100
+
+type RiskLimits struct {
+	MaxOrderNotional string   `json:"maxOrderNotional"`
+	MaxOpenDeals     int      `json:"maxOpenDeals"`
+	MaxDailyLoss     string   `json:"maxDailyLoss"`
+	AllowedSymbols   []string `json:"allowedSymbols"`
+}
+
+func (r *RiskLimits) Validate() error {
+	return validation.ValidateStruct(r,
+		validation.Field(&r.MaxOrderNotional,
+			validation.Match(intRegexp),
+		),
+		validation.Field(&r.MaxOpenDeals,
+			validation.Min(0),
+		),
+		validation.Field(&r.MaxDailyLoss,
+			validation.Match(intRegexp),
+		),
+		validation.Field(&r.AllowedSymbols,
+			validation.Length(0, maxAllowedSymbols),
+			validation.Each(validation.Length(4, 20), validation.Match(symbolRegexp)),
+		),
+	)
+}
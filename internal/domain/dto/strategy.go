@@ -0,0 +1,110 @@
+package dto
+
+import (
+	"math/big"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+var (
+	maxSafetyOrders = 20
+	maxGridLevels   = 100
+)
+
+type Strategy struct {
+	Command  string `json:"command"`
+	Type     string `json:"type"`
+	Exchange string `json:"exchange"`
+	Symbol   string `json:"ccy"`
+	Quantity string `json:"qty"`
+
+	Side         string `json:"side"`
+	SafetyOrders int    `json:"safety_orders"`
+	Deviation    string `json:"deviation"`
+	StepScale    string `json:"step_scale"`
+	VolumeScale  string `json:"volume_scale"`
+	TpPercent    string `json:"tp_percent"`
+
+	Lower  string `json:"lower"`
+	Upper  string `json:"upper"`
+	Levels int    `json:"levels"`
+}
+
+func (s *Strategy) Validate() error {
+	isDca := s.Type == consts.StrategyTypeDca
+	isGrid := s.Type == consts.StrategyTypeGrid
+
+	return validation.ValidateStruct(s,
+		validation.Field(&s.Type,
+			validation.Required,
+			validation.In(consts.StrategyTypeDca, consts.StrategyTypeGrid)),
+
+		validation.Field(&s.Exchange,
+			validation.Required),
+
+		validation.Field(&s.Symbol,
+			validation.Required,
+			validation.Length(4, 20),
+			validation.Match(symbolRegexp)),
+
+		validation.Field(&s.Quantity,
+			validation.Required,
+			validation.Match(intRegexp),
+			validation.By(zeroString)),
+
+		validation.Field(&s.Side,
+			validation.When(isDca,
+				validation.Required,
+				validation.In(consts.OrderSideBuy, consts.OrderSideSell))),
+
+		validation.Field(&s.SafetyOrders,
+			validation.When(isDca, validation.Min(0), validation.Max(maxSafetyOrders))),
+
+		validation.Field(&s.Deviation,
+			validation.When(isDca && s.SafetyOrders > 0, validation.Required),
+			validation.Match(intRegexp),
+			validation.By(zeroString),
+			validation.By(maxPercent)),
+
+		validation.Field(&s.StepScale,
+			validation.Match(intRegexp),
+			validation.By(zeroString)),
+
+		validation.Field(&s.VolumeScale,
+			validation.Match(intRegexp),
+			validation.By(zeroString)),
+
+		validation.Field(&s.TpPercent,
+			validation.When(isDca, validation.Required),
+			validation.Match(intRegexp),
+			validation.By(zeroString),
+			validation.By(maxPercent)),
+
+		validation.Field(&s.Lower,
+			validation.When(isGrid, validation.Required),
+			validation.Match(intRegexp),
+			validation.By(zeroString)),
+
+		validation.Field(&s.Upper,
+			validation.When(isGrid, validation.Required, validation.By(s.aboveLower)),
+			validation.Match(intRegexp),
+			validation.By(zeroString)),
+
+		validation.Field(&s.Levels,
+			validation.When(isGrid, validation.Required, validation.Min(2), validation.Max(maxGridLevels))),
+	)
+}
+
+func (s *Strategy) aboveLower(value interface{}) error {
+	upper, ok := new(big.Rat).SetString(s.Upper)
+	if !ok {
+		return errInvalidFormat
+	}
+	lower, ok := new(big.Rat).SetString(s.Lower)
+	if ok && upper.Cmp(lower) <= 0 {
+		return errInvalidFormat
+	}
+
+	return nil
+}
@@ -22,10 +22,15 @@ type TgOrder struct {
 	SlType    string `json:"sl_type"`
 	Ts        string `json:"ts"`
 
+	TpLevels    []TpLevel `json:"tp_levels"`
+	SlBreakeven bool      `json:"sl_breakeven"`
+
 	TimeInForce string `json:"tif"`
 	StopPercent string `json:"stopPercent"`
 	StopPrice   string `json:"stopPrice"`
+	RiskPercent string `json:"risk_percent"`
 	IcebergQty  string `json:"icebergQty"`
+	Protection  string `json:"protection"`
 }
 
 func (o *TgOrder) Validate() error {
@@ -56,10 +61,16 @@ func (o *TgOrder) Validate() error {
 				validation.By(zeroString))),
 
 		validation.Field(&o.Quantity,
-			validation.When(o.Command == consts.TgCreateOrderCommand,
+			validation.When(o.Command == consts.TgCreateOrderCommand && o.RiskPercent == "",
 				validation.Required,
 				validation.Match(intRegexp),
-				validation.By(zeroString))),
+				validation.By(zeroString)),
+			validation.When(o.RiskPercent != "", validation.Empty)),
+
+		validation.Field(&o.RiskPercent,
+			validation.Match(intRegexp),
+			validation.By(zeroString),
+			validation.By(maxPercent)),
 
 		validation.Field(&o.TimeInForce,
 			validation.When(o.Command == consts.TgCreateOrderCommand && o.OrderType == consts.OrderTypeLimit,
@@ -82,11 +93,12 @@ func (o *TgOrder) Validate() error {
 		),
 
 		validation.Field(&o.TpPercent,
-			validation.When(o.Command == consts.TgCreateOrderCommand && o.TpPrice == "",
+			validation.When(o.Command == consts.TgCreateOrderCommand && o.TpPrice == "" && len(o.TpLevels) == 0,
 				validation.Required,
 				validation.Match(intRegexp),
 				validation.By(zeroString),
 			),
+			validation.When(len(o.TpLevels) != 0, validation.Empty),
 		),
 
 		validation.Field(&o.SlPercent,
@@ -98,11 +110,17 @@ func (o *TgOrder) Validate() error {
 		),
 
 		validation.Field(&o.TpPrice,
-			validation.When(o.Command == consts.TgCreateOrderCommand && o.TpPercent == "",
+			validation.When(o.Command == consts.TgCreateOrderCommand && o.TpPercent == "" && len(o.TpLevels) == 0,
 				validation.Required,
 				validation.Match(intRegexp),
 				validation.By(zeroString),
 			),
+			validation.When(len(o.TpLevels) != 0, validation.Empty),
+		),
+
+		validation.Field(&o.TpLevels,
+			validation.Length(0, maxTpLevels),
+			validation.By(tpShares),
 		),
 
 		validation.Field(&o.SlPrice,
@@ -0,0 +1,64 @@
+package dto
+
+import (
+	"errors"
+	"math/big"
+
+	validation "github.com/go-ozzo/ozzo-validation/v4"
+)
+
+var maxTpLevels = 10
+var errInvalidTpShares = errors.New("err tp level shares must sum up to 100")
+
+type TpLevel struct {
+	Percent string `json:"percent"`
+	Price   string `json:"price"`
+	Share   string `json:"share"`
+}
+
+func (l TpLevel) Validate() error {
+	return validation.ValidateStruct(&l,
+		validation.Field(&l.Percent,
+			validation.When(l.Price == "",
+				validation.Required,
+				validation.Match(intRegexp),
+				validation.By(zeroString),
+			),
+		),
+		validation.Field(&l.Price,
+			validation.When(l.Percent == "",
+				validation.Required,
+				validation.Match(intRegexp),
+				validation.By(zeroString),
+			),
+		),
+		validation.Field(&l.Share, validation.Required,
+			validation.Match(intRegexp),
+			validation.By(zeroString),
+		),
+	)
+}
+
+func tpShares(value interface{}) error {
+	levels, ok := value.([]TpLevel)
+	if !ok {
+		return errInvalidFormat
+	}
+	if len(levels) == 0 {
+		return nil
+	}
+
+	total := new(big.Rat)
+	for _, v := range levels {
+		share, ok := new(big.Rat).SetString(v.Share)
+		if !ok {
+			return errInvalidFormat
+		}
+		total.Add(total, share)
+	}
+	if total.Cmp(big.NewRat(100, 1)) != 0 {
+		return errInvalidTpShares
+	}
+
+	return nil
+}
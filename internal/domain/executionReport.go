@@ -0,0 +1,16 @@
+package domain
+
+type ExecutionReport struct {
+	UserId         int64
+	Exchange       string
+	Symbol         string
+	OrderId        int64
+	OrderListId    int64
+	Side           string
+	OrderType      string
+	Status         string
+	Quantity       string
+	FilledQuantity string
+	AvgPrice       string
+	LastPrice      string
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "errors"
+
+var ErrOcoLegsNotFound = errors.New("err oco order requires take profit and stop loss")
+
+type OcoOrder struct {
+	ListId    int64 `json:"listId"`
+	TpOrderId int64 `json:"tpOrderId"`
+	SlOrderId int64 `json:"slOrderId"`
+}
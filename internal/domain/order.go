@@ -1,26 +1,69 @@
 package domain
 
-import "sync"
+import (
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"sync"
+	"time"
+)
 
 type Order struct {
-	Id          int64  `json:"id"`
-	ExecOrderId int64  `json:"execOrderId"`
-	UserId      int64  `json:"user_id"`
-	Symbol      string `json:"symbol"`
-	Side        string `json:"side"`
-	OrderType   string `json:"orderType"`
-	Quantity    string `json:"quantity"`
-	Price       string `json:"price"`
-	TimeInForce string `json:"timeInForce"`
-	StopPrice   string `json:"stopPrice"`
-	IcebergQty  string `json:"icebergQty"`
-	Exchange    string `json:"exchange"`
-	Status      string `json:"status"`
-	TpSl        string `json:"tpSl"`
-	Inwork      bool   `json:"-"`
+	Id          int64     `json:"id"`
+	ExecOrderId int64     `json:"execOrderId"`
+	UserId      int64     `json:"user_id"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	OrderType   string    `json:"orderType"`
+	Quantity    string    `json:"quantity"`
+	Price       string    `json:"price"`
+	TimeInForce string    `json:"timeInForce"`
+	StopPrice   string    `json:"stopPrice"`
+	IcebergQty  string    `json:"icebergQty"`
+	Exchange    string    `json:"exchange"`
+	Status      string    `json:"status"`
+	TpSl        string    `json:"tpSl"`
+	Ts          string    `json:"ts"`
+	TsPrice     string    `json:"tsPrice"`
+	Protection  string    `json:"protection"`
+	OcoListId   int64     `json:"ocoListId"`
+	OcoOrderId  int64     `json:"ocoOrderId"`
+	ExecutedQty string    `json:"executedQty"`
+	AvgPrice    string    `json:"avgPrice"`
+	CreatedAt   time.Time `json:"createdAt"`
+	UpdatedAt   time.Time `json:"updatedAt"`
 	sync.RWMutex
 }
 
+func (o *Order) IsTrailingStop() bool {
+	return o.Ts != ""
+}
+
+func (o *Order) IsOco() bool {
+	return o.Protection == consts.ProtectionOco
+}
+
+func (o *Order) IsOpen() bool {
+	return o.Status == consts.OrderStatusActive || o.Status == consts.OrderStatusPartFilled
+}
+
+func (o *Order) HasExecutedQty() bool {
+	qty := helper.StringToBigFloat(o.ExecutedQty)
+
+	return qty != nil && qty.Sign() > 0
+}
+
+func (o *Order) IsOcoLeg() bool {
+	return o.IsOco() && o.OcoListId != 0
+}
+
+func (o *Order) ExchangeOrderId() int64 {
+	if o.IsOcoLeg() {
+		return o.OcoOrderId
+	}
+
+	return o.ExecOrderId
+}
+
 type OrderList struct {
 	Orders []*Order
 	sync.Mutex
@@ -0,0 +1,58 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+type OrderEvent struct {
+	Type        string    `json:"type"`
+	UserId      int64     `json:"userId"`
+	OrderId     int64     `json:"orderId"`
+	ExecOrderId int64     `json:"execOrderId"`
+	Exchange    string    `json:"exchange"`
+	Symbol      string    `json:"symbol"`
+	Side        string    `json:"side"`
+	OrderType   string    `json:"orderType"`
+	Quantity    string    `json:"quantity"`
+	Price       string    `json:"price"`
+	Status      string    `json:"status"`
+	TpSl        string    `json:"tpSl"`
+	Ts          string    `json:"ts"`
+	TsPrice     string    `json:"tsPrice"`
+	ExecutedQty string    `json:"executedQty"`
+	AvgPrice    string    `json:"avgPrice"`
+	Error       string    `json:"error,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
+
+func NewOrderEvent(eventType string, order *Order) OrderEvent {
+	return OrderEvent{
+		Type:        eventType,
+		UserId:      order.UserId,
+		OrderId:     order.Id,
+		ExecOrderId: order.ExecOrderId,
+		Exchange:    order.Exchange,
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		OrderType:   order.OrderType,
+		Quantity:    order.Quantity,
+		Price:       order.Price,
+		Status:      order.Status,
+		TpSl:        order.TpSl,
+		Ts:          order.Ts,
+		TsPrice:     order.TsPrice,
+		ExecutedQty: order.ExecutedQty,
+		AvgPrice:    order.AvgPrice,
+		CreatedAt:   time.Now().UTC(),
+	}
+}
+
+func NewExecutedEvent(order *Order) OrderEvent {
+	if order.TpSl == consts.SlOrderType {
+		return NewOrderEvent(consts.OrderEventSlExecuted, order)
+	}
+
+	return NewOrderEvent(consts.OrderEventTpExecuted, order)
+}
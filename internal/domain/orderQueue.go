@@ -1,74 +1,234 @@
 package domain
 
 import (
+	"math/big"
 	"sync"
 )
 
+type orderKey struct {
+	symbol string
+	id     int64
+}
+
+// OrdersQueue is safe for concurrent use, orders are read through snapshots and an order
+// is processed only by the worker that claimed it.
 type OrdersQueue struct {
 	Exchange string
-	Orders   map[string]map[int64]*Order
+	orders   map[string]map[int64]*Order
+	triggers map[string]*triggerIndex
+	claimed  map[orderKey]struct{}
 	sync.RWMutex
 }
 
 func NewOrderQueue(exchange string) *OrdersQueue {
 	return &OrdersQueue{
 		Exchange: exchange,
-		Orders:   make(map[string]map[int64]*Order),
+		orders:   make(map[string]map[int64]*Order),
+		triggers: make(map[string]*triggerIndex),
+		claimed:  make(map[orderKey]struct{}),
 	}
 }
 
 func (ol *OrdersQueue) Add(order *Order) bool {
 	ol.Lock()
 	defer ol.Unlock()
-	_, ok := ol.Orders[order.Symbol]
+	_, ok := ol.orders[order.Symbol]
 	if !ok {
-		ol.Orders[order.Symbol] = make(map[int64]*Order)
+		ol.orders[order.Symbol] = make(map[int64]*Order)
+		ol.triggers[order.Symbol] = newTriggerIndex()
 	}
 
-	ol.Orders[order.Symbol][order.Id] = order
+	ol.orders[order.Symbol][order.Id] = order
+	ol.triggers[order.Symbol].add(order)
 
 	return true
 }
 
+// Remove reports whether the order was in the queue, so of several concurrent callers only one gets true.
 func (ol *OrdersQueue) Remove(symbol string, id int64) bool {
-	_, ok := ol.Orders[symbol]
+	ol.Lock()
+	defer ol.Unlock()
+	orders, ok := ol.orders[symbol]
 	if !ok {
 		return false
 	}
+	if _, ok = orders[id]; !ok {
+		return false
+	}
+	delete(orders, id)
+	ol.triggers[symbol].remove(id)
+	if len(orders) == 0 {
+		delete(ol.orders, symbol)
+		delete(ol.triggers, symbol)
+	}
+
+	return true
+}
+
+// Claim marks a queued order as being processed, it fails while another worker holds the order.
+func (ol *OrdersQueue) Claim(symbol string, id int64) bool {
 	ol.Lock()
 	defer ol.Unlock()
-	delete(ol.Orders[symbol], id)
+	if _, ok := ol.orders[symbol][id]; !ok {
+		return false
+	}
+	key := orderKey{symbol: symbol, id: id}
+	if _, ok := ol.claimed[key]; ok {
+		return false
+	}
+	ol.claimed[key] = struct{}{}
+
 	return true
 }
 
+func (ol *OrdersQueue) Release(symbol string, id int64) {
+	ol.Lock()
+	defer ol.Unlock()
+	delete(ol.claimed, orderKey{symbol: symbol, id: id})
+}
+
+// UpdatePrice moves the order to its new trigger price, order prices of the queue must change only here
+// and only while the caller holds the order lock.
 func (ol *OrdersQueue) UpdatePrice(symbol string, id int64, price string) bool {
-	_, ok := ol.Orders[symbol]
-	if !ok {
-		return false
-	}
 	ol.Lock()
 	defer ol.Unlock()
-	order, ok := ol.Orders[symbol][id]
+	order, ok := ol.orders[symbol][id]
 	if !ok {
 		return false
 	}
 	order.Price = price
-	delete(ol.Orders[symbol], id)
-	ol.Orders[symbol][id] = order
+	ol.triggers[symbol].add(order)
 
 	return true
 }
 
+// Triggered returns the tp/sl orders of the symbol crossed by the trade price and its trailing stops.
+func (ol *OrdersQueue) Triggered(symbol string, price *big.Float) []*Order {
+	ol.RLock()
+	defer ol.RUnlock()
+	index, ok := ol.triggers[symbol]
+	if !ok || price == nil {
+		return nil
+	}
+
+	return index.triggered(price)
+}
+
+// Snapshot returns the queued orders at the moment of the call, it is safe to range over
+// while the queue changes.
+func (ol *OrdersQueue) Snapshot() []*Order {
+	ol.RLock()
+	defer ol.RUnlock()
+
+	result := make([]*Order, 0, ol.len())
+	for _, orders := range ol.orders {
+		for _, order := range orders {
+			result = append(result, order)
+		}
+	}
+
+	return result
+}
+
 func (ol *OrdersQueue) Len() int {
-	return len(ol.Orders)
+	ol.RLock()
+	defer ol.RUnlock()
+
+	return ol.len()
 }
 
 func (ol *OrdersQueue) Exist(symbol string, id int64) bool {
-	val, ok := ol.Orders[symbol]
-	if !ok {
-		return false
-	}
-	_, ok = val[id]
+	ol.RLock()
+	defer ol.RUnlock()
+	_, ok := ol.orders[symbol][id]
 
 	return ok
 }
+
+func (ol *OrdersQueue) Symbols() []string {
+	ol.RLock()
+	defer ol.RUnlock()
+
+	symbols := make([]string, 0, len(ol.orders))
+	for symbol, orders := range ol.orders {
+		if len(orders) > 0 {
+			symbols = append(symbols, symbol)
+		}
+	}
+
+	return symbols
+}
+
+func (ol *OrdersQueue) HasSymbol(symbol string) bool {
+	ol.RLock()
+	defer ol.RUnlock()
+
+	return len(ol.orders[symbol]) > 0
+}
+
+func (ol *OrdersQueue) Get(symbol string, id int64) *Order {
+	ol.RLock()
+	defer ol.RUnlock()
+
+	return ol.orders[symbol][id]
+}
+
+func (ol *OrdersQueue) GetByExchangeOrderId(symbol string, exchangeOrderId int64) *Order {
+	ol.RLock()
+	defer ol.RUnlock()
+	for _, order := range ol.orders[symbol] {
+		if order.ExchangeOrderId() == exchangeOrderId {
+			return order
+		}
+	}
+
+	return nil
+}
+
+func (ol *OrdersQueue) UserIds() []int64 {
+	ol.RLock()
+	defer ol.RUnlock()
+	users := make(map[int64]struct{})
+	for _, orders := range ol.orders {
+		for _, order := range orders {
+			users[order.UserId] = struct{}{}
+		}
+	}
+
+	result := make([]int64, 0, len(users))
+	for userId := range users {
+		result = append(result, userId)
+	}
+
+	return result
+}
+
+func (ol *OrdersQueue) len() int {
+	count := 0
+	for _, orders := range ol.orders {
+		count += len(orders)
+	}
+
+	return count
+}
+
+type ExchangeQueues map[string]*OrdersQueue
+
+func NewExchangeQueues(exchanges []string) ExchangeQueues {
+	queues := make(ExchangeQueues, len(exchanges))
+	for _, exchange := range exchanges {
+		queues[exchange] = NewOrderQueue(exchange)
+	}
+
+	return queues
+}
+
+func (eq ExchangeQueues) Get(exchange string) *OrdersQueue {
+	return eq[exchange]
+}
+
+func (eq ExchangeQueues) HasSymbol(exchange, symbol string) bool {
+	queue, ok := eq[exchange]
+
+	return ok && queue.HasSymbol(symbol)
+}
@@ -0,0 +1,26 @@
+package domain
+
+import "errors"
+
+var (
+	ErrPaperInsufficientBalance = errors.New("err insufficient paper balance")
+	ErrPaperOrderNotFound       = errors.New("err paper order not found")
+)
+
+type PaperOrder struct {
+	Id            int64
+	UserId        int64
+	Symbol        string
+	Side          string
+	OrderType     string
+	Status        string
+	Quantity      string
+	Price         string
+	StopPrice     string
+	TimeInForce   string
+	ReserveAsset  string
+	ReserveAmount string
+	ReceiveAsset  string
+	ReceiveAmount string
+	Date
+}
@@ -0,0 +1,179 @@
+package domain
+
+import (
+	"math/big"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+type PnlSymbol struct {
+	Exchange      string `json:"exchange"`
+	Symbol        string `json:"symbol"`
+	Currency      string `json:"currency"`
+	RealizedPnl   string `json:"realizedPnl"`
+	UnrealizedPnl string `json:"unrealizedPnl"`
+	Fees          string `json:"fees"`
+	TotalPnl      string `json:"totalPnl"`
+	ClosedDeals   int    `json:"closedDeals"`
+	OpenDeals     int    `json:"openDeals"`
+	Wins          int    `json:"wins"`
+	WinRate       string `json:"winRate"`
+	AvgR          string `json:"avgR"`
+
+	stats pnlStats
+	rate  *big.Rat
+}
+
+type PnlReport struct {
+	Period        string       `json:"period"`
+	From          time.Time    `json:"from"`
+	To            time.Time    `json:"to"`
+	Currency      string       `json:"currency"`
+	RealizedPnl   string       `json:"realizedPnl"`
+	UnrealizedPnl string       `json:"unrealizedPnl"`
+	Fees          string       `json:"fees"`
+	TotalPnl      string       `json:"totalPnl"`
+	ClosedDeals   int          `json:"closedDeals"`
+	OpenDeals     int          `json:"openDeals"`
+	Wins          int          `json:"wins"`
+	WinRate       string       `json:"winRate"`
+	AvgR          string       `json:"avgR"`
+	Symbols       []*PnlSymbol `json:"symbols"`
+
+	symbols map[string]*PnlSymbol
+}
+
+type pnlStats struct {
+	realized    big.Rat
+	unrealized  big.Rat
+	fees        big.Rat
+	rSum        big.Rat
+	rCount      int
+	closedDeals int
+	openDeals   int
+	wins        int
+}
+
+func PnlPeriodStart(period string, now time.Time) (time.Time, bool) {
+	now = now.UTC()
+	day := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch strings.ToLower(period) {
+	case consts.PnlPeriodDay:
+		return day, true
+	case consts.PnlPeriodWeek:
+		return day.AddDate(0, 0, -(int(day.Weekday())+6)%7), true
+	case consts.PnlPeriodMonth:
+		return time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.UTC), true
+	default:
+		return time.Time{}, false
+	}
+}
+
+func NewPnlReport(period string, from, to time.Time) *PnlReport {
+	return &PnlReport{
+		Period:   period,
+		From:     from,
+		To:       to,
+		Currency: UsdtAsset,
+		Symbols:  make([]*PnlSymbol, 0),
+		symbols:  make(map[string]*PnlSymbol),
+	}
+}
+
+// Add accounts a calculated deal. Symbols without usdtRate stay in their quote currency and out of the totals.
+func (r *PnlReport) Add(deal *Deal, markPrice, usdtRate string) {
+	if deal.Status != consts.DealStatusOpen && deal.Status != consts.DealStatusClosed {
+		return
+	}
+	if deal.Status == consts.DealStatusClosed && deal.ClosedAt.Before(r.From) {
+		return
+	}
+
+	key := deal.BaseOrder.Exchange + "_" + deal.BaseOrder.Symbol
+	symbol, ok := r.symbols[key]
+	if !ok {
+		symbol = &PnlSymbol{
+			Exchange: deal.BaseOrder.Exchange,
+			Symbol:   deal.BaseOrder.Symbol,
+			Currency: QuoteAsset(deal.BaseOrder.Symbol),
+		}
+		if rate, ok := new(big.Rat).SetString(usdtRate); ok {
+			symbol.Currency = UsdtAsset
+			symbol.rate = rate
+		}
+		r.symbols[key] = symbol
+		r.Symbols = append(r.Symbols, symbol)
+	}
+
+	stats := &symbol.stats
+	stats.realized.Add(&stats.realized, ratOrZero(deal.Pnl))
+	stats.fees.Add(&stats.fees, ratOrZero(deal.Fee))
+	if deal.Status == consts.DealStatusOpen {
+		stats.openDeals++
+		stats.unrealized.Add(&stats.unrealized, ratOrZero(deal.UnrealizedPnl(markPrice)))
+
+		return
+	}
+
+	stats.closedDeals++
+	if ratOrZero(deal.NetPnl).Sign() > 0 {
+		stats.wins++
+	}
+	if rMultiple, ok := deal.RMultiple(); ok {
+		stats.rSum.Add(&stats.rSum, ratOrZero(rMultiple))
+		stats.rCount++
+	}
+}
+
+func (r *PnlReport) Build() *PnlReport {
+	var total pnlStats
+	for _, v := range r.Symbols {
+		stats := &v.stats
+		if v.rate != nil {
+			stats.realized.Mul(&stats.realized, v.rate)
+			stats.unrealized.Mul(&stats.unrealized, v.rate)
+			stats.fees.Mul(&stats.fees, v.rate)
+			total.realized.Add(&total.realized, &stats.realized)
+			total.unrealized.Add(&total.unrealized, &stats.unrealized)
+			total.fees.Add(&total.fees, &stats.fees)
+		}
+		total.rSum.Add(&total.rSum, &stats.rSum)
+		total.rCount += stats.rCount
+		total.closedDeals += stats.closedDeals
+		total.openDeals += stats.openDeals
+		total.wins += stats.wins
+
+		v.RealizedPnl, v.UnrealizedPnl, v.Fees, v.TotalPnl = stats.amounts()
+		v.ClosedDeals, v.OpenDeals, v.Wins, v.WinRate, v.AvgR = stats.counters()
+	}
+
+	r.RealizedPnl, r.UnrealizedPnl, r.Fees, r.TotalPnl = total.amounts()
+	r.ClosedDeals, r.OpenDeals, r.Wins, r.WinRate, r.AvgR = total.counters()
+	sort.SliceStable(r.Symbols, func(i, j int) bool {
+		return r.Symbols[i].Exchange+r.Symbols[i].Symbol < r.Symbols[j].Exchange+r.Symbols[j].Symbol
+	})
+
+	return r
+}
+
+func (s *pnlStats) amounts() (realized, unrealized, fees, total string) {
+	sum := new(big.Rat).Sub(&s.realized, &s.fees)
+	sum.Add(sum, &s.unrealized)
+
+	return formatQuantity(&s.realized), formatQuantity(&s.unrealized), formatQuantity(&s.fees), formatQuantity(sum)
+}
+
+func (s *pnlStats) counters() (closed, open, wins int, winRate, avgR string) {
+	winRate, avgR = "0", "0"
+	if s.closedDeals > 0 {
+		winRate = new(big.Rat).SetFrac64(int64(s.wins)*100, int64(s.closedDeals)).FloatString(2)
+	}
+	if s.rCount > 0 {
+		avgR = new(big.Rat).Quo(&s.rSum, big.NewRat(int64(s.rCount), 1)).FloatString(2)
+	}
+
+	return s.closedDeals, s.openDeals, s.wins, winRate, avgR
+}
@@ -0,0 +1,92 @@
+package domain
+
+import (
+	"math/big"
+	"sort"
+)
+
+type PortfolioAsset struct {
+	Exchange string `json:"exchange"`
+	Asset    string `json:"asset"`
+	Free     string `json:"free"`
+	Locked   string `json:"locked"`
+	Total    string `json:"total"`
+	Price    string `json:"price"`
+	Value    string `json:"value"`
+	Share    string `json:"share"`
+
+	value *big.Rat
+}
+
+type Portfolio struct {
+	Currency  string            `json:"currency"`
+	Total     string            `json:"total"`
+	Exchanges []string          `json:"exchanges"`
+	Assets    []*PortfolioAsset `json:"assets"`
+
+	total big.Rat
+	dust  *big.Rat
+}
+
+func NewPortfolio(dustValue string) *Portfolio {
+	return &Portfolio{
+		Currency:  UsdtAsset,
+		Exchanges: make([]string, 0),
+		Assets:    make([]*PortfolioAsset, 0),
+		dust:      ratOrZero(dustValue),
+	}
+}
+
+// Add values a balance row at usdtPrice. Rows without a price are kept unless empty, but stay out of the total.
+func (p *Portfolio) Add(exchange string, balance BalanceSymbol, usdtPrice string) {
+	if balance.IsEmpty() {
+		return
+	}
+	free, locked := ratOrZero(balance.Quantity), ratOrZero(balance.Locked)
+	total := new(big.Rat).Add(free, locked)
+
+	asset := &PortfolioAsset{
+		Exchange: exchange,
+		Asset:    balance.Symbol,
+		Free:     formatQuantity(free),
+		Locked:   formatQuantity(locked),
+		Total:    formatQuantity(total),
+	}
+	if price, ok := new(big.Rat).SetString(usdtPrice); ok {
+		asset.value = new(big.Rat).Mul(total, price)
+		if asset.value.Cmp(p.dust) < 0 {
+			return
+		}
+		asset.Price = formatQuantity(price)
+		asset.Value = asset.value.FloatString(2)
+		p.total.Add(&p.total, asset.value)
+	}
+
+	p.Assets = append(p.Assets, asset)
+}
+
+func (p *Portfolio) AddExchange(exchange string) {
+	p.Exchanges = append(p.Exchanges, exchange)
+}
+
+func (p *Portfolio) Build() *Portfolio {
+	p.Total = p.total.FloatString(2)
+	for _, v := range p.Assets {
+		if v.value == nil || p.total.Sign() == 0 {
+			continue
+		}
+		share := new(big.Rat).Quo(v.value, &p.total)
+		v.Share = share.Mul(share, big.NewRat(100, 1)).FloatString(2)
+	}
+
+	sort.SliceStable(p.Assets, func(i, j int) bool {
+		left, right := p.Assets[i].value, p.Assets[j].value
+		if left == nil || right == nil {
+			return right == nil && left != nil
+		}
+
+		return left.Cmp(right) > 0
+	})
+
+	return p
+}
@@ -0,0 +1,39 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+var (
+	ErrRiskNoBalance    = errors.New("err balance is too small for the risk")
+	ErrRiskStopDistance = errors.New("err stop loss must be on the losing side of the entry price")
+)
+
+// RiskQuantity sizes a position so that hitting slPrice loses riskPercent of balance.
+// The result is not rounded to the symbol lot step.
+func RiskQuantity(side, balance, riskPercent, entryPrice, slPrice string) (string, error) {
+	riskAmount := new(big.Rat).Mul(ratOrZero(balance), ratOrZero(riskPercent))
+	riskAmount.Quo(riskAmount, big.NewRat(100, 1))
+	if riskAmount.Sign() <= 0 {
+		return "", ErrRiskNoBalance
+	}
+
+	entry, sl := ratOrZero(entryPrice), ratOrZero(slPrice)
+	distance := new(big.Rat).Sub(entry, sl)
+	if side == consts.OrderSideSell {
+		distance.Neg(distance)
+	}
+	if distance.Sign() <= 0 || sl.Sign() <= 0 {
+		return "", ErrRiskStopDistance
+	}
+
+	quantity := formatQuantity(riskAmount.Quo(riskAmount, distance))
+	if quantity == "0" {
+		return "", ErrRiskNoBalance
+	}
+
+	return quantity, nil
+}
@@ -0,0 +1,97 @@
+package domain
+
+import (
+	"math/big"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+// PriceAlert pings the user on a price level or move. Value is a price for level conditions
+// and a percent for a move from BasePrice.
+type PriceAlert struct {
+	Id          int64      `json:"id"`
+	UserId      int64      `json:"userId"`
+	Exchange    string     `json:"exchange"`
+	Symbol      string     `json:"symbol"`
+	Condition   string     `json:"condition"`
+	Value       string     `json:"value"`
+	BasePrice   string     `json:"basePrice"`
+	Recurring   bool       `json:"recurring"`
+	Armed       bool       `json:"armed"`
+	Status      string     `json:"status"`
+	TriggeredAt *time.Time `json:"triggeredAt"`
+	CreatedAt   time.Time  `json:"createdAt"`
+	UpdatedAt   time.Time  `json:"updatedAt"`
+
+	lastPrice string
+}
+
+func (a *PriceAlert) IsActive() bool {
+	return a.Status == consts.AlertStatusActive
+}
+
+// Check evaluates the alert at price. Level alerts fire once per crossing: a recurring one re-arms
+// after the price is back on the other side. A recurring move alert measures the next move from price.
+func (a *PriceAlert) Check(price string) bool {
+	current := ratOrZero(price)
+	if current.Sign() <= 0 || !a.IsActive() {
+		return false
+	}
+	value := ratOrZero(a.Value)
+
+	var triggered bool
+	switch a.Condition {
+	case consts.AlertConditionAbove, consts.AlertConditionBelow:
+		hit := current.Cmp(value) >= 0
+		if a.Condition == consts.AlertConditionBelow {
+			hit = current.Cmp(value) <= 0
+		}
+		triggered = hit && a.Armed
+		a.Armed = !hit
+	case consts.AlertConditionCrosses:
+		last := a.lastPrice
+		if last == "" {
+			last = a.BasePrice
+		}
+		a.lastPrice = price
+		if lastPrice := ratOrZero(last); lastPrice.Sign() > 0 {
+			triggered = (lastPrice.Cmp(value) < 0) != (current.Cmp(value) < 0)
+		}
+	case consts.AlertConditionMove:
+		triggered = isPositive(a.BasePrice) && a.moveFrom(current).Cmp(value) >= 0
+		if triggered && a.Recurring {
+			a.BasePrice = price
+		}
+	}
+
+	if triggered {
+		now := time.Now().UTC()
+		a.TriggeredAt = &now
+		if !a.Recurring {
+			a.Status = consts.AlertStatusTriggered
+		}
+	}
+
+	return triggered
+}
+
+// Change is the percent move of price from BasePrice.
+func (a *PriceAlert) Change(price string) string {
+	base := ratOrZero(a.BasePrice)
+	if base.Sign() == 0 {
+		return "0"
+	}
+	change := new(big.Rat).Sub(ratOrZero(price), base)
+	change.Mul(change, big.NewRat(100, 1))
+
+	return change.Quo(change, base).FloatString(2)
+}
+
+func (a *PriceAlert) moveFrom(current *big.Rat) *big.Rat {
+	base := ratOrZero(a.BasePrice)
+	move := new(big.Rat).Sub(current, base)
+	move.Abs(move).Mul(move, big.NewRat(100, 1))
+
+	return move.Quo(move, base)
+}
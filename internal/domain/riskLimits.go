@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRiskLimitPaused      = errors.New("err trading is paused by the daily loss limit")
+	ErrRiskLimitSymbol      = errors.New("err symbol is not allowed")
+	ErrRiskLimitNotional    = errors.New("err order value is above the limit")
+	ErrRiskLimitOpenDeals   = errors.New("err too many open deals")
+	ErrRiskLimitDailyLoss   = errors.New("err daily loss limit is reached")
+	ErrRiskLimitNoUsdtPrice = errors.New("err no usdt price to check the order value")
+	ErrRiskLimitLoosen      = errors.New("err only admins can loosen risk limits")
+)
+
+// RiskLimits are per-user safeguards checked before an order is placed. Empty and zero values mean no limit.
+type RiskLimits struct {
+	UserId           int64      `json:"userId"`
+	MaxOrderNotional string     `json:"maxOrderNotional"`
+	MaxOpenDeals     int        `json:"maxOpenDeals"`
+	MaxDailyLoss     string     `json:"maxDailyLoss"`
+	AllowedSymbols   []string   `json:"allowedSymbols"`
+	PausedUntil      *time.Time `json:"pausedUntil,omitempty"`
+}
+
+func (l *RiskLimits) IsPaused(now time.Time) bool {
+	return l.PausedUntil != nil && now.Before(*l.PausedUntil)
+}
+
+func (l *RiskLimits) IsSymbolAllowed(symbol string) bool {
+	if len(l.AllowedSymbols) == 0 {
+		return true
+	}
+	for _, v := range l.AllowedSymbols {
+		if strings.EqualFold(v, symbol) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// IsTighterThan reports whether every limit of current stays at least as strict in l.
+func (l *RiskLimits) IsTighterThan(current *RiskLimits) bool {
+	if !isTighterAmount(l.MaxOrderNotional, current.MaxOrderNotional) ||
+		!isTighterAmount(l.MaxDailyLoss, current.MaxDailyLoss) {
+		return false
+	}
+	if current.MaxOpenDeals > 0 && (l.MaxOpenDeals <= 0 || l.MaxOpenDeals > current.MaxOpenDeals) {
+		return false
+	}
+	if len(current.AllowedSymbols) == 0 {
+		return true
+	}
+	if len(l.AllowedSymbols) == 0 {
+		return false
+	}
+	for _, v := range l.AllowedSymbols {
+		if !current.IsSymbolAllowed(v) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func (l *RiskLimits) HasNotionalLimit() bool {
+	return isPositive(l.MaxOrderNotional)
+}
+
+func (l *RiskLimits) HasDailyLossLimit() bool {
+	return isPositive(l.MaxDailyLoss)
+}
+
+// CheckNotional compares the order value converted with usdtRate against the limit.
+func (l *RiskLimits) CheckNotional(price, quantity, usdtRate string) error {
+	if !l.HasNotionalLimit() {
+		return nil
+	}
+	rate := ratOrZero(usdtRate)
+	if rate.Sign() <= 0 || ratOrZero(price).Sign() <= 0 {
+		return ErrRiskLimitNoUsdtPrice
+	}
+
+	notional := new(big.Rat).Mul(ratOrZero(price), ratOrZero(quantity))
+	if notional.Mul(notional, rate).Cmp(ratOrZero(l.MaxOrderNotional)) > 0 {
+		return ErrRiskLimitNotional
+	}
+
+	return nil
+}
+
+func (l *RiskLimits) IsOpenDealsReached(openDeals int) bool {
+	return l.MaxOpenDeals > 0 && openDeals >= l.MaxOpenDeals
+}
+
+// IsDailyLossReached takes the day total pnl in USDT, losses are negative.
+func (l *RiskLimits) IsDailyLossReached(dayPnl string) bool {
+	if !l.HasDailyLossLimit() {
+		return false
+	}
+	loss := new(big.Rat).Neg(ratOrZero(dayPnl))
+
+	return loss.Cmp(ratOrZero(l.MaxDailyLoss)) >= 0
+}
+
+func isTighterAmount(val, current string) bool {
+	if !isPositive(current) {
+		return true
+	}
+
+	return isPositive(val) && ratOrZero(val).Cmp(ratOrZero(current)) <= 0
+}
@@ -1,22 +1,43 @@
 package domain
 
+type TpLevel struct {
+	Percent string `json:"percent"`
+	Price   string `json:"price"`
+	Share   string `json:"share"`
+}
+
 type Settings struct {
-	Id        int64  `json:"id"`
-	OrderId   int64  `json:"order_id"`
-	TpPercent string `json:"tp_percent"`
-	SlPercent string `json:"sl_percent"`
-	TpPrice   string `json:"tp_price"`
-	SlPrice   string `json:"sl_price"`
-	TpType    string `json:"tp_type"`
-	SlType    string `json:"sl_type"`
-	Ts        string `json:"ts"`
+	Id          int64     `json:"id"`
+	OrderId     int64     `json:"order_id"`
+	TpPercent   string    `json:"tp_percent"`
+	SlPercent   string    `json:"sl_percent"`
+	TpPrice     string    `json:"tp_price"`
+	SlPrice     string    `json:"sl_price"`
+	TpType      string    `json:"tp_type"`
+	SlType      string    `json:"sl_type"`
+	Ts          string    `json:"ts"`
+	TpLevels    []TpLevel `json:"tp_levels"`
+	SlBreakeven bool      `json:"sl_breakeven"`
 	Date
 }
 
 func (s *Settings) IsTpEmpty() bool {
-	return s.TpPrice == "" && s.TpPercent == ""
+	return s.TpPrice == "" && s.TpPercent == "" && !s.HasTpLadder()
 }
 
 func (s *Settings) IsSlEmpty() bool {
 	return s.SlPrice == "" && s.SlPercent == ""
 }
+
+func (s *Settings) HasTpLadder() bool {
+	return len(s.TpLevels) != 0
+}
+
+func (s *Settings) GetTpShares() []string {
+	shares := make([]string, 0, len(s.TpLevels))
+	for _, v := range s.TpLevels {
+		shares = append(shares, v.Share)
+	}
+
+	return shares
+}
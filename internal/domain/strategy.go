@@ -0,0 +1,248 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+var (
+	ErrStrategyDeviation = errors.New("err safety orders deviation reaches zero price")
+	ErrStrategyGridRange = errors.New("err no grid levels below the current price")
+)
+
+// DcaParams describe a DCA bot: a market base order, safety orders placed deviation percent apart
+// (each next step stepScale times wider and volumeScale times bigger) and one take profit on the average entry.
+type DcaParams struct {
+	Side         string `json:"side"`
+	BaseQty      string `json:"baseQty"`
+	SafetyOrders int    `json:"safetyOrders"`
+	Deviation    string `json:"deviation"`
+	StepScale    string `json:"stepScale"`
+	VolumeScale  string `json:"volumeScale"`
+	TpPercent    string `json:"tpPercent"`
+}
+
+// GridParams describe a spot grid: levels equal steps between lower and upper with quantity per order.
+type GridParams struct {
+	Lower    string `json:"lower"`
+	Upper    string `json:"upper"`
+	Levels   int    `json:"levels"`
+	Quantity string `json:"quantity"`
+}
+
+type StrategyOrder struct {
+	StrategyId int64  `json:"strategyId"`
+	OrderId    int64  `json:"orderId"`
+	Role       string `json:"role"`
+	Level      int    `json:"level"`
+	Side       string `json:"side"`
+	Price      string `json:"price"`
+	Quantity   string `json:"quantity"`
+	Status     string `json:"status"`
+}
+
+type StrategyLevel struct {
+	Level    int
+	Side     string
+	Price    string
+	Quantity string
+}
+
+type Strategy struct {
+	Id        int64            `json:"id"`
+	UserId    int64            `json:"userId"`
+	Type      string           `json:"type"`
+	Exchange  string           `json:"exchange"`
+	Symbol    string           `json:"symbol"`
+	Status    string           `json:"status"`
+	Dca       *DcaParams       `json:"dca,omitempty"`
+	Grid      *GridParams      `json:"grid,omitempty"`
+	Orders    []*StrategyOrder `json:"orders"`
+	CreatedAt time.Time        `json:"createdAt"`
+	UpdatedAt time.Time        `json:"updatedAt"`
+}
+
+func (s *Strategy) IsActive() bool {
+	return s.Status == consts.StrategyStatusActive
+}
+
+func (s *Strategy) GetOrder(orderId int64) *StrategyOrder {
+	for _, v := range s.Orders {
+		if v.OrderId == orderId {
+			return v
+		}
+	}
+
+	return nil
+}
+
+// GetActiveOrders returns orders still open on the exchange, all roles when none is given.
+func (s *Strategy) GetActiveOrders(roles ...string) []*StrategyOrder {
+	result := make([]*StrategyOrder, 0, len(s.Orders))
+	for _, v := range s.Orders {
+		if v.Status != consts.OrderStatusActive {
+			continue
+		}
+		if len(roles) == 0 {
+			result = append(result, v)
+
+			continue
+		}
+		for _, role := range roles {
+			if v.Role == role {
+				result = append(result, v)
+
+				break
+			}
+		}
+	}
+
+	return result
+}
+
+// HasActiveLevel reports whether an open order already covers the level, so a retried fill does not
+// place its opposite order twice.
+func (s *Strategy) HasActiveLevel(level StrategyLevel) bool {
+	for _, v := range s.GetActiveOrders(consts.StrategyOrderGrid) {
+		if v.Level == level.Level && v.Side == level.Side {
+			return true
+		}
+	}
+
+	return false
+}
+
+// AverageEntry sums filled base and safety orders of a DCA bot.
+func (s *Strategy) AverageEntry() (price, quantity string) {
+	total, value := new(big.Rat), new(big.Rat)
+	for _, v := range s.Orders {
+		if v.Status != consts.OrderStatusFilled || (v.Role != consts.StrategyOrderBase && v.Role != consts.StrategyOrderSafety) {
+			continue
+		}
+		qty := ratOrZero(v.Quantity)
+		total.Add(total, qty)
+		value.Add(value, qty.Mul(qty, ratOrZero(v.Price)))
+	}
+	if total.Sign() == 0 {
+		return "", "0"
+	}
+
+	return formatQuantity(value.Quo(value, total)), formatQuantity(total)
+}
+
+// GridProfit is the quote profit of closed grid cycles: every filled sell earns one grid step on its quantity.
+func (s *Strategy) GridProfit() string {
+	if s.Grid == nil {
+		return "0"
+	}
+	step := s.Grid.step()
+	profit := new(big.Rat)
+	for _, v := range s.Orders {
+		if v.Role == consts.StrategyOrderGrid && v.Side == consts.OrderSideSell && v.Status == consts.OrderStatusFilled {
+			profit.Add(profit, new(big.Rat).Mul(step, ratOrZero(v.Quantity)))
+		}
+	}
+
+	return formatQuantity(profit)
+}
+
+func (p *DcaParams) TpSide() string {
+	return GetTpSlSide(p.Side)
+}
+
+// SafetyLevels lists safety orders for the base order filled at basePrice.
+func (p *DcaParams) SafetyLevels(basePrice string) []StrategyLevel {
+	levels := make([]StrategyLevel, 0, p.SafetyOrders)
+	stepScale, volumeScale := ratOrOne(p.StepScale), ratOrOne(p.VolumeScale)
+	step := ratOrZero(p.Deviation)
+	deviation := new(big.Rat)
+	quantity := ratOrZero(p.BaseQty)
+	for i := 1; i <= p.SafetyOrders; i++ {
+		deviation.Add(deviation, step)
+		step = new(big.Rat).Mul(step, stepScale)
+		quantity = new(big.Rat).Mul(quantity, volumeScale)
+
+		levels = append(levels, StrategyLevel{
+			Level:    i,
+			Side:     p.Side,
+			Price:    percentPrice(basePrice, deviation.FloatString(8), p.Side, consts.SlOrderType),
+			Quantity: formatQuantity(quantity),
+		})
+	}
+
+	return levels
+}
+
+// CheckLevels rejects safety orders priced at zero or below.
+func (p *DcaParams) CheckLevels(basePrice string) error {
+	for _, v := range p.SafetyLevels(basePrice) {
+		if !isPositive(v.Price) {
+			return ErrStrategyDeviation
+		}
+	}
+
+	return nil
+}
+
+func (p *DcaParams) TakeProfitPrice(avgPrice string) string {
+	return percentPrice(avgPrice, p.TpPercent, p.Side, consts.TpOrderType)
+}
+
+func (p *GridParams) Prices() []string {
+	step := p.step()
+	prices := make([]string, 0, p.Levels+1)
+	for i := 0; i <= p.Levels; i++ {
+		price := new(big.Rat).Mul(step, big.NewRat(int64(i), 1))
+		prices = append(prices, formatQuantity(price.Add(price, ratOrZero(p.Lower))))
+	}
+
+	return prices
+}
+
+// InitialLevels places buys on every level below the current price. Sells appear as the buys fill.
+func (p *GridParams) InitialLevels(currentPrice string) []StrategyLevel {
+	current := ratOrZero(currentPrice)
+	prices := p.Prices()
+	levels := make([]StrategyLevel, 0, len(prices))
+	for i := 0; i < p.Levels; i++ {
+		if ratOrZero(prices[i]).Cmp(current) >= 0 {
+			break
+		}
+		levels = append(levels, StrategyLevel{Level: i, Side: consts.OrderSideBuy, Price: prices[i], Quantity: p.Quantity})
+	}
+
+	return levels
+}
+
+// OppositeLevel is a sell one level above a filled buy or a buy one level below a filled sell.
+func (p *GridParams) OppositeLevel(filled *StrategyOrder) (StrategyLevel, bool) {
+	level, side := filled.Level+1, consts.OrderSideSell
+	if filled.Side == consts.OrderSideSell {
+		level, side = filled.Level-1, consts.OrderSideBuy
+	}
+	if level < 0 || level > p.Levels {
+		return StrategyLevel{}, false
+	}
+
+	return StrategyLevel{Level: level, Side: side, Price: p.Prices()[level], Quantity: filled.Quantity}, true
+}
+
+func (p *GridParams) step() *big.Rat {
+	if p.Levels <= 0 {
+		return new(big.Rat)
+	}
+	step := new(big.Rat).Sub(ratOrZero(p.Upper), ratOrZero(p.Lower))
+
+	return step.Quo(step, big.NewRat(int64(p.Levels), 1))
+}
+
+func ratOrOne(val string) *big.Rat {
+	if rat := ratOrZero(val); rat.Sign() > 0 {
+		return rat
+	}
+
+	return big.NewRat(1, 1)
+}
@@ -1,5 +1,13 @@
 package domain
 
+import "strings"
+
+const UsdtAsset = "USDT"
+
+var QuoteCurrencies = []string{"USDT", "BUSD", "USDC", "TUSD", "FDUSD", "BTC", "ETH", "BNB", "EUR", "TRY"}
+
+var usdStablecoins = []string{"USDT", "BUSD", "USDC", "TUSD", "FDUSD"}
+
 type Symbol string
 
 type SymbolList []Symbol
@@ -12,3 +20,37 @@ type Symbols struct {
 func (sl SymbolList) IsEmpty() bool {
 	return len(sl) == 0
 }
+
+func QuoteAsset(symbol string) string {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if idx := strings.LastIndex(symbol, "-"); idx >= 0 {
+		return symbol[idx+1:]
+	}
+	for _, quote := range QuoteCurrencies {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return quote
+		}
+	}
+
+	return ""
+}
+
+func IsUsdStablecoin(asset string) bool {
+	asset = strings.ToUpper(asset)
+	for _, v := range usdStablecoins {
+		if v == asset {
+			return true
+		}
+	}
+
+	return false
+}
+
+func UsdtSymbol(asset, likeSymbol string) string {
+	asset = strings.ToUpper(asset)
+	if strings.Contains(likeSymbol, "-") {
+		return asset + "-" + UsdtAsset
+	}
+
+	return asset + UsdtAsset
+}
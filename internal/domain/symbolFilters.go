@@ -0,0 +1,117 @@
+package domain
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+)
+
+var (
+	ErrFilterMinQty       = errors.New("err quantity is below the minimum lot size")
+	ErrFilterMaxQty       = errors.New("err quantity is above the maximum lot size")
+	ErrFilterMinPrice     = errors.New("err price is below the minimum price")
+	ErrFilterMaxPrice     = errors.New("err price is above the maximum price")
+	ErrFilterMinNotional  = errors.New("err order value is below the minimum notional")
+	ErrFilterPercentPrice = errors.New("err price is too far from the market price")
+)
+
+// SymbolFilters holds exchange trading rules of a symbol. Empty values mean the rule is not set.
+type SymbolFilters struct {
+	Symbol         string `json:"symbol"`
+	TickSize       string `json:"tickSize"`
+	MinPrice       string `json:"minPrice"`
+	MaxPrice       string `json:"maxPrice"`
+	StepSize       string `json:"stepSize"`
+	MinQty         string `json:"minQty"`
+	MaxQty         string `json:"maxQty"`
+	MinNotional    string `json:"minNotional"`
+	MultiplierUp   string `json:"multiplierUp"`
+	MultiplierDown string `json:"multiplierDown"`
+}
+
+// RoundPrice rounds price to the nearest tick.
+func (f *SymbolFilters) RoundPrice(price string) string {
+	return roundToStep(price, f.TickSize, true)
+}
+
+// RoundQuantity rounds quantity down to the lot step.
+func (f *SymbolFilters) RoundQuantity(quantity string) string {
+	return roundToStep(quantity, f.StepSize, false)
+}
+
+// Validate checks rounded values. Notional is checked against price or markPrice when price is empty,
+// percent price only when markPrice is set.
+func (f *SymbolFilters) Validate(price, quantity, markPrice string) error {
+	qty := ratOrZero(quantity)
+	if isPositive(f.MinQty) && qty.Cmp(ratOrZero(f.MinQty)) < 0 {
+		return ErrFilterMinQty
+	}
+	if isPositive(f.MaxQty) && qty.Cmp(ratOrZero(f.MaxQty)) > 0 {
+		return ErrFilterMaxQty
+	}
+
+	orderPrice := ratOrZero(price)
+	if orderPrice.Sign() > 0 {
+		if isPositive(f.MinPrice) && orderPrice.Cmp(ratOrZero(f.MinPrice)) < 0 {
+			return ErrFilterMinPrice
+		}
+		if isPositive(f.MaxPrice) && orderPrice.Cmp(ratOrZero(f.MaxPrice)) > 0 {
+			return ErrFilterMaxPrice
+		}
+	}
+
+	mark := ratOrZero(markPrice)
+	notionalPrice := orderPrice
+	if notionalPrice.Sign() <= 0 {
+		notionalPrice = mark
+	}
+	if isPositive(f.MinNotional) && notionalPrice.Sign() > 0 &&
+		new(big.Rat).Mul(notionalPrice, qty).Cmp(ratOrZero(f.MinNotional)) < 0 {
+		return ErrFilterMinNotional
+	}
+
+	if mark.Sign() <= 0 || orderPrice.Sign() <= 0 {
+		return nil
+	}
+	if isPositive(f.MultiplierUp) && orderPrice.Cmp(new(big.Rat).Mul(mark, ratOrZero(f.MultiplierUp))) > 0 {
+		return ErrFilterPercentPrice
+	}
+	if isPositive(f.MultiplierDown) && orderPrice.Cmp(new(big.Rat).Mul(mark, ratOrZero(f.MultiplierDown))) < 0 {
+		return ErrFilterPercentPrice
+	}
+
+	return nil
+}
+
+func roundToStep(value, step string, nearest bool) string {
+	val, ok := new(big.Rat).SetString(value)
+	if !ok {
+		return value
+	}
+	stepRat := ratOrZero(step)
+	if stepRat.Sign() <= 0 {
+		return formatQuantity(val)
+	}
+
+	steps := new(big.Rat).Quo(val, stepRat)
+	if nearest {
+		steps.Add(steps, big.NewRat(1, 2))
+	}
+	count := new(big.Int).Quo(steps.Num(), steps.Denom())
+	result := new(big.Rat).Mul(new(big.Rat).SetInt(count), stepRat)
+
+	return result.FloatString(stepDecimals(step))
+}
+
+func stepDecimals(step string) int {
+	dot := strings.IndexByte(step, '.')
+	if dot < 0 {
+		return 0
+	}
+
+	return len(strings.TrimRight(step[dot+1:], "0"))
+}
+
+func isPositive(val string) bool {
+	return ratOrZero(val).Sign() > 0
+}
@@ -0,0 +1,205 @@
+package tests
+
+import (
+	"math/big"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+func newQueueOrder(id int64, symbol string) *domain.Order {
+	return &domain.Order{
+		Id:     id,
+		Symbol: symbol,
+		Side:   consts.OrderSideSell,
+		TpSl:   consts.SlOrderType,
+		Price:  "100",
+		Status: consts.OrderStatusActive,
+	}
+}
+
+func TestOrdersQueue_Remove(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prepare  func(queue *domain.OrdersQueue)
+		symbol   string
+		id       int64
+		expected bool
+	}{
+		{
+			name:     "Queued order",
+			prepare:  func(queue *domain.OrdersQueue) { queue.Add(newQueueOrder(1, "BTCUSDT")) },
+			symbol:   "BTCUSDT",
+			id:       1,
+			expected: true,
+		},
+		{
+			name:     "Unknown id",
+			prepare:  func(queue *domain.OrdersQueue) { queue.Add(newQueueOrder(1, "BTCUSDT")) },
+			symbol:   "BTCUSDT",
+			id:       2,
+			expected: false,
+		},
+		{
+			name:     "Unknown symbol",
+			prepare:  func(queue *domain.OrdersQueue) {},
+			symbol:   "BTCUSDT",
+			id:       1,
+			expected: false,
+		},
+		{
+			name: "Already removed",
+			prepare: func(queue *domain.OrdersQueue) {
+				queue.Add(newQueueOrder(1, "BTCUSDT"))
+				queue.Remove("BTCUSDT", 1)
+			},
+			symbol:   "BTCUSDT",
+			id:       1,
+			expected: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			queue := domain.NewOrderQueue(consts.Binance)
+			tc.prepare(queue)
+
+			assert.Equal(t, tc.expected, queue.Remove(tc.symbol, tc.id))
+			assert.False(t, queue.Exist(tc.symbol, tc.id))
+		})
+	}
+}
+
+func TestOrdersQueue_ClaimRelease(t *testing.T) {
+	queue := domain.NewOrderQueue(consts.Binance)
+	queue.Add(newQueueOrder(1, "BTCUSDT"))
+
+	assert.True(t, queue.Claim("BTCUSDT", 1))
+	assert.False(t, queue.Claim("BTCUSDT", 1))
+	assert.False(t, queue.Claim("BTCUSDT", 2))
+
+	queue.Release("BTCUSDT", 1)
+	assert.True(t, queue.Claim("BTCUSDT", 1))
+
+	queue.Remove("BTCUSDT", 1)
+	queue.Release("BTCUSDT", 1)
+	assert.False(t, queue.Claim("BTCUSDT", 1))
+}
+
+func TestOrdersQueue_Snapshot(t *testing.T) {
+	queue := domain.NewOrderQueue(consts.Binance)
+	queue.Add(newQueueOrder(1, "BTCUSDT"))
+	queue.Add(newQueueOrder(2, "ETHUSDT"))
+
+	snapshot := queue.Snapshot()
+	queue.Remove("BTCUSDT", 1)
+	queue.Add(newQueueOrder(3, "BTCUSDT"))
+
+	assert.Equal(t, []int64{1, 2}, triggeredIds(snapshot))
+	assert.Equal(t, []int64{2, 3}, triggeredIds(queue.Snapshot()))
+	assert.Equal(t, 2, queue.Len())
+}
+
+func TestOrdersQueue_ConcurrentRemove(t *testing.T) {
+	const (
+		orders  = 100
+		workers = 8
+	)
+	queue := domain.NewOrderQueue(consts.Binance)
+	for i := int64(1); i <= orders; i++ {
+		queue.Add(newQueueOrder(i, "BTCUSDT"))
+	}
+
+	var removed, claimed atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int64(1); i <= orders; i++ {
+				if queue.Claim("BTCUSDT", i) {
+					claimed.Add(1)
+				}
+				if queue.Remove("BTCUSDT", i) {
+					removed.Add(1)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int64(orders), removed.Load())
+	assert.LessOrEqual(t, claimed.Load(), int64(orders))
+	assert.Zero(t, queue.Len())
+}
+
+// TestOrdersQueue_ConcurrentTick mimics the tickers scanning the queue while the service adds,
+// reprices and removes orders, run it with -race.
+func TestOrdersQueue_ConcurrentTick(t *testing.T) {
+	const orders = 500
+	queue := domain.NewOrderQueue(consts.Binance)
+	symbols := []string{"BTCUSDT", "ETHUSDT"}
+
+	var wg sync.WaitGroup
+	for _, symbol := range symbols {
+		symbol := symbol
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int64(1); i <= orders; i++ {
+				queue.Add(newQueueOrder(i, symbol))
+				queue.UpdatePrice(symbol, i-1, "101")
+				if i%3 == 0 {
+					queue.Remove(symbol, i-2)
+				}
+			}
+		}()
+	}
+
+	var processed atomic.Int64
+	price := big.NewFloat(99)
+	for w := 0; w < 4; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := 0; i < orders; i++ {
+				for _, v := range queue.Snapshot() {
+					if !queue.Claim(v.Symbol, v.Id) {
+						continue
+					}
+					v.Lock()
+					processed.Add(1)
+					v.Unlock()
+					queue.Release(v.Symbol, v.Id)
+				}
+				for _, symbol := range queue.Symbols() {
+					for _, v := range queue.Triggered(symbol, price) {
+						assert.Equal(t, symbol, v.Symbol)
+					}
+				}
+				queue.Len()
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Positive(t, processed.Load())
+	for _, symbol := range symbols {
+		assert.Equal(t, len(queue.Triggered(symbol, price)), len(snapshotOf(queue, symbol)))
+	}
+}
+
+func snapshotOf(queue *domain.OrdersQueue, symbol string) []*domain.Order {
+	result := make([]*domain.Order, 0)
+	for _, v := range queue.Snapshot() {
+		if v.Symbol == symbol {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,151 @@
+package tests
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+	"testing"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/stretchr/testify/assert"
+)
+
+func triggeredIds(orders []*domain.Order) []int64 {
+	ids := make([]int64, 0, len(orders))
+	for _, v := range orders {
+		ids = append(ids, v.Id)
+	}
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+func TestOrdersQueue_Triggered(t *testing.T) {
+	queue := domain.NewOrderQueue(consts.Binance)
+	orders := []*domain.Order{
+		{Id: 1, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "110"},
+		{Id: 2, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.SlOrderType, Price: "90"},
+		{Id: 3, Symbol: "BTCUSDT", Side: consts.OrderSideBuy, TpSl: consts.TpOrderType, Price: "80"},
+		{Id: 4, Symbol: "BTCUSDT", Side: consts.OrderSideBuy, TpSl: consts.SlOrderType, Price: "120"},
+		{Id: 5, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "110"},
+		{Id: 6, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.SlOrderType, Price: "95", Ts: "2"},
+		{Id: 7, Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+			TpSl: consts.BaseOrderType, Price: "100"},
+		{Id: 8, Symbol: "ETHUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "50"},
+	}
+	for _, v := range orders {
+		queue.Add(v)
+	}
+
+	testCases := []struct {
+		name     string
+		price    string
+		expected []int64
+	}{
+		{name: "Price inside all levels only touches trailing stops", price: "100", expected: []int64{6}},
+		{name: "Rise to take profit level", price: "110", expected: []int64{1, 5, 6}},
+		{name: "Rise above every level above", price: "125", expected: []int64{1, 4, 5, 6}},
+		{name: "Fall to stop loss level", price: "90", expected: []int64{2, 6}},
+		{name: "Fall below every level below", price: "70", expected: []int64{2, 3, 6}},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			price, _ := new(big.Float).SetString(tc.price)
+			triggered := queue.Triggered("BTCUSDT", price)
+
+			assert.Equal(t, tc.expected, triggeredIds(triggered))
+			for _, v := range triggered {
+				assert.True(t, v.IsTrailingStop() || v.IsTpSlTriggered(price))
+			}
+		})
+	}
+
+	assert.Empty(t, queue.Triggered("XRPUSDT", big.NewFloat(1)))
+}
+
+func TestOrdersQueue_TriggeredAfterChanges(t *testing.T) {
+	queue := domain.NewOrderQueue(consts.Binance)
+	queue.Add(&domain.Order{Id: 1, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "110"})
+	queue.Add(&domain.Order{Id: 2, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.SlOrderType, Price: "90"})
+	queue.Add(&domain.Order{Id: 3, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "110"})
+
+	assert.True(t, queue.UpdatePrice("BTCUSDT", 2, "99"))
+	assert.Equal(t, []int64{2}, triggeredIds(queue.Triggered("BTCUSDT", big.NewFloat(98))))
+	assert.Equal(t, "99", queue.Get("BTCUSDT", 2).Price)
+
+	assert.True(t, queue.Remove("BTCUSDT", 1))
+	assert.Equal(t, []int64{3}, triggeredIds(queue.Triggered("BTCUSDT", big.NewFloat(111))))
+
+	queue.Add(&domain.Order{Id: 3, Symbol: "BTCUSDT", Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "120"})
+	assert.Empty(t, queue.Triggered("BTCUSDT", big.NewFloat(111)))
+
+	assert.False(t, queue.UpdatePrice("BTCUSDT", 10, "100"))
+	assert.False(t, queue.Remove("ETHUSDT", 1))
+	assert.Equal(t, []string{"BTCUSDT"}, queue.Symbols())
+}
+
+func newBenchmarkQueue(size int) *domain.OrdersQueue {
+	queue := domain.NewOrderQueue(consts.Binance)
+	for i := 0; i < size; i++ {
+		order := &domain.Order{Id: int64(i + 1), Symbol: "BTCUSDT", Status: consts.OrderStatusActive}
+		offset := 1 + i%5000
+		order.Side = consts.OrderSideSell
+		if i%2 == 0 {
+			order.TpSl, order.Price = consts.TpOrderType, strconv.Itoa(30000+offset)
+		} else {
+			order.TpSl, order.Price = consts.SlOrderType, strconv.Itoa(20000-offset)
+		}
+		queue.Add(order)
+	}
+
+	return queue
+}
+
+var benchmarkSizes = []int{1000, 10000, 50000}
+
+func BenchmarkOrdersQueue_Triggered(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			queue := newBenchmarkQueue(size)
+			price := big.NewFloat(25000)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if len(queue.Triggered("BTCUSDT", price)) != 0 {
+					b.Fatal("unexpected triggered order")
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrdersQueue_FullScan(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			queue := newBenchmarkQueue(size)
+			price := big.NewFloat(25000)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				for _, v := range queue.Snapshot() {
+					if v.IsTpSlTriggered(price) {
+						b.Fatal("unexpected triggered order")
+					}
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkOrdersQueue_UpdatePrice(b *testing.B) {
+	for _, size := range benchmarkSizes {
+		b.Run(strconv.Itoa(size), func(b *testing.B) {
+			queue := newBenchmarkQueue(size)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				id := int64(i%size + 1)
+				queue.UpdatePrice("BTCUSDT", id, queue.Get("BTCUSDT", id).Price)
+			}
+		})
+	}
+}
@@ -0,0 +1,226 @@
+package domain
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+)
+
+func IsTakeProfitTriggered(side string, orderPrice, tradePrice *big.Float) bool {
+	if orderPrice == nil || tradePrice == nil {
+		return false
+	}
+
+	cmp := tradePrice.Cmp(orderPrice)
+
+	return (side == consts.OrderSideBuy && cmp <= 0) ||
+		(side == consts.OrderSideSell && cmp >= 0)
+}
+
+func IsStopLossTriggered(side string, orderPrice, tradePrice *big.Float) bool {
+	if orderPrice == nil || tradePrice == nil {
+		return false
+	}
+
+	cmp := tradePrice.Cmp(orderPrice)
+
+	return (side == consts.OrderSideSell && cmp <= 0) ||
+		(side == consts.OrderSideBuy && cmp >= 0)
+}
+
+func (o *Order) IsTpSlTriggered(tradePrice *big.Float) bool {
+	orderPrice := helper.StringToBigFloat(o.Price)
+	switch o.TpSl {
+	case consts.TpOrderType:
+		return IsTakeProfitTriggered(o.Side, orderPrice, tradePrice)
+	case consts.SlOrderType:
+		return IsStopLossTriggered(o.Side, orderPrice, tradePrice)
+	default:
+		return false
+	}
+}
+
+func (o *Order) NextTrailingStop(tradePrice *big.Float) (stopPrice *big.Float, isWatermark bool, isMoved bool) {
+	if o.TpSl != consts.SlOrderType || !o.IsTrailingStop() || tradePrice == nil {
+		return nil, false, false
+	}
+
+	watermark := helper.StringToBigFloat(o.TsPrice)
+	if watermark != nil {
+		if o.Side == consts.OrderSideSell && tradePrice.Cmp(watermark) <= 0 {
+			return nil, false, false
+		}
+		if o.Side == consts.OrderSideBuy && tradePrice.Cmp(watermark) >= 0 {
+			return nil, false, false
+		}
+	}
+
+	percent := helper.StringToBigFloat(o.Ts)
+	stopPrice = new(big.Float).Copy(tradePrice)
+	if o.Side == consts.OrderSideSell {
+		stopPrice = helper.BigDiffWithPercent(stopPrice, percent)
+	} else {
+		stopPrice = helper.BigSumWithPercent(stopPrice, percent)
+	}
+
+	return stopPrice, true, o.IsStopImproved(stopPrice)
+}
+
+// IsStopImproved tells whether the stop price locks in more profit than the current stop.
+func (o *Order) IsStopImproved(stopPrice *big.Float) bool {
+	if stopPrice == nil {
+		return false
+	}
+	currentStop := helper.StringToBigFloat(o.Price)
+
+	return currentStop == nil ||
+		(o.Side == consts.OrderSideSell && stopPrice.Cmp(currentStop) > 0) ||
+		(o.Side == consts.OrderSideBuy && stopPrice.Cmp(currentStop) < 0)
+}
+
+func (s *Settings) GetTpSlPrice(order *Order, tpSlType string) string {
+	if tpSlType == consts.TpOrderType {
+		if s.TpPrice == "" && s.TpPercent != "" {
+			return percentPrice(order.Price, s.TpPercent, order.Side, tpSlType)
+		} else {
+			return s.TpPrice
+		}
+	} else if tpSlType == consts.SlOrderType {
+		if s.SlPrice == "" && s.SlPercent != "" {
+			return percentPrice(order.Price, s.SlPercent, order.Side, tpSlType)
+		} else {
+			return s.SlPrice
+		}
+	}
+
+	return ""
+}
+
+func (l *TpLevel) GetPrice(order *Order) string {
+	if l.Price == "" && l.Percent != "" {
+		return percentPrice(order.Price, l.Percent, order.Side, consts.TpOrderType)
+	}
+
+	return l.Price
+}
+
+type TpSlLeg struct {
+	TpSl     string
+	Price    string
+	Quantity string
+}
+
+// GetTpSlLegs lists the take profit orders (one per ladder level) followed by the stop loss for the base order.
+func (s *Settings) GetTpSlLegs(order *Order) []TpSlLeg {
+	if s == nil {
+		return nil
+	}
+
+	legs := make([]TpSlLeg, 0, len(s.TpLevels)+2)
+	if s.HasTpLadder() {
+		quantities := SplitQuantity(order.Quantity, s.GetTpShares())
+		for i, level := range s.TpLevels {
+			legs = append(legs, TpSlLeg{TpSl: consts.TpOrderType, Price: level.GetPrice(order), Quantity: quantities[i]})
+		}
+	} else if s.TpPercent != "" || s.TpPrice != "" {
+		legs = append(legs, TpSlLeg{TpSl: consts.TpOrderType,
+			Price:    s.GetTpSlPrice(order, consts.TpOrderType),
+			Quantity: order.Quantity})
+	}
+	if s.SlPercent != "" || s.SlPrice != "" {
+		legs = append(legs, TpSlLeg{TpSl: consts.SlOrderType,
+			Price:    s.GetTpSlPrice(order, consts.SlOrderType),
+			Quantity: order.Quantity})
+	}
+
+	return legs
+}
+
+func (o *Order) IsTighterStop(price *big.Float) bool {
+	currentStop := helper.StringToBigFloat(o.Price)
+	if price == nil || currentStop == nil {
+		return false
+	}
+
+	return (o.Side == consts.OrderSideSell && price.Cmp(currentStop) > 0) ||
+		(o.Side == consts.OrderSideBuy && price.Cmp(currentStop) < 0)
+}
+
+func SplitQuantity(quantity string, weights []string) []string {
+	result := make([]string, len(weights))
+	total, ok := new(big.Rat).SetString(quantity)
+	if !ok || len(weights) == 0 {
+		return result
+	}
+
+	weightSum := new(big.Rat)
+	ratWeights := make([]*big.Rat, len(weights))
+	for i, v := range weights {
+		weight, ok := new(big.Rat).SetString(v)
+		if !ok || weight.Sign() < 0 {
+			weight = new(big.Rat)
+		}
+		ratWeights[i] = weight
+		weightSum.Add(weightSum, weight)
+	}
+	if weightSum.Sign() == 0 {
+		return result
+	}
+
+	rest := new(big.Rat).Set(total)
+	for i, weight := range ratWeights {
+		part := rest
+		if i != len(ratWeights)-1 {
+			part, _ = new(big.Rat).SetString(new(big.Rat).Quo(new(big.Rat).Mul(total, weight), weightSum).FloatString(8))
+			rest = new(big.Rat).Sub(rest, part)
+		}
+		result[i] = formatQuantity(part)
+	}
+
+	return result
+}
+
+func SumQuantity(orders []*Order) string {
+	total := new(big.Rat)
+	for _, v := range orders {
+		if qty, ok := new(big.Rat).SetString(v.Quantity); ok {
+			total.Add(total, qty)
+		}
+	}
+
+	return formatQuantity(total)
+}
+
+func formatQuantity(qty *big.Rat) string {
+	return strings.TrimRight(strings.TrimRight(qty.FloatString(8), "0"), ".")
+}
+
+func percentPrice(price, prct, ordSide, tpSlType string) string {
+	ordPrice := helper.StringToBigFloat(price)
+	percent := helper.StringToBigFloat(prct)
+	totalPrice := new(big.Float)
+	ordSide = strings.ToUpper(ordSide)
+	tpSlType = strings.ToLower(tpSlType)
+
+	if ordSide == consts.OrderSideBuy && tpSlType == consts.TpOrderType {
+		totalPrice = helper.BigSumWithPercent(ordPrice, percent)
+	} else if ordSide == consts.OrderSideSell && tpSlType == consts.TpOrderType {
+		totalPrice = helper.BigDiffWithPercent(ordPrice, percent)
+	} else if ordSide == consts.OrderSideBuy && tpSlType == consts.SlOrderType {
+		totalPrice = helper.BigDiffWithPercent(ordPrice, percent)
+	} else if ordSide == consts.OrderSideSell && tpSlType == consts.SlOrderType {
+		totalPrice = helper.BigSumWithPercent(ordPrice, percent)
+	}
+
+	return totalPrice.String()
+}
+
+func GetTpSlSide(side string) string {
+	if side == consts.OrderSideSell {
+		return consts.OrderSideBuy
+	}
+
+	return consts.OrderSideSell
+}
@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"math/big"
+	"slices"
+	"sort"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+)
+
+type triggerLevel struct {
+	price *big.Float
+	order *Order
+}
+
+type triggerEntry struct {
+	price    *big.Float
+	above    bool
+	trailing bool
+}
+
+// triggerIndex keeps tp/sl orders of one symbol sorted by trigger price, so a trade price
+// only touches the orders it crossed. Trailing stops follow every price and are kept apart.
+type triggerIndex struct {
+	above    []triggerLevel
+	below    []triggerLevel
+	trailing map[int64]*Order
+	entries  map[int64]triggerEntry
+}
+
+func newTriggerIndex() *triggerIndex {
+	return &triggerIndex{
+		trailing: make(map[int64]*Order),
+		entries:  make(map[int64]triggerEntry),
+	}
+}
+
+// triggersAbove tells whether the order fires when the price rises to its level.
+func triggersAbove(order *Order) bool {
+	if order.TpSl == consts.TpOrderType {
+		return order.Side == consts.OrderSideSell
+	}
+
+	return order.Side == consts.OrderSideBuy
+}
+
+func (ti *triggerIndex) add(order *Order) {
+	ti.remove(order.Id)
+	if order.TpSl != consts.TpOrderType && order.TpSl != consts.SlOrderType {
+		return
+	}
+	if order.TpSl == consts.SlOrderType && order.IsTrailingStop() {
+		ti.trailing[order.Id] = order
+		ti.entries[order.Id] = triggerEntry{trailing: true}
+
+		return
+	}
+
+	price := helper.StringToBigFloat(order.Price)
+	if price == nil {
+		return
+	}
+
+	entry := triggerEntry{price: price, above: triggersAbove(order)}
+	levels := ti.levels(entry.above)
+	i := sort.Search(len(*levels), func(i int) bool {
+		return (*levels)[i].price.Cmp(price) > 0
+	})
+	*levels = slices.Insert(*levels, i, triggerLevel{price: price, order: order})
+	ti.entries[order.Id] = entry
+}
+
+func (ti *triggerIndex) remove(id int64) {
+	entry, ok := ti.entries[id]
+	if !ok {
+		return
+	}
+	delete(ti.entries, id)
+	if entry.trailing {
+		delete(ti.trailing, id)
+
+		return
+	}
+
+	levels := ti.levels(entry.above)
+	i := sort.Search(len(*levels), func(i int) bool {
+		return (*levels)[i].price.Cmp(entry.price) >= 0
+	})
+	for ; i < len(*levels) && (*levels)[i].price.Cmp(entry.price) == 0; i++ {
+		if (*levels)[i].order.Id == id {
+			*levels = slices.Delete(*levels, i, i+1)
+
+			return
+		}
+	}
+}
+
+// triggered returns the orders crossed by the price and all trailing stops.
+func (ti *triggerIndex) triggered(price *big.Float) []*Order {
+	above := sort.Search(len(ti.above), func(i int) bool {
+		return ti.above[i].price.Cmp(price) > 0
+	})
+	below := sort.Search(len(ti.below), func(i int) bool {
+		return ti.below[i].price.Cmp(price) >= 0
+	})
+
+	result := make([]*Order, 0, above+len(ti.below)-below+len(ti.trailing))
+	for _, v := range ti.above[:above] {
+		result = append(result, v.order)
+	}
+	for _, v := range ti.below[below:] {
+		result = append(result, v.order)
+	}
+	for _, v := range ti.trailing {
+		result = append(result, v)
+	}
+
+	return result
+}
+
+func (ti *triggerIndex) levels(above bool) *[]triggerLevel {
+	if above {
+		return &ti.above
+	}
+
+	return &ti.below
+}
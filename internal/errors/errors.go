@@ -42,8 +42,9 @@ func BadRequestError(err string) Error {
 
 func WrapBadRequestError(err error) Error {
 	return Error{
-		err: err,
-		msg: err.Error(),
+		code: badRequest,
+		err:  err,
+		msg:  err.Error(),
 	}
 }
 
@@ -74,3 +75,48 @@ func ValidationError(err error) Error {
 func (e Error) IsValidationError() bool {
 	return e.GetCode() == validation
 }
+
+func UnauthorizedError(err string) Error {
+	return Error{
+		code: unauthorized,
+		msg:  err,
+	}
+}
+
+func (e Error) IsUnauthorizedError() bool {
+	return e.GetCode() == unauthorized
+}
+
+func AccessDeniedError(err string) Error {
+	return Error{
+		code: accessDenied,
+		msg:  err,
+	}
+}
+
+func (e Error) IsAccessDeniedError() bool {
+	return e.GetCode() == accessDenied
+}
+
+func NotFoundError(err string) Error {
+	return Error{
+		code: notFound,
+		msg:  err,
+	}
+}
+
+func (e Error) IsNotFoundError() bool {
+	return e.GetCode() == notFound
+}
+
+func (e Error) IsBadGatewayError() bool {
+	return e.GetCode() == badGateway
+}
+
+func (e Error) IsTooManyRequestError() bool {
+	return e.GetCode() == tooManyRequest
+}
+
+func (e Error) Unwrap() error {
+	return e.err
+}
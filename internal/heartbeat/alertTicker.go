@@ -0,0 +1,82 @@
+package heartbeat
+
+import (
+	"context"
+	"os"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type AlertSrv interface {
+	GetActiveAlerts(exchange string) []*domain.PriceAlert
+	CheckAlert(ctx context.Context, alert *domain.PriceAlert, price string)
+}
+
+type AlertTicker struct {
+	cfg      *config.Config
+	alertSrv AlertSrv
+	cache    PriceSource
+	logger   *log.Logger
+	ticker   *time.Ticker
+	exchange string
+}
+
+func NewAlertTicker(cfg *config.Config,
+	alertSrv AlertSrv,
+	cache PriceSource,
+	logger *log.Logger,
+	heartbeatPeriod time.Duration,
+	exchange string) *AlertTicker {
+	return &AlertTicker{
+		cfg:      cfg,
+		alertSrv: alertSrv,
+		cache:    cache,
+		logger:   logger,
+		ticker:   time.NewTicker(heartbeatPeriod),
+		exchange: exchange,
+	}
+}
+
+func (t *AlertTicker) Tick(ctx context.Context, interrupt chan os.Signal) {
+	t.logger.InfoLog.Printf("Start check alerts in %s ", t.exchange)
+	for {
+		select {
+		case <-interrupt:
+			t.logger.InfoLog.Println("Alert Ticker stop")
+
+		case <-ctx.Done():
+			t.logger.InfoLog.Println("Alert Ticker stop")
+			return
+		case <-t.ticker.C:
+			t.checkAlerts(ctx)
+		}
+	}
+}
+
+// checkAlerts reads the cached price once per symbol and evaluates every alert of the exchange with it.
+func (t *AlertTicker) checkAlerts(ctx context.Context) {
+	prices := make(map[string]string)
+	for _, v := range t.alertSrv.GetActiveAlerts(t.exchange) {
+		price, ok := prices[v.Symbol]
+		if !ok {
+			price = t.getPriceFromCache(ctx, v.Symbol)
+			prices[v.Symbol] = price
+		}
+		if price == "" {
+			continue
+		}
+		t.alertSrv.CheckAlert(ctx, v, price)
+	}
+}
+
+func (t *AlertTicker) getPriceFromCache(ctx context.Context, symbol string) string {
+	price, err := t.cache.GetPrice(ctx, t.exchange, symbol)
+	if err != nil {
+		return ""
+	}
+
+	return price
+}
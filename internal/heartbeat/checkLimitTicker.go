@@ -19,6 +19,7 @@ type LimitOrderTicker struct {
 	logger           *log.Logger
 	ordersQueue      *domain.OrdersQueue
 	limitOrdersQueue *domain.OrdersQueue
+	userStreams      UserStreamStatus
 	ticker           *time.Ticker
 	exchange         string
 }
@@ -26,6 +27,7 @@ type LimitOrderTicker struct {
 func NewLimitOrderTicker(cfg *config.Config,
 	orderSrv telegram.OrderSrv,
 	limitOrdersQueue *domain.OrdersQueue,
+	userStreams UserStreamStatus,
 	logger *log.Logger,
 	exchange string,
 	heartbeatPeriod time.Duration) *LimitOrderTicker {
@@ -36,6 +38,7 @@ func NewLimitOrderTicker(cfg *config.Config,
 		logger:           logger,
 		exchange:         exchange,
 		limitOrdersQueue: limitOrdersQueue,
+		userStreams:      userStreams,
 	}
 
 	result.checkLimitQueue()
@@ -59,26 +62,31 @@ func (l *LimitOrderTicker) Tick(ctx context.Context, interrupt chan os.Signal) {
 }
 
 func (l *LimitOrderTicker) checkOrders(ctx context.Context) {
-	for _, val := range l.limitOrdersQueue.Orders {
-		val := val
-		for _, v := range val {
-			if v.Inwork == true {
-				continue
-			}
-			go l.checkOrder(ctx, v)
+	for _, v := range l.limitOrdersQueue.Snapshot() {
+		if l.userStreams != nil && l.userStreams.IsAlive(v.UserId) {
+			continue
 		}
+		if !l.limitOrdersQueue.Claim(v.Symbol, v.Id) {
+			continue
+		}
+		go l.checkOrder(ctx, v)
 	}
 }
 
 func (l *LimitOrderTicker) checkOrder(ctx context.Context, order *domain.Order) {
-	order.Inwork = true
+	defer l.limitOrdersQueue.Release(order.Symbol, order.Id)
 	order.Lock()
-	defer func() {
-		order.Inwork = false
-		order.Unlock()
-	}()
+	defer order.Unlock()
+
+	if !order.IsOpen() {
+		return
+	}
+
+	if order.IsOcoLeg() {
+		if err := l.orderSrv.SyncOcoOrder(ctx, order); err != nil {
+			l.logger.ErrorLog.Println("err sync oco order:", order.Id, err)
+		}
 
-	if order.Status != consts.OrderStatusActive {
 		return
 	}
 
@@ -93,12 +101,15 @@ func (l *LimitOrderTicker) checkOrder(ctx context.Context, order *domain.Order)
 		return
 	}
 
-	if excOrder.Status == consts.OrderStatusFilled {
-		err = l.orderSrv.SetFilledLimitOrder(ctx, order)
-		if err != nil {
+	switch excOrder.Status {
+	case consts.OrderStatusFilled:
+		order.AvgPrice = excOrder.AvgPrice
+		if err = l.orderSrv.SetFilledLimitOrder(ctx, order); err != nil {
 			l.logger.ErrorLog.Println("err set status filled order:", order.Id, err)
-
-			return
+		}
+	case consts.OrderStatusPartFilled:
+		if err = l.orderSrv.SetPartFilledLimitOrder(ctx, order, excOrder.ExecutedQty, excOrder.AvgPrice); err != nil {
+			l.logger.ErrorLog.Println("err set status part filled order:", order.Id, err)
 		}
 	}
 }
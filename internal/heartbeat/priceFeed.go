@@ -0,0 +1,449 @@
+package heartbeat
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+var errUknownExchange = errors.New("err unknown exchange ")
+
+type TradeStream interface {
+	Subscribe(symbols ...string) error
+	Unsubscribe(symbols ...string) error
+	ReadTrade() (symbol string, price string, err error)
+	Close() error
+}
+
+type TradeStreamOpener func(exchange string, symbols ...string) (TradeStream, error)
+
+// PriceCache keeps the last streamed trade price of a symbol.
+type PriceCache interface {
+	SetPrice(ctx context.Context, exchange, symbol, price string, at time.Time) error
+	DeletePrice(ctx context.Context, exchange, symbol string) error
+}
+
+// PriceListener is told about every trade price written to the cache, it must not block.
+type PriceListener interface {
+	OnPrice(exchange, symbol, price string)
+}
+
+// PriceDemand reports whether something still needs the streamed price of a symbol.
+// It is asked under the feed lock and must not call the feed.
+type PriceDemand interface {
+	HasSymbol(exchange, symbol string) bool
+}
+
+func ExchangeTradeStreamOpener() TradeStreamOpener {
+	return func(exchange string, symbols ...string) (TradeStream, error) {
+		switch exchange {
+		case consts.Binance, consts.Paper:
+			stream, err := exchanger.NewBinanceTradeStream(exchanger.BinanceStreamUrl, symbols...)
+			if err != nil {
+				return nil, err
+			}
+
+			return stream, nil
+		case consts.Kucoin:
+			stream, err := exchanger.NewKucoinTradeStream(exchanger.KucoinApiUrl, symbols...)
+			if err != nil {
+				return nil, err
+			}
+
+			return stream, nil
+		case consts.Okx:
+			stream, err := exchanger.NewOkxTradeStream(exchanger.OkxPublicWsUrl, symbols...)
+			if err != nil {
+				return nil, err
+			}
+
+			return stream, nil
+		default:
+			return nil, errUknownExchange
+		}
+	}
+}
+
+const (
+	priceFeedMinBackoff = time.Second
+	priceFeedMaxBackoff = time.Minute
+)
+
+// exchangeFeed has no stream while its connection is being restored.
+type exchangeFeed struct {
+	stream  TradeStream
+	symbols map[string]string
+}
+
+func (f *exchangeFeed) list() []string {
+	symbols := make([]string, 0, len(f.symbols))
+	for _, symbol := range f.symbols {
+		symbols = append(symbols, symbol)
+	}
+
+	return symbols
+}
+
+func (f *exchangeFeed) missing(symbols []string) []string {
+	known := make(map[string]struct{}, len(symbols))
+	for _, symbol := range symbols {
+		known[streamSymbol(symbol)] = struct{}{}
+	}
+
+	result := make([]string, 0)
+	for name, symbol := range f.symbols {
+		if _, ok := known[name]; !ok {
+			result = append(result, symbol)
+		}
+	}
+
+	return result
+}
+
+// PriceFeed streams trade prices into the cache over one multiplexed connection per exchange.
+// Symbols are subscribed on demand and dropped once no order queue or alert needs them,
+// a broken connection is restored with backoff for all subscribed symbols.
+type PriceFeed struct {
+	cache     PriceCache
+	open      TradeStreamOpener
+	demands   []PriceDemand
+	listeners []PriceListener
+	logger    *log.Logger
+	minDelay  time.Duration
+	maxDelay  time.Duration
+	feeds     map[string]*exchangeFeed
+	holds     map[string]int
+	done      chan struct{}
+	closeOnce sync.Once
+	sync.Mutex
+}
+
+func NewPriceFeed(cache PriceCache,
+	open TradeStreamOpener,
+	logger *log.Logger,
+	demands ...PriceDemand) *PriceFeed {
+	return &PriceFeed{
+		cache:    cache,
+		open:     open,
+		demands:  demands,
+		logger:   logger,
+		minDelay: priceFeedMinBackoff,
+		maxDelay: priceFeedMaxBackoff,
+		feeds:    make(map[string]*exchangeFeed),
+		holds:    make(map[string]int),
+		done:     make(chan struct{}),
+	}
+}
+
+func (p *PriceFeed) Subscribe(exchange, symbol string) error {
+	p.Lock()
+	defer p.Unlock()
+
+	return p.subscribe(exchange, symbol)
+}
+
+// Hold subscribes the symbol and keeps it subscribed until the returned release is called,
+// so an order being created is not unsubscribed before it reaches a queue.
+func (p *PriceFeed) Hold(exchange, symbol string) (func(), error) {
+	p.Lock()
+	defer p.Unlock()
+
+	if err := p.subscribe(exchange, symbol); err != nil {
+		return func() {}, err
+	}
+	key := holdKey(exchange, symbol)
+	p.holds[key]++
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			p.Lock()
+			defer p.Unlock()
+
+			if p.holds[key]--; p.holds[key] <= 0 {
+				delete(p.holds, key)
+			}
+			p.release(exchange, symbol)
+		})
+	}, nil
+}
+
+// SetBackoff changes the first and the longest delay between reconnection attempts.
+func (p *PriceFeed) SetBackoff(minDelay, maxDelay time.Duration) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.minDelay, p.maxDelay = minDelay, maxDelay
+}
+
+func (p *PriceFeed) AddDemand(demand PriceDemand) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.demands = append(p.demands, demand)
+}
+
+func (p *PriceFeed) AddListener(listener PriceListener) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.listeners = append(p.listeners, listener)
+}
+
+// Release unsubscribes the symbol when nothing holds it and no demand reports it.
+// Demands are checked under the lock, so an order added to a queue before its hold is
+// released always keeps the symbol.
+func (p *PriceFeed) Release(exchange, symbol string) {
+	p.Lock()
+	defer p.Unlock()
+
+	p.release(exchange, symbol)
+}
+
+func (p *PriceFeed) release(exchange, symbol string) {
+	if p.holds[holdKey(exchange, symbol)] > 0 {
+		return
+	}
+	for _, demand := range p.demands {
+		if demand.HasSymbol(exchange, symbol) {
+			return
+		}
+	}
+	if err := p.unsubscribe(exchange, symbol); err != nil {
+		p.logger.ErrorLog.Println("err unsubscribe price feed: ", exchange, symbol, err)
+	}
+}
+
+func (p *PriceFeed) Symbols(exchange string) []string {
+	p.Lock()
+	defer p.Unlock()
+
+	feed, ok := p.feeds[exchange]
+	if !ok {
+		return nil
+	}
+
+	return feed.list()
+}
+
+// Listen releases the symbol of every order event, unsubscribing it after its last order is closed.
+func (p *PriceFeed) Listen(ctx context.Context, events <-chan domain.OrderEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Exchange == "" || event.Symbol == "" {
+				continue
+			}
+			p.Release(event.Exchange, event.Symbol)
+		}
+	}
+}
+
+func (p *PriceFeed) Close() {
+	p.closeOnce.Do(func() {
+		close(p.done)
+	})
+
+	p.Lock()
+	defer p.Unlock()
+
+	for exchange, feed := range p.feeds {
+		if feed.stream != nil {
+			_ = feed.stream.Close()
+		}
+		delete(p.feeds, exchange)
+	}
+}
+
+func (p *PriceFeed) subscribe(exchange, symbol string) error {
+	symbol = strings.ToUpper(symbol)
+	name := streamSymbol(symbol)
+
+	feed, ok := p.feeds[exchange]
+	if ok {
+		if _, ok := feed.symbols[name]; ok || feed.stream == nil {
+			feed.symbols[name] = symbol
+
+			return nil
+		}
+		if err := feed.stream.Subscribe(symbol); err != nil {
+			p.logger.ErrorLog.Println("err subscribe price feed: ", exchange, symbol, err)
+
+			return err
+		}
+		feed.symbols[name] = symbol
+
+		return nil
+	}
+
+	stream, err := p.open(exchange, symbol)
+	if err != nil {
+		p.logger.ErrorLog.Println("err socket connection:", exchange, err)
+
+		return err
+	}
+	feed = &exchangeFeed{
+		stream:  stream,
+		symbols: map[string]string{name: symbol},
+	}
+	p.feeds[exchange] = feed
+	go p.read(exchange, feed, stream)
+
+	return nil
+}
+
+func (p *PriceFeed) unsubscribe(exchange, symbol string) error {
+	symbol = strings.ToUpper(symbol)
+	name := streamSymbol(symbol)
+
+	feed, ok := p.feeds[exchange]
+	if !ok {
+		return nil
+	}
+	if _, ok := feed.symbols[name]; !ok {
+		return nil
+	}
+	symbol = feed.symbols[name]
+	delete(feed.symbols, name)
+	_ = p.cache.DeletePrice(context.Background(), exchange, symbol)
+
+	if len(feed.symbols) == 0 {
+		delete(p.feeds, exchange)
+		if feed.stream != nil {
+			return feed.stream.Close()
+		}
+
+		return nil
+	}
+	if feed.stream == nil {
+		return nil
+	}
+
+	return feed.stream.Unsubscribe(symbol)
+}
+
+// read keeps the feed streaming until it is unsubscribed or the price feed is closed.
+func (p *PriceFeed) read(exchange string, feed *exchangeFeed, stream TradeStream) {
+	p.logger.InfoLog.Println("Start price feed " + exchange)
+	for stream != nil {
+		p.consume(exchange, stream)
+		stream = p.reconnect(exchange, feed, stream)
+	}
+	p.logger.InfoLog.Println("Price feed stop " + exchange)
+}
+
+func (p *PriceFeed) consume(exchange string, stream TradeStream) {
+	defer stream.Close()
+	for {
+		symbol, price, err := stream.ReadTrade()
+		var streamErr exchanger.TradeStreamError
+		if errors.As(err, &streamErr) {
+			p.logger.ErrorLog.Println("err price feed request: ", streamErr)
+
+			continue
+		}
+		if err != nil {
+			p.logger.ErrorLog.Printf("err read message: %v\n", err)
+
+			return
+		}
+		if price == "" {
+			continue
+		}
+
+		p.setPrice(exchange, symbol, price)
+	}
+}
+
+// reconnect opens a new stream for the current symbols of the feed, doubling the delay after every failure.
+func (p *PriceFeed) reconnect(exchange string, feed *exchangeFeed, broken TradeStream) TradeStream {
+	p.Lock()
+	if feed.stream == broken {
+		feed.stream = nil
+	}
+	delay, maxDelay := p.minDelay, p.maxDelay
+	p.Unlock()
+
+	for {
+		select {
+		case <-p.done:
+			return nil
+		case <-time.After(delay):
+		}
+
+		p.Lock()
+		if p.feeds[exchange] != feed {
+			p.Unlock()
+
+			return nil
+		}
+		symbols := feed.list()
+		p.Unlock()
+
+		stream, err := p.open(exchange, symbols...)
+		if err != nil {
+			delay = min(delay*2, maxDelay)
+			p.logger.ErrorLog.Println("err reconnect price feed: ", exchange, err, "retry in", delay)
+
+			continue
+		}
+
+		p.Lock()
+		if p.feeds[exchange] != feed {
+			p.Unlock()
+			_ = stream.Close()
+
+			return nil
+		}
+		feed.stream = stream
+		missing := feed.missing(symbols)
+		p.Unlock()
+
+		if len(missing) > 0 {
+			if err = stream.Subscribe(missing...); err != nil {
+				p.logger.ErrorLog.Println("err subscribe price feed: ", exchange, missing, err)
+			}
+		}
+		p.logger.InfoLog.Println("Price feed reconnected " + exchange)
+
+		return stream
+	}
+}
+
+func (p *PriceFeed) setPrice(exchange, symbol, price string) {
+	p.Lock()
+	feed, ok := p.feeds[exchange]
+	if ok {
+		symbol, ok = feed.symbols[streamSymbol(symbol)]
+	}
+	listeners := p.listeners
+	p.Unlock()
+	if !ok {
+		return
+	}
+
+	_ = p.cache.SetPrice(context.Background(), exchange, symbol, price, time.Now())
+	for _, listener := range listeners {
+		listener.OnPrice(exchange, symbol, price)
+	}
+}
+
+func streamSymbol(symbol string) string {
+	return strings.ReplaceAll(strings.ToUpper(symbol), "-", "")
+}
+
+func holdKey(exchange, symbol string) string {
+	return exchange + "_" + streamSymbol(symbol)
+}
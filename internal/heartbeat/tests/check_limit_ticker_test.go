@@ -0,0 +1,225 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+const (
+	testExchange = "binance"
+	testPeriod   = 10 * time.Millisecond
+)
+
+func newLimitOrder() *domain.Order {
+	return &domain.Order{
+		Id:          1,
+		UserId:      7,
+		Symbol:      "BTCUSDT",
+		Exchange:    testExchange,
+		ExecOrderId: 100,
+		OrderType:   consts.OrderTypeLimit,
+		Side:        consts.OrderSideBuy,
+		Price:       "100",
+		Status:      consts.OrderStatusActive,
+	}
+}
+
+func expectGetOrder(orderSrv *mocks.MockOrderSrv, status string) *gomock.Call {
+	return orderSrv.EXPECT().
+		GetOrder(gomock.Any(), int64(100), int64(7), "BTCUSDT", testExchange, true).
+		Return(&domain.Order{Status: status, ExecutedQty: "0.5", AvgPrice: "99"}, nil)
+}
+
+func TestLimitOrderTicker_Fallback(t *testing.T) {
+	testCases := []struct {
+		name    string
+		streams func(ctrl *gomock.Controller) heartbeat.UserStreamStatus
+		prepare func(orderSrv *mocks.MockOrderSrv, queue *domain.OrdersQueue, done func())
+		polled  bool
+	}{
+		{
+			name: "stream down polls rest and fills order",
+			streams: func(ctrl *gomock.Controller) heartbeat.UserStreamStatus {
+				status := mocks.NewMockUserStreamStatus(ctrl)
+				status.EXPECT().IsAlive(int64(7)).Return(false).MinTimes(1)
+
+				return status
+			},
+			prepare: func(orderSrv *mocks.MockOrderSrv, queue *domain.OrdersQueue, done func()) {
+				expectGetOrder(orderSrv, consts.OrderStatusFilled).Times(1)
+				orderSrv.EXPECT().SetFilledLimitOrder(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(_ context.Context, order *domain.Order) error {
+						assert.Equal(t, "99", order.AvgPrice)
+						queue.Remove(order.Symbol, order.Id)
+						done()
+
+						return nil
+					}).Times(1)
+			},
+			polled: true,
+		},
+		{
+			name: "stream down polls rest and saves part fill",
+			streams: func(ctrl *gomock.Controller) heartbeat.UserStreamStatus {
+				status := mocks.NewMockUserStreamStatus(ctrl)
+				status.EXPECT().IsAlive(int64(7)).Return(false).MinTimes(1)
+
+				return status
+			},
+			prepare: func(orderSrv *mocks.MockOrderSrv, queue *domain.OrdersQueue, done func()) {
+				expectGetOrder(orderSrv, consts.OrderStatusPartFilled).MinTimes(1)
+				orderSrv.EXPECT().SetPartFilledLimitOrder(gomock.Any(), gomock.Any(), "0.5", "99").
+					DoAndReturn(func(_ context.Context, _ *domain.Order, _, _ string) error {
+						done()
+
+						return nil
+					}).MinTimes(1)
+			},
+			polled: true,
+		},
+		{
+			name: "without stream status polls rest",
+			streams: func(ctrl *gomock.Controller) heartbeat.UserStreamStatus {
+				return nil
+			},
+			prepare: func(orderSrv *mocks.MockOrderSrv, queue *domain.OrdersQueue, done func()) {
+				expectGetOrder(orderSrv, consts.OrderStatusActive).
+					Do(func(_ context.Context, _, _ int64, _, _ string, _ bool) { done() }).
+					MinTimes(1)
+			},
+			polled: true,
+		},
+		{
+			name: "stream alive skips rest",
+			streams: func(ctrl *gomock.Controller) heartbeat.UserStreamStatus {
+				status := mocks.NewMockUserStreamStatus(ctrl)
+				status.EXPECT().IsAlive(int64(7)).Return(true).MinTimes(1)
+
+				return status
+			},
+			prepare: func(orderSrv *mocks.MockOrderSrv, queue *domain.OrdersQueue, done func()) {
+				orderSrv.EXPECT().GetOrder(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(0)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderSrv := mocks.NewMockOrderSrv(ctrl)
+			queue := domain.NewOrderQueue(testExchange)
+			queue.Add(newLimitOrder())
+
+			var once sync.Once
+			polled := make(chan struct{})
+			tc.prepare(orderSrv, queue, func() { once.Do(func() { close(polled) }) })
+
+			ticker := heartbeat.NewLimitOrderTicker(nil, orderSrv, queue, tc.streams(ctrl), log.NewLogger(), testExchange, testPeriod)
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				ticker.Tick(ctx, nil)
+				close(stopped)
+			}()
+
+			select {
+			case <-polled:
+				assert.True(t, tc.polled, "rest polled while stream is alive")
+			case <-time.After(20 * testPeriod):
+				assert.False(t, tc.polled, "rest was not polled")
+			}
+			cancel()
+			<-stopped
+			time.Sleep(testPeriod)
+		})
+	}
+}
+
+func TestLimitOrderTicker_StreamRecovery(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderSrv := mocks.NewMockOrderSrv(ctrl)
+	orderSrv.EXPECT().GetApiKeys(gomock.Any(), int64(7), testExchange).
+		Return(&domain.ApiKeys{PubKey: "pub"}, nil).AnyTimes()
+
+	var polls atomic.Int64
+	expectGetOrder(orderSrv, consts.OrderStatusActive).
+		Do(func(_ context.Context, _, _ int64, _, _ string, _ bool) { polls.Add(1) }).
+		AnyTimes()
+
+	broken := make(chan struct{})
+	stream := mocks.NewMockUserStream(ctrl)
+	stream.EXPECT().ReadExecutionReport().DoAndReturn(func() (*domain.ExecutionReport, error) {
+		<-broken
+
+		return nil, errors.New("stream closed")
+	}).AnyTimes()
+	stream.EXPECT().Close().Return(nil).AnyTimes()
+
+	var streamUp atomic.Bool
+	opener := func(keys *domain.ApiKeys) (heartbeat.UserStream, error) {
+		if !streamUp.Load() {
+			return nil, errors.New("stream unavailable")
+		}
+
+		return stream, nil
+	}
+
+	queue := domain.NewOrderQueue(testExchange)
+	queue.Add(newLimitOrder())
+	logger := log.NewLogger()
+	streams := heartbeat.NewUserDataStreams(orderSrv, queue, opener, logger, testExchange, testPeriod)
+	ticker := heartbeat.NewLimitOrderTicker(nil, orderSrv, queue, streams, logger, testExchange, testPeriod)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		streams.Tick(ctx, nil)
+	}()
+	go func() {
+		defer wg.Done()
+		ticker.Tick(ctx, nil)
+	}()
+	defer func() {
+		cancel()
+		wg.Wait()
+		time.Sleep(testPeriod)
+	}()
+
+	waitFor := 50 * testPeriod
+
+	assert.Eventually(t, func() bool { return polls.Load() >= 2 }, waitFor, testPeriod,
+		"rest is not polled while stream is down")
+
+	streamUp.Store(true)
+	assert.Eventually(t, func() bool { return streams.IsAlive(7) }, waitFor, testPeriod,
+		"stream did not come back")
+	time.Sleep(2 * testPeriod)
+	recovered := polls.Load()
+	time.Sleep(10 * testPeriod)
+	assert.Equal(t, recovered, polls.Load(), "rest is polled while stream is alive")
+
+	streamUp.Store(false)
+	close(broken)
+	assert.Eventually(t, func() bool { return !streams.IsAlive(7) }, waitFor, testPeriod,
+		"stream is alive after disconnect")
+	assert.Eventually(t, func() bool { return polls.Load() > recovered }, waitFor, testPeriod,
+		"rest polling did not resume after disconnect")
+}
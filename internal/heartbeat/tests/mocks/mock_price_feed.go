@@ -0,0 +1,225 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: priceFeed.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockTradeStream is a mock of TradeStream interface.
+type MockTradeStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockTradeStreamMockRecorder
+}
+
+// MockTradeStreamMockRecorder is the mock recorder for MockTradeStream.
+type MockTradeStreamMockRecorder struct {
+	mock *MockTradeStream
+}
+
+// NewMockTradeStream creates a new mock instance.
+func NewMockTradeStream(ctrl *gomock.Controller) *MockTradeStream {
+	mock := &MockTradeStream{ctrl: ctrl}
+	mock.recorder = &MockTradeStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTradeStream) EXPECT() *MockTradeStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockTradeStream) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockTradeStreamMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTradeStream)(nil).Close))
+}
+
+// ReadTrade mocks base method.
+func (m *MockTradeStream) ReadTrade() (string, string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadTrade")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(string)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// ReadTrade indicates an expected call of ReadTrade.
+func (mr *MockTradeStreamMockRecorder) ReadTrade() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadTrade", reflect.TypeOf((*MockTradeStream)(nil).ReadTrade))
+}
+
+// Subscribe mocks base method.
+func (m *MockTradeStream) Subscribe(symbols ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range symbols {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Subscribe", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockTradeStreamMockRecorder) Subscribe(symbols ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockTradeStream)(nil).Subscribe), symbols...)
+}
+
+// Unsubscribe mocks base method.
+func (m *MockTradeStream) Unsubscribe(symbols ...string) error {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{}
+	for _, a := range symbols {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Unsubscribe", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Unsubscribe indicates an expected call of Unsubscribe.
+func (mr *MockTradeStreamMockRecorder) Unsubscribe(symbols ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Unsubscribe", reflect.TypeOf((*MockTradeStream)(nil).Unsubscribe), symbols...)
+}
+
+// MockPriceCache is a mock of PriceCache interface.
+type MockPriceCache struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceCacheMockRecorder
+}
+
+// MockPriceCacheMockRecorder is the mock recorder for MockPriceCache.
+type MockPriceCacheMockRecorder struct {
+	mock *MockPriceCache
+}
+
+// NewMockPriceCache creates a new mock instance.
+func NewMockPriceCache(ctrl *gomock.Controller) *MockPriceCache {
+	mock := &MockPriceCache{ctrl: ctrl}
+	mock.recorder = &MockPriceCacheMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceCache) EXPECT() *MockPriceCacheMockRecorder {
+	return m.recorder
+}
+
+// DeletePrice mocks base method.
+func (m *MockPriceCache) DeletePrice(ctx context.Context, exchange, symbol string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeletePrice", ctx, exchange, symbol)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeletePrice indicates an expected call of DeletePrice.
+func (mr *MockPriceCacheMockRecorder) DeletePrice(ctx, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeletePrice", reflect.TypeOf((*MockPriceCache)(nil).DeletePrice), ctx, exchange, symbol)
+}
+
+// SetPrice mocks base method.
+func (m *MockPriceCache) SetPrice(ctx context.Context, exchange, symbol, price string, at time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPrice", ctx, exchange, symbol, price, at)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPrice indicates an expected call of SetPrice.
+func (mr *MockPriceCacheMockRecorder) SetPrice(ctx, exchange, symbol, price, at interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPrice", reflect.TypeOf((*MockPriceCache)(nil).SetPrice), ctx, exchange, symbol, price, at)
+}
+
+// MockPriceListener is a mock of PriceListener interface.
+type MockPriceListener struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceListenerMockRecorder
+}
+
+// MockPriceListenerMockRecorder is the mock recorder for MockPriceListener.
+type MockPriceListenerMockRecorder struct {
+	mock *MockPriceListener
+}
+
+// NewMockPriceListener creates a new mock instance.
+func NewMockPriceListener(ctrl *gomock.Controller) *MockPriceListener {
+	mock := &MockPriceListener{ctrl: ctrl}
+	mock.recorder = &MockPriceListenerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceListener) EXPECT() *MockPriceListenerMockRecorder {
+	return m.recorder
+}
+
+// OnPrice mocks base method.
+func (m *MockPriceListener) OnPrice(exchange, symbol, price string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "OnPrice", exchange, symbol, price)
+}
+
+// OnPrice indicates an expected call of OnPrice.
+func (mr *MockPriceListenerMockRecorder) OnPrice(exchange, symbol, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "OnPrice", reflect.TypeOf((*MockPriceListener)(nil).OnPrice), exchange, symbol, price)
+}
+
+// MockPriceDemand is a mock of PriceDemand interface.
+type MockPriceDemand struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceDemandMockRecorder
+}
+
+// MockPriceDemandMockRecorder is the mock recorder for MockPriceDemand.
+type MockPriceDemandMockRecorder struct {
+	mock *MockPriceDemand
+}
+
+// NewMockPriceDemand creates a new mock instance.
+func NewMockPriceDemand(ctrl *gomock.Controller) *MockPriceDemand {
+	mock := &MockPriceDemand{ctrl: ctrl}
+	mock.recorder = &MockPriceDemandMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceDemand) EXPECT() *MockPriceDemandMockRecorder {
+	return m.recorder
+}
+
+// HasSymbol mocks base method.
+func (m *MockPriceDemand) HasSymbol(exchange, symbol string) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HasSymbol", exchange, symbol)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// HasSymbol indicates an expected call of HasSymbol.
+func (mr *MockPriceDemandMockRecorder) HasSymbol(exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HasSymbol", reflect.TypeOf((*MockPriceDemand)(nil).HasSymbol), exchange, symbol)
+}
@@ -0,0 +1,542 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pkg/telegram/processor.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+// MockUserSrv is a mock of UserSrv interface.
+type MockUserSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserSrvMockRecorder
+}
+
+// MockUserSrvMockRecorder is the mock recorder for MockUserSrv.
+type MockUserSrvMockRecorder struct {
+	mock *MockUserSrv
+}
+
+// NewMockUserSrv creates a new mock instance.
+func NewMockUserSrv(ctrl *gomock.Controller) *MockUserSrv {
+	mock := &MockUserSrv{ctrl: ctrl}
+	mock.recorder = &MockUserSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserSrv) EXPECT() *MockUserSrvMockRecorder {
+	return m.recorder
+}
+
+// CreateUser mocks base method.
+func (m *MockUserSrv) CreateUser(ctx context.Context, username string, tgId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateUser", ctx, username, tgId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreateUser indicates an expected call of CreateUser.
+func (mr *MockUserSrvMockRecorder) CreateUser(ctx, username, tgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateUser", reflect.TypeOf((*MockUserSrv)(nil).CreateUser), ctx, username, tgId)
+}
+
+// MockOrderSrv is a mock of OrderSrv interface.
+type MockOrderSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderSrvMockRecorder
+}
+
+// MockOrderSrvMockRecorder is the mock recorder for MockOrderSrv.
+type MockOrderSrvMockRecorder struct {
+	mock *MockOrderSrv
+}
+
+// NewMockOrderSrv creates a new mock instance.
+func NewMockOrderSrv(ctrl *gomock.Controller) *MockOrderSrv {
+	mock := &MockOrderSrv{ctrl: ctrl}
+	mock.recorder = &MockOrderSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderSrv) EXPECT() *MockOrderSrvMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderSrv) CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, order, tgUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderSrvMockRecorder) CancelOrder(ctx, order, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderSrv)(nil).CancelOrder), ctx, order, tgUserId)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderSrv) CreateOrder(ctx context.Context, order *dto.Order, tgUserId int64) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, order, tgUserId)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderSrvMockRecorder) CreateOrder(ctx, order, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderSrv)(nil).CreateOrder), ctx, order, tgUserId)
+}
+
+// ExecuteTpSlOrder mocks base method.
+func (m *MockOrderSrv) ExecuteTpSlOrder(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ExecuteTpSlOrder", ctx, userId, order, price)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ExecuteTpSlOrder indicates an expected call of ExecuteTpSlOrder.
+func (mr *MockOrderSrvMockRecorder) ExecuteTpSlOrder(ctx, userId, order, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ExecuteTpSlOrder", reflect.TypeOf((*MockOrderSrv)(nil).ExecuteTpSlOrder), ctx, userId, order, price)
+}
+
+// GetActiveTpSlOrders mocks base method.
+func (m *MockOrderSrv) GetActiveTpSlOrders(ctx context.Context, exchange string) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveTpSlOrders", ctx, exchange)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveTpSlOrders indicates an expected call of GetActiveTpSlOrders.
+func (mr *MockOrderSrvMockRecorder) GetActiveTpSlOrders(ctx, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveTpSlOrders", reflect.TypeOf((*MockOrderSrv)(nil).GetActiveTpSlOrders), ctx, exchange)
+}
+
+// GetApiKeys mocks base method.
+func (m *MockOrderSrv) GetApiKeys(ctx context.Context, userId int64, exchange string) (*domain.ApiKeys, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetApiKeys", ctx, userId, exchange)
+	ret0, _ := ret[0].(*domain.ApiKeys)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetApiKeys indicates an expected call of GetApiKeys.
+func (mr *MockOrderSrvMockRecorder) GetApiKeys(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetApiKeys", reflect.TypeOf((*MockOrderSrv)(nil).GetApiKeys), ctx, userId, exchange)
+}
+
+// GetLimitOrders mocks base method.
+func (m *MockOrderSrv) GetLimitOrders(ctx context.Context, exchange string) ([]*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetLimitOrders", ctx, exchange)
+	ret0, _ := ret[0].([]*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLimitOrders indicates an expected call of GetLimitOrders.
+func (mr *MockOrderSrvMockRecorder) GetLimitOrders(ctx, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLimitOrders", reflect.TypeOf((*MockOrderSrv)(nil).GetLimitOrders), ctx, exchange)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderSrv) GetOrder(ctx context.Context, orderId, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, orderId, tgUserId, symbol, exchange, inExchange)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderSrvMockRecorder) GetOrder(ctx, orderId, tgUserId, symbol, exchange, inExchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderSrv)(nil).GetOrder), ctx, orderId, tgUserId, symbol, exchange, inExchange)
+}
+
+// GetUserActiveOrders mocks base method.
+func (m *MockOrderSrv) GetUserActiveOrders(ctx context.Context, userId int64, exchange string) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserActiveOrders", ctx, userId, exchange)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserActiveOrders indicates an expected call of GetUserActiveOrders.
+func (mr *MockOrderSrvMockRecorder) GetUserActiveOrders(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActiveOrders", reflect.TypeOf((*MockOrderSrv)(nil).GetUserActiveOrders), ctx, userId, exchange)
+}
+
+// HandleExecutionReport mocks base method.
+func (m *MockOrderSrv) HandleExecutionReport(ctx context.Context, report *domain.ExecutionReport) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "HandleExecutionReport", ctx, report)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// HandleExecutionReport indicates an expected call of HandleExecutionReport.
+func (mr *MockOrderSrvMockRecorder) HandleExecutionReport(ctx, report interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "HandleExecutionReport", reflect.TypeOf((*MockOrderSrv)(nil).HandleExecutionReport), ctx, report)
+}
+
+// MoveTrailingStop mocks base method.
+func (m *MockOrderSrv) MoveTrailingStop(ctx context.Context, order *domain.Order, tradePrice string) (bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MoveTrailingStop", ctx, order, tradePrice)
+	ret0, _ := ret[0].(bool)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// MoveTrailingStop indicates an expected call of MoveTrailingStop.
+func (mr *MockOrderSrvMockRecorder) MoveTrailingStop(ctx, order, tradePrice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MoveTrailingStop", reflect.TypeOf((*MockOrderSrv)(nil).MoveTrailingStop), ctx, order, tradePrice)
+}
+
+// SetFilledLimitOrder mocks base method.
+func (m *MockOrderSrv) SetFilledLimitOrder(ctx context.Context, order *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetFilledLimitOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetFilledLimitOrder indicates an expected call of SetFilledLimitOrder.
+func (mr *MockOrderSrvMockRecorder) SetFilledLimitOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetFilledLimitOrder", reflect.TypeOf((*MockOrderSrv)(nil).SetFilledLimitOrder), ctx, order)
+}
+
+// SetPartFilledLimitOrder mocks base method.
+func (m *MockOrderSrv) SetPartFilledLimitOrder(ctx context.Context, order *domain.Order, executedQty, avgPrice string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPartFilledLimitOrder", ctx, order, executedQty, avgPrice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPartFilledLimitOrder indicates an expected call of SetPartFilledLimitOrder.
+func (mr *MockOrderSrvMockRecorder) SetPartFilledLimitOrder(ctx, order, executedQty, avgPrice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPartFilledLimitOrder", reflect.TypeOf((*MockOrderSrv)(nil).SetPartFilledLimitOrder), ctx, order, executedQty, avgPrice)
+}
+
+// SyncOcoOrder mocks base method.
+func (m *MockOrderSrv) SyncOcoOrder(ctx context.Context, order *domain.Order) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SyncOcoOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SyncOcoOrder indicates an expected call of SyncOcoOrder.
+func (mr *MockOrderSrvMockRecorder) SyncOcoOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SyncOcoOrder", reflect.TypeOf((*MockOrderSrv)(nil).SyncOcoOrder), ctx, order)
+}
+
+// UpdateTpslOrder mocks base method.
+func (m *MockOrderSrv) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTpslOrder", ctx, orderDto, tgUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTpslOrder indicates an expected call of UpdateTpslOrder.
+func (mr *MockOrderSrvMockRecorder) UpdateTpslOrder(ctx, orderDto, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTpslOrder", reflect.TypeOf((*MockOrderSrv)(nil).UpdateTpslOrder), ctx, orderDto, tgUserId)
+}
+
+// MockAccountSrv is a mock of AccountSrv interface.
+type MockAccountSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountSrvMockRecorder
+}
+
+// MockAccountSrvMockRecorder is the mock recorder for MockAccountSrv.
+type MockAccountSrvMockRecorder struct {
+	mock *MockAccountSrv
+}
+
+// NewMockAccountSrv creates a new mock instance.
+func NewMockAccountSrv(ctrl *gomock.Controller) *MockAccountSrv {
+	mock := &MockAccountSrv{ctrl: ctrl}
+	mock.recorder = &MockAccountSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountSrv) EXPECT() *MockAccountSrvMockRecorder {
+	return m.recorder
+}
+
+// GetBalance mocks base method.
+func (m *MockAccountSrv) GetBalance(ctx context.Context, userId int64, exchange string) (domain.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, userId, exchange)
+	ret0, _ := ret[0].(domain.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockAccountSrvMockRecorder) GetBalance(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockAccountSrv)(nil).GetBalance), ctx, userId, exchange)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockAccountSrv) GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, userId, exchange)
+	ret0, _ := ret[0].(*domain.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockAccountSrvMockRecorder) GetPortfolio(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockAccountSrv)(nil).GetPortfolio), ctx, userId, exchange)
+}
+
+// MockDealSrv is a mock of DealSrv interface.
+type MockDealSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockDealSrvMockRecorder
+}
+
+// MockDealSrvMockRecorder is the mock recorder for MockDealSrv.
+type MockDealSrvMockRecorder struct {
+	mock *MockDealSrv
+}
+
+// NewMockDealSrv creates a new mock instance.
+func NewMockDealSrv(ctrl *gomock.Controller) *MockDealSrv {
+	mock := &MockDealSrv{ctrl: ctrl}
+	mock.recorder = &MockDealSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDealSrv) EXPECT() *MockDealSrvMockRecorder {
+	return m.recorder
+}
+
+// GetDeals mocks base method.
+func (m *MockDealSrv) GetDeals(ctx context.Context, userId int64, exchange string, page, limit int) (*domain.DealPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeals", ctx, userId, exchange, page, limit)
+	ret0, _ := ret[0].(*domain.DealPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeals indicates an expected call of GetDeals.
+func (mr *MockDealSrvMockRecorder) GetDeals(ctx, userId, exchange, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeals", reflect.TypeOf((*MockDealSrv)(nil).GetDeals), ctx, userId, exchange, page, limit)
+}
+
+// MockPnlSrv is a mock of PnlSrv interface.
+type MockPnlSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockPnlSrvMockRecorder
+}
+
+// MockPnlSrvMockRecorder is the mock recorder for MockPnlSrv.
+type MockPnlSrvMockRecorder struct {
+	mock *MockPnlSrv
+}
+
+// NewMockPnlSrv creates a new mock instance.
+func NewMockPnlSrv(ctrl *gomock.Controller) *MockPnlSrv {
+	mock := &MockPnlSrv{ctrl: ctrl}
+	mock.recorder = &MockPnlSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPnlSrv) EXPECT() *MockPnlSrvMockRecorder {
+	return m.recorder
+}
+
+// GetPnl mocks base method.
+func (m *MockPnlSrv) GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPnl", ctx, userId, period)
+	ret0, _ := ret[0].(*domain.PnlReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPnl indicates an expected call of GetPnl.
+func (mr *MockPnlSrvMockRecorder) GetPnl(ctx, userId, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPnl", reflect.TypeOf((*MockPnlSrv)(nil).GetPnl), ctx, userId, period)
+}
+
+// MockStrategySrv is a mock of StrategySrv interface.
+type MockStrategySrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockStrategySrvMockRecorder
+}
+
+// MockStrategySrvMockRecorder is the mock recorder for MockStrategySrv.
+type MockStrategySrvMockRecorder struct {
+	mock *MockStrategySrv
+}
+
+// NewMockStrategySrv creates a new mock instance.
+func NewMockStrategySrv(ctrl *gomock.Controller) *MockStrategySrv {
+	mock := &MockStrategySrv{ctrl: ctrl}
+	mock.recorder = &MockStrategySrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStrategySrv) EXPECT() *MockStrategySrvMockRecorder {
+	return m.recorder
+}
+
+// GetStrategies mocks base method.
+func (m *MockStrategySrv) GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrategies", ctx, userId)
+	ret0, _ := ret[0].([]*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStrategies indicates an expected call of GetStrategies.
+func (mr *MockStrategySrvMockRecorder) GetStrategies(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategies", reflect.TypeOf((*MockStrategySrv)(nil).GetStrategies), ctx, userId)
+}
+
+// Start mocks base method.
+func (m *MockStrategySrv) Start(ctx context.Context, userId int64, strategyDto *dto.Strategy) (*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Start", ctx, userId, strategyDto)
+	ret0, _ := ret[0].(*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Start indicates an expected call of Start.
+func (mr *MockStrategySrvMockRecorder) Start(ctx, userId, strategyDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockStrategySrv)(nil).Start), ctx, userId, strategyDto)
+}
+
+// Stop mocks base method.
+func (m *MockStrategySrv) Stop(ctx context.Context, userId, id int64) (*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Stop", ctx, userId, id)
+	ret0, _ := ret[0].(*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Stop indicates an expected call of Stop.
+func (mr *MockStrategySrvMockRecorder) Stop(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockStrategySrv)(nil).Stop), ctx, userId, id)
+}
+
+// MockAlertSrv is a mock of AlertSrv interface.
+type MockAlertSrv struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertSrvMockRecorder
+}
+
+// MockAlertSrvMockRecorder is the mock recorder for MockAlertSrv.
+type MockAlertSrvMockRecorder struct {
+	mock *MockAlertSrv
+}
+
+// NewMockAlertSrv creates a new mock instance.
+func NewMockAlertSrv(ctrl *gomock.Controller) *MockAlertSrv {
+	mock := &MockAlertSrv{ctrl: ctrl}
+	mock.recorder = &MockAlertSrvMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertSrv) EXPECT() *MockAlertSrvMockRecorder {
+	return m.recorder
+}
+
+// CreateAlert mocks base method.
+func (m *MockAlertSrv) CreateAlert(ctx context.Context, userId int64, alertDto *dto.PriceAlert) (*domain.PriceAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, userId, alertDto)
+	ret0, _ := ret[0].(*domain.PriceAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockAlertSrvMockRecorder) CreateAlert(ctx, userId, alertDto interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockAlertSrv)(nil).CreateAlert), ctx, userId, alertDto)
+}
+
+// DeleteAlert mocks base method.
+func (m *MockAlertSrv) DeleteAlert(ctx context.Context, userId, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteAlert", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteAlert indicates an expected call of DeleteAlert.
+func (mr *MockAlertSrvMockRecorder) DeleteAlert(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteAlert", reflect.TypeOf((*MockAlertSrv)(nil).DeleteAlert), ctx, userId, id)
+}
+
+// GetAlerts mocks base method.
+func (m *MockAlertSrv) GetAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlerts", ctx, userId)
+	ret0, _ := ret[0].([]*domain.PriceAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlerts indicates an expected call of GetAlerts.
+func (mr *MockAlertSrvMockRecorder) GetAlerts(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlerts", reflect.TypeOf((*MockAlertSrv)(nil).GetAlerts), ctx, userId)
+}
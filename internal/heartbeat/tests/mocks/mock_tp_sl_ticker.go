@@ -0,0 +1,151 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tpSlTicker.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+)
+
+// MockPriceSource is a mock of PriceSource interface.
+type MockPriceSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceSourceMockRecorder
+}
+
+// MockPriceSourceMockRecorder is the mock recorder for MockPriceSource.
+type MockPriceSourceMockRecorder struct {
+	mock *MockPriceSource
+}
+
+// NewMockPriceSource creates a new mock instance.
+func NewMockPriceSource(ctrl *gomock.Controller) *MockPriceSource {
+	mock := &MockPriceSource{ctrl: ctrl}
+	mock.recorder = &MockPriceSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceSource) EXPECT() *MockPriceSourceMockRecorder {
+	return m.recorder
+}
+
+// GetPrice mocks base method.
+func (m *MockPriceSource) GetPrice(ctx context.Context, exchange, symbol string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", ctx, exchange, symbol)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockPriceSourceMockRecorder) GetPrice(ctx, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockPriceSource)(nil).GetPrice), ctx, exchange, symbol)
+}
+
+// GetPriceTime mocks base method.
+func (m *MockPriceSource) GetPriceTime(ctx context.Context, exchange, symbol string) (time.Time, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPriceTime", ctx, exchange, symbol)
+	ret0, _ := ret[0].(time.Time)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPriceTime indicates an expected call of GetPriceTime.
+func (mr *MockPriceSourceMockRecorder) GetPriceTime(ctx, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPriceTime", reflect.TypeOf((*MockPriceSource)(nil).GetPriceTime), ctx, exchange, symbol)
+}
+
+// MockAdminSource is a mock of AdminSource interface.
+type MockAdminSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminSourceMockRecorder
+}
+
+// MockAdminSourceMockRecorder is the mock recorder for MockAdminSource.
+type MockAdminSourceMockRecorder struct {
+	mock *MockAdminSource
+}
+
+// NewMockAdminSource creates a new mock instance.
+func NewMockAdminSource(ctrl *gomock.Controller) *MockAdminSource {
+	mock := &MockAdminSource{ctrl: ctrl}
+	mock.recorder = &MockAdminSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminSource) EXPECT() *MockAdminSourceMockRecorder {
+	return m.recorder
+}
+
+// GetAdmins mocks base method.
+func (m *MockAdminSource) GetAdmins(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdmins", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdmins indicates an expected call of GetAdmins.
+func (mr *MockAdminSourceMockRecorder) GetAdmins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmins", reflect.TypeOf((*MockAdminSource)(nil).GetAdmins), ctx)
+}
+
+// MockStalePriceNotifier is a mock of StalePriceNotifier interface.
+type MockStalePriceNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockStalePriceNotifierMockRecorder
+}
+
+// MockStalePriceNotifierMockRecorder is the mock recorder for MockStalePriceNotifier.
+type MockStalePriceNotifierMockRecorder struct {
+	mock *MockStalePriceNotifier
+}
+
+// NewMockStalePriceNotifier creates a new mock instance.
+func NewMockStalePriceNotifier(ctrl *gomock.Controller) *MockStalePriceNotifier {
+	mock := &MockStalePriceNotifier{ctrl: ctrl}
+	mock.recorder = &MockStalePriceNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStalePriceNotifier) EXPECT() *MockStalePriceNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPriceRecovered mocks base method.
+func (m *MockStalePriceNotifier) NotifyPriceRecovered(ctx context.Context, admins []int, exchange, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPriceRecovered", ctx, admins, exchange, symbol)
+}
+
+// NotifyPriceRecovered indicates an expected call of NotifyPriceRecovered.
+func (mr *MockStalePriceNotifierMockRecorder) NotifyPriceRecovered(ctx, admins, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPriceRecovered", reflect.TypeOf((*MockStalePriceNotifier)(nil).NotifyPriceRecovered), ctx, admins, exchange, symbol)
+}
+
+// NotifyStalePrice mocks base method.
+func (m *MockStalePriceNotifier) NotifyStalePrice(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyStalePrice", ctx, admins, exchange, symbol, updatedAt)
+}
+
+// NotifyStalePrice indicates an expected call of NotifyStalePrice.
+func (mr *MockStalePriceNotifierMockRecorder) NotifyStalePrice(ctx, admins, exchange, symbol, updatedAt interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyStalePrice", reflect.TypeOf((*MockStalePriceNotifier)(nil).NotifyStalePrice), ctx, admins, exchange, symbol, updatedAt)
+}
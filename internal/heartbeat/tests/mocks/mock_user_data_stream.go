@@ -0,0 +1,101 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: userDataStream.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockUserStream is a mock of UserStream interface.
+type MockUserStream struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStreamMockRecorder
+}
+
+// MockUserStreamMockRecorder is the mock recorder for MockUserStream.
+type MockUserStreamMockRecorder struct {
+	mock *MockUserStream
+}
+
+// NewMockUserStream creates a new mock instance.
+func NewMockUserStream(ctrl *gomock.Controller) *MockUserStream {
+	mock := &MockUserStream{ctrl: ctrl}
+	mock.recorder = &MockUserStreamMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStream) EXPECT() *MockUserStreamMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockUserStream) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockUserStreamMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockUserStream)(nil).Close))
+}
+
+// ReadExecutionReport mocks base method.
+func (m *MockUserStream) ReadExecutionReport() (*domain.ExecutionReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadExecutionReport")
+	ret0, _ := ret[0].(*domain.ExecutionReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ReadExecutionReport indicates an expected call of ReadExecutionReport.
+func (mr *MockUserStreamMockRecorder) ReadExecutionReport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadExecutionReport", reflect.TypeOf((*MockUserStream)(nil).ReadExecutionReport))
+}
+
+// MockUserStreamStatus is a mock of UserStreamStatus interface.
+type MockUserStreamStatus struct {
+	ctrl     *gomock.Controller
+	recorder *MockUserStreamStatusMockRecorder
+}
+
+// MockUserStreamStatusMockRecorder is the mock recorder for MockUserStreamStatus.
+type MockUserStreamStatusMockRecorder struct {
+	mock *MockUserStreamStatus
+}
+
+// NewMockUserStreamStatus creates a new mock instance.
+func NewMockUserStreamStatus(ctrl *gomock.Controller) *MockUserStreamStatus {
+	mock := &MockUserStreamStatus{ctrl: ctrl}
+	mock.recorder = &MockUserStreamStatusMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockUserStreamStatus) EXPECT() *MockUserStreamStatusMockRecorder {
+	return m.recorder
+}
+
+// IsAlive mocks base method.
+func (m *MockUserStreamStatus) IsAlive(userId int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAlive", userId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAlive indicates an expected call of IsAlive.
+func (mr *MockUserStreamStatusMockRecorder) IsAlive(userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAlive", reflect.TypeOf((*MockUserStreamStatus)(nil).IsAlive), userId)
+}
@@ -0,0 +1,490 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errStreamClosed = errors.New("stream closed")
+
+// testStream is a trade stream mock which blocks on reading until a trade is pushed or it is closed.
+type testStream struct {
+	*mocks.MockTradeStream
+	trades chan [2]string
+	closed chan struct{}
+}
+
+func newTestStream(ctrl *gomock.Controller) *testStream {
+	s := &testStream{
+		MockTradeStream: mocks.NewMockTradeStream(ctrl),
+		trades:          make(chan [2]string),
+		closed:          make(chan struct{}),
+	}
+
+	var once sync.Once
+	s.EXPECT().ReadTrade().DoAndReturn(func() (string, string, error) {
+		select {
+		case trade := <-s.trades:
+			return trade[0], trade[1], nil
+		case <-s.closed:
+			return "", "", errStreamClosed
+		}
+	}).AnyTimes()
+	s.EXPECT().Close().DoAndReturn(func() error {
+		once.Do(func() { close(s.closed) })
+
+		return nil
+	}).AnyTimes()
+
+	return s
+}
+
+func (s *testStream) isClosed() bool {
+	select {
+	case <-s.closed:
+		return true
+	default:
+		return false
+	}
+}
+
+// testOpener hands out the prepared streams of an exchange in turn, a nil stream refuses the connection.
+type testOpener struct {
+	sync.Mutex
+	streams map[string][]*testStream
+	opened  []string
+	times   []time.Time
+}
+
+func newTestOpener(streams map[string][]*testStream) *testOpener {
+	return &testOpener{streams: streams}
+}
+
+func (o *testOpener) open(exchange string, symbols ...string) (heartbeat.TradeStream, error) {
+	o.Lock()
+	defer o.Unlock()
+
+	symbols = slices.Clone(symbols)
+	slices.Sort(symbols)
+	o.opened = append(o.opened, exchange+":"+strings.Join(symbols, ","))
+	o.times = append(o.times, time.Now())
+	if len(o.streams[exchange]) == 0 {
+		return nil, errors.New("unknown exchange")
+	}
+	stream := o.streams[exchange][0]
+	o.streams[exchange] = o.streams[exchange][1:]
+	if stream == nil {
+		return nil, errors.New("connection refused")
+	}
+
+	return stream, nil
+}
+
+func (o *testOpener) connections() []string {
+	o.Lock()
+	defer o.Unlock()
+
+	return slices.Clone(o.opened)
+}
+
+func (o *testOpener) delays() []time.Duration {
+	o.Lock()
+	defer o.Unlock()
+
+	result := make([]time.Duration, 0, len(o.times))
+	for i := 1; i < len(o.times); i++ {
+		result = append(result, o.times[i].Sub(o.times[i-1]))
+	}
+
+	return result
+}
+
+func TestPriceFeed_Subscriptions(t *testing.T) {
+	testCases := []struct {
+		name    string
+		demand  bool
+		prepare func(binance, kucoin *testStream, cache *mocks.MockPriceCache)
+		run     func(t *testing.T, feed *heartbeat.PriceFeed)
+		opened  []string
+		symbols map[string][]string
+		closed  []string
+	}{
+		{
+			name: "first symbol opens a connection",
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "btcusdt"))
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT"}},
+		},
+		{
+			name: "next symbols are multiplexed over the same connection",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				binance.EXPECT().Subscribe("ETHUSDT").Return(nil)
+				binance.EXPECT().Subscribe("SOLUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "SOLUSDT"))
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT", "ETHUSDT", "SOLUSDT"}},
+		},
+		{
+			name: "subscribed symbol is not subscribed again",
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "btcusdt"))
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT"}},
+		},
+		{
+			name: "every exchange gets its own connection",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				binance.EXPECT().Subscribe("ETHUSDT").Return(nil)
+				kucoin.EXPECT().Subscribe("ETH-USDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Kucoin, "BTC-USDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Kucoin, "ETH-USDT"))
+			},
+			opened: []string{"binance:BTCUSDT", "kucoin:BTC-USDT"},
+			symbols: map[string][]string{
+				consts.Binance: {"BTCUSDT", "ETHUSDT"},
+				consts.Kucoin:  {"BTC-USDT", "ETH-USDT"},
+			},
+		},
+		{
+			name: "failed connection is not kept",
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.Error(t, feed.Subscribe(consts.Okx, "BTC-USDT"))
+			},
+			opened:  []string{"okx:BTC-USDT"},
+			symbols: map[string][]string{consts.Okx: nil},
+		},
+		{
+			name: "symbol without demand is unsubscribed",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				binance.EXPECT().Subscribe("ETHUSDT").Return(nil)
+				binance.EXPECT().Unsubscribe("ETHUSDT").Return(nil)
+				cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, "ETHUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+				feed.Release(consts.Binance, "ETHUSDT")
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT"}},
+		},
+		{
+			name:   "symbol needed by an order or alert stays subscribed",
+			demand: true,
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				binance.EXPECT().Subscribe("ETHUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+				feed.Release(consts.Binance, "ETHUSDT")
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT", "ETHUSDT"}},
+		},
+		{
+			name: "last symbol closes the connection",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, "BTCUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				feed.Release(consts.Binance, "BTCUSDT")
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: nil},
+			closed:  []string{consts.Binance},
+		},
+		{
+			name: "held symbol is unsubscribed after the hold is released",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, "BTCUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				release, err := feed.Hold(consts.Binance, "BTCUSDT")
+				require.NoError(t, err)
+				feed.Release(consts.Binance, "BTCUSDT")
+				assert.Equal(t, []string{"BTCUSDT"}, feed.Symbols(consts.Binance))
+				release()
+				release()
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: nil},
+			closed:  []string{consts.Binance},
+		},
+		{
+			name: "order events release their symbols",
+			prepare: func(binance, kucoin *testStream, cache *mocks.MockPriceCache) {
+				binance.EXPECT().Subscribe("ETHUSDT").Return(nil)
+				binance.EXPECT().Unsubscribe("ETHUSDT").Return(nil)
+				cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, "ETHUSDT").Return(nil)
+			},
+			run: func(t *testing.T, feed *heartbeat.PriceFeed) {
+				require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+				require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+
+				events := make(chan domain.OrderEvent, 2)
+				events <- domain.OrderEvent{Type: consts.OrderEventSlExecuted}
+				events <- domain.OrderEvent{Type: consts.OrderEventSlExecuted, Exchange: consts.Binance, Symbol: "ETHUSDT"}
+				close(events)
+				feed.Listen(context.Background(), events)
+			},
+			opened:  []string{"binance:BTCUSDT"},
+			symbols: map[string][]string{consts.Binance: {"BTCUSDT"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			binance, kucoin := newTestStream(ctrl), newTestStream(ctrl)
+			streams := map[string]*testStream{consts.Binance: binance, consts.Kucoin: kucoin}
+			opener := newTestOpener(map[string][]*testStream{consts.Binance: {binance}, consts.Kucoin: {kucoin}})
+			cache := mocks.NewMockPriceCache(ctrl)
+			demand := mocks.NewMockPriceDemand(ctrl)
+			demand.EXPECT().HasSymbol(gomock.Any(), gomock.Any()).Return(tc.demand).AnyTimes()
+			if tc.prepare != nil {
+				tc.prepare(binance, kucoin, cache)
+			}
+
+			feed := heartbeat.NewPriceFeed(cache, opener.open, log.NewLogger(), demand)
+			defer feed.Close()
+
+			tc.run(t, feed)
+
+			assert.Equal(t, tc.opened, opener.connections())
+			for exchange, symbols := range tc.symbols {
+				assert.ElementsMatch(t, symbols, feed.Symbols(exchange), exchange)
+			}
+			for exchange, stream := range streams {
+				assert.Equal(t, slices.Contains(tc.closed, exchange), stream.isClosed(), exchange)
+			}
+		})
+	}
+}
+
+// TestPriceFeed_ReleaseRace releases a symbol while an order holds it and reaches its queue
+// in the middle of the demand check, the symbol must stay subscribed.
+func TestPriceFeed_ReleaseRace(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	binance := newTestStream(ctrl)
+	binance.EXPECT().Subscribe("ETHUSDT").Return(nil).AnyTimes()
+	binance.EXPECT().Unsubscribe("ETHUSDT").Return(nil).AnyTimes()
+	cache := mocks.NewMockPriceCache(ctrl)
+	cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, "ETHUSDT").Return(nil).AnyTimes()
+
+	var queued atomic.Bool
+	checking, gate := make(chan struct{}), make(chan struct{})
+	demand := mocks.NewMockPriceDemand(ctrl)
+	gomock.InOrder(
+		demand.EXPECT().HasSymbol(consts.Binance, "ETHUSDT").DoAndReturn(func(exchange, symbol string) bool {
+			result := queued.Load()
+			close(checking)
+			<-gate
+
+			return result
+		}),
+		demand.EXPECT().HasSymbol(consts.Binance, "ETHUSDT").DoAndReturn(func(exchange, symbol string) bool {
+			return queued.Load()
+		}).AnyTimes(),
+	)
+
+	feed := heartbeat.NewPriceFeed(cache, newTestOpener(map[string][]*testStream{consts.Binance: {binance}}).open,
+		log.NewLogger(), demand)
+	defer feed.Close()
+	require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+	require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+
+	released := make(chan struct{})
+	go func() {
+		defer close(released)
+		feed.Release(consts.Binance, "ETHUSDT")
+	}()
+	<-checking
+
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		release, err := feed.Hold(consts.Binance, "ETHUSDT")
+		assert.NoError(t, err)
+		queued.Store(true)
+		release()
+	}()
+	select {
+	case <-added:
+	case <-time.After(100 * time.Millisecond):
+	}
+	close(gate)
+	<-released
+	<-added
+
+	assert.ElementsMatch(t, []string{"BTCUSDT", "ETHUSDT"}, feed.Symbols(consts.Binance))
+}
+
+func TestPriceFeed_Prices(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	binance, kucoin := newTestStream(ctrl), newTestStream(ctrl)
+	opener := newTestOpener(map[string][]*testStream{consts.Binance: {binance}, consts.Kucoin: {kucoin}})
+	cache := mocks.NewMockPriceCache(ctrl)
+	listener := mocks.NewMockPriceListener(ctrl)
+
+	received := make(chan string, 2)
+	cache.EXPECT().SetPrice(gomock.Any(), consts.Binance, "BTCUSDT", "100", gomock.Any()).Return(nil)
+	cache.EXPECT().SetPrice(gomock.Any(), consts.Kucoin, "BTC-USDT", "101", gomock.Any()).Return(nil)
+	listener.EXPECT().OnPrice(consts.Binance, "BTCUSDT", "100").Do(func(exchange, symbol, price string) {
+		received <- exchange
+	})
+	listener.EXPECT().OnPrice(consts.Kucoin, "BTC-USDT", "101").Do(func(exchange, symbol, price string) {
+		received <- exchange
+	})
+
+	feed := heartbeat.NewPriceFeed(cache, opener.open, log.NewLogger())
+	defer feed.Close()
+	feed.AddListener(listener)
+	require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+	require.NoError(t, feed.Subscribe(consts.Kucoin, "BTC-USDT"))
+
+	binance.trades <- [2]string{"ETHUSDT", "5"}
+	binance.trades <- [2]string{"BTCUSDT", ""}
+	binance.trades <- [2]string{"btcusdt", "100"}
+	kucoin.trades <- [2]string{"BTCUSDT", "101"}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-received:
+		case <-time.After(time.Second):
+			t.Fatal("price is not delivered")
+		}
+	}
+}
+
+func TestPriceFeed_Reconnect(t *testing.T) {
+	const (
+		minDelay = 20 * time.Millisecond
+		maxDelay = 60 * time.Millisecond
+	)
+
+	testCases := []struct {
+		name    string
+		refused int
+		run     func(t *testing.T, feed *heartbeat.PriceFeed, opener *testOpener, broken, restored *testStream)
+		opened  []string
+	}{
+		{
+			name: "broken connection is restored with all symbols",
+			run: func(t *testing.T, feed *heartbeat.PriceFeed, opener *testOpener, broken, restored *testStream) {
+				restored.trades <- [2]string{"ETHUSDT", "10"}
+			},
+			opened: []string{"binance:BTCUSDT", "binance:BTCUSDT,ETHUSDT"},
+		},
+		{
+			name:    "failed attempts are retried with a growing delay up to the limit",
+			refused: 4,
+			run: func(t *testing.T, feed *heartbeat.PriceFeed, opener *testOpener, broken, restored *testStream) {
+				restored.trades <- [2]string{"ETHUSDT", "10"}
+
+				delays := opener.delays()
+				var total time.Duration
+				for i, expected := range []time.Duration{2 * minDelay, maxDelay, maxDelay, maxDelay} {
+					assert.GreaterOrEqual(t, delays[i+1], expected, "attempt %d", i+2)
+					total += delays[i+1]
+				}
+				// without the limit the delays would sum up to 600ms instead of 220ms
+				assert.Less(t, total, 400*time.Millisecond)
+			},
+			opened: []string{"binance:BTCUSDT", "binance:BTCUSDT,ETHUSDT", "binance:BTCUSDT,ETHUSDT",
+				"binance:BTCUSDT,ETHUSDT", "binance:BTCUSDT,ETHUSDT", "binance:BTCUSDT,ETHUSDT"},
+		},
+		{
+			name:    "released feed is not restored",
+			refused: 100,
+			run: func(t *testing.T, feed *heartbeat.PriceFeed, opener *testOpener, broken, restored *testStream) {
+				assert.Eventually(t, func() bool { return len(opener.connections()) >= 2 }, time.Second, minDelay)
+				feed.Release(consts.Binance, "BTCUSDT")
+				feed.Release(consts.Binance, "ETHUSDT")
+				attempts := len(opener.connections())
+				time.Sleep(5 * maxDelay)
+				assert.LessOrEqual(t, len(opener.connections()), attempts+1)
+				assert.Nil(t, feed.Symbols(consts.Binance))
+			},
+		},
+		{
+			name:    "closed feed is not restored",
+			refused: 100,
+			run: func(t *testing.T, feed *heartbeat.PriceFeed, opener *testOpener, broken, restored *testStream) {
+				assert.Eventually(t, func() bool { return len(opener.connections()) >= 2 }, time.Second, minDelay)
+				feed.Close()
+				attempts := len(opener.connections())
+				time.Sleep(5 * maxDelay)
+				assert.LessOrEqual(t, len(opener.connections()), attempts+1)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			broken, restored := newTestStream(ctrl), newTestStream(ctrl)
+			queue := []*testStream{broken}
+			for i := 0; i < tc.refused; i++ {
+				queue = append(queue, nil)
+			}
+			opener := newTestOpener(map[string][]*testStream{consts.Binance: append(queue, restored)})
+
+			cache := mocks.NewMockPriceCache(ctrl)
+			cache.EXPECT().SetPrice(gomock.Any(), consts.Binance, "ETHUSDT", "10", gomock.Any()).Return(nil).AnyTimes()
+			cache.EXPECT().DeletePrice(gomock.Any(), consts.Binance, gomock.Any()).Return(nil).AnyTimes()
+			broken.EXPECT().Subscribe("ETHUSDT").Return(nil)
+			restored.EXPECT().Unsubscribe(gomock.Any()).Return(nil).AnyTimes()
+
+			feed := heartbeat.NewPriceFeed(cache, opener.open, log.NewLogger())
+			defer feed.Close()
+			feed.SetBackoff(minDelay, maxDelay)
+			require.NoError(t, feed.Subscribe(consts.Binance, "BTCUSDT"))
+			require.NoError(t, feed.Subscribe(consts.Binance, "ETHUSDT"))
+
+			_ = broken.Close()
+			tc.run(t, feed, opener, broken, restored)
+
+			if tc.opened != nil {
+				assert.Equal(t, tc.opened, opener.connections())
+			}
+		})
+	}
+}
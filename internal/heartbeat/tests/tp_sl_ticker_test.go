@@ -0,0 +1,403 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat"
+	"github.com/linnoxlewis/trade-bot/internal/heartbeat/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+type tpSlMocks struct {
+	orderSrv *mocks.MockOrderSrv
+	cache    *mocks.MockPriceSource
+	admins   *mocks.MockAdminSource
+	notifier *mocks.MockStalePriceNotifier
+}
+
+func newTpSlMocks(ctrl *gomock.Controller) tpSlMocks {
+	return tpSlMocks{
+		orderSrv: mocks.NewMockOrderSrv(ctrl),
+		cache:    mocks.NewMockPriceSource(ctrl),
+		admins:   mocks.NewMockAdminSource(ctrl),
+		notifier: mocks.NewMockStalePriceNotifier(ctrl),
+	}
+}
+
+func newStopLoss(id int64, symbol, price string) *domain.Order {
+	return &domain.Order{
+		Id:       id,
+		UserId:   7,
+		Symbol:   symbol,
+		Exchange: testExchange,
+		Side:     consts.OrderSideSell,
+		TpSl:     consts.SlOrderType,
+		Quantity: "1",
+		Price:    price,
+		Status:   consts.OrderStatusActive,
+	}
+}
+
+// priceTime is the price time served by the cache mock, the zero time stands for a missing key.
+type priceTime struct {
+	sync.Mutex
+	at time.Time
+}
+
+func (p *priceTime) get(ctx context.Context, exchange, symbol string) (time.Time, error) {
+	p.Lock()
+	defer p.Unlock()
+	if p.at.IsZero() {
+		return time.Time{}, errors.New("redis: nil")
+	}
+
+	return p.at, nil
+}
+
+func (p *priceTime) set(at time.Time) {
+	p.Lock()
+	defer p.Unlock()
+	p.at = at
+}
+
+func TestTpSlTicker_StaleGate(t *testing.T) {
+	staleAt := time.Now().Add(-2 * time.Minute)
+
+	testCases := []struct {
+		name    string
+		at      time.Time
+		prepare func(m tpSlMocks, queue *domain.OrdersQueue, at *priceTime, event func())
+		events  int
+	}{
+		{
+			name: "fresh price executes the crossed order",
+			at:   time.Now(),
+			prepare: func(m tpSlMocks, queue *domain.OrdersQueue, at *priceTime, event func()) {
+				m.orderSrv.EXPECT().ExecuteTpSlOrder(gomock.Any(), int64(7), gomock.Any(), "90").
+					DoAndReturn(func(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
+						queue.Remove(order.Symbol, order.Id)
+						event()
+
+						return order.Id, nil
+					})
+			},
+			events: 1,
+		},
+		{
+			name: "stale price is refused and alerted once",
+			at:   staleAt,
+			prepare: func(m tpSlMocks, queue *domain.OrdersQueue, at *priceTime, event func()) {
+				m.notifier.EXPECT().NotifyStalePrice(gomock.Any(), []int{1}, testExchange, "BTCUSDT", staleAt).
+					Do(func(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time) {
+						event()
+					})
+			},
+			events: 1,
+		},
+		{
+			name: "missing price time is refused and alerted once",
+			prepare: func(m tpSlMocks, queue *domain.OrdersQueue, at *priceTime, event func()) {
+				m.notifier.EXPECT().NotifyStalePrice(gomock.Any(), []int{1}, testExchange, "BTCUSDT", time.Time{}).
+					Do(func(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time) {
+						event()
+					})
+			},
+			events: 1,
+		},
+		{
+			name: "recovered price is alerted once and executes",
+			at:   staleAt,
+			prepare: func(m tpSlMocks, queue *domain.OrdersQueue, at *priceTime, event func()) {
+				m.notifier.EXPECT().NotifyStalePrice(gomock.Any(), []int{1}, testExchange, "BTCUSDT", staleAt).
+					Do(func(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time) {
+						at.set(time.Now())
+						event()
+					})
+				m.notifier.EXPECT().NotifyPriceRecovered(gomock.Any(), []int{1}, testExchange, "BTCUSDT").
+					Do(func(ctx context.Context, admins []int, exchange, symbol string) {
+						event()
+					})
+				m.orderSrv.EXPECT().ExecuteTpSlOrder(gomock.Any(), int64(7), gomock.Any(), "90").
+					DoAndReturn(func(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
+						queue.Remove(order.Symbol, order.Id)
+						event()
+
+						return order.Id, nil
+					})
+			},
+			events: 3,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newTpSlMocks(ctrl)
+			queue := domain.NewOrderQueue(testExchange)
+			queue.Add(newStopLoss(1, "BTCUSDT", "95"))
+
+			at := &priceTime{at: tc.at}
+			events := make(chan struct{}, tc.events+1)
+			m.cache.EXPECT().GetPriceTime(gomock.Any(), testExchange, "BTCUSDT").DoAndReturn(at.get).AnyTimes()
+			m.cache.EXPECT().GetPrice(gomock.Any(), testExchange, "BTCUSDT").Return("90", nil).AnyTimes()
+			m.admins.EXPECT().GetAdmins(gomock.Any()).Return([]int{1}, nil).AnyTimes()
+			tc.prepare(m, queue, at, func() { events <- struct{}{} })
+
+			ticker := heartbeat.NewTpSlTicker(config.NewConfig(), m.orderSrv, m.cache, m.admins, m.notifier,
+				log.NewLogger(), testPeriod, queue, testExchange, false)
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				ticker.Tick(ctx, nil)
+				close(stopped)
+			}()
+
+			for i := 0; i < tc.events; i++ {
+				select {
+				case <-events:
+				case <-time.After(time.Second):
+					t.Fatalf("got %d of %d expected calls", i, tc.events)
+				}
+			}
+			// the gate keeps running, repeated alerts or executions would break the expectations
+			time.Sleep(10 * testPeriod)
+			cancel()
+			<-stopped
+			assert.Len(t, events, 0)
+		})
+	}
+}
+
+type tickerPrice struct {
+	exchange string
+	symbol   string
+	price    string
+}
+
+// tpSlCalls records what the ticker asked the order service to do.
+type tpSlCalls struct {
+	sync.Mutex
+	executed []string
+	trailed  []string
+}
+
+func (c *tpSlCalls) count() int {
+	c.Lock()
+	defer c.Unlock()
+
+	return len(c.executed) + len(c.trailed)
+}
+
+func TestTpSlTicker_OnPrice(t *testing.T) {
+	testCases := []struct {
+		name     string
+		prices   []tickerPrice
+		executed []string
+		trailed  []string
+	}{
+		{
+			name:     "crossed orders and trailing stops run",
+			prices:   []tickerPrice{{testExchange, "BTCUSDT", "90"}},
+			executed: []string{"1:90"},
+			trailed:  []string{"4:90"},
+		},
+		{
+			name: "prices are coalesced per symbol",
+			prices: []tickerPrice{
+				{testExchange, "BTCUSDT", "100"},
+				{testExchange, "ETHUSDT", "40"},
+				{testExchange, "BTCUSDT", "96"},
+				{testExchange, "BTCUSDT", "90"},
+				{testExchange, "ETHUSDT", "60"},
+			},
+			executed: []string{"1:90"},
+			trailed:  []string{"4:90"},
+		},
+		{
+			name:    "price crossing nothing runs only trailing stops",
+			prices:  []tickerPrice{{testExchange, "BTCUSDT", "100"}},
+			trailed: []string{"4:100"},
+		},
+		{
+			name:     "take profit is run on its side",
+			prices:   []tickerPrice{{testExchange, "BTCUSDT", "110"}},
+			executed: []string{"3:110"},
+			trailed:  []string{"4:110"},
+		},
+		{
+			name:   "price of another exchange is ignored",
+			prices: []tickerPrice{{consts.Kucoin, "BTCUSDT", "1"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := newTpSlMocks(ctrl)
+			queue := domain.NewOrderQueue(testExchange)
+			takeProfit := newStopLoss(3, "BTCUSDT", "110")
+			takeProfit.TpSl = consts.TpOrderType
+			trailingStop := newStopLoss(4, "BTCUSDT", "80")
+			trailingStop.Ts = "5"
+			for _, v := range []*domain.Order{
+				newStopLoss(1, "BTCUSDT", "95"),
+				newStopLoss(2, "BTCUSDT", "85"),
+				takeProfit,
+				trailingStop,
+				newStopLoss(5, "ETHUSDT", "50"),
+			} {
+				queue.Add(v)
+			}
+
+			calls := &tpSlCalls{}
+			m.orderSrv.EXPECT().ExecuteTpSlOrder(gomock.Any(), int64(7), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
+					calls.Lock()
+					calls.executed = append(calls.executed, strconv.FormatInt(order.Id, 10)+":"+price)
+					calls.Unlock()
+					queue.Remove(order.Symbol, order.Id)
+
+					return order.Id, nil
+				}).AnyTimes()
+			m.orderSrv.EXPECT().MoveTrailingStop(gomock.Any(), gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, order *domain.Order, price string) (bool, error) {
+					calls.Lock()
+					calls.trailed = append(calls.trailed, strconv.FormatInt(order.Id, 10)+":"+price)
+					calls.Unlock()
+
+					return false, nil
+				}).AnyTimes()
+
+			ticker := heartbeat.NewTpSlTicker(config.NewConfig(), m.orderSrv, m.cache, m.admins, m.notifier,
+				log.NewLogger(), time.Hour, queue, testExchange, false)
+			for _, v := range tc.prices {
+				ticker.OnPrice(v.exchange, v.symbol, v.price)
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			stopped := make(chan struct{})
+			go func() {
+				ticker.Tick(ctx, nil)
+				close(stopped)
+			}()
+
+			expected := len(tc.executed) + len(tc.trailed)
+			assert.Eventually(t, func() bool { return calls.count() >= expected }, time.Second, testPeriod)
+			time.Sleep(5 * testPeriod)
+			cancel()
+			<-stopped
+
+			calls.Lock()
+			defer calls.Unlock()
+			assert.ElementsMatch(t, tc.executed, calls.executed)
+			assert.ElementsMatch(t, tc.trailed, calls.trailed)
+		})
+	}
+}
+
+func TestTpSlTicker_ConcurrentQueue(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	m := newTpSlMocks(ctrl)
+	queue := domain.NewOrderQueue(testExchange)
+	for i := int64(1); i <= 10; i++ {
+		trailingStop := newStopLoss(i, "BTCUSDT", "80")
+		trailingStop.Ts = "5"
+		queue.Add(trailingStop)
+		queue.Add(newStopLoss(i+10, "BTCUSDT", "50"))
+	}
+
+	m.cache.EXPECT().GetPriceTime(gomock.Any(), testExchange, "BTCUSDT").Return(time.Now(), nil).AnyTimes()
+	m.cache.EXPECT().GetPrice(gomock.Any(), testExchange, "BTCUSDT").Return("90", nil).AnyTimes()
+	m.orderSrv.EXPECT().ExecuteTpSlOrder(gomock.Any(), int64(7), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
+			// failed orders stay in the queue at their trigger price
+			if order.Id%2 == 0 {
+				return 0, errors.New("exchange unavailable")
+			}
+			queue.Remove(order.Symbol, order.Id)
+
+			return order.Id, nil
+		}).AnyTimes()
+	// the order service moves the stop through the queue while the ticker holds the order
+	m.orderSrv.EXPECT().MoveTrailingStop(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order *domain.Order, price string) (bool, error) {
+			stop := new(big.Float).Sub(helper.StringToBigFloat(price), big.NewFloat(5))
+			if stop.Cmp(helper.StringToBigFloat(order.Price)) <= 0 {
+				return false, nil
+			}
+
+			return queue.UpdatePrice(order.Symbol, order.Id, stop.String()), nil
+		}).AnyTimes()
+
+	ticker := heartbeat.NewTpSlTicker(config.NewConfig(), m.orderSrv, m.cache, m.admins, m.notifier,
+		log.NewLogger(), testPeriod, queue, testExchange, false)
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		ticker.Tick(ctx, nil)
+		close(stopped)
+	}()
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			ticker.OnPrice(testExchange, "BTCUSDT", strconv.Itoa(40+i%80))
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for i := int64(100); i < 300; i++ {
+			queue.Add(newStopLoss(i, "BTCUSDT", strconv.FormatInt(i%50+40, 10)))
+			queue.Remove("BTCUSDT", i-10)
+			time.Sleep(time.Millisecond)
+		}
+	}()
+	// a breakeven move of another worker updates the stop under the order lock
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 200; i++ {
+			if order := queue.Get("BTCUSDT", int64(11+i%10)); order != nil {
+				order.Lock()
+				queue.UpdatePrice(order.Symbol, order.Id, strconv.Itoa(45+i%10))
+				order.Unlock()
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}()
+
+	wg.Wait()
+	cancel()
+	<-stopped
+	time.Sleep(5 * testPeriod)
+
+	for _, order := range queue.Snapshot() {
+		if order.IsTrailingStop() {
+			continue
+		}
+		price := helper.StringToBigFloat(order.Price)
+		assert.Contains(t, queue.Triggered(order.Symbol, price), order, "order %d is not triggered at its price", order.Id)
+		above := new(big.Float).Add(price, big.NewFloat(1))
+		assert.NotContains(t, queue.Triggered(order.Symbol, above), order, "order %d is indexed at a stale price", order.Id)
+	}
+}
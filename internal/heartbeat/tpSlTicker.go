@@ -2,47 +2,60 @@ package heartbeat
 
 import (
 	"context"
-	"github.com/go-redis/redis/v8"
 	"github.com/linnoxlewis/trade-bot/config"
 	"github.com/linnoxlewis/trade-bot/internal/domain"
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
 	"github.com/linnoxlewis/trade-bot/internal/helper"
 	ordSrv "github.com/linnoxlewis/trade-bot/internal/pkg/telegram"
-	"github.com/linnoxlewis/trade-bot/pkg/i18n"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
-	"github.com/linnoxlewis/trade-bot/pkg/telegram"
 	"math/big"
 	"os"
+	"sync"
 	"time"
 )
 
-var (
-	successExecuted = "successExecuted"
-	executeFailed   = "executeFailed"
-)
+// PriceSource reads the trade prices written by the price feed.
+type PriceSource interface {
+	GetPrice(ctx context.Context, exchange, symbol string) (string, error)
+	GetPriceTime(ctx context.Context, exchange, symbol string) (time.Time, error)
+}
+
+type AdminSource interface {
+	GetAdmins(ctx context.Context) ([]int, error)
+}
+
+type StalePriceNotifier interface {
+	NotifyStalePrice(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time)
+	NotifyPriceRecovered(ctx context.Context, admins []int, exchange, symbol string)
+}
 
 type TpSlTicker struct {
 	cfg         *config.Config
 	orderSrv    ordSrv.OrderSrv
-	keyDbCli    *redis.Client
-	telegramCli *telegram.Client
+	cache       PriceSource
 	logger      *log.Logger
+	admins      AdminSource
+	notifier    StalePriceNotifier
 	ordersQueue *domain.OrdersQueue
 	ticker      *time.Ticker
-	i18n        *i18n.I18n
+	staleAfter  time.Duration
+	stale       map[string]time.Time
+	prices      map[string]string
+	pricesMu    sync.Mutex
+	wake        chan struct{}
 	exchange    string
 	debugMode   bool
 }
 
 func NewTpSlTicker(cfg *config.Config,
 	orderSrv ordSrv.OrderSrv,
-	cache *redis.Client,
+	cache PriceSource,
+	admins AdminSource,
+	notifier StalePriceNotifier,
 	logger *log.Logger,
-	telegramCli *telegram.Client,
 	heartbeatPeriod time.Duration,
 	ordersQueue *domain.OrdersQueue,
 	exchange string,
-	i18n *i18n.I18n,
 	debugMode bool,
 ) *TpSlTicker {
 	result := &TpSlTicker{
@@ -51,10 +64,14 @@ func NewTpSlTicker(cfg *config.Config,
 		ordersQueue: ordersQueue,
 		orderSrv:    orderSrv,
 		logger:      logger,
-		keyDbCli:    cache,
+		admins:      admins,
+		notifier:    notifier,
+		cache:       cache,
+		staleAfter:  cfg.GetPriceStaleThreshold(),
+		stale:       make(map[string]time.Time),
+		prices:      make(map[string]string),
+		wake:        make(chan struct{}, 1),
 		exchange:    exchange,
-		telegramCli: telegramCli,
-		i18n:        i18n,
 		debugMode:   debugMode,
 	}
 
@@ -63,6 +80,8 @@ func NewTpSlTicker(cfg *config.Config,
 	return result
 }
 
+// Tick evaluates tp/sl on every trade price of the feed, the heartbeat only re-checks symbols
+// with the cached price and watches for stale prices.
 func (t *TpSlTicker) Tick(ctx context.Context, interrupt chan os.Signal) {
 	t.logger.InfoLog.Printf("Start check tpsl in %s ", t.exchange)
 	for {
@@ -73,35 +92,74 @@ func (t *TpSlTicker) Tick(ctx context.Context, interrupt chan os.Signal) {
 		case <-ctx.Done():
 			t.logger.InfoLog.Println("Tp/Sl Ticker stop")
 			return
+		case <-t.wake:
+			t.checkPrices()
 		case <-t.ticker.C:
 			t.checkOrders()
 		}
 	}
 }
 
+// OnPrice queues the trade price for evaluation, only the latest price of a symbol is kept.
+func (t *TpSlTicker) OnPrice(exchange, symbol, price string) {
+	if exchange != t.exchange {
+		return
+	}
+
+	t.pricesMu.Lock()
+	t.prices[symbol] = price
+	t.pricesMu.Unlock()
+
+	select {
+	case t.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (t *TpSlTicker) checkPrices() {
+	t.pricesMu.Lock()
+	prices := t.prices
+	t.prices = make(map[string]string, len(prices))
+	t.pricesMu.Unlock()
+
+	for symbol, price := range prices {
+		t.checkSymbol(symbol, price)
+	}
+}
+
 func (t *TpSlTicker) checkOrders() {
-	for _, val := range t.ordersQueue.Orders {
-		val := val
-		for _, v := range val {
-			if v.Inwork == true {
-				continue
-			}
-			go t.checkTpSl(v)
+	for _, symbol := range t.ordersQueue.Symbols() {
+		if !t.isPriceFresh(symbol) {
+			continue
+		}
+		t.checkSymbol(symbol, t.getPriceFromCache(symbol))
+	}
+}
+
+// checkSymbol touches only the orders crossed by the price and the trailing stops of the symbol.
+func (t *TpSlTicker) checkSymbol(symbol, price string) {
+	tradePrice := helper.StringToBigFloat(price)
+	if tradePrice == nil {
+		t.logger.ErrorLog.Println("TRADE PRICE NIL")
+
+		return
+	}
+
+	for _, v := range t.ordersQueue.Triggered(symbol, tradePrice) {
+		if !t.ordersQueue.Claim(v.Symbol, v.Id) {
+			continue
 		}
+		go t.checkTpSl(v, price)
 	}
 }
 
-func (t *TpSlTicker) checkTpSl(order *domain.Order) {
-	order.Inwork = true
+func (t *TpSlTicker) checkTpSl(order *domain.Order, price string) {
+	defer t.ordersQueue.Release(order.Symbol, order.Id)
 	order.Lock()
-	defer func() {
-		order.Inwork = false
-		order.Unlock()
-	}()
+	defer order.Unlock()
 	if order.Status != consts.OrderStatusActive {
 		return
 	}
-	price := t.getPriceFromCache(order.Symbol)
 	if t.debugMode {
 		t.logger.InfoLog.Printf("current trade price %s", price)
 		t.logger.InfoLog.Printf("start checking %s %s order %d with price %s ", order.Side, order.TpSl, order.Id, order.Price)
@@ -109,20 +167,35 @@ func (t *TpSlTicker) checkTpSl(order *domain.Order) {
 	currentPrice := helper.StringToBigFloat(order.Price)
 	tradePrice := helper.StringToBigFloat(price)
 
-	if tradePrice == nil {
-		t.logger.ErrorLog.Println("TRADE PRICE NIL")
-
-		return
-	}
-
 	if order.TpSl == consts.TpOrderType {
 		t.checkTakeProfit(order, currentPrice, tradePrice)
 	}
 	if order.TpSl == consts.SlOrderType {
+		if order.IsTrailingStop() && t.checkTrailingStop(order, price) {
+			currentPrice = helper.StringToBigFloat(order.Price)
+		}
 		t.checkStopLoss(order, currentPrice, tradePrice)
 	}
 }
 
+func (t *TpSlTicker) checkTrailingStop(order *domain.Order, tradePrice string) bool {
+	moved, err := t.orderSrv.MoveTrailingStop(context.Background(), order, tradePrice)
+	if err != nil {
+		t.logger.ErrorLog.Println("err move trailing stop:", order.Id, err)
+
+		return false
+	}
+	if !moved {
+		return false
+	}
+
+	if t.debugMode {
+		t.logger.InfoLog.Println("trailing stop moved:", order.Id, order.Price, order.TsPrice)
+	}
+
+	return true
+}
+
 func (t *TpSlTicker) checkTakeProfit(order *domain.Order, virtualPrice, tradePrice *big.Float) {
 	if t.debugMode {
 		t.logger.InfoLog.Println("----------------------------------------")
@@ -132,10 +205,7 @@ func (t *TpSlTicker) checkTakeProfit(order *domain.Order, virtualPrice, tradePri
 		t.logger.InfoLog.Println("----------------------------------------")
 	}
 
-	if (order.Side == consts.OrderSideBuy && (tradePrice.Cmp(virtualPrice) == 0 ||
-		tradePrice.Cmp(virtualPrice) == -1)) ||
-		(order.Side == consts.OrderSideSell && (tradePrice.Cmp(virtualPrice) == 1 ||
-			tradePrice.Cmp(virtualPrice) == 0)) {
+	if domain.IsTakeProfitTriggered(order.Side, virtualPrice, tradePrice) {
 		if t.debugMode {
 			t.logger.InfoLog.Println("execute TP order:", order.Id,
 				tradePrice,
@@ -143,34 +213,8 @@ func (t *TpSlTicker) checkTakeProfit(order *domain.Order, virtualPrice, tradePri
 				virtualPrice,
 				tradePrice.Cmp(virtualPrice))
 		}
-		order.Price = tradePrice.String()
-		id, err := t.orderSrv.ExecuteTpSlOrder(context.Background(), order.UserId, order)
-		if err != nil {
+		if _, err := t.orderSrv.ExecuteTpSlOrder(context.Background(), order.UserId, order, tradePrice.String()); err != nil {
 			t.logger.ErrorLog.Println("err execute order:", order.Id, err)
-
-			t.telegramCli.SendMessage(context.Background(),
-				int(order.UserId),
-				t.i18n.T(executeFailed, map[string]interface{}{
-					"TpSl":  order.TpSl,
-					"Side":  order.Side,
-					"Price": order.Price,
-				}, "ru")+":"+err.Error(), "")
-
-			return
-		}
-
-		if err := t.telegramCli.SendMessage(context.Background(), int(order.UserId),
-			t.i18n.T(successExecuted, map[string]interface{}{
-				"TpSl":     order.TpSl,
-				"Side":     order.Side,
-				"Price":    order.Price,
-				"ExecId":   id,
-				"Id":       order.Id,
-				"Exchange": order.Exchange,
-				"Symbol":   order.Symbol,
-				"Quantity": order.Quantity,
-			}, "ru"), ""); err != nil {
-			t.logger.ErrorLog.Println("cant`t send tg message: ", err)
 		}
 
 		return
@@ -185,8 +229,7 @@ func (t *TpSlTicker) checkStopLoss(order *domain.Order, virtualPrice, tradePrice
 		t.logger.InfoLog.Println("cmp SL:", tradePrice.Cmp(virtualPrice), " ")
 		t.logger.InfoLog.Println("----------------------------------------")
 	}
-	if (order.Side == consts.OrderSideSell && (tradePrice.Cmp(virtualPrice) == -1 || tradePrice.Cmp(virtualPrice) == 0)) ||
-		(order.Side == consts.OrderSideBuy && (tradePrice.Cmp(virtualPrice) == +1 || tradePrice.Cmp(virtualPrice) == 0)) {
+	if domain.IsStopLossTriggered(order.Side, virtualPrice, tradePrice) {
 		if t.debugMode {
 			t.logger.InfoLog.Println("----------------------------------------")
 			t.logger.InfoLog.Println("order id::", order.Id, "  ")
@@ -202,34 +245,8 @@ func (t *TpSlTicker) checkStopLoss(order *domain.Order, virtualPrice, tradePrice
 				virtualPrice,
 				tradePrice.Cmp(virtualPrice))
 		}
-		order.Price = tradePrice.String()
-		id, err := t.orderSrv.ExecuteTpSlOrder(context.Background(), order.UserId, order)
-		if err != nil {
+		if _, err := t.orderSrv.ExecuteTpSlOrder(context.Background(), order.UserId, order, tradePrice.String()); err != nil {
 			t.logger.InfoLog.Println("err execute order:", order.Id, err)
-			t.telegramCli.SendMessage(context.Background(),
-				int(order.UserId),
-				t.i18n.T(executeFailed, map[string]interface{}{
-					"TpSl":  order.TpSl,
-					"Side":  order.Side,
-					"Price": order.Price,
-				}, "ru")+":"+err.Error(), "")
-
-			return
-		}
-
-		if err := t.telegramCli.SendMessage(context.Background(), int(order.UserId),
-			t.i18n.T(successExecuted, map[string]interface{}{
-				"TpSl":        order.TpSl,
-				"Side":        order.Side,
-				"Price":       order.Price,
-				"ExecOrderId": id,
-				"Id":          order.Id,
-				"Exchange":    order.Exchange,
-				"Symbol":      order.Symbol,
-				"Quantity":    order.Quantity,
-			}, "ru"), "",
-		); err != nil {
-			t.logger.ErrorLog.Println("cant`t send tg message: ", err)
 		}
 
 		return
@@ -248,10 +265,59 @@ func (t *TpSlTicker) checkQueue() {
 	}
 }
 
+// isPriceFresh refuses prices older than the stale threshold, the admins are alerted once per outage.
+// A missing or unreadable price time counts as stale.
+func (t *TpSlTicker) isPriceFresh(symbol string) bool {
+	if t.staleAfter <= 0 {
+		return true
+	}
+
+	updatedAt, err := t.cache.GetPriceTime(context.Background(), t.exchange, symbol)
+	if err != nil {
+		updatedAt = time.Time{}
+	}
+
+	if !updatedAt.IsZero() && time.Since(updatedAt) <= t.staleAfter {
+		if _, ok := t.stale[symbol]; ok {
+			delete(t.stale, symbol)
+			t.logger.InfoLog.Println("price is updating again:", t.exchange, symbol)
+			go t.notifyAdmins(func(ctx context.Context, admins []int) {
+				t.notifier.NotifyPriceRecovered(ctx, admins, t.exchange, symbol)
+			})
+		}
+
+		return true
+	}
+
+	if _, ok := t.stale[symbol]; !ok {
+		t.stale[symbol] = updatedAt
+		t.logger.ErrorLog.Println("STALE TRADE PRICE:", t.exchange, symbol, updatedAt)
+		go t.notifyAdmins(func(ctx context.Context, admins []int) {
+			t.notifier.NotifyStalePrice(ctx, admins, t.exchange, symbol, updatedAt)
+		})
+	}
+
+	return false
+}
+
+func (t *TpSlTicker) notifyAdmins(notify func(ctx context.Context, admins []int)) {
+	if t.admins == nil || t.notifier == nil {
+		return
+	}
+
+	ctx := context.Background()
+	admins, err := t.admins.GetAdmins(ctx)
+	if err != nil {
+		t.logger.ErrorLog.Println("err get admins for stale price: ", err)
+
+		return
+	}
+	notify(ctx, admins)
+}
+
 func (t *TpSlTicker) getPriceFromCache(symbol string) string {
-	var price string
-	if err := t.keyDbCli.Get(context.Background(),
-		consts.TradePriceCacheKey+t.exchange+"_"+symbol).Scan(&price); err != nil {
+	price, err := t.cache.GetPrice(context.Background(), t.exchange, symbol)
+	if err != nil {
 		return ""
 	}
 
@@ -0,0 +1,176 @@
+package heartbeat
+
+import (
+	"context"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/telegram"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"os"
+	"sync"
+	"time"
+)
+
+type UserStream interface {
+	ReadExecutionReport() (*domain.ExecutionReport, error)
+	Close() error
+}
+
+type UserStreamOpener func(keys *domain.ApiKeys) (UserStream, error)
+
+type UserStreamStatus interface {
+	IsAlive(userId int64) bool
+}
+
+func BinanceUserStreamOpener(useTestnet bool) UserStreamOpener {
+	apiUrl, wsUrl := exchanger.BinanceApiUrl, exchanger.BinanceWsUrl
+	if useTestnet {
+		apiUrl, wsUrl = exchanger.BinanceTestnetApiUrl, exchanger.BinanceTestnetWsUrl
+	}
+
+	return func(keys *domain.ApiKeys) (UserStream, error) {
+		return exchanger.NewBinanceUserStream(apiUrl, wsUrl, keys.PubKey)
+	}
+}
+
+type userStreamConn struct {
+	stream      UserStream
+	connectedAt time.Time
+}
+
+type UserDataStreams struct {
+	orderSrv         telegram.OrderSrv
+	logger           *log.Logger
+	limitOrdersQueue *domain.OrdersQueue
+	open             UserStreamOpener
+	ticker           *time.Ticker
+	warmUp           time.Duration
+	exchange         string
+	streams          map[int64]*userStreamConn
+	sync.RWMutex
+}
+
+func NewUserDataStreams(orderSrv telegram.OrderSrv,
+	limitOrdersQueue *domain.OrdersQueue,
+	open UserStreamOpener,
+	logger *log.Logger,
+	exchange string,
+	heartbeatPeriod time.Duration) *UserDataStreams {
+	return &UserDataStreams{
+		orderSrv:         orderSrv,
+		logger:           logger,
+		limitOrdersQueue: limitOrdersQueue,
+		open:             open,
+		ticker:           time.NewTicker(heartbeatPeriod),
+		warmUp:           heartbeatPeriod * 2,
+		exchange:         exchange,
+		streams:          make(map[int64]*userStreamConn),
+	}
+}
+
+func (u *UserDataStreams) Tick(ctx context.Context, interrupt chan os.Signal) {
+	u.logger.InfoLog.Printf("Start user data streams in %s ", u.exchange)
+	defer u.closeAll()
+	u.connectUsers(ctx)
+	for {
+		select {
+		case <-interrupt:
+			u.logger.InfoLog.Println("User data streams stop")
+		case <-ctx.Done():
+			u.logger.InfoLog.Println("User data streams stop")
+			return
+		case <-u.ticker.C:
+			u.connectUsers(ctx)
+		}
+	}
+}
+
+func (u *UserDataStreams) IsAlive(userId int64) bool {
+	u.RLock()
+	defer u.RUnlock()
+	conn, ok := u.streams[userId]
+
+	return ok && time.Since(conn.connectedAt) >= u.warmUp
+}
+
+func (u *UserDataStreams) isConnected(userId int64) bool {
+	u.RLock()
+	defer u.RUnlock()
+	_, ok := u.streams[userId]
+
+	return ok
+}
+
+func (u *UserDataStreams) connectUsers(ctx context.Context) {
+	users := make(map[int64]struct{})
+	for _, userId := range u.limitOrdersQueue.UserIds() {
+		users[userId] = struct{}{}
+		if u.isConnected(userId) {
+			continue
+		}
+
+		keys, err := u.orderSrv.GetApiKeys(ctx, userId, u.exchange)
+		if err != nil {
+			u.logger.ErrorLog.Println("err get api keys for user stream:", userId, err)
+
+			continue
+		}
+		stream, err := u.open(keys)
+		if err != nil {
+			u.logger.ErrorLog.Println("err open user stream:", userId, err)
+
+			continue
+		}
+
+		u.Lock()
+		u.streams[userId] = &userStreamConn{stream: stream, connectedAt: time.Now()}
+		u.Unlock()
+		go u.listen(ctx, userId, stream)
+	}
+
+	u.Lock()
+	defer u.Unlock()
+	for userId, conn := range u.streams {
+		if _, ok := users[userId]; !ok {
+			delete(u.streams, userId)
+			_ = conn.stream.Close()
+		}
+	}
+}
+
+func (u *UserDataStreams) listen(ctx context.Context, userId int64, stream UserStream) {
+	defer u.drop(userId, stream)
+	for {
+		report, err := stream.ReadExecutionReport()
+		if err != nil {
+			if ctx.Err() == nil {
+				u.logger.ErrorLog.Println("err read user stream:", userId, err)
+			}
+
+			return
+		}
+
+		report.UserId = userId
+		if err = u.orderSrv.HandleExecutionReport(ctx, report); err != nil {
+			u.logger.ErrorLog.Println("err handle execution report:", userId, report.OrderId, err)
+		}
+	}
+}
+
+func (u *UserDataStreams) drop(userId int64, stream UserStream) {
+	u.Lock()
+	defer u.Unlock()
+	if conn, ok := u.streams[userId]; ok && conn.stream == stream {
+		delete(u.streams, userId)
+	}
+	_ = stream.Close()
+}
+
+func (u *UserDataStreams) closeAll() {
+	u.Lock()
+	defer u.Unlock()
+	for userId, conn := range u.streams {
+		delete(u.streams, userId)
+		_ = conn.stream.Close()
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"github.com/gin-gonic/gin"
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
 	"net/http"
 )
 
@@ -15,6 +16,11 @@ var (
 	errNotFound       = errors.New(consts.ErrNotFound)
 )
 
+type ApiResponse struct {
+	Data   interface{} `json:"data"`
+	Errors interface{} `json:"errors"`
+}
+
 func SuccessResponse(c *gin.Context, data interface{}) {
 	c.JSON(http.StatusOK, gin.H{
 		"data":   data,
@@ -67,3 +73,35 @@ func ValidateErrorResponse(c *gin.Context, err error) {
 		"errors": data,
 	})
 }
+
+func ErrorResponse(c *gin.Context, err error) {
+	var appErr srvErr.Error
+	if !errors.As(err, &appErr) {
+		InternalServerErrorResponse(c)
+
+		return
+	}
+
+	code := http.StatusBadRequest
+	switch {
+	case appErr.IsInternalServerError():
+		InternalServerErrorResponse(c)
+
+		return
+	case appErr.IsUnauthorizedError():
+		code = http.StatusUnauthorized
+	case appErr.IsAccessDeniedError():
+		code = http.StatusForbidden
+	case appErr.IsNotFoundError():
+		code = http.StatusNotFound
+	case appErr.IsTooManyRequestError():
+		code = http.StatusTooManyRequests
+	case appErr.IsBadGatewayError():
+		code = http.StatusBadGateway
+	}
+
+	c.JSON(code, gin.H{
+		"data":   nil,
+		"errors": appErr.Error(),
+	})
+}
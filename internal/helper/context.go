@@ -2,15 +2,18 @@ package helper
 
 import (
 	"context"
-	"github.com/google/uuid"
 )
 
-var clientIDKey = new(struct{})
+type contextKey string
 
-func UserToContext(ctx context.Context, userId uuid.UUID) context.Context {
-	return context.WithValue(ctx, "user_id", userId)
+const clientIDKey contextKey = "user_id"
+
+func UserToContext(ctx context.Context, userId int64) context.Context {
+	return context.WithValue(ctx, clientIDKey, userId)
 }
 
-func UserFromContext(ctx context.Context) uuid.UUID {
-	return ctx.Value("user_id").(uuid.UUID)
+func UserFromContext(ctx context.Context) (int64, bool) {
+	userId, ok := ctx.Value(clientIDKey).(int64)
+
+	return userId, ok
 }
@@ -12,6 +12,7 @@ func FromTgOrderToDtoOrder(dtoOrd *dto.TgOrder, ord *dto.Order) {
 	ord.Exchange = strings.ToLower(dtoOrd.Exchange)
 	ord.Price = dtoOrd.Price
 	ord.Quantity = dtoOrd.Quantity
+	ord.RiskPercent = dtoOrd.RiskPercent
 	ord.TimeInForce = strings.ToUpper(dtoOrd.TimeInForce)
 	ord.Side = strings.ToUpper(dtoOrd.Side)
 	ord.TpPercent = dtoOrd.TpPercent
@@ -19,11 +20,14 @@ func FromTgOrderToDtoOrder(dtoOrd *dto.TgOrder, ord *dto.Order) {
 	ord.TpPrice = dtoOrd.TpPrice
 	ord.SlPrice = dtoOrd.SlPrice
 	ord.Ts = dtoOrd.Ts
+	ord.TpLevels = dtoOrd.TpLevels
+	ord.SlBreakeven = dtoOrd.SlBreakeven
 	ord.StopPercent = dtoOrd.StopPercent
 	ord.StopPrice = dtoOrd.StopPrice
 	ord.IcebergQty = dtoOrd.StopPrice
 	ord.TpType = strings.ToUpper(dtoOrd.TpType)
 	ord.SlType = strings.ToUpper(dtoOrd.SlType)
+	ord.Protection = strings.ToLower(dtoOrd.Protection)
 }
 
 func FromTgOrderToDtoCancelOrder(dtoOrd *dto.TgOrder, ord *dto.CancelOrder) {
@@ -0,0 +1,101 @@
+package eventbus
+
+import (
+	"sync"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type Bus struct {
+	mu          sync.RWMutex
+	subscribers map[int64]subscriber
+	lastId      int64
+	logger      *log.Logger
+}
+
+type subscriber struct {
+	ch      chan domain.OrderEvent
+	timeout time.Duration
+}
+
+func New(logger *log.Logger) *Bus {
+	return &Bus{
+		subscribers: make(map[int64]subscriber),
+		logger:      logger,
+	}
+}
+
+func (b *Bus) Publish(event domain.OrderEvent) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	for id, sub := range b.subscribers {
+		if !sub.send(event) {
+			b.logger.ErrorLog.Println("err order event dropped for slow subscriber:", id, event.Type, event.OrderId)
+		}
+	}
+}
+
+// Subscribe returns a subscription that drops events while its buffer is full, it suits consumers
+// that can miss an event, like notifications and watch streams.
+func (b *Bus) Subscribe(bufferSize int) (<-chan domain.OrderEvent, func()) {
+	return b.subscribe(bufferSize, 0)
+}
+
+// SubscribeReliable returns a subscription for consumers that act on every fill or cancel,
+// a full buffer blocks the publisher up to the timeout before the event is dropped.
+func (b *Bus) SubscribeReliable(bufferSize int, timeout time.Duration) (<-chan domain.OrderEvent, func()) {
+	return b.subscribe(bufferSize, timeout)
+}
+
+func (b *Bus) subscribe(bufferSize int, timeout time.Duration) (<-chan domain.OrderEvent, func()) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.lastId++
+	id := b.lastId
+	ch := make(chan domain.OrderEvent, bufferSize)
+	b.subscribers[id] = subscriber{ch: ch, timeout: timeout}
+
+	var once sync.Once
+	unsubscribe := func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+
+			delete(b.subscribers, id)
+			close(ch)
+		})
+	}
+
+	return ch, unsubscribe
+}
+
+func (b *Bus) Len() int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	return len(b.subscribers)
+}
+
+func (s subscriber) send(event domain.OrderEvent) bool {
+	select {
+	case s.ch <- event:
+		return true
+	default:
+	}
+	if s.timeout <= 0 {
+		return false
+	}
+
+	timer := time.NewTimer(s.timeout)
+	defer timer.Stop()
+	select {
+	case s.ch <- event:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
@@ -0,0 +1,88 @@
+package tests
+
+import (
+	"testing"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/eventbus"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBus_PublishSubscribe(t *testing.T) {
+	bus := eventbus.New(log.NewLogger())
+	first, unsubscribeFirst := bus.Subscribe(1)
+	second, unsubscribeSecond := bus.Subscribe(1)
+	defer unsubscribeSecond()
+
+	event := domain.OrderEvent{Type: consts.OrderEventCreated, OrderId: 1}
+	bus.Publish(event)
+
+	assert.Equal(t, event, <-first)
+	assert.Equal(t, event, <-second)
+
+	unsubscribeFirst()
+	unsubscribeFirst()
+	_, ok := <-first
+	assert.False(t, ok)
+	assert.Equal(t, 1, bus.Len())
+
+	bus.Publish(domain.OrderEvent{Type: consts.OrderEventFilled, OrderId: 2})
+	bus.Publish(domain.OrderEvent{Type: consts.OrderEventFilled, OrderId: 3})
+
+	assert.Equal(t, int64(2), (<-second).OrderId)
+	assert.Len(t, second, 0)
+}
+
+func TestBus_SubscribeReliable(t *testing.T) {
+	testCases := []struct {
+		name     string
+		timeout  time.Duration
+		readIn   time.Duration
+		expected []int64
+	}{
+		{
+			name:     "Full buffer waits for the reader",
+			timeout:  time.Second,
+			readIn:   20 * time.Millisecond,
+			expected: []int64{1, 2},
+		},
+		{
+			name:     "Full buffer drops the event after timeout",
+			timeout:  10 * time.Millisecond,
+			readIn:   100 * time.Millisecond,
+			expected: []int64{1},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			bus := eventbus.New(log.NewLogger())
+			events, unsubscribe := bus.SubscribeReliable(1, tc.timeout)
+			defer unsubscribe()
+			lossy, unsubscribeLossy := bus.Subscribe(1)
+			defer unsubscribeLossy()
+
+			received := make(chan []int64)
+			go func() {
+				time.Sleep(tc.readIn)
+				ids := []int64{(<-events).OrderId}
+				select {
+				case event := <-events:
+					ids = append(ids, event.OrderId)
+				case <-time.After(tc.readIn):
+				}
+				received <- ids
+			}()
+
+			bus.Publish(domain.OrderEvent{Type: consts.OrderEventFilled, OrderId: 1})
+			bus.Publish(domain.OrderEvent{Type: consts.OrderEventFilled, OrderId: 2})
+
+			assert.Equal(t, tc.expected, <-received)
+			assert.Equal(t, int64(1), (<-lossy).OrderId)
+			assert.Len(t, lossy, 0)
+		})
+	}
+}
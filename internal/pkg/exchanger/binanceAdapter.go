@@ -11,6 +11,8 @@ import (
 	"sync"
 )
 
+const binancePercentPriceBySide = "PERCENT_PRICE_BY_SIDE"
+
 type BinanceAdapter struct {
 	useTestnet bool
 }
@@ -44,6 +46,36 @@ func (b *BinanceAdapter) CreateOrder(pubKey, secKey, passPhrase string, order *d
 	return result.OrderID, nil
 }
 
+func (b *BinanceAdapter) CreateOcoOrder(pubKey, secKey, passPhrase string, order *dto.OcoOrder) (*domain.OcoOrder, error) {
+	binanceCli.UseTestnet = b.useTestnet
+	result, err := binanceCli.NewClient(pubKey, secKey).
+		NewCreateOCOService().
+		Symbol(strings.ToUpper(order.Symbol)).
+		Side(binanceCli.SideType(strings.ToUpper(order.Side))).
+		Quantity(order.Quantity).
+		Price(order.Price).
+		StopPrice(order.StopPrice).
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	ocoOrder := &domain.OcoOrder{ListId: result.OrderListID}
+	for _, v := range result.OrderReports {
+		switch v.Type {
+		case binanceCli.OrderTypeLimitMaker:
+			ocoOrder.TpOrderId = v.OrderID
+		case binanceCli.OrderTypeStopLoss, binanceCli.OrderTypeStopLossLimit:
+			ocoOrder.SlOrderId = v.OrderID
+		}
+	}
+	if ocoOrder.TpOrderId == 0 || ocoOrder.SlOrderId == 0 {
+		return nil, errors.New("err unexpected oco order legs")
+	}
+
+	return ocoOrder, nil
+}
+
 func (b *BinanceAdapter) CancelOrder(pubKey, secKey, passPhrase string, order *dto.CancelOrder) error {
 	binanceCli.UseTestnet = b.useTestnet
 	_, err := binanceCli.NewClient(pubKey, secKey).
@@ -87,7 +119,7 @@ func (b *BinanceAdapter) GetBalance(pubKey, secKey, passPhrase string) (domain.B
 		return nil, errors.New("empty balances")
 	}
 	for _, v := range result.Balances {
-		balSymb := domain.NewBalanceSymbol(v.Asset, v.Free)
+		balSymb := domain.NewBalanceSymbol(v.Asset, v.Free, v.Locked)
 		balance = append(balance, balSymb)
 	}
 
@@ -120,7 +152,7 @@ func (b *BinanceAdapter) GetOpenOrders(pubKey, secKey, passPhrase, symbol string
 
 		order.ExecOrderId = openOrders[i].OrderID
 		order.OrderType = string(openOrders[i].Type)
-		order.Status = b.getStatus(string(openOrders[i].Status))
+		order.Status = getBinanceStatus(string(openOrders[i].Status))
 		order.Quantity = openOrders[i].OrigQuantity
 		order.Symbol = openOrders[i].Symbol
 		order.Exchange = BinanceType
@@ -148,7 +180,7 @@ func (b *BinanceAdapter) GetOrder(pubKey, secKey, passPhrase, symbol string, ord
 	order := &domain.Order{
 		Id:          result.OrderID,
 		OrderType:   string(result.Type),
-		Status:      b.getStatus(string(result.Status)),
+		Status:      getBinanceStatus(string(result.Status)),
 		Quantity:    result.OrigQuantity,
 		Symbol:      result.Symbol,
 		Exchange:    BinanceType,
@@ -157,6 +189,8 @@ func (b *BinanceAdapter) GetOrder(pubKey, secKey, passPhrase, symbol string, ord
 		TimeInForce: string(result.TimeInForce),
 		StopPrice:   result.StopPrice,
 		IcebergQty:  result.IcebergQuantity,
+		ExecutedQty: result.ExecutedQuantity,
+		AvgPrice:    avgPrice(result.CummulativeQuoteQuantity, result.ExecutedQuantity),
 	}
 
 	return order, nil
@@ -179,7 +213,72 @@ func (b *BinanceAdapter) GetSymbols(pubKey, secKey, passPhrase string) ([]string
 	return symbols, err
 }
 
-func (b *BinanceAdapter) getStatus(status string) string {
+func (b *BinanceAdapter) GetSymbolFilters(pubKey, secKey, passPhrase string) ([]domain.SymbolFilters, error) {
+	binanceCli.UseTestnet = b.useTestnet
+	result, err := binanceCli.NewClient(pubKey, secKey).
+		NewExchangeInfoService().
+		Do(context.Background())
+	if err != nil {
+		return nil, err
+	}
+
+	filters := make([]domain.SymbolFilters, 0, len(result.Symbols))
+	for i := range result.Symbols {
+		filters = append(filters, toBinanceSymbolFilters(&result.Symbols[i]))
+	}
+
+	return filters, nil
+}
+
+func (b *BinanceAdapter) GetPrice(pubKey, secKey, passPhrase, symbol string) (string, error) {
+	binanceCli.UseTestnet = b.useTestnet
+	result, err := binanceCli.NewClient(pubKey, secKey).
+		NewListPricesService().
+		Symbol(symbol).
+		Do(context.Background())
+	if err != nil {
+		return "", err
+	}
+	if len(result) == 0 {
+		return "", errors.New("empty price")
+	}
+
+	return result[0].Price, nil
+}
+
+func toBinanceSymbolFilters(symbol *binanceCli.Symbol) domain.SymbolFilters {
+	filters := domain.SymbolFilters{Symbol: symbol.Symbol}
+	if f := symbol.PriceFilter(); f != nil {
+		filters.TickSize, filters.MinPrice, filters.MaxPrice = f.TickSize, f.MinPrice, f.MaxPrice
+	}
+	if f := symbol.LotSizeFilter(); f != nil {
+		filters.StepSize, filters.MinQty, filters.MaxQty = f.StepSize, f.MinQuantity, f.MaxQuantity
+	}
+	if f := symbol.NotionalFilter(); f != nil {
+		filters.MinNotional = f.MinNotional
+	} else if f := symbol.MinNotionalFilter(); f != nil {
+		filters.MinNotional = f.MinNotional
+	}
+	if f := symbol.PercentPriceFilter(); f != nil {
+		filters.MultiplierUp, filters.MultiplierDown = f.MultiplierUp, f.MultiplierDown
+	}
+	for _, f := range symbol.Filters {
+		if f["filterType"] != binancePercentPriceBySide {
+			continue
+		}
+		// one pair of bounds for both sides, so keep the narrower of bid and ask
+		bidUp, _ := f["bidMultiplierUp"].(string)
+		askUp, _ := f["askMultiplierUp"].(string)
+		bidDown, _ := f["bidMultiplierDown"].(string)
+		askDown, _ := f["askMultiplierDown"].(string)
+		filters.MultiplierUp = compareDecimal(bidUp, askUp, -1)
+		filters.MultiplierDown = compareDecimal(bidDown, askDown, 1)
+	}
+
+	return filters
+}
+
+func getBinanceStatus(status string) string {
 	switch status {
 	case string(binanceCli.OrderStatusTypeNew):
 		return consts.OrderStatusActive
@@ -187,7 +286,7 @@ func (b *BinanceAdapter) getStatus(status string) string {
 		return consts.OrderStatusPartFilled
 	case string(binanceCli.OrderStatusTypeFilled):
 		return consts.OrderStatusFilled
-	case string(binanceCli.OrderStatusTypeCanceled):
+	case string(binanceCli.OrderStatusTypeCanceled), string(binanceCli.OrderStatusTypeExpired):
 		return consts.OrderStatusCanceled
 	default:
 		return ""
@@ -251,7 +350,7 @@ func (b *BinanceAdapter) Trades(pubKey, secKey, passPhrase, symbol string) (doma
 		return nil, errors.New("empty balances")
 	}
 	for _, v := range result.Balances {
-		balSymb := domain.NewBalanceSymbol(v.Asset, v.Free)
+		balSymb := domain.NewBalanceSymbol(v.Asset, v.Free, v.Locked)
 		balance = append(balance, balSymb)
 	}
 
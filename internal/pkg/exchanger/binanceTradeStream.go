@@ -0,0 +1,189 @@
+package exchanger
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+const (
+	BinanceStreamUrl = "wss://stream.binance.com:9443/stream"
+
+	binanceTradeChannel     = "@trade"
+	binanceSubscribe        = "SUBSCRIBE"
+	binanceUnsubscribe      = "UNSUBSCRIBE"
+	binanceStreamPingTime   = 20 * time.Second
+	binanceStreamReadWait   = time.Minute
+	binanceStreamWriteLimit = 10 * time.Second
+)
+
+type binanceStreamMessage struct {
+	Stream string          `json:"stream"`
+	Data   json.RawMessage `json:"data"`
+	Id     int64           `json:"id"`
+	Error  *struct {
+		Code int64  `json:"code"`
+		Msg  string `json:"msg"`
+	} `json:"error"`
+}
+
+type binanceTrade struct {
+	EventType string `json:"e"`
+	EventTime int64  `json:"E"`
+	Symbol    string `json:"s"`
+	Price     string `json:"p"`
+	Quantity  string `json:"q"`
+}
+
+// TradeStreamError is a subscribe or unsubscribe request rejected by the exchange,
+// the connection and the other symbols of the stream stay alive.
+type TradeStreamError struct {
+	Exchange string
+	Msg      string
+}
+
+func (e TradeStreamError) Error() string {
+	return e.Exchange + " stream error: " + e.Msg
+}
+
+// BinanceTradeStream multiplexes trade streams of many symbols over one combined stream connection.
+type BinanceTradeStream struct {
+	conn      *websocket.Conn
+	lastId    int64
+	done      chan struct{}
+	closeOnce sync.Once
+	sync.Mutex
+}
+
+func NewBinanceTradeStream(wsUrl string, symbols ...string) (*BinanceTradeStream, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(wsUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &BinanceTradeStream{
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	conn.SetPingHandler(stream.handlePing)
+	conn.SetPongHandler(stream.handlePong)
+	if err = stream.Subscribe(symbols...); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+	go stream.keepAlive()
+
+	return stream, nil
+}
+
+func (b *BinanceTradeStream) Subscribe(symbols ...string) error {
+	return b.send(binanceSubscribe, symbols)
+}
+
+func (b *BinanceTradeStream) Unsubscribe(symbols ...string) error {
+	return b.send(binanceUnsubscribe, symbols)
+}
+
+func (b *BinanceTradeStream) ReadTrade() (string, string, error) {
+	for {
+		if err := b.conn.SetReadDeadline(time.Now().Add(binanceStreamReadWait)); err != nil {
+			return "", "", err
+		}
+		_, message, err := b.conn.ReadMessage()
+		if err != nil {
+			return "", "", err
+		}
+
+		var msg binanceStreamMessage
+		if err = json.Unmarshal(message, &msg); err != nil {
+			return "", "", err
+		}
+		if msg.Error != nil {
+			return "", "", TradeStreamError{Exchange: consts.Binance, Msg: msg.Error.Msg}
+		}
+		if !strings.HasSuffix(msg.Stream, binanceTradeChannel) {
+			continue
+		}
+
+		var trade binanceTrade
+		if err = json.Unmarshal(msg.Data, &trade); err != nil {
+			return "", "", err
+		}
+		if trade.Price == "" {
+			continue
+		}
+
+		return trade.Symbol, trade.Price, nil
+	}
+}
+
+func (b *BinanceTradeStream) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		err = b.conn.Close()
+	})
+
+	return err
+}
+
+// handlePing answers the server pings, a silent connection is closed by binance after a minute.
+func (b *BinanceTradeStream) handlePing(message string) error {
+	if err := b.conn.SetReadDeadline(time.Now().Add(binanceStreamReadWait)); err != nil {
+		return err
+	}
+	err := b.conn.WriteControl(websocket.PongMessage, []byte(message), time.Now().Add(binanceStreamWriteLimit))
+	if errors.Is(err, websocket.ErrCloseSent) {
+		return nil
+	}
+
+	return err
+}
+
+func (b *BinanceTradeStream) handlePong(string) error {
+	return b.conn.SetReadDeadline(time.Now().Add(binanceStreamReadWait))
+}
+
+func (b *BinanceTradeStream) keepAlive() {
+	ticker := time.NewTicker(binanceStreamPingTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			if err := b.conn.WriteControl(websocket.PingMessage, nil,
+				time.Now().Add(binanceStreamWriteLimit)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (b *BinanceTradeStream) send(method string, symbols []string) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	params := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		params = append(params, strings.ToLower(symbol)+binanceTradeChannel)
+	}
+
+	b.Lock()
+	defer b.Unlock()
+	b.lastId++
+
+	return b.conn.WriteJSON(map[string]interface{}{
+		"method": method,
+		"params": params,
+		"id":     b.lastId,
+	})
+}
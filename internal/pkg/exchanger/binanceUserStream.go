@@ -0,0 +1,191 @@
+package exchanger
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+const (
+	BinanceApiUrl        = "https://api.binance.com"
+	BinanceTestnetApiUrl = "https://testnet.binance.vision"
+	BinanceWsUrl         = "wss://stream.binance.com:9443/ws"
+	BinanceTestnetWsUrl  = "wss://testnet.binance.vision/ws"
+
+	binanceUserStreamPath      = "/api/v3/userDataStream"
+	binanceExecutionReport     = "executionReport"
+	binanceListenKeyExpired    = "listenKeyExpired"
+	binanceListenKeyKeepAlive  = 30 * time.Minute
+	binanceUserStreamHttpLimit = 10 * time.Second
+)
+
+var errBinanceListenKeyExpired = errors.New("err binance listen key expired")
+
+type binanceListenKey struct {
+	ListenKey string `json:"listenKey"`
+}
+
+type binanceUserEvent struct {
+	EventType           string `json:"e"`
+	EventTime           int64  `json:"E"`
+	Symbol              string `json:"s"`
+	Side                string `json:"S"`
+	OrderType           string `json:"o"`
+	OrderCreationTime   int64  `json:"O"`
+	Quantity            string `json:"q"`
+	QuoteQuantity       string `json:"Q"`
+	ExecutionType       string `json:"x"`
+	Status              string `json:"X"`
+	OrderId             int64  `json:"i"`
+	Ignore              int64  `json:"I"`
+	OrderListId         int64  `json:"g"`
+	LastQuantity        string `json:"l"`
+	LastPrice           string `json:"L"`
+	FilledQuantity      string `json:"z"`
+	FilledQuoteQuantity string `json:"Z"`
+}
+
+type BinanceUserStream struct {
+	apiUrl    string
+	apiKey    string
+	listenKey string
+	httpCli   *http.Client
+	conn      *websocket.Conn
+	keepAlive time.Duration
+	done      chan struct{}
+	closeOnce sync.Once
+}
+
+func NewBinanceUserStream(apiUrl, wsUrl, apiKey string) (*BinanceUserStream, error) {
+	stream := &BinanceUserStream{
+		apiUrl:    strings.TrimRight(apiUrl, "/"),
+		apiKey:    apiKey,
+		httpCli:   &http.Client{Timeout: binanceUserStreamHttpLimit},
+		keepAlive: binanceListenKeyKeepAlive,
+		done:      make(chan struct{}),
+	}
+
+	var listenKey binanceListenKey
+	if err := stream.do(http.MethodPost, &listenKey); err != nil {
+		return nil, err
+	}
+	if listenKey.ListenKey == "" {
+		return nil, errors.New("err binance empty listen key")
+	}
+	stream.listenKey = listenKey.ListenKey
+
+	conn, _, err := websocket.DefaultDialer.Dial(strings.TrimRight(wsUrl, "/")+"/"+stream.listenKey, nil)
+	if err != nil {
+		_ = stream.do(http.MethodDelete, nil)
+
+		return nil, err
+	}
+	stream.conn = conn
+	go stream.keepListenKey()
+
+	return stream, nil
+}
+
+func (b *BinanceUserStream) ReadExecutionReport() (*domain.ExecutionReport, error) {
+	for {
+		_, message, err := b.conn.ReadMessage()
+		if err != nil {
+			return nil, err
+		}
+
+		var event binanceUserEvent
+		if err = json.Unmarshal(message, &event); err != nil {
+			return nil, err
+		}
+
+		switch event.EventType {
+		case binanceExecutionReport:
+			return &domain.ExecutionReport{
+				Exchange:       BinanceType,
+				Symbol:         event.Symbol,
+				OrderId:        event.OrderId,
+				OrderListId:    event.OrderListId,
+				Side:           event.Side,
+				OrderType:      event.OrderType,
+				Status:         getBinanceStatus(event.Status),
+				Quantity:       event.Quantity,
+				FilledQuantity: event.FilledQuantity,
+				AvgPrice:       avgPrice(event.FilledQuoteQuantity, event.FilledQuantity),
+				LastPrice:      event.LastPrice,
+			}, nil
+		case binanceListenKeyExpired:
+			return nil, errBinanceListenKeyExpired
+		default:
+			continue
+		}
+	}
+}
+
+func (b *BinanceUserStream) Close() error {
+	var err error
+	b.closeOnce.Do(func() {
+		close(b.done)
+		err = b.conn.Close()
+		_ = b.do(http.MethodDelete, nil)
+	})
+
+	return err
+}
+
+func (b *BinanceUserStream) keepListenKey() {
+	ticker := time.NewTicker(b.keepAlive)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-b.done:
+			return
+		case <-ticker.C:
+			if err := b.do(http.MethodPut, nil); err != nil {
+				_ = b.conn.Close()
+
+				return
+			}
+		}
+	}
+}
+
+func (b *BinanceUserStream) do(method string, result interface{}) error {
+	endpoint := b.apiUrl + binanceUserStreamPath
+	if b.listenKey != "" {
+		endpoint += "?" + url.Values{"listenKey": {b.listenKey}}.Encode()
+	}
+
+	req, err := http.NewRequest(method, endpoint, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("X-MBX-APIKEY", b.apiKey)
+
+	resp, err := b.httpCli.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return errors.New("err binance user stream: " + string(body))
+	}
+	if result == nil {
+		return nil
+	}
+
+	return json.Unmarshal(body, result)
+}
@@ -1,6 +1,7 @@
 package exchanger
 
 import (
+	"errors"
 	"github.com/linnoxlewis/trade-bot/internal/domain"
 	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
 )
@@ -10,18 +11,27 @@ const (
 	KucoinType  = "kucoin"
 	OkxType     = "okx"
 	HuobiType   = "huobi"
+	PaperType   = "paper"
 )
 
-var ExchangeList = []string{BinanceType}
+var ExchangeList = []string{BinanceType, KucoinType, OkxType, PaperType}
+
+var (
+	ErrUnknownExchange = errors.New("err unknown exchange")
+	ErrOcoNotSupported = errors.New("err oco orders are not supported by exchange")
+)
 
 type Exchanger interface {
 	CreateOrder(keys *domain.ApiKeys, order *dto.Order) (int64, error)
+	CreateOcoOrder(keys *domain.ApiKeys, order *dto.OcoOrder) (*domain.OcoOrder, error)
 	CancelOrder(keys *domain.ApiKeys, order *dto.CancelOrder) error
 	UpdateOrder(keys *domain.ApiKeys, order *dto.UpdateOrder) (int64, error)
 	Balance(keys *domain.ApiKeys, exchange string) (domain.Balance, error)
 	GetOpenOrders(keys *domain.ApiKeys, exchange, symbol string) ([]domain.Order, error)
 	GetOrder(keys *domain.ApiKeys, exchange, symbol string, orderId int64) (*domain.Order, error)
 	GetSymbols(keys *domain.ApiKeys, exchange string) ([]string, error)
+	GetPrice(keys *domain.ApiKeys, exchange, symbol string) (string, error)
+	GetSymbolFilters(keys *domain.ApiKeys, exchange string) ([]domain.SymbolFilters, error)
 }
 
 type ExchangerCli interface {
@@ -32,54 +42,130 @@ type ExchangerCli interface {
 	GetOpenOrders(pubKey, secKey, passPhrase, symbol string) ([]domain.Order, error)
 	GetOrder(pubKey, secKey, passPhrase, symbol string, orderId int64) (*domain.Order, error)
 	GetSymbols(pubKey, secKey, passPhrase string) ([]string, error)
+	GetPrice(pubKey, secKey, passPhrase, symbol string) (string, error)
+	GetSymbolFilters(pubKey, secKey, passPhrase string) ([]domain.SymbolFilters, error)
 }
 
 type ExchangeCli struct {
 	binanceCli *BinanceAdapter
+	kucoinCli  *KucoinAdapter
+	okxCli     *OkxAdapter
 	exType     string
 	isTestnet  bool
 }
 
 func NewExchanger(isTestnet bool) *ExchangeCli {
+	kucoinUrl := KucoinApiUrl
+	if isTestnet {
+		kucoinUrl = KucoinSandboxApiUrl
+	}
+
 	return &ExchangeCli{
 		binanceCli: NewBinanceAdapter(isTestnet),
+		kucoinCli:  NewKucoinAdapter(kucoinUrl),
+		okxCli:     NewOkxAdapter(OkxApiUrl, isTestnet),
 		isTestnet:  isTestnet,
 	}
 }
 
-func (e *ExchangeCli) getType(exType string) ExchangerCli {
+func (e *ExchangeCli) getType(exType string) (ExchangerCli, error) {
 	switch exType {
 	case BinanceType:
-		return e.binanceCli
+		return e.binanceCli, nil
+	case KucoinType:
+		return e.kucoinCli, nil
+	case OkxType:
+		return e.okxCli, nil
 	default:
-		return nil
+		return nil, ErrUnknownExchange
 	}
 }
 
 func (e *ExchangeCli) CreateOrder(keys *domain.ApiKeys, order *dto.Order) (int64, error) {
-	return e.getType(order.Exchange).CreateOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
+	cli, err := e.getType(order.Exchange)
+	if err != nil {
+		return 0, err
+	}
+
+	return cli.CreateOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
+}
+
+func (e *ExchangeCli) CreateOcoOrder(keys *domain.ApiKeys, order *dto.OcoOrder) (*domain.OcoOrder, error) {
+	if order.Exchange != BinanceType {
+		return nil, ErrOcoNotSupported
+	}
+
+	return e.binanceCli.CreateOcoOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
 }
 
 func (e *ExchangeCli) CancelOrder(keys *domain.ApiKeys, order *dto.CancelOrder) error {
-	return e.getType(order.Exchange).CancelOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
+	cli, err := e.getType(order.Exchange)
+	if err != nil {
+		return err
+	}
+
+	return cli.CancelOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
 }
 
 func (e *ExchangeCli) UpdateOrder(keys *domain.ApiKeys, order *dto.UpdateOrder) (int64, error) {
-	return e.getType(order.Exchange).UpdateOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
+	cli, err := e.getType(order.Exchange)
+	if err != nil {
+		return 0, err
+	}
+
+	return cli.UpdateOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, order)
 }
 
 func (e *ExchangeCli) Balance(keys *domain.ApiKeys, exchange string) (domain.Balance, error) {
-	return e.getType(exchange).GetBalance(keys.PubKey, keys.PrivKey, keys.Passphrase)
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.GetBalance(keys.PubKey, keys.PrivKey, keys.Passphrase)
 }
 
 func (e *ExchangeCli) GetOpenOrders(keys *domain.ApiKeys, exchange, symbol string) ([]domain.Order, error) {
-	return e.getType(exchange).GetOpenOrders(keys.PubKey, keys.PrivKey, keys.Passphrase, symbol)
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.GetOpenOrders(keys.PubKey, keys.PrivKey, keys.Passphrase, symbol)
 }
 
 func (e *ExchangeCli) GetOrder(keys *domain.ApiKeys, exchange, symbol string, orderId int64) (*domain.Order, error) {
-	return e.getType(exchange).GetOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, symbol, orderId)
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.GetOrder(keys.PubKey, keys.PrivKey, keys.Passphrase, symbol, orderId)
 }
 
 func (e *ExchangeCli) GetSymbols(keys *domain.ApiKeys, exchange string) ([]string, error) {
-	return e.getType(exchange).GetSymbols(keys.PubKey, keys.PrivKey, keys.Passphrase)
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.GetSymbols(keys.PubKey, keys.PrivKey, keys.Passphrase)
+}
+
+func (e *ExchangeCli) GetPrice(keys *domain.ApiKeys, exchange, symbol string) (string, error) {
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return "", err
+	}
+
+	return cli.GetPrice(keys.PubKey, keys.PrivKey, keys.Passphrase, symbol)
+}
+
+func (e *ExchangeCli) GetSymbolFilters(keys *domain.ApiKeys, exchange string) ([]domain.SymbolFilters, error) {
+	cli, err := e.getType(exchange)
+	if err != nil {
+		return nil, err
+	}
+
+	return cli.GetSymbolFilters(keys.PubKey, keys.PrivKey, keys.Passphrase)
 }
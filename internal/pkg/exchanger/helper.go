@@ -0,0 +1,68 @@
+package exchanger
+
+import (
+	"math/big"
+	"strings"
+	"sync/atomic"
+	"time"
+)
+
+var lastClientOrderId atomic.Int64
+
+func nextClientOrderId() int64 {
+	for {
+		last := lastClientOrderId.Load()
+		next := time.Now().UnixNano()
+		if next <= last {
+			next = last + 1
+		}
+		if lastClientOrderId.CompareAndSwap(last, next) {
+			return next
+		}
+	}
+}
+
+func toDashSymbol(symbol string, quotes []string) (string, bool) {
+	symbol = strings.ToUpper(strings.TrimSpace(symbol))
+	if strings.Contains(symbol, "-") {
+		return symbol, true
+	}
+
+	for _, quote := range quotes {
+		if strings.HasSuffix(symbol, quote) && len(symbol) > len(quote) {
+			return strings.TrimSuffix(symbol, quote) + "-" + quote, true
+		}
+	}
+
+	return "", false
+}
+
+func avgPrice(quoteQty, qty string) string {
+	quote, ok := new(big.Rat).SetString(quoteQty)
+	if !ok {
+		return ""
+	}
+	base, ok := new(big.Rat).SetString(qty)
+	if !ok || base.Sign() == 0 {
+		return ""
+	}
+
+	return strings.TrimRight(strings.TrimRight(new(big.Rat).Quo(quote, base).FloatString(8), "0"), ".")
+}
+
+// compareDecimal returns b when it compares to a as sign, otherwise a. Unparsable values lose.
+func compareDecimal(a, b string, sign int) string {
+	left, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return b
+	}
+	right, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return a
+	}
+	if right.Cmp(left) == sign {
+		return b
+	}
+
+	return a
+}
@@ -0,0 +1,476 @@
+package exchanger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+const (
+	KucoinApiUrl        = "https://api.kucoin.com"
+	KucoinSandboxApiUrl = "https://openapi-sandbox.kucoin.com"
+
+	kucoinApiKeyVersion   = "2"
+	kucoinSuccessCode     = "200000"
+	kucoinNotFoundCode    = "400100"
+	kucoinStopLoss        = "loss"
+	kucoinStopEntry       = "entry"
+	kucoinTradeAccount    = "trade"
+	kucoinActiveStatus    = "active"
+	kucoinStopOrderActive = "NEW"
+	kucoinStopOrderDone   = "TRIGGERED"
+	kucoinStopOrderCancel = "CANCELLED"
+)
+
+var kucoinQuoteCurrencies = []string{"USDT", "USDC", "TUSD", "BTC", "ETH", "KCS", "TRX", "EUR", "DAI"}
+
+var (
+	errKucoinSymbol   = errors.New("err unknown kucoin symbol")
+	errKucoinResponse = errors.New("err kucoin empty response")
+)
+
+type KucoinError struct {
+	Code string
+	Msg  string
+}
+
+func (e KucoinError) Error() string {
+	return "kucoin error " + e.Code + ": " + e.Msg
+}
+
+func (e KucoinError) IsNotFound() bool {
+	return e.Code == kucoinNotFoundCode
+}
+
+type kucoinResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type kucoinOrder struct {
+	Id          string `json:"id"`
+	ClientOid   string `json:"clientOid"`
+	Symbol      string `json:"symbol"`
+	Type        string `json:"type"`
+	Side        string `json:"side"`
+	Price       string `json:"price"`
+	Size        string `json:"size"`
+	DealSize    string `json:"dealSize"`
+	DealFunds   string `json:"dealFunds"`
+	TimeInForce string `json:"timeInForce"`
+	Stop        string `json:"stop"`
+	StopPrice   string `json:"stopPrice"`
+	Status      string `json:"status"`
+	IsActive    bool   `json:"isActive"`
+	CancelExist bool   `json:"cancelExist"`
+}
+
+type kucoinOrderList struct {
+	Items []kucoinOrder `json:"items"`
+}
+
+type kucoinAccount struct {
+	Currency  string `json:"currency"`
+	Type      string `json:"type"`
+	Balance   string `json:"balance"`
+	Available string `json:"available"`
+	Holds     string `json:"holds"`
+}
+
+type kucoinSymbol struct {
+	Symbol         string `json:"symbol"`
+	BaseCurrency   string `json:"baseCurrency"`
+	QuoteCurrency  string `json:"quoteCurrency"`
+	EnableTrading  bool   `json:"enableTrading"`
+	BaseMinSize    string `json:"baseMinSize"`
+	BaseMaxSize    string `json:"baseMaxSize"`
+	BaseIncrement  string `json:"baseIncrement"`
+	PriceIncrement string `json:"priceIncrement"`
+	MinFunds       string `json:"minFunds"`
+}
+
+type kucoinTicker struct {
+	Price string `json:"price"`
+}
+
+type KucoinAdapter struct {
+	baseUrl string
+	client  *http.Client
+}
+
+func NewKucoinAdapter(baseUrl string) *KucoinAdapter {
+	return &KucoinAdapter{
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (k *KucoinAdapter) CreateOrder(pubKey, secKey, passPhrase string, order *dto.Order) (int64, error) {
+	symbol, err := ToKucoinSymbol(order.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	clientOid := nextClientOrderId()
+	side := strings.ToLower(order.Side)
+	body := map[string]string{
+		"clientOid": strconv.FormatInt(clientOid, 10),
+		"side":      side,
+		"symbol":    symbol,
+		"size":      order.Quantity,
+	}
+
+	path := "/api/v1/orders"
+	switch strings.ToUpper(order.OrderType) {
+	case consts.OrderTypeMarket:
+		body["type"] = "market"
+	case consts.OrderTypeStopLossLimit:
+		path = "/api/v1/stop-order"
+		body["type"] = "limit"
+		body["price"] = order.Price
+		body["stopPrice"] = order.StopPrice
+		body["stop"] = kucoinStopLoss
+		if side == strings.ToLower(consts.OrderSideBuy) {
+			body["stop"] = kucoinStopEntry
+		}
+	default:
+		body["type"] = "limit"
+		body["price"] = order.Price
+		body["timeInForce"] = consts.TimeInForceGTC
+		if order.TimeInForce != "" {
+			body["timeInForce"] = strings.ToUpper(order.TimeInForce)
+		}
+	}
+
+	if err = k.do(http.MethodPost, path, nil, body, pubKey, secKey, passPhrase, nil); err != nil {
+		return 0, err
+	}
+
+	return clientOid, nil
+}
+
+func (k *KucoinAdapter) CancelOrder(pubKey, secKey, passPhrase string, order *dto.CancelOrder) error {
+	clientOid := strconv.FormatInt(order.Id, 10)
+	err := k.do(http.MethodDelete, "/api/v1/order/client-order/"+clientOid, nil, nil,
+		pubKey, secKey, passPhrase, nil)
+	if !isKucoinNotFound(err) {
+		return err
+	}
+
+	query := url.Values{}
+	query.Set("clientOid", clientOid)
+	if symbol, err := ToKucoinSymbol(order.Symbol); err == nil {
+		query.Set("symbol", symbol)
+	}
+
+	return k.do(http.MethodDelete, "/api/v1/stop-order/cancelOrderByClientOid", query, nil,
+		pubKey, secKey, passPhrase, nil)
+}
+
+func (k *KucoinAdapter) UpdateOrder(pubKey, secKey, passPhrase string, order *dto.UpdateOrder) (int64, error) {
+	if err := k.CancelOrder(pubKey, secKey, passPhrase, &dto.CancelOrder{
+		Id:       order.OrderId,
+		Symbol:   order.Symbol,
+		Exchange: order.Exchange,
+	}); err != nil {
+		return 0, err
+	}
+
+	return k.CreateOrder(pubKey, secKey, passPhrase, &dto.Order{
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		OrderType:   order.OrderType,
+		TimeInForce: order.TimeInForce,
+		Quantity:    order.Quantity,
+		Price:       order.Price,
+		StopPrice:   order.StopPrice,
+	})
+}
+
+func (k *KucoinAdapter) GetBalance(pubKey, secKey, passPhrase string) (domain.Balance, error) {
+	query := url.Values{}
+	query.Set("type", kucoinTradeAccount)
+
+	var accounts []kucoinAccount
+	if err := k.do(http.MethodGet, "/api/v1/accounts", query, nil, pubKey, secKey, passPhrase, &accounts); err != nil {
+		return nil, err
+	}
+	if accounts == nil {
+		return nil, errors.New("empty balances")
+	}
+
+	var balance domain.Balance
+	for _, v := range accounts {
+		balance = append(balance, domain.NewBalanceSymbol(v.Currency, v.Available, v.Holds))
+	}
+
+	return balance, nil
+}
+
+func (k *KucoinAdapter) GetOpenOrders(pubKey, secKey, passPhrase, symbol string) ([]domain.Order, error) {
+	query := url.Values{}
+	query.Set("status", kucoinActiveStatus)
+	if symbol != "" {
+		kcSymbol, err := ToKucoinSymbol(symbol)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("symbol", kcSymbol)
+	}
+
+	var list kucoinOrderList
+	if err := k.do(http.MethodGet, "/api/v1/orders", query, nil, pubKey, secKey, passPhrase, &list); err != nil {
+		return nil, err
+	}
+	if len(list.Items) == 0 {
+		return nil, nil
+	}
+
+	orderList := make([]domain.Order, 0, len(list.Items))
+	for _, v := range list.Items {
+		orderList = append(orderList, k.toDomainOrder(&v))
+	}
+
+	return orderList, nil
+}
+
+func (k *KucoinAdapter) GetOrder(pubKey, secKey, passPhrase, symbol string, orderId int64) (*domain.Order, error) {
+	clientOid := strconv.FormatInt(orderId, 10)
+
+	var result kucoinOrder
+	err := k.do(http.MethodGet, "/api/v1/order/client-order/"+clientOid, nil, nil,
+		pubKey, secKey, passPhrase, &result)
+	if isKucoinNotFound(err) {
+		query := url.Values{}
+		query.Set("clientOid", clientOid)
+
+		var stopOrders []kucoinOrder
+		err = k.do(http.MethodGet, "/api/v1/stop-order/queryOrderByClientOid", query, nil,
+			pubKey, secKey, passPhrase, &stopOrders)
+		if len(stopOrders) > 0 {
+			result = stopOrders[0]
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if result.Symbol == "" {
+		return nil, errKucoinResponse
+	}
+
+	order := k.toDomainOrder(&result)
+	order.Id = orderId
+
+	return &order, nil
+}
+
+func (k *KucoinAdapter) GetSymbols(pubKey, secKey, passPhrase string) ([]string, error) {
+	var result []kucoinSymbol
+	if err := k.do(http.MethodGet, "/api/v2/symbols", nil, nil, "", "", "", &result); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(result))
+	for _, v := range result {
+		if !v.EnableTrading {
+			continue
+		}
+		symbols = append(symbols, v.BaseCurrency+v.QuoteCurrency)
+	}
+
+	return symbols, nil
+}
+
+func (k *KucoinAdapter) GetSymbolFilters(pubKey, secKey, passPhrase string) ([]domain.SymbolFilters, error) {
+	var result []kucoinSymbol
+	if err := k.do(http.MethodGet, "/api/v2/symbols", nil, nil, "", "", "", &result); err != nil {
+		return nil, err
+	}
+
+	filters := make([]domain.SymbolFilters, 0, len(result))
+	for _, v := range result {
+		filters = append(filters, domain.SymbolFilters{
+			Symbol:      v.BaseCurrency + v.QuoteCurrency,
+			TickSize:    v.PriceIncrement,
+			StepSize:    v.BaseIncrement,
+			MinQty:      v.BaseMinSize,
+			MaxQty:      v.BaseMaxSize,
+			MinNotional: v.MinFunds,
+		})
+	}
+
+	return filters, nil
+}
+
+func (k *KucoinAdapter) GetPrice(pubKey, secKey, passPhrase, symbol string) (string, error) {
+	kcSymbol, err := ToKucoinSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("symbol", kcSymbol)
+
+	var result kucoinTicker
+	if err = k.do(http.MethodGet, "/api/v1/market/orderbook/level1", query, nil, "", "", "", &result); err != nil {
+		return "", err
+	}
+	if result.Price == "" {
+		return "", errKucoinResponse
+	}
+
+	return result.Price, nil
+}
+
+func (k *KucoinAdapter) toDomainOrder(order *kucoinOrder) domain.Order {
+	var execId int64
+	if order.ClientOid != "" {
+		execId, _ = strconv.ParseInt(order.ClientOid, 10, 64)
+	}
+
+	orderType := strings.ToUpper(order.Type)
+	if order.Stop != "" {
+		orderType = consts.OrderTypeStopLossLimit
+	}
+
+	return domain.Order{
+		ExecOrderId: execId,
+		OrderType:   orderType,
+		Status:      k.getStatus(order),
+		Quantity:    order.Size,
+		Symbol:      FromKucoinSymbol(order.Symbol),
+		Exchange:    KucoinType,
+		Price:       order.Price,
+		Side:        strings.ToUpper(order.Side),
+		TimeInForce: order.TimeInForce,
+		StopPrice:   order.StopPrice,
+		ExecutedQty: order.DealSize,
+		AvgPrice:    avgPrice(order.DealFunds, order.DealSize),
+	}
+}
+
+func (k *KucoinAdapter) getStatus(order *kucoinOrder) string {
+	dealSize, _ := strconv.ParseFloat(order.DealSize, 64)
+	if order.Status != "" {
+		size, _ := strconv.ParseFloat(order.Size, 64)
+		switch {
+		case order.CancelExist || strings.EqualFold(order.Status, kucoinStopOrderCancel):
+			return consts.OrderStatusCanceled
+		case order.Status == kucoinStopOrderDone && dealSize > 0 && dealSize >= size:
+			return consts.OrderStatusFilled
+		case order.Status == kucoinStopOrderDone && dealSize > 0:
+			return consts.OrderStatusPartFilled
+		default:
+			return consts.OrderStatusActive
+		}
+	}
+
+	switch {
+	case order.IsActive && dealSize > 0:
+		return consts.OrderStatusPartFilled
+	case order.IsActive:
+		return consts.OrderStatusActive
+	case order.CancelExist:
+		return consts.OrderStatusCanceled
+	default:
+		return consts.OrderStatusFilled
+	}
+}
+
+func (k *KucoinAdapter) do(method, path string,
+	query url.Values,
+	body interface{},
+	pubKey, secKey, passPhrase string,
+	result interface{}) error {
+	endpoint := path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, k.baseUrl+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if pubKey != "" {
+		timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+		req.Header.Set("KC-API-KEY", pubKey)
+		req.Header.Set("KC-API-TIMESTAMP", timestamp)
+		req.Header.Set("KC-API-SIGN", KucoinSign(secKey, timestamp+method+endpoint+string(payload)))
+		req.Header.Set("KC-API-PASSPHRASE", KucoinSign(secKey, passPhrase))
+		req.Header.Set("KC-API-KEY-VERSION", kucoinApiKeyVersion)
+	}
+
+	resp, err := k.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response kucoinResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	if response.Code != kucoinSuccessCode {
+		return KucoinError{Code: response.Code, Msg: response.Msg}
+	}
+
+	if result == nil || len(response.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Data, result)
+}
+
+func KucoinSign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func ToKucoinSymbol(symbol string) (string, error) {
+	dashSymbol, ok := toDashSymbol(symbol, kucoinQuoteCurrencies)
+	if !ok {
+		return "", errKucoinSymbol
+	}
+
+	return dashSymbol, nil
+}
+
+func FromKucoinSymbol(symbol string) string {
+	return strings.ReplaceAll(symbol, "-", "")
+}
+
+func isKucoinNotFound(err error) bool {
+	var kcErr KucoinError
+
+	return errors.As(err, &kcErr) && kcErr.IsNotFound()
+}
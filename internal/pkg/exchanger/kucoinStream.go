@@ -0,0 +1,219 @@
+package exchanger
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+const (
+	kucoinMatchTopic       = "/market/match:"
+	kucoinWelcomeMessage   = "welcome"
+	kucoinDataMessage      = "message"
+	kucoinErrorMessage     = "error"
+	kucoinSubscribe        = "subscribe"
+	kucoinUnsubscribe      = "unsubscribe"
+	kucoinDefaultPingDelay = 18 * time.Second
+)
+
+var errKucoinStreamServer = errors.New("err kucoin stream has no instance servers")
+
+type kucoinBullet struct {
+	Token           string `json:"token"`
+	InstanceServers []struct {
+		Endpoint     string `json:"endpoint"`
+		PingInterval int64  `json:"pingInterval"`
+	} `json:"instanceServers"`
+}
+
+type kucoinStreamMessage struct {
+	Id      string          `json:"id"`
+	Type    string          `json:"type"`
+	Topic   string          `json:"topic"`
+	Subject string          `json:"subject"`
+	Data    json.RawMessage `json:"data"`
+}
+
+type kucoinMatch struct {
+	Symbol string `json:"symbol"`
+	Price  string `json:"price"`
+	Size   string `json:"size"`
+}
+
+// KucoinTradeStream multiplexes match topics of many symbols over one connection.
+type KucoinTradeStream struct {
+	conn         *websocket.Conn
+	pingInterval time.Duration
+	done         chan struct{}
+	closeOnce    sync.Once
+	sync.Mutex
+}
+
+func NewKucoinTradeStream(baseUrl string, symbols ...string) (*KucoinTradeStream, error) {
+	var bullet kucoinBullet
+	if err := NewKucoinAdapter(baseUrl).do(http.MethodPost, "/api/v1/bullet-public", nil, nil,
+		"", "", "", &bullet); err != nil {
+		return nil, err
+	}
+	if len(bullet.InstanceServers) == 0 {
+		return nil, errKucoinStreamServer
+	}
+
+	server := bullet.InstanceServers[0]
+	connectId := strconv.FormatInt(time.Now().UnixNano(), 10)
+	conn, _, err := websocket.DefaultDialer.Dial(server.Endpoint+"?token="+bullet.Token+"&connectId="+connectId, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &KucoinTradeStream{
+		conn:         conn,
+		pingInterval: kucoinDefaultPingDelay,
+		done:         make(chan struct{}),
+	}
+	if server.PingInterval > 0 {
+		stream.pingInterval = time.Duration(server.PingInterval) * time.Millisecond
+	}
+
+	if err = stream.welcome(); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+	if err = stream.Subscribe(symbols...); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+	go stream.keepAlive()
+
+	return stream, nil
+}
+
+func (k *KucoinTradeStream) Subscribe(symbols ...string) error {
+	return k.send(kucoinSubscribe, symbols)
+}
+
+func (k *KucoinTradeStream) Unsubscribe(symbols ...string) error {
+	return k.send(kucoinUnsubscribe, symbols)
+}
+
+func (k *KucoinTradeStream) ReadTrade() (string, string, error) {
+	for {
+		if err := k.conn.SetReadDeadline(time.Now().Add(k.pingInterval * 2)); err != nil {
+			return "", "", err
+		}
+		_, message, err := k.conn.ReadMessage()
+		if err != nil {
+			return "", "", err
+		}
+
+		var msg kucoinStreamMessage
+		if err = json.Unmarshal(message, &msg); err != nil {
+			return "", "", err
+		}
+
+		switch msg.Type {
+		case kucoinDataMessage:
+			if !strings.HasPrefix(msg.Topic, kucoinMatchTopic) {
+				continue
+			}
+			var match kucoinMatch
+			if err = json.Unmarshal(msg.Data, &match); err != nil {
+				return "", "", err
+			}
+			if match.Symbol == "" {
+				match.Symbol = strings.TrimPrefix(msg.Topic, kucoinMatchTopic)
+			}
+
+			return FromKucoinSymbol(match.Symbol), match.Price, nil
+		case kucoinErrorMessage:
+			return "", "", TradeStreamError{Exchange: consts.Kucoin, Msg: string(msg.Data)}
+		default:
+			continue
+		}
+	}
+}
+
+func (k *KucoinTradeStream) Close() error {
+	var err error
+	k.closeOnce.Do(func() {
+		close(k.done)
+		err = k.conn.Close()
+	})
+
+	return err
+}
+
+func (k *KucoinTradeStream) welcome() error {
+	_, message, err := k.conn.ReadMessage()
+	if err != nil {
+		return err
+	}
+
+	var welcome kucoinStreamMessage
+	if err = json.Unmarshal(message, &welcome); err != nil {
+		return err
+	}
+	if welcome.Type != kucoinWelcomeMessage {
+		return errors.New("kucoin stream unexpected message: " + welcome.Type)
+	}
+
+	return nil
+}
+
+func (k *KucoinTradeStream) send(messageType string, symbols []string) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	kcSymbols := make([]string, 0, len(symbols))
+	for _, symbol := range symbols {
+		kcSymbol, err := ToKucoinSymbol(symbol)
+		if err != nil {
+			return err
+		}
+		kcSymbols = append(kcSymbols, kcSymbol)
+	}
+
+	return k.writeJSON(map[string]interface{}{
+		"id":             strconv.FormatInt(time.Now().UnixNano(), 10),
+		"type":           messageType,
+		"topic":          kucoinMatchTopic + strings.Join(kcSymbols, ","),
+		"privateChannel": false,
+		"response":       true,
+	})
+}
+
+func (k *KucoinTradeStream) keepAlive() {
+	ticker := time.NewTicker(k.pingInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-k.done:
+			return
+		case <-ticker.C:
+			if err := k.writeJSON(map[string]string{
+				"id":   strconv.FormatInt(time.Now().UnixNano(), 10),
+				"type": "ping",
+			}); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (k *KucoinTradeStream) writeJSON(v interface{}) error {
+	k.Lock()
+	defer k.Unlock()
+
+	return k.conn.WriteJSON(v)
+}
@@ -0,0 +1,597 @@
+package exchanger
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+const (
+	OkxApiUrl = "https://www.okx.com"
+
+	okxTimestampLayout   = "2006-01-02T15:04:05.000Z"
+	okxSuccessCode       = "0"
+	okxNotFoundCode      = "51603"
+	okxCancelFailedCode  = "51400"
+	okxSpotInstType      = "SPOT"
+	okxCashTradeMode     = "cash"
+	okxBaseCcy           = "base_ccy"
+	okxConditionalType   = "conditional"
+	okxLiveInstrument    = "live"
+	okxStateLive         = "live"
+	okxStatePartFilled   = "partially_filled"
+	okxStateFilled       = "filled"
+	okxStateAlgoPause    = "pause"
+	okxStateAlgoPartial  = "partially_effective"
+	okxStateAlgoEffected = "effective"
+)
+
+var okxQuoteCurrencies = []string{"USDT", "USDC", "BTC", "ETH", "OKB", "EUR", "DAI"}
+
+var (
+	errOkxSymbol   = errors.New("err unknown okx symbol")
+	errOkxResponse = errors.New("err okx empty response")
+)
+
+type OkxError struct {
+	Code string
+	Msg  string
+}
+
+func (e OkxError) Error() string {
+	return "okx error " + e.Code + ": " + e.Msg
+}
+
+func (e OkxError) IsNotFound() bool {
+	return e.Code == okxNotFoundCode || e.Code == okxCancelFailedCode
+}
+
+type okxResponse struct {
+	Code string          `json:"code"`
+	Msg  string          `json:"msg"`
+	Data json.RawMessage `json:"data"`
+}
+
+type okxResult struct {
+	SCode string `json:"sCode"`
+	SMsg  string `json:"sMsg"`
+}
+
+type okxOrder struct {
+	InstId    string `json:"instId"`
+	OrdId     string `json:"ordId"`
+	ClOrdId   string `json:"clOrdId"`
+	Px        string `json:"px"`
+	Sz        string `json:"sz"`
+	OrdType   string `json:"ordType"`
+	Side      string `json:"side"`
+	State     string `json:"state"`
+	AccFillSz string `json:"accFillSz"`
+	AvgPx     string `json:"avgPx"`
+}
+
+type okxAlgoOrder struct {
+	InstId      string `json:"instId"`
+	AlgoId      string `json:"algoId"`
+	AlgoClOrdId string `json:"algoClOrdId"`
+	Sz          string `json:"sz"`
+	OrdType     string `json:"ordType"`
+	Side        string `json:"side"`
+	State       string `json:"state"`
+	SlTriggerPx string `json:"slTriggerPx"`
+	SlOrdPx     string `json:"slOrdPx"`
+}
+
+type okxAccount struct {
+	Details []struct {
+		Ccy       string `json:"ccy"`
+		AvailBal  string `json:"availBal"`
+		FrozenBal string `json:"frozenBal"`
+	} `json:"details"`
+}
+
+type okxInstrument struct {
+	InstId   string `json:"instId"`
+	BaseCcy  string `json:"baseCcy"`
+	QuoteCcy string `json:"quoteCcy"`
+	State    string `json:"state"`
+	TickSz   string `json:"tickSz"`
+	LotSz    string `json:"lotSz"`
+	MinSz    string `json:"minSz"`
+	MaxLmtSz string `json:"maxLmtSz"`
+}
+
+type okxTicker struct {
+	InstId string `json:"instId"`
+	Last   string `json:"last"`
+}
+
+type OkxAdapter struct {
+	baseUrl string
+	isDemo  bool
+	client  *http.Client
+}
+
+func NewOkxAdapter(baseUrl string, isDemo bool) *OkxAdapter {
+	return &OkxAdapter{
+		baseUrl: strings.TrimRight(baseUrl, "/"),
+		isDemo:  isDemo,
+		client:  &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (o *OkxAdapter) CreateOrder(pubKey, secKey, passPhrase string, order *dto.Order) (int64, error) {
+	instId, err := ToOkxSymbol(order.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	clOrdId := nextClientOrderId()
+	body := map[string]string{
+		"instId": instId,
+		"tdMode": okxCashTradeMode,
+		"side":   strings.ToLower(order.Side),
+		"sz":     order.Quantity,
+	}
+
+	path := "/api/v5/trade/order"
+	switch strings.ToUpper(order.OrderType) {
+	case consts.OrderTypeMarket:
+		body["clOrdId"] = strconv.FormatInt(clOrdId, 10)
+		body["ordType"] = "market"
+		body["tgtCcy"] = okxBaseCcy
+	case consts.OrderTypeStopLossLimit:
+		path = "/api/v5/trade/order-algo"
+		body["algoClOrdId"] = strconv.FormatInt(clOrdId, 10)
+		body["ordType"] = okxConditionalType
+		body["slTriggerPx"] = order.StopPrice
+		body["slOrdPx"] = order.Price
+	default:
+		body["clOrdId"] = strconv.FormatInt(clOrdId, 10)
+		body["ordType"] = o.getLimitType(order.TimeInForce)
+		body["px"] = order.Price
+	}
+
+	if err = o.do(http.MethodPost, path, nil, body, pubKey, secKey, passPhrase, nil); err != nil {
+		return 0, err
+	}
+
+	return clOrdId, nil
+}
+
+func (o *OkxAdapter) CancelOrder(pubKey, secKey, passPhrase string, order *dto.CancelOrder) error {
+	instId, err := ToOkxSymbol(order.Symbol)
+	if err != nil {
+		return err
+	}
+
+	clOrdId := strconv.FormatInt(order.Id, 10)
+	err = o.do(http.MethodPost, "/api/v5/trade/cancel-order", nil, map[string]string{
+		"instId":  instId,
+		"clOrdId": clOrdId,
+	}, pubKey, secKey, passPhrase, nil)
+	if !isOkxNotFound(err) {
+		return err
+	}
+
+	algoOrder, algoErr := o.getAlgoOrder(pubKey, secKey, passPhrase, clOrdId)
+	if isOkxNotFound(algoErr) {
+		return err
+	}
+	if algoErr != nil {
+		return algoErr
+	}
+
+	return o.do(http.MethodPost, "/api/v5/trade/cancel-algos", nil, []map[string]string{{
+		"instId": instId,
+		"algoId": algoOrder.AlgoId,
+	}}, pubKey, secKey, passPhrase, nil)
+}
+
+func (o *OkxAdapter) UpdateOrder(pubKey, secKey, passPhrase string, order *dto.UpdateOrder) (int64, error) {
+	instId, err := ToOkxSymbol(order.Symbol)
+	if err != nil {
+		return 0, err
+	}
+
+	clOrdId := strconv.FormatInt(order.OrderId, 10)
+	if strings.ToUpper(order.OrderType) == consts.OrderTypeStopLossLimit {
+		body := map[string]string{
+			"instId":      instId,
+			"algoClOrdId": clOrdId,
+		}
+		setIfNotEmpty(body, "newSz", order.Quantity)
+		setIfNotEmpty(body, "newSlTriggerPx", order.StopPrice)
+		setIfNotEmpty(body, "newSlOrdPx", order.Price)
+		if err = o.do(http.MethodPost, "/api/v5/trade/amend-algos", nil, body,
+			pubKey, secKey, passPhrase, nil); err != nil {
+			return 0, err
+		}
+
+		return order.OrderId, nil
+	}
+
+	body := map[string]string{
+		"instId":  instId,
+		"clOrdId": clOrdId,
+	}
+	setIfNotEmpty(body, "newSz", order.Quantity)
+	setIfNotEmpty(body, "newPx", order.Price)
+	if err = o.do(http.MethodPost, "/api/v5/trade/amend-order", nil, body,
+		pubKey, secKey, passPhrase, nil); err != nil {
+		return 0, err
+	}
+
+	return order.OrderId, nil
+}
+
+func (o *OkxAdapter) GetBalance(pubKey, secKey, passPhrase string) (domain.Balance, error) {
+	var accounts []okxAccount
+	if err := o.do(http.MethodGet, "/api/v5/account/balance", nil, nil,
+		pubKey, secKey, passPhrase, &accounts); err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, errors.New("empty balances")
+	}
+
+	var balance domain.Balance
+	for _, v := range accounts[0].Details {
+		balance = append(balance, domain.NewBalanceSymbol(v.Ccy, v.AvailBal, v.FrozenBal))
+	}
+
+	return balance, nil
+}
+
+func (o *OkxAdapter) GetOpenOrders(pubKey, secKey, passPhrase, symbol string) ([]domain.Order, error) {
+	query := url.Values{}
+	query.Set("instType", okxSpotInstType)
+	if symbol != "" {
+		instId, err := ToOkxSymbol(symbol)
+		if err != nil {
+			return nil, err
+		}
+		query.Set("instId", instId)
+	}
+
+	var orders []okxOrder
+	if err := o.do(http.MethodGet, "/api/v5/trade/orders-pending", query, nil,
+		pubKey, secKey, passPhrase, &orders); err != nil {
+		return nil, err
+	}
+
+	query.Set("ordType", okxConditionalType)
+	var algoOrders []okxAlgoOrder
+	if err := o.do(http.MethodGet, "/api/v5/trade/orders-algo-pending", query, nil,
+		pubKey, secKey, passPhrase, &algoOrders); err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 && len(algoOrders) == 0 {
+		return nil, nil
+	}
+
+	orderList := make([]domain.Order, 0, len(orders)+len(algoOrders))
+	for _, v := range orders {
+		orderList = append(orderList, o.toDomainOrder(&v))
+	}
+	for _, v := range algoOrders {
+		orderList = append(orderList, o.algoToDomainOrder(&v))
+	}
+
+	return orderList, nil
+}
+
+func (o *OkxAdapter) GetOrder(pubKey, secKey, passPhrase, symbol string, orderId int64) (*domain.Order, error) {
+	instId, err := ToOkxSymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+
+	clOrdId := strconv.FormatInt(orderId, 10)
+	query := url.Values{}
+	query.Set("instId", instId)
+	query.Set("clOrdId", clOrdId)
+
+	var orders []okxOrder
+	err = o.do(http.MethodGet, "/api/v5/trade/order", query, nil, pubKey, secKey, passPhrase, &orders)
+	if isOkxNotFound(err) {
+		algoOrder, err := o.getAlgoOrder(pubKey, secKey, passPhrase, clOrdId)
+		if err != nil {
+			return nil, err
+		}
+
+		order := o.algoToDomainOrder(algoOrder)
+		order.Id = orderId
+
+		return &order, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, errOkxResponse
+	}
+
+	order := o.toDomainOrder(&orders[0])
+	order.Id = orderId
+
+	return &order, nil
+}
+
+func (o *OkxAdapter) GetSymbols(pubKey, secKey, passPhrase string) ([]string, error) {
+	query := url.Values{}
+	query.Set("instType", okxSpotInstType)
+
+	var result []okxInstrument
+	if err := o.do(http.MethodGet, "/api/v5/public/instruments", query, nil, "", "", "", &result); err != nil {
+		return nil, err
+	}
+
+	symbols := make([]string, 0, len(result))
+	for _, v := range result {
+		if v.State != okxLiveInstrument {
+			continue
+		}
+		symbols = append(symbols, v.BaseCcy+v.QuoteCcy)
+	}
+
+	return symbols, nil
+}
+
+func (o *OkxAdapter) GetSymbolFilters(pubKey, secKey, passPhrase string) ([]domain.SymbolFilters, error) {
+	query := url.Values{}
+	query.Set("instType", okxSpotInstType)
+
+	var result []okxInstrument
+	if err := o.do(http.MethodGet, "/api/v5/public/instruments", query, nil, "", "", "", &result); err != nil {
+		return nil, err
+	}
+
+	filters := make([]domain.SymbolFilters, 0, len(result))
+	for _, v := range result {
+		filters = append(filters, domain.SymbolFilters{
+			Symbol:   v.BaseCcy + v.QuoteCcy,
+			TickSize: v.TickSz,
+			StepSize: v.LotSz,
+			MinQty:   v.MinSz,
+			MaxQty:   v.MaxLmtSz,
+		})
+	}
+
+	return filters, nil
+}
+
+func (o *OkxAdapter) GetPrice(pubKey, secKey, passPhrase, symbol string) (string, error) {
+	instId, err := ToOkxSymbol(symbol)
+	if err != nil {
+		return "", err
+	}
+	query := url.Values{}
+	query.Set("instId", instId)
+
+	var result []okxTicker
+	if err = o.do(http.MethodGet, "/api/v5/market/ticker", query, nil, "", "", "", &result); err != nil {
+		return "", err
+	}
+	if len(result) == 0 || result[0].Last == "" {
+		return "", errOkxResponse
+	}
+
+	return result[0].Last, nil
+}
+
+func (o *OkxAdapter) getAlgoOrder(pubKey, secKey, passPhrase, algoClOrdId string) (*okxAlgoOrder, error) {
+	query := url.Values{}
+	query.Set("algoClOrdId", algoClOrdId)
+
+	var algoOrders []okxAlgoOrder
+	if err := o.do(http.MethodGet, "/api/v5/trade/order-algo", query, nil,
+		pubKey, secKey, passPhrase, &algoOrders); err != nil {
+		return nil, err
+	}
+	if len(algoOrders) == 0 {
+		return nil, errOkxResponse
+	}
+
+	return &algoOrders[0], nil
+}
+
+func (o *OkxAdapter) getLimitType(timeInForce string) string {
+	switch strings.ToUpper(timeInForce) {
+	case consts.TimeInForceFOK:
+		return "fok"
+	case consts.TimeInForceIOK:
+		return "ioc"
+	default:
+		return "limit"
+	}
+}
+
+func (o *OkxAdapter) toDomainOrder(order *okxOrder) domain.Order {
+	execId, _ := strconv.ParseInt(order.ClOrdId, 10, 64)
+
+	orderType := consts.OrderTypeLimit
+	timeInForce := consts.TimeInForceGTC
+	switch order.OrdType {
+	case "market":
+		orderType = consts.OrderTypeMarket
+		timeInForce = ""
+	case "fok":
+		timeInForce = consts.TimeInForceFOK
+	case "ioc":
+		timeInForce = consts.TimeInForceIOK
+	}
+
+	var status string
+	switch order.State {
+	case okxStateLive:
+		status = consts.OrderStatusActive
+	case okxStatePartFilled:
+		status = consts.OrderStatusPartFilled
+	case okxStateFilled:
+		status = consts.OrderStatusFilled
+	default:
+		status = consts.OrderStatusCanceled
+	}
+
+	return domain.Order{
+		ExecOrderId: execId,
+		OrderType:   orderType,
+		Status:      status,
+		Quantity:    order.Sz,
+		Symbol:      FromOkxSymbol(order.InstId),
+		Exchange:    OkxType,
+		Price:       order.Px,
+		Side:        strings.ToUpper(order.Side),
+		TimeInForce: timeInForce,
+		ExecutedQty: order.AccFillSz,
+		AvgPrice:    order.AvgPx,
+	}
+}
+
+func (o *OkxAdapter) algoToDomainOrder(order *okxAlgoOrder) domain.Order {
+	execId, _ := strconv.ParseInt(order.AlgoClOrdId, 10, 64)
+
+	var status string
+	switch order.State {
+	case okxStateLive, okxStateAlgoPause:
+		status = consts.OrderStatusActive
+	case okxStateAlgoPartial:
+		status = consts.OrderStatusPartFilled
+	case okxStateAlgoEffected:
+		status = consts.OrderStatusFilled
+	default:
+		status = consts.OrderStatusCanceled
+	}
+
+	return domain.Order{
+		ExecOrderId: execId,
+		OrderType:   consts.OrderTypeStopLossLimit,
+		Status:      status,
+		Quantity:    order.Sz,
+		Symbol:      FromOkxSymbol(order.InstId),
+		Exchange:    OkxType,
+		Price:       order.SlOrdPx,
+		Side:        strings.ToUpper(order.Side),
+		StopPrice:   order.SlTriggerPx,
+	}
+}
+
+func (o *OkxAdapter) do(method, path string,
+	query url.Values,
+	body interface{},
+	pubKey, secKey, passPhrase string,
+	result interface{}) error {
+	endpoint := path
+	if len(query) > 0 {
+		endpoint += "?" + query.Encode()
+	}
+
+	var payload []byte
+	if body != nil {
+		var err error
+		if payload, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	req, err := http.NewRequest(method, o.baseUrl+endpoint, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if o.isDemo {
+		req.Header.Set("x-simulated-trading", "1")
+	}
+
+	if pubKey != "" {
+		timestamp := time.Now().UTC().Format(okxTimestampLayout)
+		req.Header.Set("OK-ACCESS-KEY", pubKey)
+		req.Header.Set("OK-ACCESS-TIMESTAMP", timestamp)
+		req.Header.Set("OK-ACCESS-SIGN", OkxSign(secKey, timestamp+method+endpoint+string(payload)))
+		req.Header.Set("OK-ACCESS-PASSPHRASE", passPhrase)
+	}
+
+	resp, err := o.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var response okxResponse
+	if err = json.Unmarshal(data, &response); err != nil {
+		return err
+	}
+	if response.Code != okxSuccessCode {
+		return getOkxError(&response)
+	}
+
+	if result == nil || len(response.Data) == 0 {
+		return nil
+	}
+
+	return json.Unmarshal(response.Data, result)
+}
+
+func getOkxError(response *okxResponse) error {
+	var results []okxResult
+	if err := json.Unmarshal(response.Data, &results); err == nil {
+		for _, v := range results {
+			if v.SCode != "" && v.SCode != okxSuccessCode {
+				return OkxError{Code: v.SCode, Msg: v.SMsg}
+			}
+		}
+	}
+
+	return OkxError{Code: response.Code, Msg: response.Msg}
+}
+
+func OkxSign(secret, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+
+	return base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func ToOkxSymbol(symbol string) (string, error) {
+	instId, ok := toDashSymbol(symbol, okxQuoteCurrencies)
+	if !ok {
+		return "", errOkxSymbol
+	}
+
+	return instId, nil
+}
+
+func FromOkxSymbol(instId string) string {
+	return strings.ReplaceAll(instId, "-", "")
+}
+
+func setIfNotEmpty(body map[string]string, key, value string) {
+	if value != "" {
+		body[key] = value
+	}
+}
+
+func isOkxNotFound(err error) bool {
+	var okxErr OkxError
+
+	return errors.As(err, &okxErr) && okxErr.IsNotFound()
+}
@@ -0,0 +1,163 @@
+package exchanger
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+const (
+	OkxPublicWsUrl = "wss://ws.okx.com:8443/ws/v5/public"
+
+	okxTradesChannel  = "trades"
+	okxPingMessage    = "ping"
+	okxPongMessage    = "pong"
+	okxErrorEvent     = "error"
+	okxSubscribeOp    = "subscribe"
+	okxUnsubscribeOp  = "unsubscribe"
+	okxStreamPingTime = 25 * time.Second
+)
+
+type okxStreamArg struct {
+	Channel string `json:"channel"`
+	InstId  string `json:"instId"`
+}
+
+type okxStreamMessage struct {
+	Event string       `json:"event"`
+	Code  string       `json:"code"`
+	Msg   string       `json:"msg"`
+	Arg   okxStreamArg `json:"arg"`
+	Data  []struct {
+		InstId string `json:"instId"`
+		Px     string `json:"px"`
+		Sz     string `json:"sz"`
+	} `json:"data"`
+}
+
+// OkxTradeStream multiplexes trades channels of many instruments over one connection.
+type OkxTradeStream struct {
+	conn      *websocket.Conn
+	done      chan struct{}
+	closeOnce sync.Once
+	sync.Mutex
+}
+
+func NewOkxTradeStream(wsUrl string, symbols ...string) (*OkxTradeStream, error) {
+	conn, _, err := websocket.DefaultDialer.Dial(wsUrl, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	stream := &OkxTradeStream{
+		conn: conn,
+		done: make(chan struct{}),
+	}
+	if err = stream.Subscribe(symbols...); err != nil {
+		_ = conn.Close()
+
+		return nil, err
+	}
+	go stream.keepAlive()
+
+	return stream, nil
+}
+
+func (o *OkxTradeStream) Subscribe(symbols ...string) error {
+	return o.send(okxSubscribeOp, symbols)
+}
+
+func (o *OkxTradeStream) Unsubscribe(symbols ...string) error {
+	return o.send(okxUnsubscribeOp, symbols)
+}
+
+func (o *OkxTradeStream) ReadTrade() (string, string, error) {
+	for {
+		if err := o.conn.SetReadDeadline(time.Now().Add(okxStreamPingTime * 2)); err != nil {
+			return "", "", err
+		}
+		_, message, err := o.conn.ReadMessage()
+		if err != nil {
+			return "", "", err
+		}
+		if string(message) == okxPongMessage {
+			continue
+		}
+
+		var msg okxStreamMessage
+		if err = json.Unmarshal(message, &msg); err != nil {
+			return "", "", err
+		}
+		if msg.Event == okxErrorEvent {
+			return "", "", TradeStreamError{Exchange: consts.Okx, Msg: msg.Code + ": " + msg.Msg}
+		}
+		if msg.Event != "" || msg.Arg.Channel != okxTradesChannel || len(msg.Data) == 0 {
+			continue
+		}
+
+		return FromOkxSymbol(msg.Arg.InstId), msg.Data[len(msg.Data)-1].Px, nil
+	}
+}
+
+func (o *OkxTradeStream) Close() error {
+	var err error
+	o.closeOnce.Do(func() {
+		close(o.done)
+		err = o.conn.Close()
+	})
+
+	return err
+}
+
+func (o *OkxTradeStream) send(op string, symbols []string) error {
+	if len(symbols) == 0 {
+		return nil
+	}
+
+	args := make([]okxStreamArg, 0, len(symbols))
+	for _, symbol := range symbols {
+		instId, err := ToOkxSymbol(symbol)
+		if err != nil {
+			return err
+		}
+		args = append(args, okxStreamArg{Channel: okxTradesChannel, InstId: instId})
+	}
+
+	return o.writeJSON(map[string]interface{}{
+		"op":   op,
+		"args": args,
+	})
+}
+
+func (o *OkxTradeStream) keepAlive() {
+	ticker := time.NewTicker(okxStreamPingTime)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-o.done:
+			return
+		case <-ticker.C:
+			if err := o.writeText(okxPingMessage); err != nil {
+				return
+			}
+		}
+	}
+}
+
+func (o *OkxTradeStream) writeJSON(v interface{}) error {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.conn.WriteJSON(v)
+}
+
+func (o *OkxTradeStream) writeText(message string) error {
+	o.Lock()
+	defer o.Unlock()
+
+	return o.conn.WriteMessage(websocket.TextMessage, []byte(message))
+}
@@ -0,0 +1,396 @@
+package exchanger
+
+import (
+	"context"
+	"errors"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/go-redis/redis/v8"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+const (
+	PaperQuoteAsset     = "USDT"
+	PaperInitialBalance = "10000"
+)
+
+var paperQuoteCurrencies = domain.QuoteCurrencies
+
+var (
+	errPaperSymbol = errors.New("err unknown paper symbol")
+	errPaperPrice  = errors.New("err paper trade price not found")
+	errPaperAmount = errors.New("err invalid paper order amount")
+)
+
+type PaperStore interface {
+	InitBalance(ctx context.Context, userId int64, asset, amount string) error
+	GetBalances(ctx context.Context, userId int64) (domain.Balance, error)
+	PlaceOrder(ctx context.Context, order *domain.PaperOrder) (int64, error)
+	FillOrder(ctx context.Context, id int64) error
+	CancelOrder(ctx context.Context, userId, id int64) error
+	GetOrder(ctx context.Context, userId, id int64) (*domain.PaperOrder, error)
+	GetOpenOrders(ctx context.Context, userId int64, symbol string) ([]*domain.PaperOrder, error)
+}
+
+type PriceSource interface {
+	GetPrice(ctx context.Context, exchange, symbol string) (string, error)
+}
+
+type CachePriceSource struct {
+	keyDbCli *redis.Client
+}
+
+func NewCachePriceSource(keyDbCli *redis.Client) *CachePriceSource {
+	return &CachePriceSource{keyDbCli: keyDbCli}
+}
+
+func (c *CachePriceSource) GetPrice(ctx context.Context, exchange, symbol string) (string, error) {
+	var price string
+	if err := c.keyDbCli.Get(ctx, consts.TradePriceCacheKey+exchange+"_"+symbol).Scan(&price); err != nil {
+		return "", err
+	}
+
+	return price, nil
+}
+
+// GetPriceTime returns when the trade price of the symbol was received.
+func (c *CachePriceSource) GetPriceTime(ctx context.Context, exchange, symbol string) (time.Time, error) {
+	var updatedAtMs int64
+	if err := c.keyDbCli.Get(ctx, consts.TradePriceTimeCacheKey+exchange+"_"+symbol).Scan(&updatedAtMs); err != nil {
+		return time.Time{}, err
+	}
+
+	return time.UnixMilli(updatedAtMs), nil
+}
+
+// SetPrice stores the trade price with the time it was received.
+func (c *CachePriceSource) SetPrice(ctx context.Context, exchange, symbol, price string, at time.Time) error {
+	return c.keyDbCli.MSet(ctx,
+		consts.TradePriceCacheKey+exchange+"_"+symbol, price,
+		consts.TradePriceTimeCacheKey+exchange+"_"+symbol, at.UnixMilli()).Err()
+}
+
+func (c *CachePriceSource) DeletePrice(ctx context.Context, exchange, symbol string) error {
+	return c.keyDbCli.Del(ctx,
+		consts.TradePriceCacheKey+exchange+"_"+symbol,
+		consts.TradePriceTimeCacheKey+exchange+"_"+symbol).Err()
+}
+
+type PaperExchanger struct {
+	next           Exchanger
+	store          PaperStore
+	prices         PriceSource
+	initialBalance string
+}
+
+func NewPaperExchanger(next Exchanger, store PaperStore, prices PriceSource, initialBalance string) *PaperExchanger {
+	if initialBalance == "" {
+		initialBalance = PaperInitialBalance
+	}
+
+	return &PaperExchanger{
+		next:           next,
+		store:          store,
+		prices:         prices,
+		initialBalance: initialBalance,
+	}
+}
+
+func (p *PaperExchanger) CreateOrder(keys *domain.ApiKeys, order *dto.Order) (int64, error) {
+	if order.Exchange != PaperType {
+		return p.next.CreateOrder(keys, order)
+	}
+
+	ctx := context.Background()
+	if err := p.initBalance(ctx, keys.UserId); err != nil {
+		return 0, err
+	}
+
+	paperOrder, err := p.newPaperOrder(ctx, keys.UserId, order)
+	if err != nil {
+		return 0, err
+	}
+
+	return p.store.PlaceOrder(ctx, paperOrder)
+}
+
+func (p *PaperExchanger) CreateOcoOrder(keys *domain.ApiKeys, order *dto.OcoOrder) (*domain.OcoOrder, error) {
+	if order.Exchange != PaperType {
+		return p.next.CreateOcoOrder(keys, order)
+	}
+
+	return nil, ErrOcoNotSupported
+}
+
+func (p *PaperExchanger) CancelOrder(keys *domain.ApiKeys, order *dto.CancelOrder) error {
+	if order.Exchange != PaperType {
+		return p.next.CancelOrder(keys, order)
+	}
+
+	return p.store.CancelOrder(context.Background(), keys.UserId, order.Id)
+}
+
+func (p *PaperExchanger) UpdateOrder(keys *domain.ApiKeys, order *dto.UpdateOrder) (int64, error) {
+	if order.Exchange != PaperType {
+		return p.next.UpdateOrder(keys, order)
+	}
+
+	if err := p.CancelOrder(keys, &dto.CancelOrder{
+		Id:       order.OrderId,
+		Symbol:   order.Symbol,
+		Exchange: order.Exchange,
+	}); err != nil {
+		return 0, err
+	}
+
+	return p.CreateOrder(keys, &dto.Order{
+		Exchange:    order.Exchange,
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		OrderType:   order.OrderType,
+		TimeInForce: order.TimeInForce,
+		Quantity:    order.Quantity,
+		Price:       order.Price,
+		StopPrice:   order.StopPrice,
+	})
+}
+
+func (p *PaperExchanger) Balance(keys *domain.ApiKeys, exchange string) (domain.Balance, error) {
+	if exchange != PaperType {
+		return p.next.Balance(keys, exchange)
+	}
+
+	ctx := context.Background()
+	if err := p.initBalance(ctx, keys.UserId); err != nil {
+		return nil, err
+	}
+
+	return p.store.GetBalances(ctx, keys.UserId)
+}
+
+func (p *PaperExchanger) GetOpenOrders(keys *domain.ApiKeys, exchange, symbol string) ([]domain.Order, error) {
+	if exchange != PaperType {
+		return p.next.GetOpenOrders(keys, exchange, symbol)
+	}
+
+	ctx := context.Background()
+	orders, err := p.store.GetOpenOrders(ctx, keys.UserId, strings.ToUpper(symbol))
+	if err != nil {
+		return nil, err
+	}
+	if len(orders) == 0 {
+		return nil, nil
+	}
+
+	orderList := make([]domain.Order, 0, len(orders))
+	for _, v := range orders {
+		if err = p.tryFill(ctx, v); err != nil {
+			return nil, err
+		}
+		if v.Status != consts.OrderStatusActive {
+			continue
+		}
+		orderList = append(orderList, p.toDomainOrder(v))
+	}
+
+	return orderList, nil
+}
+
+func (p *PaperExchanger) GetOrder(keys *domain.ApiKeys, exchange, symbol string, orderId int64) (*domain.Order, error) {
+	if exchange != PaperType {
+		return p.next.GetOrder(keys, exchange, symbol, orderId)
+	}
+
+	ctx := context.Background()
+	paperOrder, err := p.store.GetOrder(ctx, keys.UserId, orderId)
+	if err != nil {
+		return nil, err
+	}
+	if paperOrder == nil {
+		return nil, domain.ErrPaperOrderNotFound
+	}
+	if err = p.tryFill(ctx, paperOrder); err != nil {
+		return nil, err
+	}
+
+	order := p.toDomainOrder(paperOrder)
+
+	return &order, nil
+}
+
+func (p *PaperExchanger) GetSymbols(keys *domain.ApiKeys, exchange string) ([]string, error) {
+	if exchange != PaperType {
+		return p.next.GetSymbols(keys, exchange)
+	}
+
+	return p.next.GetSymbols(keys, BinanceType)
+}
+
+func (p *PaperExchanger) GetSymbolFilters(keys *domain.ApiKeys, exchange string) ([]domain.SymbolFilters, error) {
+	if exchange != PaperType {
+		return p.next.GetSymbolFilters(keys, exchange)
+	}
+
+	return p.next.GetSymbolFilters(keys, BinanceType)
+}
+
+func (p *PaperExchanger) GetPrice(keys *domain.ApiKeys, exchange, symbol string) (string, error) {
+	if exchange != PaperType {
+		return p.next.GetPrice(keys, exchange, symbol)
+	}
+
+	price, err := p.prices.GetPrice(context.Background(), PaperType, strings.ToUpper(symbol))
+	if err == nil && price != "" {
+		return price, nil
+	}
+
+	return p.next.GetPrice(keys, BinanceType, symbol)
+}
+
+func (p *PaperExchanger) initBalance(ctx context.Context, userId int64) error {
+	return p.store.InitBalance(ctx, userId, PaperQuoteAsset, p.initialBalance)
+}
+
+func (p *PaperExchanger) newPaperOrder(ctx context.Context, userId int64, order *dto.Order) (*domain.PaperOrder, error) {
+	symbol := strings.ToUpper(order.Symbol)
+	pair, ok := toDashSymbol(symbol, paperQuoteCurrencies)
+	if !ok {
+		return nil, errPaperSymbol
+	}
+	assets := strings.SplitN(pair, "-", 2)
+	base, quote := assets[0], assets[1]
+
+	paperOrder := &domain.PaperOrder{
+		UserId:      userId,
+		Symbol:      symbol,
+		Side:        strings.ToUpper(order.Side),
+		OrderType:   strings.ToUpper(order.OrderType),
+		Status:      consts.OrderStatusActive,
+		Quantity:    order.Quantity,
+		Price:       order.Price,
+		StopPrice:   order.StopPrice,
+		TimeInForce: order.TimeInForce,
+	}
+
+	if paperOrder.OrderType == consts.OrderTypeMarket {
+		price, err := p.prices.GetPrice(ctx, PaperType, symbol)
+		if err != nil || price == "" {
+			return nil, errPaperPrice
+		}
+		paperOrder.Price = price
+		paperOrder.TimeInForce = ""
+		paperOrder.Status = consts.OrderStatusFilled
+	}
+
+	total, err := mulDecimal(paperOrder.Quantity, paperOrder.Price)
+	if err != nil {
+		return nil, err
+	}
+
+	if paperOrder.Side == consts.OrderSideBuy {
+		paperOrder.ReserveAsset, paperOrder.ReserveAmount = quote, total
+		paperOrder.ReceiveAsset, paperOrder.ReceiveAmount = base, paperOrder.Quantity
+	} else {
+		paperOrder.ReserveAsset, paperOrder.ReserveAmount = base, paperOrder.Quantity
+		paperOrder.ReceiveAsset, paperOrder.ReceiveAmount = quote, total
+	}
+
+	return paperOrder, nil
+}
+
+func (p *PaperExchanger) tryFill(ctx context.Context, order *domain.PaperOrder) error {
+	if order.Status != consts.OrderStatusActive {
+		return nil
+	}
+
+	price, err := p.prices.GetPrice(ctx, PaperType, order.Symbol)
+	if err != nil || price == "" {
+		return nil
+	}
+	if !isPaperOrderCrossed(order, price) {
+		return nil
+	}
+
+	if err = p.store.FillOrder(ctx, order.Id); err != nil {
+		if errors.Is(err, domain.ErrPaperOrderNotFound) {
+			return nil
+		}
+
+		return err
+	}
+	order.Status = consts.OrderStatusFilled
+
+	return nil
+}
+
+func (p *PaperExchanger) toDomainOrder(order *domain.PaperOrder) domain.Order {
+	var executedQty, execPrice string
+	if order.Status == consts.OrderStatusFilled {
+		executedQty, execPrice = order.Quantity, order.Price
+	}
+
+	return domain.Order{
+		Id:          order.Id,
+		ExecOrderId: order.Id,
+		UserId:      order.UserId,
+		OrderType:   order.OrderType,
+		Status:      order.Status,
+		Quantity:    order.Quantity,
+		Symbol:      order.Symbol,
+		Exchange:    PaperType,
+		Price:       order.Price,
+		Side:        order.Side,
+		TimeInForce: order.TimeInForce,
+		StopPrice:   order.StopPrice,
+		ExecutedQty: executedQty,
+		AvgPrice:    execPrice,
+	}
+}
+
+func isPaperOrderCrossed(order *domain.PaperOrder, tradePrice string) bool {
+	price, ok := new(big.Rat).SetString(tradePrice)
+	if !ok {
+		return false
+	}
+
+	trigger := order.Price
+	if order.OrderType == consts.OrderTypeStopLossLimit {
+		trigger = order.StopPrice
+	}
+	level, ok := new(big.Rat).SetString(trigger)
+	if !ok {
+		return false
+	}
+
+	cmp := price.Cmp(level)
+	switch {
+	case order.OrderType == consts.OrderTypeStopLossLimit && order.Side == consts.OrderSideSell:
+		return cmp <= 0
+	case order.OrderType == consts.OrderTypeStopLossLimit:
+		return cmp >= 0
+	case order.Side == consts.OrderSideBuy:
+		return cmp <= 0
+	default:
+		return cmp >= 0
+	}
+}
+
+func mulDecimal(a, b string) (string, error) {
+	x, ok := new(big.Rat).SetString(a)
+	if !ok {
+		return "", errPaperAmount
+	}
+	y, ok := new(big.Rat).SetString(b)
+	if !ok {
+		return "", errPaperAmount
+	}
+
+	result := new(big.Rat).Mul(x, y).FloatString(18)
+	result = strings.TrimRight(result, "0")
+
+	return strings.TrimSuffix(result, "."), nil
+}
@@ -0,0 +1,101 @@
+package exchanger
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+const (
+	DefaultSymbolFiltersTtl = time.Hour
+	symbolFiltersRetryDelay = time.Minute
+)
+
+type symbolFiltersEntry struct {
+	filters   map[string]*domain.SymbolFilters
+	err       error
+	expiresAt time.Time
+	loading   bool
+}
+
+// SymbolFilterCache keeps exchange info per exchange and reloads it once the ttl is over.
+type SymbolFilterCache struct {
+	exchanger Exchanger
+	ttl       time.Duration
+
+	mu      sync.Mutex
+	entries map[string]*symbolFiltersEntry
+}
+
+func NewSymbolFilterCache(exchanger Exchanger, ttl time.Duration) *SymbolFilterCache {
+	if ttl <= 0 {
+		ttl = DefaultSymbolFiltersTtl
+	}
+
+	return &SymbolFilterCache{
+		exchanger: exchanger,
+		ttl:       ttl,
+		entries:   make(map[string]*symbolFiltersEntry),
+	}
+}
+
+// GetFilters returns nil filters for symbols the exchange does not list.
+// A failed load keeps serving the previous exchange info, or the error when there is none,
+// and is retried after a short delay. The exchange is queried without holding the lock.
+func (c *SymbolFilterCache) GetFilters(exchange, symbol string) (*domain.SymbolFilters, error) {
+	symbol = strings.ToUpper(strings.ReplaceAll(symbol, "-", ""))
+
+	c.mu.Lock()
+	entry, ok := c.entries[exchange]
+	if ok && (entry.loading || time.Now().Before(entry.expiresAt)) && (entry.filters != nil || entry.err != nil) {
+		c.mu.Unlock()
+
+		return entry.lookup(symbol)
+	}
+	if !ok {
+		entry = &symbolFiltersEntry{}
+		c.entries[exchange] = entry
+	}
+	entry.loading = true
+	c.mu.Unlock()
+
+	list, err := c.exchanger.GetSymbolFilters(domain.NewApiKeys(0, exchange, "", "", ""), exchange)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if err != nil {
+		retry := symbolFiltersRetryDelay
+		if c.ttl < retry {
+			retry = c.ttl
+		}
+		next := &symbolFiltersEntry{filters: entry.filters, expiresAt: time.Now().Add(retry)}
+		if next.filters == nil {
+			next.err = err
+		}
+		c.entries[exchange] = next
+
+		return next.lookup(symbol)
+	}
+
+	next := &symbolFiltersEntry{
+		filters:   make(map[string]*domain.SymbolFilters, len(list)),
+		expiresAt: time.Now().Add(c.ttl),
+	}
+	for i := range list {
+		next.filters[strings.ToUpper(list[i].Symbol)] = &list[i]
+	}
+	c.entries[exchange] = next
+
+	return next.lookup(symbol)
+}
+
+func (e *symbolFiltersEntry) lookup(symbol string) (*domain.SymbolFilters, error) {
+	if e.filters == nil {
+		return nil, e.err
+	}
+
+	return e.filters[symbol], nil
+}
@@ -0,0 +1,209 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBinanceUserStream_ReadExecutionReport(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	requests := make(chan string, 4)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/api/v3/userDataStream" {
+			assert.Equal(t, "binance-pub", r.Header.Get("X-MBX-APIKEY"))
+			requests <- r.Method + " " + r.URL.RawQuery
+			_, _ = w.Write([]byte(`{"listenKey":"pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"}`))
+
+			return
+		}
+
+		assert.Equal(t, "/ws/pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"e":"outboundAccountPosition","E":1564034571105,"u":1564034571073,"B":[]}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"e":"executionReport","E":1499405658658,"s":"ETHBTC","c":"mUvoqJxFIILMdfAW5iGSOW","S":"BUY","o":"LIMIT","f":"GTC","q":"1.00000000","p":"0.10264410","P":"0.00000000","x":"TRADE","X":"PARTIALLY_FILLED","i":4293153,"l":"0.40000000","z":"0.40000000","L":"0.10264400","n":"0","N":null,"T":1499405658657,"t":1,"I":8641984,"w":false,"m":false,"M":false,"O":1499405658657,"Z":"0.04105760","Y":"0.04105760","Q":"0.00000000","g":-1}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"e":"executionReport","E":1499405658659,"s":"ETHBTC","S":"SELL","o":"LIMIT_MAKER","q":"1.00000000","x":"EXPIRED","X":"EXPIRED","i":4293154,"z":"0.00000000","L":"0.00000000","g":29}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"e":"listenKeyExpired","E":1576653824250,"listenKey":"pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"}`)))
+
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	stream, err := exchanger.NewBinanceUserStream(server.URL, "ws"+strings.TrimPrefix(server.URL, "http")+"/ws",
+		"binance-pub")
+	require.NoError(t, err)
+
+	report, err := stream.ReadExecutionReport()
+	require.NoError(t, err)
+	assert.Equal(t, &domain.ExecutionReport{
+		Exchange:       consts.Binance,
+		Symbol:         "ETHBTC",
+		OrderId:        4293153,
+		OrderListId:    -1,
+		Side:           "BUY",
+		OrderType:      "LIMIT",
+		Status:         consts.OrderStatusPartFilled,
+		Quantity:       "1.00000000",
+		FilledQuantity: "0.40000000",
+		AvgPrice:       "0.102644",
+		LastPrice:      "0.10264400",
+	}, report)
+
+	report, err = stream.ReadExecutionReport()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4293154), report.OrderId)
+	assert.Equal(t, int64(29), report.OrderListId)
+	assert.Equal(t, consts.OrderStatusCanceled, report.Status)
+
+	_, err = stream.ReadExecutionReport()
+	assert.Error(t, err)
+
+	require.NoError(t, stream.Close())
+
+	for _, expected := range []string{"POST ", "DELETE listenKey=pqia91ma19a5s61cv6a81va65sdf19v8a65a1a5s61cv6a81va65sdf19v8a65a1"} {
+		select {
+		case request := <-requests:
+			assert.Equal(t, expected, request)
+		case <-time.After(time.Second):
+			t.Fatal("listen key request was not sent")
+		}
+	}
+}
+
+func TestBinanceUserStream_ListenKeyError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		_, _ = w.Write([]byte(`{"code":-2015,"msg":"Invalid API-key, IP, or permissions for action."}`))
+	}))
+	defer server.Close()
+
+	stream, err := exchanger.NewBinanceUserStream(server.URL, "ws"+strings.TrimPrefix(server.URL, "http")+"/ws",
+		"binance-pub")
+	assert.Nil(t, stream)
+	assert.Error(t, err)
+}
+
+func TestBinanceTradeStream_ReadTrade(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	requests := make(chan string, 2)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/stream", r.URL.Path)
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+		requests <- string(message)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(`{"result":null,"id":1}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"stream":"ethusdt@trade","data":{"e":"trade","E":1672515782136,"s":"ETHUSDT","t":12345,"p":"2000.10","q":"1.5","T":1672515782136,"m":true,"M":true}}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"stream":"btcusdt@trade","data":{"e":"trade","E":1672515782136,"s":"BTCUSDT","t":12346,"p":"30123.50","q":"0.01","T":1672515782136,"m":false,"M":true}}`)))
+
+		_, message, err = conn.ReadMessage()
+		require.NoError(t, err)
+		requests <- string(message)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"error":{"code":2,"msg":"Invalid request: unknown variant"},"id":2}`)))
+
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	stream, err := exchanger.NewBinanceTradeStream("ws"+strings.TrimPrefix(server.URL, "http")+"/stream",
+		"BTCUSDT", "ETHUSDT")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	select {
+	case request := <-requests:
+		assert.JSONEq(t, `{"method":"SUBSCRIBE","params":["btcusdt@trade","ethusdt@trade"],"id":1}`, request)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not sent")
+	}
+
+	symbol, price, err := stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "ETHUSDT", symbol)
+	assert.Equal(t, "2000.10", price)
+
+	symbol, price, err = stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", symbol)
+	assert.Equal(t, "30123.50", price)
+
+	require.NoError(t, stream.Unsubscribe("ETHUSDT"))
+	select {
+	case request := <-requests:
+		assert.JSONEq(t, `{"method":"UNSUBSCRIBE","params":["ethusdt@trade"],"id":2}`, request)
+	case <-time.After(time.Second):
+		t.Fatal("unsubscription was not sent")
+	}
+
+	_, _, err = stream.ReadTrade()
+	var streamErr exchanger.TradeStreamError
+	require.ErrorAs(t, err, &streamErr)
+	assert.Equal(t, consts.Binance, streamErr.Exchange)
+}
+
+func TestBinanceTradeStream_AnswersPing(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	pongs := make(chan string, 1)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, _, err = conn.ReadMessage()
+		require.NoError(t, err)
+
+		conn.SetPongHandler(func(message string) error {
+			pongs <- message
+
+			return conn.WriteMessage(websocket.TextMessage,
+				[]byte(`{"stream":"btcusdt@trade","data":{"e":"trade","E":1672515782136,"s":"BTCUSDT","p":"30123.50","q":"0.01"}}`))
+		})
+		require.NoError(t, conn.WriteControl(websocket.PingMessage, []byte("1672515782136"), time.Now().Add(time.Second)))
+
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer server.Close()
+
+	stream, err := exchanger.NewBinanceTradeStream("ws"+strings.TrimPrefix(server.URL, "http"), "BTCUSDT")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	symbol, price, err := stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", symbol)
+	assert.Equal(t, "30123.50", price)
+
+	select {
+	case message := <-pongs:
+		assert.Equal(t, "1672515782136", message)
+	case <-time.After(time.Second):
+		t.Fatal("pong was not sent")
+	}
+}
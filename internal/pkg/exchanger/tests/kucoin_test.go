@@ -0,0 +1,422 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	kucoinPubKey     = "pub"
+	kucoinSecKey     = "secret"
+	kucoinPassPhrase = "phrase"
+)
+
+type kucoinRequest struct {
+	method string
+	path   string
+	query  string
+	body   map[string]string
+}
+
+func newKucoinServer(t *testing.T, routes map[string]string) (*httptest.Server, *[]kucoinRequest) {
+	requests := make([]kucoinRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		endpoint := r.URL.Path
+		if r.URL.RawQuery != "" {
+			endpoint += "?" + r.URL.RawQuery
+		}
+
+		if r.Header.Get("KC-API-KEY") != "" {
+			timestamp := r.Header.Get("KC-API-TIMESTAMP")
+			assert.Equal(t, kucoinPubKey, r.Header.Get("KC-API-KEY"))
+			assert.Equal(t, "2", r.Header.Get("KC-API-KEY-VERSION"))
+			assert.Equal(t, exchanger.KucoinSign(kucoinSecKey, kucoinPassPhrase), r.Header.Get("KC-API-PASSPHRASE"))
+			assert.Equal(t, exchanger.KucoinSign(kucoinSecKey, timestamp+r.Method+endpoint+string(payload)),
+				r.Header.Get("KC-API-SIGN"))
+		}
+
+		req := kucoinRequest{method: r.Method, path: r.URL.Path, query: r.URL.RawQuery}
+		if len(payload) > 0 {
+			require.NoError(t, json.Unmarshal(payload, &req.body))
+		}
+		requests = append(requests, req)
+
+		response, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			response = `{"code":"400100","msg":"order not exist"}`
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+func TestKucoinAdapter_CreateOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		order        *dto.Order
+		expectedPath string
+		expectedBody map[string]string
+		expectedErr  string
+	}{
+		{
+			name: "limit",
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+				Quantity: "0.1", Price: "30000", TimeInForce: consts.TimeInForceGTC},
+			expectedPath: "/api/v1/orders",
+			expectedBody: map[string]string{"side": "buy", "symbol": "BTC-USDT", "type": "limit",
+				"size": "0.1", "price": "30000", "timeInForce": "GTC"},
+		},
+		{
+			name:         "market",
+			order:        &dto.Order{Symbol: "ETHBTC", Side: consts.OrderSideSell, OrderType: consts.OrderTypeMarket, Quantity: "2"},
+			expectedPath: "/api/v1/orders",
+			expectedBody: map[string]string{"side": "sell", "symbol": "ETH-BTC", "type": "market", "size": "2"},
+		},
+		{
+			name: "stop loss limit",
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideSell, OrderType: consts.OrderTypeStopLossLimit,
+				Quantity: "0.1", Price: "29000", StopPrice: "29500"},
+			expectedPath: "/api/v1/stop-order",
+			expectedBody: map[string]string{"side": "sell", "symbol": "BTC-USDT", "type": "limit",
+				"size": "0.1", "price": "29000", "stopPrice": "29500", "stop": "loss"},
+		},
+		{
+			name:        "unknown symbol",
+			order:       &dto.Order{Symbol: "FOO", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket, Quantity: "1"},
+			expectedErr: "err unknown kucoin symbol",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newKucoinServer(t, map[string]string{
+				"POST /api/v1/orders":     `{"code":"200000","data":{"orderId":"5bd6e9286d99522a52e458de"}}`,
+				"POST /api/v1/stop-order": `{"code":"200000","data":{"orderId":"vs8hoo8q2ceshiue003b67c0"}}`,
+			})
+			defer server.Close()
+
+			id, err := exchanger.NewKucoinAdapter(server.URL).
+				CreateOrder(kucoinPubKey, kucoinSecKey, kucoinPassPhrase, tt.order)
+			if tt.expectedErr != "" {
+				assert.EqualError(t, err, tt.expectedErr)
+				assert.Len(t, *requests, 0)
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, *requests, 1)
+			req := (*requests)[0]
+			assert.Equal(t, tt.expectedPath, req.path)
+			assert.Equal(t, strconv.FormatInt(id, 10), req.body["clientOid"])
+			delete(req.body, "clientOid")
+			assert.Equal(t, tt.expectedBody, req.body)
+		})
+	}
+}
+
+func TestKucoinAdapter_CancelOrder(t *testing.T) {
+	t.Run("regular order", func(t *testing.T) {
+		server, requests := newKucoinServer(t, map[string]string{
+			"DELETE /api/v1/order/client-order/42": `{"code":"200000","data":{"cancelledOrderId":"1","clientOid":"42"}}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewKucoinAdapter(server.URL).CancelOrder(kucoinPubKey, kucoinSecKey, kucoinPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Kucoin})
+
+		assert.NoError(t, err)
+		assert.Len(t, *requests, 1)
+	})
+
+	t.Run("fallback to stop order", func(t *testing.T) {
+		server, requests := newKucoinServer(t, map[string]string{
+			"DELETE /api/v1/stop-order/cancelOrderByClientOid": `{"code":"200000","data":{"cancelledOrderId":"1","clientOid":"42"}}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewKucoinAdapter(server.URL).CancelOrder(kucoinPubKey, kucoinSecKey, kucoinPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Kucoin})
+
+		assert.NoError(t, err)
+		require.Len(t, *requests, 2)
+		assert.Equal(t, "clientOid=42&symbol=BTC-USDT", (*requests)[1].query)
+	})
+
+	t.Run("exchange error", func(t *testing.T) {
+		server, _ := newKucoinServer(t, map[string]string{
+			"DELETE /api/v1/order/client-order/42": `{"code":"400001","msg":"Please check the header of your request"}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewKucoinAdapter(server.URL).CancelOrder(kucoinPubKey, kucoinSecKey, kucoinPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Kucoin})
+
+		assert.EqualError(t, err, "kucoin error 400001: Please check the header of your request")
+	})
+}
+
+func TestKucoinAdapter_GetOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		route          string
+		response       string
+		expectedStatus string
+		expectedType   string
+	}{
+		{
+			name:           "active",
+			route:          "GET /api/v1/order/client-order/42",
+			response:       `{"code":"200000","data":{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"buy","price":"30000","size":"0.1","dealSize":"0","isActive":true}}`,
+			expectedStatus: consts.OrderStatusActive,
+			expectedType:   consts.OrderTypeLimit,
+		},
+		{
+			name:           "partially filled",
+			route:          "GET /api/v1/order/client-order/42",
+			response:       `{"code":"200000","data":{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"buy","price":"30000","size":"0.1","dealSize":"0.05","isActive":true}}`,
+			expectedStatus: consts.OrderStatusPartFilled,
+			expectedType:   consts.OrderTypeLimit,
+		},
+		{
+			name:           "filled",
+			route:          "GET /api/v1/order/client-order/42",
+			response:       `{"code":"200000","data":{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"buy","price":"30000","size":"0.1","dealSize":"0.1","isActive":false}}`,
+			expectedStatus: consts.OrderStatusFilled,
+			expectedType:   consts.OrderTypeLimit,
+		},
+		{
+			name:           "canceled",
+			route:          "GET /api/v1/order/client-order/42",
+			response:       `{"code":"200000","data":{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"buy","price":"30000","size":"0.1","dealSize":"0","isActive":false,"cancelExist":true}}`,
+			expectedStatus: consts.OrderStatusCanceled,
+			expectedType:   consts.OrderTypeLimit,
+		},
+		{
+			name:           "untriggered stop order",
+			route:          "GET /api/v1/stop-order/queryOrderByClientOid",
+			response:       `{"code":"200000","data":[{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"sell","price":"29000","size":"0.1","stop":"loss","stopPrice":"29500","status":"NEW"}]}`,
+			expectedStatus: consts.OrderStatusActive,
+			expectedType:   consts.OrderTypeStopLossLimit,
+		},
+		{
+			name:           "triggered stop order without deal",
+			route:          "GET /api/v1/stop-order/queryOrderByClientOid",
+			response:       `{"code":"200000","data":[{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"sell","price":"29000","size":"0.1","dealSize":"0","stop":"loss","stopPrice":"29500","status":"TRIGGERED"}]}`,
+			expectedStatus: consts.OrderStatusActive,
+			expectedType:   consts.OrderTypeStopLossLimit,
+		},
+		{
+			name:           "triggered stop order partially filled",
+			route:          "GET /api/v1/stop-order/queryOrderByClientOid",
+			response:       `{"code":"200000","data":[{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"sell","price":"29000","size":"0.1","dealSize":"0.04","stop":"loss","stopPrice":"29500","status":"TRIGGERED"}]}`,
+			expectedStatus: consts.OrderStatusPartFilled,
+			expectedType:   consts.OrderTypeStopLossLimit,
+		},
+		{
+			name:           "triggered stop order filled",
+			route:          "GET /api/v1/stop-order/queryOrderByClientOid",
+			response:       `{"code":"200000","data":[{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"sell","price":"29000","size":"0.1","dealSize":"0.1","stop":"loss","stopPrice":"29500","status":"TRIGGERED"}]}`,
+			expectedStatus: consts.OrderStatusFilled,
+			expectedType:   consts.OrderTypeStopLossLimit,
+		},
+		{
+			name:           "cancelled stop order",
+			route:          "GET /api/v1/stop-order/queryOrderByClientOid",
+			response:       `{"code":"200000","data":[{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"sell","price":"29000","size":"0.1","dealSize":"0","stop":"loss","stopPrice":"29500","status":"CANCELLED","cancelExist":true}]}`,
+			expectedStatus: consts.OrderStatusCanceled,
+			expectedType:   consts.OrderTypeStopLossLimit,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newKucoinServer(t, map[string]string{tt.route: tt.response})
+			defer server.Close()
+
+			order, err := exchanger.NewKucoinAdapter(server.URL).
+				GetOrder(kucoinPubKey, kucoinSecKey, kucoinPassPhrase, "BTCUSDT", 42)
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), order.Id)
+			assert.Equal(t, int64(42), order.ExecOrderId)
+			assert.Equal(t, "BTCUSDT", order.Symbol)
+			assert.Equal(t, consts.Kucoin, order.Exchange)
+			assert.Equal(t, tt.expectedStatus, order.Status)
+			assert.Equal(t, tt.expectedType, order.OrderType)
+		})
+	}
+}
+
+func TestKucoinAdapter_GetBalance(t *testing.T) {
+	server, requests := newKucoinServer(t, map[string]string{
+		"GET /api/v1/accounts": `{"code":"200000","data":[
+			{"currency":"USDT","type":"trade","balance":"100","available":"80","holds":"20"},
+			{"currency":"BTC","type":"trade","balance":"1","available":"1","holds":"0"}]}`,
+	})
+	defer server.Close()
+
+	balance, err := exchanger.NewKucoinAdapter(server.URL).GetBalance(kucoinPubKey, kucoinSecKey, kucoinPassPhrase)
+
+	require.NoError(t, err)
+	require.Len(t, balance, 2)
+	assert.Equal(t, "USDT", balance[0].Symbol)
+	assert.Equal(t, "80", balance[0].Quantity)
+	assert.Equal(t, "20", balance[0].Locked)
+	assert.Equal(t, "type=trade", (*requests)[0].query)
+}
+
+func TestKucoinAdapter_GetSymbolFilters(t *testing.T) {
+	server, _ := newKucoinServer(t, map[string]string{
+		"GET /api/v2/symbols": `{"code":"200000","data":[
+			{"symbol":"BTC-USDT","baseCurrency":"BTC","quoteCurrency":"USDT","enableTrading":true,"baseMinSize":"0.00001",
+			"baseMaxSize":"10000000000","baseIncrement":"0.00000001","priceIncrement":"0.1","minFunds":"0.1"}]}`,
+	})
+	defer server.Close()
+
+	filters, err := exchanger.NewKucoinAdapter(server.URL).GetSymbolFilters("", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.1", StepSize: "0.00000001",
+		MinQty: "0.00001", MaxQty: "10000000000", MinNotional: "0.1"}}, filters)
+}
+
+func TestKucoinAdapter_GetPrice(t *testing.T) {
+	server, requests := newKucoinServer(t, map[string]string{
+		"GET /api/v1/market/orderbook/level1": `{"code":"200000","data":{"sequence":"1","price":"30123.4","size":"0.01"}}`,
+	})
+	defer server.Close()
+
+	price, err := exchanger.NewKucoinAdapter(server.URL).GetPrice("", "", "", "BTCUSDT")
+
+	require.NoError(t, err)
+	assert.Equal(t, "30123.4", price)
+	assert.Equal(t, "symbol=BTC-USDT", (*requests)[0].query)
+}
+
+func TestKucoinAdapter_GetOpenOrders(t *testing.T) {
+	server, requests := newKucoinServer(t, map[string]string{
+		"GET /api/v1/orders": `{"code":"200000","data":{"currentPage":1,"items":[
+			{"id":"1","clientOid":"42","symbol":"BTC-USDT","type":"limit","side":"buy","price":"30000","size":"0.1","dealSize":"0","isActive":true}]}}`,
+	})
+	defer server.Close()
+
+	orders, err := exchanger.NewKucoinAdapter(server.URL).
+		GetOpenOrders(kucoinPubKey, kucoinSecKey, kucoinPassPhrase, "BTCUSDT")
+
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, int64(42), orders[0].ExecOrderId)
+	assert.Equal(t, consts.OrderSideBuy, orders[0].Side)
+	assert.Equal(t, "status=active&symbol=BTC-USDT", (*requests)[0].query)
+}
+
+func TestKucoinAdapter_GetSymbols(t *testing.T) {
+	server, _ := newKucoinServer(t, map[string]string{
+		"GET /api/v2/symbols": `{"code":"200000","data":[
+			{"symbol":"BTC-USDT","baseCurrency":"BTC","quoteCurrency":"USDT","enableTrading":true},
+			{"symbol":"OLD-USDT","baseCurrency":"OLD","quoteCurrency":"USDT","enableTrading":false}]}`,
+	})
+	defer server.Close()
+
+	symbols, err := exchanger.NewKucoinAdapter(server.URL).GetSymbols("", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT"}, symbols)
+}
+
+func TestKucoinTradeStream_ReadTrade(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	subscribed := make(chan map[string]interface{}, 2)
+
+	wsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "token123", r.URL.Query().Get("token"))
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		require.NoError(t, conn.WriteJSON(map[string]string{"id": r.URL.Query().Get("connectId"), "type": "welcome"}))
+
+		var sub map[string]interface{}
+		require.NoError(t, conn.ReadJSON(&sub))
+		subscribed <- sub
+
+		require.NoError(t, conn.WriteJSON(map[string]string{"id": sub["id"].(string), "type": "ack"}))
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"type": "message", "topic": "/market/match:ETH-USDT", "subject": "trade.l3match",
+			"data": map[string]string{"symbol": "ETH-USDT", "price": "2000"},
+		}))
+		require.NoError(t, conn.WriteJSON(map[string]interface{}{
+			"type": "message", "topic": "/market/match:BTC-USDT", "subject": "trade.l3match",
+			"data": map[string]string{"symbol": "BTC-USDT", "price": "30123.5"},
+		}))
+
+		for {
+			var unsub map[string]interface{}
+			require.NoError(t, conn.ReadJSON(&unsub))
+			if unsub["type"] != "ping" {
+				subscribed <- unsub
+
+				break
+			}
+		}
+
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer wsServer.Close()
+
+	restServer, _ := newKucoinServer(t, map[string]string{
+		"POST /api/v1/bullet-public": `{"code":"200000","data":{"token":"token123","instanceServers":[{"endpoint":"` +
+			"ws" + strings.TrimPrefix(wsServer.URL, "http") + `","pingInterval":50}]}}`,
+	})
+	defer restServer.Close()
+
+	stream, err := exchanger.NewKucoinTradeStream(restServer.URL, "BTCUSDT", "ETHUSDT")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	select {
+	case sub := <-subscribed:
+		assert.Equal(t, "subscribe", sub["type"])
+		assert.Equal(t, "/market/match:BTC-USDT,ETH-USDT", sub["topic"])
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not sent")
+	}
+
+	symbol, price, err := stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "ETHUSDT", symbol)
+	assert.Equal(t, "2000", price)
+
+	symbol, price, err = stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", symbol)
+	assert.Equal(t, "30123.5", price)
+
+	require.NoError(t, stream.Unsubscribe("ETHUSDT"))
+	select {
+	case unsub := <-subscribed:
+		assert.Equal(t, "unsubscribe", unsub["type"])
+		assert.Equal(t, "/market/match:ETH-USDT", unsub["topic"])
+	case <-time.After(time.Second):
+		t.Fatal("unsubscription was not sent")
+	}
+
+	assert.Error(t, stream.Subscribe("BTCXYZ"))
+}
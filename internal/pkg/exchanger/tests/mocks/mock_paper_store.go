@@ -0,0 +1,176 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: paperExchanger.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockPaperStore is a mock of PaperStore interface.
+type MockPaperStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockPaperStoreMockRecorder
+}
+
+// MockPaperStoreMockRecorder is the mock recorder for MockPaperStore.
+type MockPaperStoreMockRecorder struct {
+	mock *MockPaperStore
+}
+
+// NewMockPaperStore creates a new mock instance.
+func NewMockPaperStore(ctrl *gomock.Controller) *MockPaperStore {
+	mock := &MockPaperStore{ctrl: ctrl}
+	mock.recorder = &MockPaperStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPaperStore) EXPECT() *MockPaperStoreMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockPaperStore) CancelOrder(ctx context.Context, userId, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, userId, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockPaperStoreMockRecorder) CancelOrder(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockPaperStore)(nil).CancelOrder), ctx, userId, id)
+}
+
+// FillOrder mocks base method.
+func (m *MockPaperStore) FillOrder(ctx context.Context, id int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FillOrder", ctx, id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// FillOrder indicates an expected call of FillOrder.
+func (mr *MockPaperStoreMockRecorder) FillOrder(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FillOrder", reflect.TypeOf((*MockPaperStore)(nil).FillOrder), ctx, id)
+}
+
+// GetBalances mocks base method.
+func (m *MockPaperStore) GetBalances(ctx context.Context, userId int64) (domain.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalances", ctx, userId)
+	ret0, _ := ret[0].(domain.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalances indicates an expected call of GetBalances.
+func (mr *MockPaperStoreMockRecorder) GetBalances(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalances", reflect.TypeOf((*MockPaperStore)(nil).GetBalances), ctx, userId)
+}
+
+// GetOpenOrders mocks base method.
+func (m *MockPaperStore) GetOpenOrders(ctx context.Context, userId int64, symbol string) ([]*domain.PaperOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOpenOrders", ctx, userId, symbol)
+	ret0, _ := ret[0].([]*domain.PaperOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOpenOrders indicates an expected call of GetOpenOrders.
+func (mr *MockPaperStoreMockRecorder) GetOpenOrders(ctx, userId, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOpenOrders", reflect.TypeOf((*MockPaperStore)(nil).GetOpenOrders), ctx, userId, symbol)
+}
+
+// GetOrder mocks base method.
+func (m *MockPaperStore) GetOrder(ctx context.Context, userId, id int64) (*domain.PaperOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, userId, id)
+	ret0, _ := ret[0].(*domain.PaperOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockPaperStoreMockRecorder) GetOrder(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockPaperStore)(nil).GetOrder), ctx, userId, id)
+}
+
+// InitBalance mocks base method.
+func (m *MockPaperStore) InitBalance(ctx context.Context, userId int64, asset, amount string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "InitBalance", ctx, userId, asset, amount)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// InitBalance indicates an expected call of InitBalance.
+func (mr *MockPaperStoreMockRecorder) InitBalance(ctx, userId, asset, amount interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InitBalance", reflect.TypeOf((*MockPaperStore)(nil).InitBalance), ctx, userId, asset, amount)
+}
+
+// PlaceOrder mocks base method.
+func (m *MockPaperStore) PlaceOrder(ctx context.Context, order *domain.PaperOrder) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PlaceOrder", ctx, order)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PlaceOrder indicates an expected call of PlaceOrder.
+func (mr *MockPaperStoreMockRecorder) PlaceOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PlaceOrder", reflect.TypeOf((*MockPaperStore)(nil).PlaceOrder), ctx, order)
+}
+
+// MockPriceSource is a mock of PriceSource interface.
+type MockPriceSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceSourceMockRecorder
+}
+
+// MockPriceSourceMockRecorder is the mock recorder for MockPriceSource.
+type MockPriceSourceMockRecorder struct {
+	mock *MockPriceSource
+}
+
+// NewMockPriceSource creates a new mock instance.
+func NewMockPriceSource(ctrl *gomock.Controller) *MockPriceSource {
+	mock := &MockPriceSource{ctrl: ctrl}
+	mock.recorder = &MockPriceSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceSource) EXPECT() *MockPriceSourceMockRecorder {
+	return m.recorder
+}
+
+// GetPrice mocks base method.
+func (m *MockPriceSource) GetPrice(ctx context.Context, exchange, symbol string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", ctx, exchange, symbol)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockPriceSourceMockRecorder) GetPrice(ctx, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockPriceSource)(nil).GetPrice), ctx, exchange, symbol)
+}
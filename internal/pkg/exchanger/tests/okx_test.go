@@ -0,0 +1,453 @@
+package tests
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gorilla/websocket"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	okxPubKey     = "okx-pub"
+	okxSecKey     = "okx-secret"
+	okxPassPhrase = "okx-phrase"
+)
+
+type okxRequest struct {
+	path    string
+	query   string
+	payload string
+	demo    string
+}
+
+func newOkxServer(t *testing.T, routes map[string]string) (*httptest.Server, *[]okxRequest) {
+	requests := make([]okxRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		payload, _ := io.ReadAll(r.Body)
+		endpoint := r.URL.Path
+		if r.URL.RawQuery != "" {
+			endpoint += "?" + r.URL.RawQuery
+		}
+
+		if r.Header.Get("OK-ACCESS-KEY") != "" {
+			timestamp := r.Header.Get("OK-ACCESS-TIMESTAMP")
+			_, err := time.Parse("2006-01-02T15:04:05.000Z", timestamp)
+			assert.NoError(t, err)
+			assert.Equal(t, okxPubKey, r.Header.Get("OK-ACCESS-KEY"))
+			assert.Equal(t, okxPassPhrase, r.Header.Get("OK-ACCESS-PASSPHRASE"))
+			assert.Equal(t, exchanger.OkxSign(okxSecKey, timestamp+r.Method+endpoint+string(payload)),
+				r.Header.Get("OK-ACCESS-SIGN"))
+		}
+
+		requests = append(requests, okxRequest{
+			path:    r.URL.Path,
+			query:   r.URL.RawQuery,
+			payload: string(payload),
+			demo:    r.Header.Get("x-simulated-trading"),
+		})
+
+		response, ok := routes[r.Method+" "+r.URL.Path]
+		if !ok {
+			response = `{"code":"51603","msg":"Order does not exist","data":[]}`
+		}
+		_, _ = w.Write([]byte(response))
+	}))
+
+	return server, &requests
+}
+
+func TestOkxSign(t *testing.T) {
+	assert.Equal(t, "5ktoTKif8DCJlIPb/3Kfd1A17bIRye6jpS9QBWj+9AU=",
+		exchanger.OkxSign("secret", "2020-12-08T09:08:57.715ZGET/api/v5/account/balance"))
+}
+
+func TestOkxAdapter_CreateOrder(t *testing.T) {
+	tests := []struct {
+		name         string
+		order        *dto.Order
+		expectedPath string
+		idField      string
+		expectedBody map[string]string
+	}{
+		{
+			name: "limit",
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+				Quantity: "0.1", Price: "30000", TimeInForce: consts.TimeInForceGTC},
+			expectedPath: "/api/v5/trade/order",
+			idField:      "clOrdId",
+			expectedBody: map[string]string{"instId": "BTC-USDT", "tdMode": "cash", "side": "buy",
+				"ordType": "limit", "sz": "0.1", "px": "30000"},
+		},
+		{
+			name: "fok limit",
+			order: &dto.Order{Symbol: "ETHUSDT", Side: consts.OrderSideSell, OrderType: consts.OrderTypeLimit,
+				Quantity: "1", Price: "2000", TimeInForce: consts.TimeInForceFOK},
+			expectedPath: "/api/v5/trade/order",
+			idField:      "clOrdId",
+			expectedBody: map[string]string{"instId": "ETH-USDT", "tdMode": "cash", "side": "sell",
+				"ordType": "fok", "sz": "1", "px": "2000"},
+		},
+		{
+			name:         "market",
+			order:        &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket, Quantity: "0.1"},
+			expectedPath: "/api/v5/trade/order",
+			idField:      "clOrdId",
+			expectedBody: map[string]string{"instId": "BTC-USDT", "tdMode": "cash", "side": "buy",
+				"ordType": "market", "sz": "0.1", "tgtCcy": "base_ccy"},
+		},
+		{
+			name: "stop loss limit",
+			order: &dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideSell, OrderType: consts.OrderTypeStopLossLimit,
+				Quantity: "0.1", Price: "29000", StopPrice: "29500"},
+			expectedPath: "/api/v5/trade/order-algo",
+			idField:      "algoClOrdId",
+			expectedBody: map[string]string{"instId": "BTC-USDT", "tdMode": "cash", "side": "sell",
+				"ordType": "conditional", "sz": "0.1", "slTriggerPx": "29500", "slOrdPx": "29000"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newOkxServer(t, map[string]string{
+				"POST /api/v5/trade/order":      `{"code":"0","msg":"","data":[{"ordId":"312269865356374016","sCode":"0","sMsg":""}]}`,
+				"POST /api/v5/trade/order-algo": `{"code":"0","msg":"","data":[{"algoId":"12345689","sCode":"0","sMsg":""}]}`,
+			})
+			defer server.Close()
+
+			id, err := exchanger.NewOkxAdapter(server.URL, false).
+				CreateOrder(okxPubKey, okxSecKey, okxPassPhrase, tt.order)
+
+			require.NoError(t, err)
+			require.Len(t, *requests, 1)
+			req := (*requests)[0]
+			assert.Equal(t, tt.expectedPath, req.path)
+			assert.Empty(t, req.demo)
+
+			var body map[string]string
+			require.NoError(t, json.Unmarshal([]byte(req.payload), &body))
+			assert.Equal(t, strconv.FormatInt(id, 10), body[tt.idField])
+			delete(body, tt.idField)
+			assert.Equal(t, tt.expectedBody, body)
+		})
+	}
+}
+
+func TestOkxAdapter_CreateOrderRejected(t *testing.T) {
+	server, _ := newOkxServer(t, map[string]string{
+		"POST /api/v5/trade/order": `{"code":"1","msg":"Operation failed.","data":[{"ordId":"","sCode":"51008","sMsg":"Order failed. Insufficient balance"}]}`,
+	})
+	defer server.Close()
+
+	_, err := exchanger.NewOkxAdapter(server.URL, false).CreateOrder(okxPubKey, okxSecKey, okxPassPhrase,
+		&dto.Order{Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeMarket, Quantity: "1"})
+
+	assert.EqualError(t, err, "okx error 51008: Order failed. Insufficient balance")
+}
+
+func TestOkxAdapter_DemoHeader(t *testing.T) {
+	server, requests := newOkxServer(t, map[string]string{
+		"GET /api/v5/account/balance": `{"code":"0","msg":"","data":[{"details":[{"ccy":"USDT","availBal":"100"}]}]}`,
+	})
+	defer server.Close()
+
+	_, err := exchanger.NewOkxAdapter(server.URL, true).GetBalance(okxPubKey, okxSecKey, okxPassPhrase)
+
+	require.NoError(t, err)
+	assert.Equal(t, "1", (*requests)[0].demo)
+}
+
+func TestOkxAdapter_CancelOrder(t *testing.T) {
+	t.Run("regular order", func(t *testing.T) {
+		server, requests := newOkxServer(t, map[string]string{
+			"POST /api/v5/trade/cancel-order": `{"code":"0","msg":"","data":[{"clOrdId":"42","sCode":"0","sMsg":""}]}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewOkxAdapter(server.URL, false).CancelOrder(okxPubKey, okxSecKey, okxPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Okx})
+
+		assert.NoError(t, err)
+		require.Len(t, *requests, 1)
+		assert.JSONEq(t, `{"instId":"BTC-USDT","clOrdId":"42"}`, (*requests)[0].payload)
+	})
+
+	t.Run("fallback to algo order", func(t *testing.T) {
+		server, requests := newOkxServer(t, map[string]string{
+			"POST /api/v5/trade/cancel-order": `{"code":"1","msg":"","data":[{"clOrdId":"42","sCode":"51400","sMsg":"Cancellation failed"}]}`,
+			"GET /api/v5/trade/order-algo":    `{"code":"0","msg":"","data":[{"algoId":"777","algoClOrdId":"42","instId":"BTC-USDT","state":"live"}]}`,
+			"POST /api/v5/trade/cancel-algos": `{"code":"0","msg":"","data":[{"algoId":"777","sCode":"0","sMsg":""}]}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewOkxAdapter(server.URL, false).CancelOrder(okxPubKey, okxSecKey, okxPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Okx})
+
+		assert.NoError(t, err)
+		require.Len(t, *requests, 3)
+		assert.Equal(t, "algoClOrdId=42", (*requests)[1].query)
+		assert.JSONEq(t, `[{"instId":"BTC-USDT","algoId":"777"}]`, (*requests)[2].payload)
+	})
+
+	t.Run("order not found", func(t *testing.T) {
+		server, _ := newOkxServer(t, map[string]string{
+			"POST /api/v5/trade/cancel-order": `{"code":"1","msg":"","data":[{"clOrdId":"42","sCode":"51400","sMsg":"Cancellation failed"}]}`,
+		})
+		defer server.Close()
+
+		err := exchanger.NewOkxAdapter(server.URL, false).CancelOrder(okxPubKey, okxSecKey, okxPassPhrase,
+			&dto.CancelOrder{Id: 42, Symbol: "BTCUSDT", Exchange: consts.Okx})
+
+		assert.EqualError(t, err, "okx error 51400: Cancellation failed")
+	})
+}
+
+func TestOkxAdapter_UpdateOrder(t *testing.T) {
+	tests := []struct {
+		name            string
+		order           *dto.UpdateOrder
+		expectedPath    string
+		expectedPayload string
+	}{
+		{
+			name:            "limit",
+			order:           &dto.UpdateOrder{OrderId: 42, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit, Quantity: "0.2", Price: "31000"},
+			expectedPath:    "/api/v5/trade/amend-order",
+			expectedPayload: `{"instId":"BTC-USDT","clOrdId":"42","newSz":"0.2","newPx":"31000"}`,
+		},
+		{
+			name:            "stop loss limit",
+			order:           &dto.UpdateOrder{OrderId: 42, Symbol: "BTCUSDT", OrderType: consts.OrderTypeStopLossLimit, Price: "29000", StopPrice: "29100"},
+			expectedPath:    "/api/v5/trade/amend-algos",
+			expectedPayload: `{"instId":"BTC-USDT","algoClOrdId":"42","newSlTriggerPx":"29100","newSlOrdPx":"29000"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := newOkxServer(t, map[string]string{
+				"POST /api/v5/trade/amend-order": `{"code":"0","msg":"","data":[{"clOrdId":"42","sCode":"0","sMsg":""}]}`,
+				"POST /api/v5/trade/amend-algos": `{"code":"0","msg":"","data":[{"algoClOrdId":"42","sCode":"0","sMsg":""}]}`,
+			})
+			defer server.Close()
+
+			id, err := exchanger.NewOkxAdapter(server.URL, false).UpdateOrder(okxPubKey, okxSecKey, okxPassPhrase, tt.order)
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), id)
+			require.Len(t, *requests, 1)
+			assert.Equal(t, tt.expectedPath, (*requests)[0].path)
+			assert.JSONEq(t, tt.expectedPayload, (*requests)[0].payload)
+		})
+	}
+}
+
+func TestOkxAdapter_GetOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		routes         map[string]string
+		expectedStatus string
+		expectedType   string
+		expectedPrice  string
+	}{
+		{
+			name: "active",
+			routes: map[string]string{
+				"GET /api/v5/trade/order": `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","ordId":"1","clOrdId":"42","px":"30000","sz":"0.1","ordType":"limit","side":"buy","state":"live","accFillSz":"0"}]}`,
+			},
+			expectedStatus: consts.OrderStatusActive,
+			expectedType:   consts.OrderTypeLimit,
+			expectedPrice:  "30000",
+		},
+		{
+			name: "partially filled",
+			routes: map[string]string{
+				"GET /api/v5/trade/order": `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","ordId":"1","clOrdId":"42","px":"30000","sz":"0.1","ordType":"limit","side":"buy","state":"partially_filled","accFillSz":"0.05"}]}`,
+			},
+			expectedStatus: consts.OrderStatusPartFilled,
+			expectedType:   consts.OrderTypeLimit,
+			expectedPrice:  "30000",
+		},
+		{
+			name: "filled market",
+			routes: map[string]string{
+				"GET /api/v5/trade/order": `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","ordId":"1","clOrdId":"42","px":"","sz":"0.1","ordType":"market","side":"buy","state":"filled","accFillSz":"0.1"}]}`,
+			},
+			expectedStatus: consts.OrderStatusFilled,
+			expectedType:   consts.OrderTypeMarket,
+		},
+		{
+			name: "canceled",
+			routes: map[string]string{
+				"GET /api/v5/trade/order": `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","ordId":"1","clOrdId":"42","px":"30000","sz":"0.1","ordType":"limit","side":"buy","state":"canceled","accFillSz":"0"}]}`,
+			},
+			expectedStatus: consts.OrderStatusCanceled,
+			expectedType:   consts.OrderTypeLimit,
+			expectedPrice:  "30000",
+		},
+		{
+			name: "algo order",
+			routes: map[string]string{
+				"GET /api/v5/trade/order-algo": `{"code":"0","msg":"","data":[{"instId":"BTC-USDT","algoId":"777","algoClOrdId":"42","sz":"0.1","ordType":"conditional","side":"sell","state":"effective","slTriggerPx":"29500","slOrdPx":"29000"}]}`,
+			},
+			expectedStatus: consts.OrderStatusFilled,
+			expectedType:   consts.OrderTypeStopLossLimit,
+			expectedPrice:  "29000",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, _ := newOkxServer(t, tt.routes)
+			defer server.Close()
+
+			order, err := exchanger.NewOkxAdapter(server.URL, false).
+				GetOrder(okxPubKey, okxSecKey, okxPassPhrase, "BTCUSDT", 42)
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(42), order.Id)
+			assert.Equal(t, int64(42), order.ExecOrderId)
+			assert.Equal(t, "BTCUSDT", order.Symbol)
+			assert.Equal(t, consts.Okx, order.Exchange)
+			assert.Equal(t, tt.expectedStatus, order.Status)
+			assert.Equal(t, tt.expectedType, order.OrderType)
+			assert.Equal(t, tt.expectedPrice, order.Price)
+		})
+	}
+}
+
+func TestOkxAdapter_GetOpenOrders(t *testing.T) {
+	server, requests := newOkxServer(t, map[string]string{
+		"GET /api/v5/trade/orders-pending": `{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT","ordId":"1","clOrdId":"42","px":"30000","sz":"0.1","ordType":"limit","side":"buy","state":"live"}]}`,
+		"GET /api/v5/trade/orders-algo-pending": `{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT","algoId":"777","algoClOrdId":"43","sz":"0.1","ordType":"conditional","side":"sell","state":"live","slTriggerPx":"29500","slOrdPx":"29000"}]}`,
+	})
+	defer server.Close()
+
+	orders, err := exchanger.NewOkxAdapter(server.URL, false).
+		GetOpenOrders(okxPubKey, okxSecKey, okxPassPhrase, "BTCUSDT")
+
+	require.NoError(t, err)
+	require.Len(t, orders, 2)
+	assert.Equal(t, int64(42), orders[0].ExecOrderId)
+	assert.Equal(t, consts.OrderTypeLimit, orders[0].OrderType)
+	assert.Equal(t, int64(43), orders[1].ExecOrderId)
+	assert.Equal(t, consts.OrderTypeStopLossLimit, orders[1].OrderType)
+	assert.Equal(t, "instId=BTC-USDT&instType=SPOT", (*requests)[0].query)
+	assert.Equal(t, "instId=BTC-USDT&instType=SPOT&ordType=conditional", (*requests)[1].query)
+}
+
+func TestOkxAdapter_GetSymbols(t *testing.T) {
+	server, _ := newOkxServer(t, map[string]string{
+		"GET /api/v5/public/instruments": `{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","state":"live"},
+			{"instId":"OLD-USDT","baseCcy":"OLD","quoteCcy":"USDT","state":"suspend"}]}`,
+	})
+	defer server.Close()
+
+	symbols, err := exchanger.NewOkxAdapter(server.URL, false).GetSymbols("", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT"}, symbols)
+}
+
+func TestOkxAdapter_GetSymbolFilters(t *testing.T) {
+	server, _ := newOkxServer(t, map[string]string{
+		"GET /api/v5/public/instruments": `{"code":"0","msg":"","data":[
+			{"instId":"BTC-USDT","baseCcy":"BTC","quoteCcy":"USDT","state":"live","tickSz":"0.1","lotSz":"0.00000001","minSz":"0.00001","maxLmtSz":"9999999999"}]}`,
+	})
+	defer server.Close()
+
+	filters, err := exchanger.NewOkxAdapter(server.URL, false).GetSymbolFilters("", "", "")
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.1", StepSize: "0.00000001",
+		MinQty: "0.00001", MaxQty: "9999999999"}}, filters)
+}
+
+func TestOkxAdapter_GetPrice(t *testing.T) {
+	server, requests := newOkxServer(t, map[string]string{
+		"GET /api/v5/market/ticker": `{"code":"0","msg":"","data":[{"instId":"ETH-USDT","last":"1850.12"}]}`,
+	})
+	defer server.Close()
+
+	price, err := exchanger.NewOkxAdapter(server.URL, false).GetPrice("", "", "", "ETHUSDT")
+
+	require.NoError(t, err)
+	assert.Equal(t, "1850.12", price)
+	assert.Equal(t, "instId=ETH-USDT", (*requests)[0].query)
+
+	_, err = exchanger.NewOkxAdapter(server.URL, false).GetPrice("", "", "", "ETHXYZ")
+	assert.Error(t, err)
+}
+
+func TestOkxTradeStream_ReadTrade(t *testing.T) {
+	upgrader := websocket.Upgrader{}
+	subscribed := make(chan string, 2)
+
+	wsServer := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+		defer conn.Close()
+
+		_, message, err := conn.ReadMessage()
+		require.NoError(t, err)
+		subscribed <- string(message)
+
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"event":"subscribe","arg":{"channel":"trades","instId":"BTC-USDT"},"connId":"a4d3ae55"}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte("pong")))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"arg":{"channel":"trades","instId":"ETH-USDT"},"data":[{"instId":"ETH-USDT","tradeId":"2","px":"2000","sz":"1","side":"sell","ts":"1630048897897"}]}`)))
+		require.NoError(t, conn.WriteMessage(websocket.TextMessage,
+			[]byte(`{"arg":{"channel":"trades","instId":"BTC-USDT"},"data":[{"instId":"BTC-USDT","tradeId":"1","px":"30123.5","sz":"0.01","side":"buy","ts":"1630048897897"}]}`)))
+
+		_, message, err = conn.ReadMessage()
+		require.NoError(t, err)
+		subscribed <- string(message)
+
+		_, _, _ = conn.ReadMessage()
+	}))
+	defer wsServer.Close()
+
+	stream, err := exchanger.NewOkxTradeStream("ws"+strings.TrimPrefix(wsServer.URL, "http"), "BTCUSDT", "ETHUSDT")
+	require.NoError(t, err)
+	defer stream.Close()
+
+	select {
+	case message := <-subscribed:
+		assert.JSONEq(t, `{"op":"subscribe","args":[{"channel":"trades","instId":"BTC-USDT"},{"channel":"trades","instId":"ETH-USDT"}]}`, message)
+	case <-time.After(time.Second):
+		t.Fatal("subscription was not sent")
+	}
+
+	symbol, price, err := stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "ETHUSDT", symbol)
+	assert.Equal(t, "2000", price)
+
+	symbol, price, err = stream.ReadTrade()
+	require.NoError(t, err)
+	assert.Equal(t, "BTCUSDT", symbol)
+	assert.Equal(t, "30123.5", price)
+
+	require.NoError(t, stream.Unsubscribe("ETHUSDT"))
+	select {
+	case message := <-subscribed:
+		assert.JSONEq(t, `{"op":"unsubscribe","args":[{"channel":"trades","instId":"ETH-USDT"}]}`, message)
+	case <-time.After(time.Second):
+		t.Fatal("unsubscription was not sent")
+	}
+}
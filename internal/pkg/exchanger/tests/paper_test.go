@@ -0,0 +1,229 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var paperKeys = domain.NewApiKeys(7, consts.Paper, "", "", "")
+
+func TestPaperExchanger_CreateOrder(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    *dto.Order
+		prepare  func(store *mocks.MockPaperStore, prices *mocks.MockPriceSource)
+		expected *domain.PaperOrder
+		err      error
+	}{
+		{
+			name: "market buy filled at cached price",
+			order: &dto.Order{Exchange: consts.Paper, Symbol: "BTCUSDT", Side: consts.OrderSideBuy,
+				OrderType: consts.OrderTypeMarket, Quantity: "0.1"},
+			prepare: func(store *mocks.MockPaperStore, prices *mocks.MockPriceSource) {
+				prices.EXPECT().GetPrice(gomock.Any(), consts.Paper, "BTCUSDT").Return("30000.5", nil)
+			},
+			expected: &domain.PaperOrder{UserId: 7, Symbol: "BTCUSDT", Side: consts.OrderSideBuy,
+				OrderType: consts.OrderTypeMarket, Status: consts.OrderStatusFilled, Quantity: "0.1", Price: "30000.5",
+				ReserveAsset: "USDT", ReserveAmount: "3000.05", ReceiveAsset: "BTC", ReceiveAmount: "0.1"},
+		},
+		{
+			name: "limit sell reserves base asset",
+			order: &dto.Order{Exchange: consts.Paper, Symbol: "ETHBTC", Side: consts.OrderSideSell,
+				OrderType: consts.OrderTypeLimit, Quantity: "2", Price: "0.055", TimeInForce: consts.TimeInForceGTC},
+			prepare: func(store *mocks.MockPaperStore, prices *mocks.MockPriceSource) {},
+			expected: &domain.PaperOrder{UserId: 7, Symbol: "ETHBTC", Side: consts.OrderSideSell,
+				OrderType: consts.OrderTypeLimit, Status: consts.OrderStatusActive, Quantity: "2", Price: "0.055",
+				TimeInForce: consts.TimeInForceGTC, ReserveAsset: "ETH", ReserveAmount: "2",
+				ReceiveAsset: "BTC", ReceiveAmount: "0.11"},
+		},
+		{
+			name: "market without cached price",
+			order: &dto.Order{Exchange: consts.Paper, Symbol: "BTCUSDT", Side: consts.OrderSideBuy,
+				OrderType: consts.OrderTypeMarket, Quantity: "0.1"},
+			prepare: func(store *mocks.MockPaperStore, prices *mocks.MockPriceSource) {
+				prices.EXPECT().GetPrice(gomock.Any(), consts.Paper, "BTCUSDT").Return("", errors.New("redis: nil"))
+			},
+			err: errors.New("err paper trade price not found"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			store := mocks.NewMockPaperStore(ctrl)
+			prices := mocks.NewMockPriceSource(ctrl)
+			store.EXPECT().InitBalance(gomock.Any(), int64(7), "USDT", "500").Return(nil)
+			tt.prepare(store, prices)
+			if tt.expected != nil {
+				store.EXPECT().PlaceOrder(gomock.Any(), tt.expected).Return(int64(11), nil)
+			}
+
+			id, err := exchanger.NewPaperExchanger(nil, store, prices, "500").CreateOrder(paperKeys, tt.order)
+			if tt.err != nil {
+				assert.EqualError(t, err, tt.err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(11), id)
+		})
+	}
+}
+
+func TestPaperExchanger_GetOrder(t *testing.T) {
+	tests := []struct {
+		name           string
+		order          *domain.PaperOrder
+		tradePrice     string
+		expectedFill   bool
+		expectedStatus string
+	}{
+		{
+			name:           "limit buy crossed",
+			order:          &domain.PaperOrder{Id: 3, Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit, Price: "100"},
+			tradePrice:     "99.9",
+			expectedFill:   true,
+			expectedStatus: consts.OrderStatusFilled,
+		},
+		{
+			name:           "limit buy not crossed",
+			order:          &domain.PaperOrder{Id: 3, Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit, Price: "100"},
+			tradePrice:     "100.1",
+			expectedStatus: consts.OrderStatusActive,
+		},
+		{
+			name:           "limit sell crossed",
+			order:          &domain.PaperOrder{Id: 3, Side: consts.OrderSideSell, OrderType: consts.OrderTypeLimit, Price: "100"},
+			tradePrice:     "100",
+			expectedFill:   true,
+			expectedStatus: consts.OrderStatusFilled,
+		},
+		{
+			name: "stop loss sell triggered",
+			order: &domain.PaperOrder{Id: 3, Side: consts.OrderSideSell, OrderType: consts.OrderTypeStopLossLimit,
+				Price: "94", StopPrice: "95"},
+			tradePrice:     "94.5",
+			expectedFill:   true,
+			expectedStatus: consts.OrderStatusFilled,
+		},
+		{
+			name: "stop loss sell not triggered",
+			order: &domain.PaperOrder{Id: 3, Side: consts.OrderSideSell, OrderType: consts.OrderTypeStopLossLimit,
+				Price: "94", StopPrice: "95"},
+			tradePrice:     "96",
+			expectedStatus: consts.OrderStatusActive,
+		},
+		{
+			name: "stop loss buy triggered",
+			order: &domain.PaperOrder{Id: 3, Side: consts.OrderSideBuy, OrderType: consts.OrderTypeStopLossLimit,
+				Price: "106", StopPrice: "105"},
+			tradePrice:     "105",
+			expectedFill:   true,
+			expectedStatus: consts.OrderStatusFilled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			tt.order.UserId = 7
+			tt.order.Symbol = "BTCUSDT"
+			tt.order.Status = consts.OrderStatusActive
+
+			store := mocks.NewMockPaperStore(ctrl)
+			prices := mocks.NewMockPriceSource(ctrl)
+			store.EXPECT().GetOrder(gomock.Any(), int64(7), int64(3)).Return(tt.order, nil)
+			prices.EXPECT().GetPrice(gomock.Any(), consts.Paper, "BTCUSDT").Return(tt.tradePrice, nil)
+			if tt.expectedFill {
+				store.EXPECT().FillOrder(gomock.Any(), int64(3)).Return(nil)
+			}
+
+			order, err := exchanger.NewPaperExchanger(nil, store, prices, "").
+				GetOrder(paperKeys, consts.Paper, "BTCUSDT", 3)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expectedStatus, order.Status)
+			assert.Equal(t, int64(3), order.ExecOrderId)
+			assert.Equal(t, consts.Paper, order.Exchange)
+		})
+	}
+}
+
+func TestPaperExchanger_GetOpenOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockPaperStore(ctrl)
+	prices := mocks.NewMockPriceSource(ctrl)
+	store.EXPECT().GetOpenOrders(gomock.Any(), int64(7), "BTCUSDT").Return([]*domain.PaperOrder{
+		{Id: 1, UserId: 7, Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+			Status: consts.OrderStatusActive, Price: "100"},
+		{Id: 2, UserId: 7, Symbol: "BTCUSDT", Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit,
+			Status: consts.OrderStatusActive, Price: "90"},
+	}, nil)
+	prices.EXPECT().GetPrice(gomock.Any(), consts.Paper, "BTCUSDT").Return("95", nil).Times(2)
+	store.EXPECT().FillOrder(gomock.Any(), int64(1)).Return(nil)
+
+	orders, err := exchanger.NewPaperExchanger(nil, store, prices, "").
+		GetOpenOrders(paperKeys, consts.Paper, "btcusdt")
+
+	require.NoError(t, err)
+	require.Len(t, orders, 1)
+	assert.Equal(t, int64(2), orders[0].ExecOrderId)
+}
+
+func TestPaperExchanger_DelegatesOtherExchanges(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockPaperStore(ctrl)
+	prices := mocks.NewMockPriceSource(ctrl)
+	paper := exchanger.NewPaperExchanger(exchanger.NewExchanger(false), store, prices, "")
+
+	_, err := paper.Balance(paperKeys, "unknown")
+
+	assert.ErrorIs(t, err, exchanger.ErrUnknownExchange)
+}
+
+func TestPaperExchanger_Balance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	store := mocks.NewMockPaperStore(ctrl)
+	store.EXPECT().InitBalance(context.Background(), int64(7), "USDT", exchanger.PaperInitialBalance).Return(nil)
+	store.EXPECT().GetBalances(context.Background(), int64(7)).
+		Return(domain.Balance{domain.NewBalanceSymbol("USDT", "10000", "")}, nil)
+
+	balance, err := exchanger.NewPaperExchanger(nil, store, nil, "").Balance(paperKeys, consts.Paper)
+
+	require.NoError(t, err)
+	assert.Equal(t, domain.Balance{domain.NewBalanceSymbol("USDT", "10000", "")}, balance)
+}
+
+func TestPaperExchanger_GetPrice(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	prices := mocks.NewMockPriceSource(ctrl)
+	prices.EXPECT().GetPrice(gomock.Any(), consts.Paper, "BTCUSDT").Return("30000", nil)
+
+	price, err := exchanger.NewPaperExchanger(nil, nil, prices, "").GetPrice(paperKeys, consts.Paper, "btcusdt")
+
+	require.NoError(t, err)
+	assert.Equal(t, "30000", price)
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger/tests/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSymbolFilterCache_GetFilters(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchanger := mocks.NewMockExchanger(ctrl)
+	mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Binance).
+		Return([]domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.01"}, {Symbol: "ETHBTC", TickSize: "0.00001"}}, nil)
+	cache := exchanger.NewSymbolFilterCache(mockExchanger, time.Hour)
+
+	filters, err := cache.GetFilters(consts.Binance, "btcusdt")
+	require.NoError(t, err)
+	assert.Equal(t, "0.01", filters.TickSize)
+
+	filters, err = cache.GetFilters(consts.Binance, "ETH-BTC")
+	require.NoError(t, err)
+	assert.Equal(t, "0.00001", filters.TickSize)
+
+	filters, err = cache.GetFilters(consts.Binance, "XYZUSDT")
+	require.NoError(t, err)
+	assert.Nil(t, filters)
+}
+
+func TestSymbolFilterCache_Reload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchanger := mocks.NewMockExchanger(ctrl)
+	gomock.InOrder(
+		mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Okx).Return(nil, errors.New("timeout")),
+		mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Okx).
+			Return([]domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.1"}}, nil),
+		mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Okx).Return(nil, errors.New("timeout")),
+	)
+	cache := exchanger.NewSymbolFilterCache(mockExchanger, time.Millisecond)
+
+	_, err := cache.GetFilters(consts.Okx, "BTCUSDT")
+	assert.Error(t, err)
+
+	time.Sleep(5 * time.Millisecond)
+	filters, err := cache.GetFilters(consts.Okx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", filters.TickSize)
+
+	time.Sleep(5 * time.Millisecond)
+	filters, err = cache.GetFilters(consts.Okx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", filters.TickSize)
+
+	filters, err = cache.GetFilters(consts.Okx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", filters.TickSize)
+}
+
+func TestSymbolFilterCache_FirstLoadFailure(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockExchanger := mocks.NewMockExchanger(ctrl)
+	mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Kucoin).Return(nil, errors.New("timeout")).Times(1)
+	cache := exchanger.NewSymbolFilterCache(mockExchanger, time.Hour)
+
+	for i := 0; i < 3; i++ {
+		filters, err := cache.GetFilters(consts.Kucoin, "BTC-USDT")
+		assert.EqualError(t, err, "timeout")
+		assert.Nil(t, filters)
+	}
+}
+
+func TestSymbolFilterCache_FetchOutsideLock(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	mockExchanger := mocks.NewMockExchanger(ctrl)
+	mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Binance).
+		DoAndReturn(func(_ *domain.ApiKeys, _ string) ([]domain.SymbolFilters, error) {
+			close(started)
+			<-release
+
+			return []domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.01"}}, nil
+		})
+	mockExchanger.EXPECT().GetSymbolFilters(gomock.Any(), consts.Okx).
+		Return([]domain.SymbolFilters{{Symbol: "BTCUSDT", TickSize: "0.1"}}, nil)
+	cache := exchanger.NewSymbolFilterCache(mockExchanger, time.Hour)
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		filters, err := cache.GetFilters(consts.Binance, "BTCUSDT")
+		assert.NoError(t, err)
+		assert.Equal(t, "0.01", filters.TickSize)
+	}()
+	<-started
+
+	filters, err := cache.GetFilters(consts.Okx, "BTCUSDT")
+	require.NoError(t, err)
+	assert.Equal(t, "0.1", filters.TickSize)
+
+	close(release)
+	<-done
+}
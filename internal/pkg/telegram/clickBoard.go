@@ -2,9 +2,14 @@ package telegram
 
 import (
 	"encoding/json"
+	"fmt"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"strconv"
 )
 
+const allExchangesButton = "all"
+
 type ClickBoard struct{}
 
 type ReplyKeyboardMarkup struct {
@@ -41,6 +46,10 @@ func (c ClickBoard) MakeActiveOrdersExchangeKeyboard() string {
 					Text:         consts.Okx,
 					CallbackData: activeOrdersExchangeOkxCmd,
 				},
+				InlineKeyboardButton{
+					Text:         consts.Paper,
+					CallbackData: activeOrdersExchangePaperCmd,
+				},
 			},
 		},
 	}
@@ -66,6 +75,14 @@ func (c ClickBoard) MakeBalanceExchangeKeyboard() string {
 					Text:         consts.Okx,
 					CallbackData: balanceExchangeOkxCmd,
 				},
+				InlineKeyboardButton{
+					Text:         consts.Paper,
+					CallbackData: balanceExchangePaperCmd,
+				},
+				InlineKeyboardButton{
+					Text:         allExchangesButton,
+					CallbackData: balanceExchangeAllCmd,
+				},
 			},
 		},
 	}
@@ -74,3 +91,22 @@ func (c ClickBoard) MakeBalanceExchangeKeyboard() string {
 
 	return string(result)
 }
+
+func (c ClickBoard) MakeAlertsKeyboard(alerts []*domain.PriceAlert) string {
+	rows := make([][]InlineKeyboardButton, 0, len(alerts))
+	for _, v := range alerts {
+		rows = append(rows, []InlineKeyboardButton{
+			{
+				Text:         fmt.Sprintf("❌ #%d %s", v.Id, v.Symbol),
+				CallbackData: alertDeleteCmd + strconv.FormatInt(v.Id, 10),
+			},
+		})
+	}
+	keyboard := InlineKeyboardMarkup{
+		InlineKeyboard: rows,
+	}
+
+	result, _ := json.Marshal(keyboard)
+
+	return string(result)
+}
@@ -31,13 +31,19 @@ func New(tg *telegram.Client,
 	userSrv UserSrv,
 	orderSrv OrderSrv,
 	accountSrv AccountSrv,
+	dealSrv DealSrv,
+	pnlSrv PnlSrv,
+	strategySrv StrategySrv,
+	alertSrv AlertSrv,
 	i18n *i18n.I18n,
 	admins []int,
+	jwtSecret string,
+	tokenTtl time.Duration,
 	logger *log.Logger,
 	batchSize int) Consumer {
 	return Consumer{
 		fetcher:   NewFetcher(tg),
-		processor: NewProcessor(tg, userSrv, orderSrv, accountSrv, i18n, logger, admins, 0),
+		processor: NewProcessor(tg, userSrv, orderSrv, accountSrv, dealSrv, pnlSrv, strategySrv, alertSrv, i18n, logger, admins, jwtSecret, tokenTtl, 0),
 		batchSize: batchSize,
 		logger:    logger,
 	}
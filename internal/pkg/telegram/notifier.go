@@ -0,0 +1,208 @@
+package telegram
+
+import (
+	"context"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	telegramCli "github.com/linnoxlewis/trade-bot/pkg/telegram"
+)
+
+const (
+	msgSuccessExecuted   = "successExecuted"
+	msgExecuteFailed     = "executeFailed"
+	msgTrailingStopMoved = "trailingStopMoved"
+	msgLimitOrderFilled  = "limitOrderFilled"
+	msgOcoFailed         = "ocoFailed"
+	msgLimitOrderPart    = "limitOrderPartFilled"
+	msgSlBreakeven       = "slMovedToBreakeven"
+	msgTradingPaused     = "tradingPaused"
+	msgUserTradingPaused = "userTradingPaused"
+	msgAlertTriggered    = "alertTriggered"
+	msgAlertMoved        = "alertMoveTriggered"
+	msgPriceStale        = "priceStale"
+	msgPriceMissing      = "priceMissing"
+	msgPriceRecovered    = "priceRecovered"
+)
+
+type Notifier struct {
+	tg     *telegramCli.Client
+	i18n   *i18n.I18n
+	logger *log.Logger
+}
+
+func NewNotifier(tg *telegramCli.Client, i18n *i18n.I18n, logger *log.Logger) *Notifier {
+	return &Notifier{
+		tg:     tg,
+		i18n:   i18n,
+		logger: logger,
+	}
+}
+
+func (n *Notifier) Listen(ctx context.Context, events <-chan domain.OrderEvent) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			n.notify(ctx, event)
+		}
+	}
+}
+
+func (n *Notifier) notify(ctx context.Context, event domain.OrderEvent) {
+	text := n.message(event)
+	if text == "" {
+		return
+	}
+
+	if err := n.tg.SendMessage(ctx, int(event.UserId), text, ""); err != nil {
+		n.logger.ErrorLog.Println("cant`t send tg message: ", err)
+	}
+}
+
+// NotifyTradingPaused tells the user and the admins that the daily loss limit stopped new orders of the user.
+func (n *Notifier) NotifyTradingPaused(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string) {
+	data := map[string]interface{}{
+		"UserId":       limits.UserId,
+		"DayPnl":       dayPnl,
+		"MaxDailyLoss": limits.MaxDailyLoss,
+	}
+	if limits.PausedUntil != nil {
+		data["PausedUntil"] = limits.PausedUntil.UTC().Format(time.DateTime)
+	}
+
+	if err := n.tg.SendMessage(ctx, int(limits.UserId), n.i18n.T(msgTradingPaused, data, "ru"), ""); err != nil {
+		n.logger.ErrorLog.Println("cant`t send tg message: ", err)
+	}
+	for _, v := range admins {
+		if err := n.tg.SendMessage(ctx, v, n.i18n.T(msgUserTradingPaused, data, "ru"), ""); err != nil {
+			n.logger.ErrorLog.Println("cant`t send tg message: ", err)
+		}
+	}
+}
+
+// NotifyStalePrice tells the admins that tp/sl of the symbol wait for a fresh price.
+func (n *Notifier) NotifyStalePrice(ctx context.Context, admins []int, exchange, symbol string, updatedAt time.Time) {
+	if updatedAt.IsZero() {
+		n.notifyAdmins(ctx, admins, n.i18n.T(msgPriceMissing, map[string]interface{}{
+			"Exchange": exchange,
+			"Symbol":   symbol,
+		}, "ru"))
+
+		return
+	}
+	n.notifyAdmins(ctx, admins, n.i18n.T(msgPriceStale, map[string]interface{}{
+		"Exchange":  exchange,
+		"Symbol":    symbol,
+		"UpdatedAt": updatedAt.UTC().Format(time.DateTime),
+	}, "ru"))
+}
+
+func (n *Notifier) NotifyPriceRecovered(ctx context.Context, admins []int, exchange, symbol string) {
+	n.notifyAdmins(ctx, admins, n.i18n.T(msgPriceRecovered, map[string]interface{}{
+		"Exchange": exchange,
+		"Symbol":   symbol,
+	}, "ru"))
+}
+
+func (n *Notifier) notifyAdmins(ctx context.Context, admins []int, text string) {
+	for _, v := range admins {
+		if err := n.tg.SendMessage(ctx, v, text, ""); err != nil {
+			n.logger.ErrorLog.Println("cant`t send tg message: ", err)
+		}
+	}
+}
+
+func (n *Notifier) NotifyPriceAlert(ctx context.Context, alert *domain.PriceAlert, price, change string) {
+	key := msgAlertTriggered
+	if alert.Condition == consts.AlertConditionMove {
+		key = msgAlertMoved
+	}
+	text := n.i18n.T(key, map[string]interface{}{
+		"Id":        alert.Id,
+		"Exchange":  alert.Exchange,
+		"Symbol":    alert.Symbol,
+		"Condition": alert.Condition,
+		"Value":     alert.Value,
+		"Price":     price,
+		"Change":    change,
+	}, "ru")
+
+	if err := n.tg.SendMessage(ctx, int(alert.UserId), text, ""); err != nil {
+		n.logger.ErrorLog.Println("cant`t send tg message: ", err)
+	}
+}
+
+func (n *Notifier) message(event domain.OrderEvent) string {
+	switch event.Type {
+	case consts.OrderEventTpExecuted, consts.OrderEventSlExecuted:
+		return n.i18n.T(msgSuccessExecuted, map[string]interface{}{
+			"TpSl":        event.TpSl,
+			"Side":        event.Side,
+			"Price":       event.Price,
+			"ExecOrderId": event.ExecOrderId,
+			"Id":          event.OrderId,
+			"Exchange":    event.Exchange,
+			"Symbol":      event.Symbol,
+			"Quantity":    event.Quantity,
+		}, "ru")
+	case consts.OrderEventExecuteFailed:
+		return n.i18n.T(msgExecuteFailed, map[string]interface{}{
+			"TpSl":  event.TpSl,
+			"Side":  event.Side,
+			"Price": event.Price,
+		}, "ru") + ":" + event.Error
+	case consts.OrderEventTrailingMoved:
+		return n.i18n.T(msgTrailingStopMoved, map[string]interface{}{
+			"Id":       event.OrderId,
+			"Exchange": event.Exchange,
+			"Symbol":   event.Symbol,
+			"Price":    event.Price,
+			"TsPrice":  event.TsPrice,
+			"Ts":       event.Ts,
+		}, "ru")
+	case consts.OrderEventSlBreakeven:
+		return n.i18n.T(msgSlBreakeven, map[string]interface{}{
+			"Id":       event.OrderId,
+			"Exchange": event.Exchange,
+			"Symbol":   event.Symbol,
+			"Price":    event.Price,
+			"Quantity": event.Quantity,
+		}, "ru")
+	case consts.OrderEventFilled:
+		return n.i18n.T(msgLimitOrderFilled, map[string]interface{}{
+			"ExecOrderId": event.ExecOrderId,
+			"Id":          event.OrderId,
+			"Side":        event.Side,
+			"Exchange":    event.Exchange,
+			"Symbol":      event.Symbol,
+			"Price":       event.Price,
+			"Quantity":    event.Quantity,
+		}, "ru")
+	case consts.OrderEventPartFilled:
+		return n.i18n.T(msgLimitOrderPart, map[string]interface{}{
+			"ExecOrderId": event.ExecOrderId,
+			"Id":          event.OrderId,
+			"Exchange":    event.Exchange,
+			"Symbol":      event.Symbol,
+			"ExecutedQty": event.ExecutedQty,
+			"Quantity":    event.Quantity,
+			"AvgPrice":    event.AvgPrice,
+		}, "ru")
+	case consts.OrderEventOcoFailed:
+		return n.i18n.T(msgOcoFailed, map[string]interface{}{
+			"Id":       event.OrderId,
+			"Exchange": event.Exchange,
+			"Symbol":   event.Symbol,
+		}, "ru") + ":" + event.Error
+	default:
+		return ""
+	}
+}
@@ -11,10 +11,14 @@ import (
 	"github.com/linnoxlewis/trade-bot/internal/errors"
 	"github.com/linnoxlewis/trade-bot/internal/helper"
 	"github.com/linnoxlewis/trade-bot/internal/helper/conversion"
+	"github.com/linnoxlewis/trade-bot/pkg"
 	"github.com/linnoxlewis/trade-bot/pkg/i18n"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
 	telegramCli "github.com/linnoxlewis/trade-bot/pkg/telegram"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -22,15 +26,42 @@ const (
 	StartCmd   = "/start"
 	BalanceCmd = "/balance"
 	OrdersCmd  = "/orders"
+	TokenCmd   = "/token"
+	DealsCmd   = "/deals"
+	PnlCmd     = "/pnl"
+
+	StrategiesCmd   = "/strategies"
+	StrategyStopCmd = "/strategy_stop"
+	AlertCmd        = "/alert"
+	AlertsCmd       = "/alerts"
+
+	alertDeleteCmd = "alert_delete_"
+	alertRepeatArg = "repeat"
 
 	activeOrdersExchangeBinanceCmd = "active_orders_exchange_binance"
 	activeOrdersExchangeKucoinCmd  = "active_orders_exchange_kucoin"
 	activeOrdersExchangeOkxCmd     = "active_orders_exchange_okx"
+	activeOrdersExchangePaperCmd   = "active_orders_exchange_paper"
 	balanceExchangeBinanceCmd      = "balance_exchange_binance"
 	balanceExchangeKucoinCmd       = "balance_exchange_kucoin"
 	balanceExchangeOkxCmd          = "balance_exchange_okx"
+	balanceExchangePaperCmd        = "balance_exchange_paper"
+	balanceExchangeAllCmd          = "balance_exchange_all"
 )
 
+var alertConditions = map[string]string{
+	">":                          consts.AlertConditionAbove,
+	consts.AlertConditionAbove:   consts.AlertConditionAbove,
+	"<":                          consts.AlertConditionBelow,
+	consts.AlertConditionBelow:   consts.AlertConditionBelow,
+	"x":                          consts.AlertConditionCrosses,
+	consts.AlertConditionCrosses: consts.AlertConditionCrosses,
+	"%":                          consts.AlertConditionMove,
+	consts.AlertConditionMove:    consts.AlertConditionMove,
+}
+
+var alertExchanges = []string{consts.Binance, consts.Kucoin, consts.Okx, consts.Paper}
+
 var (
 	ErrUnknownEventType = errs.New("unknown event type")
 	ErrUnknownMetaType  = errs.New("unknown meta type")
@@ -44,47 +75,91 @@ type OrderSrv interface {
 	CreateOrder(ctx context.Context, order *dto.Order, tgUserId int64) (*domain.Order, error)
 	CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserId int64) error
 	GetActiveTpSlOrders(ctx context.Context, exchange string) ([]*domain.Order, error)
-	ExecuteTpSlOrder(ctx context.Context, userId int64, order *domain.Order) (int64, error)
-	UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl) error
+	ExecuteTpSlOrder(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error)
+	MoveTrailingStop(ctx context.Context, order *domain.Order, tradePrice string) (bool, error)
+	UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error
 	GetUserActiveOrders(ctx context.Context, userId int64, exchange string) ([]domain.Order, error)
 	GetOrder(ctx context.Context, orderId int64, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error)
 	SetFilledLimitOrder(ctx context.Context, order *domain.Order) error
+	SetPartFilledLimitOrder(ctx context.Context, order *domain.Order, executedQty, avgPrice string) error
+	SyncOcoOrder(ctx context.Context, order *domain.Order) error
+	HandleExecutionReport(ctx context.Context, report *domain.ExecutionReport) error
+	GetApiKeys(ctx context.Context, userId int64, exchange string) (*domain.ApiKeys, error)
 	GetLimitOrders(ctx context.Context, exchange string) ([]*domain.Order, error)
 }
 
 type AccountSrv interface {
 	GetBalance(ctx context.Context, userId int64, exchange string) (balance domain.Balance, err error)
+	GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error)
+}
+
+type DealSrv interface {
+	GetDeals(ctx context.Context, userId int64, exchange string, page, limit int) (*domain.DealPage, error)
+}
+
+type PnlSrv interface {
+	GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error)
+}
+
+type StrategySrv interface {
+	Start(ctx context.Context, userId int64, strategyDto *dto.Strategy) (*domain.Strategy, error)
+	Stop(ctx context.Context, userId, id int64) (*domain.Strategy, error)
+	GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error)
+}
+
+type AlertSrv interface {
+	CreateAlert(ctx context.Context, userId int64, alertDto *dto.PriceAlert) (*domain.PriceAlert, error)
+	GetAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error)
+	DeleteAlert(ctx context.Context, userId, id int64) error
 }
 
 type Processor struct {
-	tg         *telegramCli.Client
-	userSrv    UserSrv
-	orderSrv   OrderSrv
-	accountSrv AccountSrv
-	i18n       *i18n.I18n
-	logger     *log.Logger
-	clbrd      ClickBoard
-	admins     []int
-	offset     int
+	tg          *telegramCli.Client
+	userSrv     UserSrv
+	orderSrv    OrderSrv
+	accountSrv  AccountSrv
+	dealSrv     DealSrv
+	pnlSrv      PnlSrv
+	strategySrv StrategySrv
+	alertSrv    AlertSrv
+	i18n        *i18n.I18n
+	logger      *log.Logger
+	clbrd       ClickBoard
+	admins      []int
+	jwtSecret   string
+	tokenTtl    time.Duration
+	offset      int
 }
 
 func NewProcessor(tg *telegramCli.Client,
 	userSrv UserSrv,
 	orderSrv OrderSrv,
 	accountSrv AccountSrv,
+	dealSrv DealSrv,
+	pnlSrv PnlSrv,
+	strategySrv StrategySrv,
+	alertSrv AlertSrv,
 	i18n *i18n.I18n,
 	logger *log.Logger,
 	admins []int,
+	jwtSecret string,
+	tokenTtl time.Duration,
 	offset int) *Processor {
 	return &Processor{
 		tg,
 		userSrv,
 		orderSrv,
 		accountSrv,
+		dealSrv,
+		pnlSrv,
+		strategySrv,
+		alertSrv,
 		i18n,
 		logger,
 		ClickBoard{},
 		admins,
+		jwtSecret,
+		tokenTtl,
 		offset}
 }
 
@@ -147,6 +222,35 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, lang str
 	text = strings.TrimSpace(text)
 	p.logger.InfoLog.Printf("got new command '%s' from '%s", text, chatID)
 
+	args := strings.Fields(text)
+	if len(args) > 0 && args[0] == DealsCmd {
+		err = p.sendDeals(ctx, chatID, args[1:], lang)
+
+		return err
+	}
+	if len(args) > 0 && args[0] == PnlCmd {
+		err = p.sendPnl(ctx, chatID, strings.Join(args[1:], " "), lang)
+
+		return err
+	}
+
+	if len(args) > 0 && args[0] == StrategyStopCmd {
+		err = p.stopStrategy(ctx, chatID, args[1:], lang)
+
+		return err
+	}
+
+	if len(args) > 0 && args[0] == AlertCmd {
+		err = p.createAlert(ctx, chatID, args[1:], lang)
+
+		return err
+	}
+	if strings.HasPrefix(text, alertDeleteCmd) {
+		err = p.deleteAlert(ctx, chatID, strings.TrimPrefix(text, alertDeleteCmd), lang)
+
+		return err
+	}
+
 	switch text {
 	case HelpCmd:
 		err = p.sendHelp(ctx, chatID, lang)
@@ -160,6 +264,15 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, lang str
 	case OrdersCmd:
 		err = p.sendChooseActiveOrdersExchange(ctx, chatID, lang)
 		break
+	case TokenCmd:
+		err = p.sendToken(ctx, chatID, lang)
+		break
+	case StrategiesCmd:
+		err = p.sendStrategies(ctx, chatID, lang)
+		break
+	case AlertsCmd:
+		err = p.sendAlerts(ctx, chatID, lang)
+		break
 	case activeOrdersExchangeBinanceCmd:
 		err = p.sendActiveOrders(ctx, consts.Binance, chatID, lang)
 		break
@@ -169,6 +282,9 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, lang str
 	case activeOrdersExchangeOkxCmd:
 		err = p.sendActiveOrders(ctx, consts.Okx, chatID, lang)
 		break
+	case activeOrdersExchangePaperCmd:
+		err = p.sendActiveOrders(ctx, consts.Paper, chatID, lang)
+		break
 	case balanceExchangeBinanceCmd:
 		err = p.sendBalance(ctx, chatID, consts.Binance, lang)
 		break
@@ -178,6 +294,12 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, lang str
 	case balanceExchangeOkxCmd:
 		err = p.sendBalance(ctx, chatID, consts.Okx, lang)
 		break
+	case balanceExchangePaperCmd:
+		err = p.sendBalance(ctx, chatID, consts.Paper, lang)
+		break
+	case balanceExchangeAllCmd:
+		err = p.sendBalance(ctx, chatID, "", lang)
+		break
 
 	default:
 		var order *dto.TgOrder
@@ -186,6 +308,11 @@ func (p *Processor) doCmd(ctx context.Context, text string, chatID int, lang str
 
 			return nil
 		}
+		if order != nil && order.Command == consts.TgStrategyCommand {
+			err = p.startStrategy(ctx, chatID, text, lang)
+
+			break
+		}
 		err = p.doJsonCmd(ctx, chatID, order, lang)
 		break
 	}
@@ -238,7 +365,7 @@ func (p *Processor) doJsonCmd(ctx context.Context, chatID int, order *dto.TgOrde
 		if err := updateTpSL.Validate(); err != nil {
 			return errors.BadRequestError(err.Error())
 		}
-		if err := p.orderSrv.UpdateTpslOrder(ctx, updateTpSL); err != nil {
+		if err := p.orderSrv.UpdateTpslOrder(ctx, updateTpSL, int64(chatID)); err != nil {
 			return err
 		}
 		go p.tg.SendMessage(ctx, chatID, fmt.Sprintf(msgUpdateTpsSlOrder), "")
@@ -288,13 +415,29 @@ func (p *Processor) sendActiveOrders(ctx context.Context, exchange string, chatI
 }
 
 func (p *Processor) sendBalance(ctx context.Context, chatID int, exchange, lang string) error {
-	balance, err := p.accountSrv.GetBalance(ctx, int64(chatID), exchange)
+	portfolio, err := p.accountSrv.GetPortfolio(ctx, int64(chatID), exchange)
 	if err != nil {
 		return err
 	}
-	msg := p.i18n.T("yourBalance", nil, lang) + "\n"
-	for _, v := range balance {
-		msg += fmt.Sprintf("%s: %s \n", v.Symbol, v.Quantity)
+	msg := p.i18n.T("yourPortfolio", map[string]interface{}{
+		"Total":     portfolio.Total,
+		"Currency":  portfolio.Currency,
+		"Exchanges": strings.Join(portfolio.Exchanges, ", "),
+	}, lang)
+	for _, v := range portfolio.Assets {
+		key := "portfolioAsset"
+		if v.Value == "" {
+			key = "portfolioAssetNoPrice"
+		}
+		msg += p.i18n.T(key, map[string]interface{}{
+			"Exchange": v.Exchange,
+			"Asset":    v.Asset,
+			"Total":    v.Total,
+			"Locked":   v.Locked,
+			"Value":    v.Value,
+			"Currency": portfolio.Currency,
+			"Share":    v.Share,
+		}, lang)
 	}
 	go func() {
 		if err := p.tg.SendMessage(ctx, chatID, msg, ""); err != nil {
@@ -305,6 +448,261 @@ func (p *Processor) sendBalance(ctx context.Context, chatID int, exchange, lang
 	return nil
 }
 
+func (p *Processor) sendDeals(ctx context.Context, chatID int, args []string, lang string) error {
+	page := 1
+	if len(args) > 0 {
+		if val, err := strconv.Atoi(args[0]); err == nil && val > 0 {
+			page = val
+		}
+	}
+	deals, err := p.dealSrv.GetDeals(ctx, int64(chatID), "", page, 0)
+	if err != nil {
+		return err
+	}
+
+	msg := p.i18n.T("noDeals", nil, lang)
+	if len(deals.Deals) > 0 {
+		msg = p.i18n.T("yourDeals", nil, lang) + "\n"
+		for _, v := range deals.Deals {
+			msg += p.i18n.T("dealInfo", map[string]interface{}{
+				"Id":         v.BaseOrder.Id,
+				"Status":     v.Status,
+				"Exchange":   v.BaseOrder.Exchange,
+				"Symbol":     v.BaseOrder.Symbol,
+				"Side":       v.BaseOrder.Side,
+				"EntryQty":   v.EntryQty,
+				"EntryPrice": v.EntryPrice,
+				"ClosedQty":  v.ClosedQty,
+				"ExitPrice":  v.ExitPrice,
+				"Pnl":        v.Pnl,
+				"Fee":        v.Fee,
+				"NetPnl":     v.NetPnl,
+				"Duration":   time.Duration(v.DurationSec) * time.Second,
+			}, lang)
+			msg += "---------------------------\n"
+		}
+		msg += p.i18n.T("dealsPage", map[string]interface{}{
+			"Page":  deals.Page,
+			"Pages": deals.Pages,
+			"Total": deals.Total,
+		}, lang)
+		if deals.Page < deals.Pages {
+			msg += "\n" + p.i18n.T("dealsNextPage", map[string]interface{}{
+				"Next": deals.Page + 1,
+			}, lang)
+		}
+	}
+	go func() {
+		if err := p.tg.SendMessage(ctx, chatID, msg, ""); err != nil {
+			p.logger.ErrorLog.Println(err)
+		}
+	}()
+
+	return nil
+}
+
+func (p *Processor) sendPnl(ctx context.Context, chatID int, period string, lang string) error {
+	report, err := p.pnlSrv.GetPnl(ctx, int64(chatID), period)
+	if err != nil {
+		return err
+	}
+
+	msg := p.i18n.T("yourPnl", map[string]interface{}{
+		"Period":        report.Period,
+		"From":          report.From.Format(time.DateOnly),
+		"Currency":      report.Currency,
+		"RealizedPnl":   report.RealizedPnl,
+		"UnrealizedPnl": report.UnrealizedPnl,
+		"Fees":          report.Fees,
+		"TotalPnl":      report.TotalPnl,
+		"ClosedDeals":   report.ClosedDeals,
+		"OpenDeals":     report.OpenDeals,
+		"WinRate":       report.WinRate,
+		"AvgR":          report.AvgR,
+	}, lang)
+	for _, v := range report.Symbols {
+		msg += "\n" + p.i18n.T("pnlSymbol", map[string]interface{}{
+			"Exchange":      v.Exchange,
+			"Symbol":        v.Symbol,
+			"Currency":      v.Currency,
+			"RealizedPnl":   v.RealizedPnl,
+			"UnrealizedPnl": v.UnrealizedPnl,
+			"Fees":          v.Fees,
+			"WinRate":       v.WinRate,
+			"AvgR":          v.AvgR,
+		}, lang)
+	}
+	go func() {
+		if err := p.tg.SendMessage(ctx, chatID, msg, ""); err != nil {
+			p.logger.ErrorLog.Println(err)
+		}
+	}()
+
+	return nil
+}
+
+func (p *Processor) startStrategy(ctx context.Context, chatID int, text string, lang string) error {
+	strategyDto := new(dto.Strategy)
+	if err := json.Unmarshal([]byte(text), strategyDto); err != nil {
+		return errors.BadRequestError(err.Error())
+	}
+	if err := strategyDto.Validate(); err != nil {
+		return errors.BadRequestError(err.Error())
+	}
+	strategy, err := p.strategySrv.Start(ctx, int64(chatID), strategyDto)
+	if err != nil {
+		return err
+	}
+
+	return p.tg.SendMessage(ctx, chatID, p.i18n.T("strategyStarted", nil, lang)+"\n"+p.strategyInfo(strategy, lang), "")
+}
+
+func (p *Processor) stopStrategy(ctx context.Context, chatID int, args []string, lang string) error {
+	if len(args) == 0 {
+		return errors.BadRequestError(p.i18n.T("strategyNotFound", nil, lang))
+	}
+	id, err := strconv.ParseInt(args[0], 10, 64)
+	if err != nil {
+		return errors.BadRequestError(p.i18n.T("strategyNotFound", nil, lang))
+	}
+	strategy, err := p.strategySrv.Stop(ctx, int64(chatID), id)
+	if err != nil {
+		return err
+	}
+
+	return p.tg.SendMessage(ctx, chatID, p.i18n.T("strategyStopped", nil, lang)+"\n"+p.strategyInfo(strategy, lang), "")
+}
+
+func (p *Processor) sendStrategies(ctx context.Context, chatID int, lang string) error {
+	strategies, err := p.strategySrv.GetStrategies(ctx, int64(chatID))
+	if err != nil {
+		return err
+	}
+
+	msg := p.i18n.T("noStrategies", nil, lang)
+	if len(strategies) > 0 {
+		msg = p.i18n.T("yourStrategies", nil, lang) + "\n"
+		for _, v := range strategies {
+			msg += p.strategyInfo(v, lang)
+			msg += "---------------------------\n"
+		}
+	}
+	go func() {
+		if err := p.tg.SendMessage(ctx, chatID, msg, ""); err != nil {
+			p.logger.ErrorLog.Println(err)
+		}
+	}()
+
+	return nil
+}
+
+func (p *Processor) strategyInfo(strategy *domain.Strategy, lang string) string {
+	params := map[string]interface{}{
+		"Id":       strategy.Id,
+		"Type":     strategy.Type,
+		"Status":   strategy.Status,
+		"Exchange": strategy.Exchange,
+		"Symbol":   strategy.Symbol,
+		"Open":     len(strategy.GetActiveOrders()),
+	}
+	if strategy.Grid != nil {
+		params["Lower"] = strategy.Grid.Lower
+		params["Upper"] = strategy.Grid.Upper
+		params["Levels"] = strategy.Grid.Levels
+		params["Profit"] = strategy.GridProfit()
+
+		return p.i18n.T("strategyGridInfo", params, lang)
+	}
+
+	params["AvgPrice"], params["Quantity"] = strategy.AverageEntry()
+	params["TpPrice"] = "-"
+	for _, v := range strategy.GetActiveOrders(consts.StrategyOrderTp) {
+		params["TpPrice"] = v.Price
+	}
+
+	return p.i18n.T("strategyDcaInfo", params, lang)
+}
+
+// createAlert handles "/alert BTCUSDT > 70000 [exchange] [repeat]", where the condition is one of > < x %.
+func (p *Processor) createAlert(ctx context.Context, chatID int, args []string, lang string) error {
+	if len(args) < 3 {
+		return errors.BadRequestError(p.i18n.T("alertUsage", nil, lang))
+	}
+	alertDto := &dto.PriceAlert{
+		Exchange:  consts.Binance,
+		Symbol:    strings.ToUpper(args[0]),
+		Condition: alertConditions[strings.ToLower(args[1])],
+		Value:     args[2],
+	}
+	for _, v := range args[3:] {
+		v = strings.ToLower(v)
+		if v == alertRepeatArg {
+			alertDto.Recurring = true
+
+			continue
+		}
+		if !slices.Contains(alertExchanges, v) {
+			return errors.BadRequestError(p.i18n.T("alertUsage", nil, lang))
+		}
+		alertDto.Exchange = v
+	}
+	if err := alertDto.Validate(); err != nil {
+		return errors.BadRequestError(p.i18n.T("alertUsage", nil, lang))
+	}
+
+	alert, err := p.alertSrv.CreateAlert(ctx, int64(chatID), alertDto)
+	if err != nil {
+		return err
+	}
+
+	return p.tg.SendMessage(ctx, chatID, p.i18n.T("alertCreated", nil, lang)+"\n"+p.alertInfo(alert, lang), "")
+}
+
+func (p *Processor) deleteAlert(ctx context.Context, chatID int, arg string, lang string) error {
+	id, err := strconv.ParseInt(arg, 10, 64)
+	if err != nil {
+		return errors.BadRequestError(p.i18n.T("alertNotFound", nil, lang))
+	}
+	if err = p.alertSrv.DeleteAlert(ctx, int64(chatID), id); err != nil {
+		return err
+	}
+
+	return p.tg.SendMessage(ctx, chatID, p.i18n.T("alertDeleted", map[string]interface{}{"Id": id}, lang), "")
+}
+
+func (p *Processor) sendAlerts(ctx context.Context, chatID int, lang string) error {
+	alerts, err := p.alertSrv.GetAlerts(ctx, int64(chatID))
+	if err != nil {
+		return err
+	}
+	if len(alerts) == 0 {
+		return p.tg.SendMessage(ctx, chatID, p.i18n.T("noAlerts", nil, lang), "")
+	}
+
+	msg := p.i18n.T("yourAlerts", nil, lang) + "\n"
+	for _, v := range alerts {
+		msg += p.alertInfo(v, lang)
+	}
+
+	return p.tg.SendMessage(ctx, chatID, msg, p.clbrd.MakeAlertsKeyboard(alerts))
+}
+
+func (p *Processor) alertInfo(alert *domain.PriceAlert, lang string) string {
+	mode := p.i18n.T("alertOneShot", nil, lang)
+	if alert.Recurring {
+		mode = p.i18n.T("alertRecurring", nil, lang)
+	}
+
+	return p.i18n.T("alertInfo", map[string]interface{}{
+		"Id":        alert.Id,
+		"Exchange":  alert.Exchange,
+		"Symbol":    alert.Symbol,
+		"Condition": alert.Condition,
+		"Value":     alert.Value,
+		"Mode":      mode,
+	}, lang)
+}
+
 func (p *Processor) sendStart(ctx context.Context, chatID int, lang string) error {
 	if err := p.userSrv.CreateUser(ctx, "", int64(chatID)); err != nil {
 		return err
@@ -320,6 +718,25 @@ func (p *Processor) sendStart(ctx context.Context, chatID int, lang string) erro
 	return nil
 }
 
+func (p *Processor) sendToken(ctx context.Context, chatID int, lang string) error {
+	token, err := pkg.NewTokenString(int64(chatID), p.tokenTtl, p.jwtSecret)
+	if err != nil {
+		p.logger.ErrorLog.Println(err)
+
+		return errors.InternalServerError(err)
+	}
+	go func() {
+		if err := p.tg.SendMessage(ctx, chatID, p.i18n.T("yourToken", map[string]interface{}{
+			"Token":     token,
+			"ExpiresAt": time.Now().Add(p.tokenTtl).UTC().Format(time.RFC3339),
+		}, lang), ""); err != nil {
+			p.logger.ErrorLog.Println(err)
+		}
+	}()
+
+	return nil
+}
+
 func (p *Processor) sendError(ctx context.Context, chatID int, err error) {
 	if errs.As(err, &errors.Error{}) {
 		err := err.(errors.Error)
@@ -0,0 +1,282 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+const dealBaseQuery = `SELECT o.id,
+       o.user_id,
+       o.exchange,
+       o.symbol,
+       o.status,
+       o.side,
+       o.order_type,
+       o.quantity,
+       COALESCE(o.price, ''),
+       o.exec_order_id,
+       COALESCE(o.time_in_force, ''),
+       o.tp_sl,
+       o.protection,
+       COALESCE(o.executed_qty, ''),
+       COALESCE(o.avg_price, ''),
+       o.created_at,
+       o.updated_at,
+       COALESCE(s.id, 0),
+       COALESCE(s.tp_percent, ''),
+       COALESCE(s.sl_percent, ''),
+       COALESCE(s.tp_price, ''),
+       COALESCE(s.sl_price, ''),
+       COALESCE(s.tp_type, ''),
+       COALESCE(s.sl_type, ''),
+       COALESCE(s.ts, ''),
+       COALESCE(s.tp_levels, ''),
+       COALESCE(s.sl_breakeven, FALSE),
+       COUNT(*) OVER() FROM orders o
+           LEFT JOIN order_settings s ON s.order_id = o.id AND s.deleted_at IS NULL`
+
+type DealRepository struct {
+	db Databaser
+}
+
+func NewDealRepository(db *sql.DB) *DealRepository {
+	return &DealRepository{
+		db: db,
+	}
+}
+
+func (d *DealRepository) GetDeals(ctx context.Context, userId int64, exchange string, limit, offset int) ([]*domain.Deal, int, error) {
+	query := dealBaseQuery + `
+             WHERE o.user_id = $1
+               AND o.tp_sl = $2
+               AND ($3 = '' OR o.exchange = $3)
+               AND NOT (o.status = $4 AND COALESCE(o.executed_qty, '') = '')
+             ORDER BY o.id DESC LIMIT $5 OFFSET $6`
+
+	deals, total, err := d.getBaseOrders(ctx, query,
+		userId,
+		consts.BaseOrderType,
+		exchange,
+		consts.OrderStatusCanceled,
+		limit,
+		offset)
+	if err != nil {
+		return nil, 0, err
+	}
+	if err = d.fillTpSlOrders(ctx, deals); err != nil {
+		return nil, 0, err
+	}
+
+	return deals, total, nil
+}
+
+func (d *DealRepository) GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error) {
+	query := dealBaseQuery + `
+             WHERE o.user_id = $1
+               AND o.tp_sl = $2
+               AND o.id = $3 LIMIT 1`
+
+	deals, _, err := d.getBaseOrders(ctx, query, userId, consts.BaseOrderType, id)
+	if err != nil {
+		return nil, err
+	}
+	if len(deals) == 0 {
+		return nil, nil
+	}
+	if err = d.fillTpSlOrders(ctx, deals); err != nil {
+		return nil, err
+	}
+
+	return deals[0], nil
+}
+
+func (d *DealRepository) GetDealsSince(ctx context.Context, userId int64, from time.Time) ([]*domain.Deal, error) {
+	query := dealBaseQuery + `
+             WHERE o.user_id = $1
+               AND o.tp_sl = $2
+               AND NOT (o.status = $3 AND COALESCE(o.executed_qty, '') = '')
+               AND (o.created_at >= $4
+                   OR o.status IN ($5, $6)
+                   OR EXISTS (SELECT 1 FROM orders l
+                                  WHERE l.exec_order_id = o.id::VARCHAR
+                                    AND l.tp_sl != $2
+                                    AND (l.updated_at >= $4 OR l.status IN ($5, $6, $7))))
+             ORDER BY o.id DESC`
+
+	deals, _, err := d.getBaseOrders(ctx, query,
+		userId,
+		consts.BaseOrderType,
+		consts.OrderStatusCanceled,
+		from,
+		consts.OrderStatusActive,
+		consts.OrderStatusPartFilled,
+		consts.OrderStatusTpSlInactive)
+	if err != nil {
+		return nil, err
+	}
+	if err = d.fillTpSlOrders(ctx, deals); err != nil {
+		return nil, err
+	}
+
+	return deals, nil
+}
+
+func (d *DealRepository) getBaseOrders(ctx context.Context, query string, args ...any) ([]*domain.Deal, int, error) {
+	rows, err := d.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, 0, nil
+		}
+		return nil, 0, err
+	}
+	defer rows.Close()
+
+	var total int
+	deals := make([]*domain.Deal, 0)
+	for rows.Next() {
+		var createdAt, updatedAt sql.NullTime
+		var tpLevels string
+		order := new(domain.Order)
+		settings := new(domain.Settings)
+		err = rows.Scan(&order.Id,
+			&order.UserId,
+			&order.Exchange,
+			&order.Symbol,
+			&order.Status,
+			&order.Side,
+			&order.OrderType,
+			&order.Quantity,
+			&order.Price,
+			&order.ExecOrderId,
+			&order.TimeInForce,
+			&order.TpSl,
+			&order.Protection,
+			&order.ExecutedQty,
+			&order.AvgPrice,
+			&createdAt,
+			&updatedAt,
+			&settings.Id,
+			&settings.TpPercent,
+			&settings.SlPercent,
+			&settings.TpPrice,
+			&settings.SlPrice,
+			&settings.TpType,
+			&settings.SlType,
+			&settings.Ts,
+			&tpLevels,
+			&settings.SlBreakeven,
+			&total)
+		if err != nil {
+			return nil, 0, err
+		}
+		order.CreatedAt, order.UpdatedAt = createdAt.Time, updatedAt.Time
+
+		if settings.Id == 0 {
+			settings = nil
+		} else {
+			settings.OrderId = order.Id
+			if tpLevels != "" {
+				if err = json.Unmarshal([]byte(tpLevels), &settings.TpLevels); err != nil {
+					return nil, 0, err
+				}
+			}
+		}
+
+		deals = append(deals, domain.NewDeal(order, nil, settings))
+	}
+	if err = rows.Err(); err != nil {
+		return nil, 0, err
+	}
+
+	return deals, total, nil
+}
+
+func (d *DealRepository) fillTpSlOrders(ctx context.Context, deals []*domain.Deal) error {
+	if len(deals) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(deals))
+	dealMap := make(map[int64]*domain.Deal, len(deals))
+	for _, v := range deals {
+		ids = append(ids, strconv.FormatInt(v.BaseOrder.Id, 10))
+		dealMap[v.BaseOrder.Id] = v
+	}
+
+	query := `SELECT id,
+       user_id,
+       exchange,
+       symbol,
+       status,
+       side,
+       order_type,
+       quantity,
+       COALESCE(price, ''),
+       exec_order_id,
+       COALESCE(time_in_force, ''),
+       tp_sl,
+       COALESCE(ts, ''),
+       COALESCE(ts_price, ''),
+       protection,
+       COALESCE(executed_qty, ''),
+       COALESCE(avg_price, ''),
+       created_at,
+       updated_at FROM orders
+             WHERE exec_order_id = ANY(string_to_array($1, ',')) AND tp_sl != $2
+             ORDER BY id`
+	rows, err := d.db.QueryContext(ctx, query, strings.Join(ids, ","), consts.BaseOrderType)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil
+		}
+		return err
+	}
+	defer rows.Close()
+
+	tpSlOrders := make(map[int64][]*domain.Order, len(deals))
+	for rows.Next() {
+		var createdAt, updatedAt sql.NullTime
+		order := new(domain.Order)
+		err = rows.Scan(&order.Id,
+			&order.UserId,
+			&order.Exchange,
+			&order.Symbol,
+			&order.Status,
+			&order.Side,
+			&order.OrderType,
+			&order.Quantity,
+			&order.Price,
+			&order.ExecOrderId,
+			&order.TimeInForce,
+			&order.TpSl,
+			&order.Ts,
+			&order.TsPrice,
+			&order.Protection,
+			&order.ExecutedQty,
+			&order.AvgPrice,
+			&createdAt,
+			&updatedAt)
+		if err != nil {
+			return err
+		}
+		order.CreatedAt, order.UpdatedAt = createdAt.Time, updatedAt.Time
+		tpSlOrders[order.ExecOrderId] = append(tpSlOrders[order.ExecOrderId], order)
+	}
+	if err = rows.Err(); err != nil {
+		return err
+	}
+
+	for id, deal := range dealMap {
+		*deal = *domain.NewDeal(deal.BaseOrder, tpSlOrders[id], deal.Settings)
+	}
+
+	return nil
+}
@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"errors"
 	"github.com/linnoxlewis/trade-bot/internal/domain"
 	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
@@ -53,7 +54,10 @@ func (o *OrderRepository) CreateOrderWithSettings(ctx context.Context, order *do
                     exec_order_id, 
                     time_in_force, 
                     tp_sl, 
-                    created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12) RETURNING ID`
+                    ts,
+                    ts_price,
+                    protection,
+                    created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15) RETURNING ID`
 
 	if err = db.QueryRowContext(ctx, query,
 		order.UserId,
@@ -66,12 +70,20 @@ func (o *OrderRepository) CreateOrderWithSettings(ctx context.Context, order *do
 		order.Price,
 		order.ExecOrderId,
 		order.TimeInForce,
-		order.TpSl, now).Scan(&id); err != nil {
+		order.TpSl,
+		order.Ts,
+		order.TsPrice,
+		order.Protection, now).Scan(&id); err != nil {
 		return 0, err
 	}
 
 	if settings != nil {
-		query = `INSERT INTO order_settings (order_id, tp_percent, sl_percent, tp_price, sl_price, ts, tp_type, sl_type, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+		tpLevels, err := marshalTpLevels(settings.TpLevels)
+		if err != nil {
+			return 0, err
+		}
+
+		query = `INSERT INTO order_settings (order_id, tp_percent, sl_percent, tp_price, sl_price, ts, tp_type, sl_type, tp_levels, sl_breakeven, created_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`
 		_, err = db.ExecContext(ctx, query,
 			id,
 			settings.TpPercent,
@@ -80,7 +92,9 @@ func (o *OrderRepository) CreateOrderWithSettings(ctx context.Context, order *do
 			settings.SlPrice,
 			settings.Ts,
 			settings.TpType,
-			settings.SlType, now)
+			settings.SlType,
+			tpLevels,
+			settings.SlBreakeven, now)
 		if err != nil {
 			return 0, err
 		}
@@ -100,7 +114,10 @@ func (o *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
                     price, 
                     exec_order_id, 
                     time_in_force, 
-                    tp_sl,created_at) VALUES ($1, $2, $3, $4, $5, $6,$7,$8,$9,$10,$11,$12) RETURNING ID`
+                    tp_sl,
+                    ts,
+                    ts_price,
+                    protection,created_at) VALUES ($1, $2, $3, $4, $5, $6,$7,$8,$9,$10,$11,$12,$13,$14,$15) RETURNING ID`
 	if err = o.transaction.GetDb(ctx).QueryRowContext(ctx, query,
 		order.UserId,
 		order.Exchange,
@@ -113,6 +130,9 @@ func (o *OrderRepository) CreateOrder(ctx context.Context, order *domain.Order)
 		order.ExecOrderId,
 		order.TimeInForce,
 		order.TpSl,
+		order.Ts,
+		order.TsPrice,
+		order.Protection,
 		time.Now()).Scan(&id); err != nil {
 		return 0, err
 	}
@@ -132,14 +152,18 @@ func (o *OrderRepository) GetActiveTpSlOrders(ctx context.Context, exchange stri
        exec_order_id,
        time_in_force,
        user_id,
-       tp_sl FROM orders 
+       tp_sl,
+       COALESCE(ts, ''),
+       COALESCE(ts_price, '') FROM orders 
              WHERE exchange = $1 
                AND status = $2 
-               AND tp_sl != $3`
+               AND tp_sl != $3
+               AND protection = $4`
 	rows, err := o.db.QueryContext(ctx, query,
 		exchange,
 		consts.OrderStatusActive,
-		consts.BaseOrderType)
+		consts.BaseOrderType,
+		consts.ProtectionVirtual)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -162,7 +186,9 @@ func (o *OrderRepository) GetActiveTpSlOrders(ctx context.Context, exchange stri
 			&mdl.ExecOrderId,
 			&mdl.TimeInForce,
 			&mdl.UserId,
-			&mdl.TpSl)
+			&mdl.TpSl,
+			&mdl.Ts,
+			&mdl.TsPrice)
 		if err != nil {
 			return nil, err
 		}
@@ -209,10 +235,11 @@ func (o *OrderRepository) CancelOrderByEx(ctx context.Context, id int64, symbol,
 }
 
 func (o *OrderRepository) ExecuteOrder(ctx context.Context, id int64) error {
-	query := `UPDATE orders SET status = $1 WHERE id = $2`
+	query := `UPDATE orders SET status = $1, updated_at = $2 WHERE id = $3`
 	_, err := o.transaction.GetDb(ctx).ExecContext(ctx,
 		query,
 		consts.OrderStatusFilled,
+		time.Now(),
 		id)
 
 	return err
@@ -252,6 +279,21 @@ func (o *OrderRepository) UpdateTpSl(ctx context.Context, id int64, price string
 	return nil
 }
 
+func (o *OrderRepository) UpdateTrailingStop(ctx context.Context, id int64, price, tsPrice string) error {
+	query := `UPDATE orders SET 
+                  price = $1, 
+                  ts_price = $2,
+                  updated_at = $3 
+              WHERE id = $4`
+	_, err := o.transaction.GetDb(ctx).ExecContext(ctx, query,
+		price,
+		tsPrice,
+		time.Now(),
+		id)
+
+	return err
+}
+
 func (o *OrderRepository) GetOrder(ctx context.Context, orderId int64, symbol, exchange string) (*domain.Order, error) {
 	var result domain.Order
 
@@ -266,7 +308,12 @@ func (o *OrderRepository) GetOrder(ctx context.Context, orderId int64, symbol, e
        o.price, 
        o.exec_order_id, 
        o.time_in_force, 
-       o.tp_sl FROM orders o 
+       o.tp_sl,
+       o.protection,
+       COALESCE(o.oco_list_id, 0),
+       COALESCE(o.oco_order_id, 0),
+       COALESCE(o.executed_qty, ''),
+       COALESCE(o.avg_price, '') FROM orders o 
                WHERE exchange = $1 
                  AND id = $2 
                  AND symbol = $3 LIMIT 1`
@@ -287,7 +334,54 @@ func (o *OrderRepository) GetOrder(ctx context.Context, orderId int64, symbol, e
 		&result.Price,
 		&result.ExecOrderId,
 		&result.TimeInForce,
-		&result.TpSl)
+		&result.TpSl,
+		&result.Protection,
+		&result.OcoListId,
+		&result.OcoOrderId,
+		&result.ExecutedQty,
+		&result.AvgPrice)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return &result, nil
+}
+
+func (o *OrderRepository) GetSettings(ctx context.Context, orderId int64) (*domain.Settings, error) {
+	var result domain.Settings
+	var tpLevels string
+
+	query := `SELECT id,
+       order_id,
+       COALESCE(tp_percent, ''),
+       COALESCE(sl_percent, ''),
+       COALESCE(tp_price, ''),
+       COALESCE(sl_price, ''),
+       COALESCE(tp_type, ''),
+       COALESCE(sl_type, ''),
+       COALESCE(ts, ''),
+       COALESCE(tp_levels, ''),
+       sl_breakeven FROM order_settings
+               WHERE order_id = $1
+                 AND deleted_at IS NULL LIMIT 1`
+
+	err := o.transaction.GetDb(ctx).
+		QueryRowContext(ctx,
+			query,
+			orderId).Scan(&result.Id,
+		&result.OrderId,
+		&result.TpPercent,
+		&result.SlPercent,
+		&result.TpPrice,
+		&result.SlPrice,
+		&result.TpType,
+		&result.SlType,
+		&result.Ts,
+		&tpLevels,
+		&result.SlBreakeven)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -295,6 +389,12 @@ func (o *OrderRepository) GetOrder(ctx context.Context, orderId int64, symbol, e
 		return nil, err
 	}
 
+	if tpLevels != "" {
+		if err = json.Unmarshal([]byte(tpLevels), &result.TpLevels); err != nil {
+			return nil, err
+		}
+	}
+
 	return &result, nil
 }
 
@@ -311,7 +411,10 @@ func (o *OrderRepository) GetTpSlOrderByBaseOrder(ctx context.Context, id int64,
        o.price, 
        o.exec_order_id, 
        o.time_in_force, 
-       o.tp_sl FROM orders o
+       o.tp_sl,
+       o.protection,
+       COALESCE(o.oco_list_id, 0),
+       COALESCE(o.oco_order_id, 0) FROM orders o
                WHERE exchange = $1
                  AND exec_order_id = $2 
                  AND symbol = $3 
@@ -334,7 +437,10 @@ func (o *OrderRepository) GetTpSlOrderByBaseOrder(ctx context.Context, id int64,
 		&result.Price,
 		&result.ExecOrderId,
 		&result.TimeInForce,
-		&result.TpSl)
+		&result.TpSl,
+		&result.Protection,
+		&result.OcoListId,
+		&result.OcoOrderId)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -382,8 +488,8 @@ func (o *OrderRepository) GetOpposingTpSlOrder(ctx context.Context, order *domai
 	return &result, nil
 }
 
-func (o *OrderRepository) GetActiveSymbols(ctx context.Context) (domain.SymbolList, error) {
-	query := `SELECT DISTINCT symbol FROM orders WHERE status = $1`
+func (o *OrderRepository) GetActiveSymbols(ctx context.Context) ([]domain.Symbols, error) {
+	query := `SELECT DISTINCT exchange, symbol FROM orders WHERE status = $1`
 	rows, err := o.db.QueryContext(ctx, query, consts.OrderStatusActive)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
@@ -393,10 +499,10 @@ func (o *OrderRepository) GetActiveSymbols(ctx context.Context) (domain.SymbolLi
 	}
 	defer rows.Close()
 
-	symbolList := make([]domain.Symbol, 0)
+	symbolList := make([]domain.Symbols, 0)
 	for rows.Next() {
-		var symbol domain.Symbol
-		if err := rows.Scan(&symbol); err != nil {
+		var symbol domain.Symbols
+		if err := rows.Scan(&symbol.Exchange, &symbol.Symbol); err != nil {
 			return nil, err
 		}
 		symbolList = append(symbolList, symbol)
@@ -415,6 +521,64 @@ func (o *OrderRepository) ActivateOrder(ctx context.Context, id int64) error {
 	return err
 }
 
+func (o *OrderRepository) SetOcoOrder(ctx context.Context, id, ocoListId, ocoOrderId int64) error {
+	query := `UPDATE orders SET 
+                  status = $1,
+                  oco_list_id = $2,
+                  oco_order_id = $3,
+                  updated_at = $4
+              WHERE id = $5`
+	_, err := o.transaction.GetDb(ctx).ExecContext(ctx,
+		query,
+		consts.OrderStatusActive,
+		ocoListId,
+		ocoOrderId,
+		time.Now(),
+		id)
+
+	return err
+}
+
+func (o *OrderRepository) SetExecutedQty(ctx context.Context, id int64, status, executedQty, avgPrice string) error {
+	query := `UPDATE orders SET 
+                  status = $1,
+                  executed_qty = $2,
+                  avg_price = $3,
+                  updated_at = $4
+              WHERE id = $5`
+	_, err := o.transaction.GetDb(ctx).ExecContext(ctx,
+		query,
+		status,
+		executedQty,
+		avgPrice,
+		time.Now(),
+		id)
+
+	return err
+}
+
+func (o *OrderRepository) UpdateQuantity(ctx context.Context, id int64, quantity string) error {
+	query := `UPDATE orders SET quantity = $1, updated_at = $2 WHERE id = $3`
+	_, err := o.transaction.GetDb(ctx).ExecContext(ctx,
+		query,
+		quantity,
+		time.Now(),
+		id)
+
+	return err
+}
+
+func (o *OrderRepository) SetProtection(ctx context.Context, id int64, protection string) error {
+	query := `UPDATE orders SET protection = $1, updated_at = $2 WHERE id = $3`
+	_, err := o.transaction.GetDb(ctx).ExecContext(ctx,
+		query,
+		protection,
+		time.Now(),
+		id)
+
+	return err
+}
+
 func (o *OrderRepository) GetTpSlOrdersByBaseOrder(ctx context.Context, id int64) ([]*domain.Order, error) {
 	query := `SELECT id, 
        exchange,
@@ -427,7 +591,12 @@ func (o *OrderRepository) GetTpSlOrdersByBaseOrder(ctx context.Context, id int64
        exec_order_id, 
        time_in_force, 
        user_id, 
-       tp_sl FROM orders 
+       tp_sl,
+       COALESCE(ts, ''),
+       COALESCE(ts_price, ''),
+       protection,
+       COALESCE(oco_list_id, 0),
+       COALESCE(oco_order_id, 0) FROM orders 
              WHERE exec_order_id = $1 AND tp_sl != $2`
 	rows, err := o.transaction.GetDb(ctx).QueryContext(ctx, query,
 		id,
@@ -454,7 +623,12 @@ func (o *OrderRepository) GetTpSlOrdersByBaseOrder(ctx context.Context, id int64
 			&mdl.ExecOrderId,
 			&mdl.TimeInForce,
 			&mdl.UserId,
-			&mdl.TpSl)
+			&mdl.TpSl,
+			&mdl.Ts,
+			&mdl.TsPrice,
+			&mdl.Protection,
+			&mdl.OcoListId,
+			&mdl.OcoOrderId)
 		if err != nil {
 			return nil, err
 		}
@@ -476,13 +650,20 @@ func (o *OrderRepository) GetLimitOrders(ctx context.Context, exchange string) (
        exec_order_id, 
        time_in_force, 
        user_id, 
-       tp_sl FROM orders WHERE tp_sl = $1 
-                           AND status = $2 
+       tp_sl,
+       protection,
+       COALESCE(oco_list_id, 0),
+       COALESCE(oco_order_id, 0),
+       COALESCE(executed_qty, ''),
+       COALESCE(avg_price, '') FROM orders WHERE (tp_sl = $1 OR (protection = $4 AND oco_list_id IS NOT NULL))
+                           AND status IN ($2, $5)
                            AND exchange = $3`
 	rows, err := o.db.QueryContext(ctx, query,
 		consts.BaseOrderType,
 		consts.OrderStatusActive,
-		exchange)
+		exchange,
+		consts.ProtectionOco,
+		consts.OrderStatusPartFilled)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, nil
@@ -505,7 +686,12 @@ func (o *OrderRepository) GetLimitOrders(ctx context.Context, exchange string) (
 			&mdl.ExecOrderId,
 			&mdl.TimeInForce,
 			&mdl.UserId,
-			&mdl.TpSl)
+			&mdl.TpSl,
+			&mdl.Protection,
+			&mdl.OcoListId,
+			&mdl.OcoOrderId,
+			&mdl.ExecutedQty,
+			&mdl.AvgPrice)
 		if err != nil {
 			return nil, err
 		}
@@ -514,3 +700,16 @@ func (o *OrderRepository) GetLimitOrders(ctx context.Context, exchange string) (
 
 	return orderList, rows.Err()
 }
+
+func marshalTpLevels(levels []domain.TpLevel) (interface{}, error) {
+	if len(levels) == 0 {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(levels)
+	if err != nil {
+		return nil, err
+	}
+
+	return string(data), nil
+}
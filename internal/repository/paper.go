@@ -0,0 +1,266 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"time"
+)
+
+type PaperRepository struct {
+	db *sql.DB
+}
+
+func NewPaperRepository(db *sql.DB) *PaperRepository {
+	return &PaperRepository{
+		db: db,
+	}
+}
+
+func (p *PaperRepository) InitBalance(ctx context.Context, userId int64, asset, amount string) error {
+	query := `INSERT INTO paper_balances (user_id, asset, free, locked, created_at, updated_at)
+				VALUES ($1, $2, $3, 0, $4, $4) ON CONFLICT (user_id, asset) DO NOTHING`
+	_, err := p.db.ExecContext(ctx, query, userId, asset, amount, time.Now())
+
+	return err
+}
+
+func (p *PaperRepository) GetBalances(ctx context.Context, userId int64) (domain.Balance, error) {
+	query := `SELECT asset, free::text, locked::text FROM paper_balances WHERE user_id = $1 ORDER BY asset`
+	rows, err := p.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	balance := make(domain.Balance, 0)
+	for rows.Next() {
+		var symbol domain.BalanceSymbol
+		if err := rows.Scan(&symbol.Symbol, &symbol.Quantity, &symbol.Locked); err != nil {
+			return nil, err
+		}
+		balance = append(balance, symbol)
+	}
+
+	return balance, rows.Err()
+}
+
+func (p *PaperRepository) PlaceOrder(ctx context.Context, order *domain.PaperOrder) (id int64, err error) {
+	err = p.atomic(ctx, func(tx *sql.Tx) error {
+		now := time.Now()
+		query := `UPDATE paper_balances SET free = free - $1, locked = locked + $1, updated_at = $2
+					WHERE user_id = $3 AND asset = $4 AND free >= $1`
+		res, err := tx.ExecContext(ctx, query, order.ReserveAmount, now, order.UserId, order.ReserveAsset)
+		if err != nil {
+			return err
+		}
+		if affected, err := res.RowsAffected(); err != nil || affected == 0 {
+			return domain.ErrPaperInsufficientBalance
+		}
+
+		query = `INSERT INTO paper_orders (user_id,
+                          symbol,
+                          side,
+                          order_type,
+                          status,
+                          quantity,
+                          price,
+                          stop_price,
+                          time_in_force,
+                          reserve_asset,
+                          reserve_amount,
+                          receive_asset,
+                          receive_amount,
+                          created_at,
+                          updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, NULLIF($8, '')::numeric, $9, $10, $11, $12, $13, $14, $14)
+                          RETURNING id`
+		if err = tx.QueryRowContext(ctx, query,
+			order.UserId,
+			order.Symbol,
+			order.Side,
+			order.OrderType,
+			consts.OrderStatusActive,
+			order.Quantity,
+			order.Price,
+			order.StopPrice,
+			order.TimeInForce,
+			order.ReserveAsset,
+			order.ReserveAmount,
+			order.ReceiveAsset,
+			order.ReceiveAmount,
+			now).Scan(&id); err != nil {
+			return err
+		}
+
+		if order.Status == consts.OrderStatusFilled {
+			return p.fill(ctx, tx, id)
+		}
+
+		return nil
+	})
+
+	return id, err
+}
+
+func (p *PaperRepository) FillOrder(ctx context.Context, id int64) error {
+	return p.atomic(ctx, func(tx *sql.Tx) error {
+		return p.fill(ctx, tx, id)
+	})
+}
+
+func (p *PaperRepository) CancelOrder(ctx context.Context, userId, id int64) error {
+	return p.atomic(ctx, func(tx *sql.Tx) error {
+		var asset, amount string
+		now := time.Now()
+		query := `UPDATE paper_orders SET status = $1, updated_at = $2
+					WHERE id = $3 AND user_id = $4 AND status = $5
+					RETURNING reserve_asset, reserve_amount::text`
+		if err := tx.QueryRowContext(ctx, query,
+			consts.OrderStatusCanceled,
+			now,
+			id,
+			userId,
+			consts.OrderStatusActive).Scan(&asset, &amount); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				return domain.ErrPaperOrderNotFound
+			}
+			return err
+		}
+
+		query = `UPDATE paper_balances SET free = free + $1, locked = locked - $1, updated_at = $2
+					WHERE user_id = $3 AND asset = $4`
+		_, err := tx.ExecContext(ctx, query, amount, now, userId, asset)
+
+		return err
+	})
+}
+
+func (p *PaperRepository) GetOrder(ctx context.Context, userId, id int64) (*domain.PaperOrder, error) {
+	query := `SELECT ` + paperOrderFields + ` FROM paper_orders WHERE id = $1 AND user_id = $2`
+	order, err := p.scanOrder(p.db.QueryRowContext(ctx, query, id, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (p *PaperRepository) GetOpenOrders(ctx context.Context, userId int64, symbol string) ([]*domain.PaperOrder, error) {
+	query := `SELECT ` + paperOrderFields + ` FROM paper_orders
+				WHERE user_id = $1 AND status = $2 AND ($3 = '' OR symbol = $3) ORDER BY id`
+	rows, err := p.db.QueryContext(ctx, query, userId, consts.OrderStatusActive, symbol)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	orders := make([]*domain.PaperOrder, 0)
+	for rows.Next() {
+		order, err := p.scanOrder(rows)
+		if err != nil {
+			return nil, err
+		}
+		orders = append(orders, order)
+	}
+
+	return orders, rows.Err()
+}
+
+const paperOrderFields = `id,
+       user_id,
+       symbol,
+       side,
+       order_type,
+       status,
+       quantity::text,
+       price::text,
+       COALESCE(stop_price::text, ''),
+       COALESCE(time_in_force, ''),
+       reserve_asset,
+       reserve_amount::text,
+       receive_asset,
+       receive_amount::text`
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func (p *PaperRepository) scanOrder(row scanner) (*domain.PaperOrder, error) {
+	order := new(domain.PaperOrder)
+	if err := row.Scan(&order.Id,
+		&order.UserId,
+		&order.Symbol,
+		&order.Side,
+		&order.OrderType,
+		&order.Status,
+		&order.Quantity,
+		&order.Price,
+		&order.StopPrice,
+		&order.TimeInForce,
+		&order.ReserveAsset,
+		&order.ReserveAmount,
+		&order.ReceiveAsset,
+		&order.ReceiveAmount); err != nil {
+		return nil, err
+	}
+
+	return order, nil
+}
+
+func (p *PaperRepository) fill(ctx context.Context, tx *sql.Tx, id int64) error {
+	var userId int64
+	var reserveAsset, reserveAmount, receiveAsset, receiveAmount string
+	now := time.Now()
+	query := `UPDATE paper_orders SET status = $1, updated_at = $2 WHERE id = $3 AND status = $4
+				RETURNING user_id, reserve_asset, reserve_amount::text, receive_asset, receive_amount::text`
+	if err := tx.QueryRowContext(ctx, query,
+		consts.OrderStatusFilled,
+		now,
+		id,
+		consts.OrderStatusActive).Scan(&userId, &reserveAsset, &reserveAmount, &receiveAsset, &receiveAmount); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return domain.ErrPaperOrderNotFound
+		}
+		return err
+	}
+
+	query = `UPDATE paper_balances SET locked = locked - $1, updated_at = $2 WHERE user_id = $3 AND asset = $4`
+	if _, err := tx.ExecContext(ctx, query, reserveAmount, now, userId, reserveAsset); err != nil {
+		return err
+	}
+
+	query = `INSERT INTO paper_balances (user_id, asset, free, locked, created_at, updated_at)
+				VALUES ($1, $2, $3, 0, $4, $4)
+				ON CONFLICT (user_id, asset) DO UPDATE SET free = paper_balances.free + EXCLUDED.free,
+				                                           updated_at = EXCLUDED.updated_at`
+	_, err := tx.ExecContext(ctx, query, userId, receiveAsset, receiveAmount, now)
+
+	return err
+}
+
+func (p *PaperRepository) atomic(ctx context.Context, fn func(tx *sql.Tx) error) (err error) {
+	tx, err := p.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	defer func() {
+		if pn := recover(); pn != nil {
+			_ = tx.Rollback()
+
+			panic(pn)
+		}
+		if err != nil {
+			_ = tx.Rollback()
+		} else {
+			err = tx.Commit()
+		}
+	}()
+
+	return fn(tx)
+}
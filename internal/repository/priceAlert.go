@@ -0,0 +1,180 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+const priceAlertColumns = `id,
+       user_id,
+       exchange,
+       symbol,
+       condition,
+       value::text,
+       COALESCE(base_price::text, ''),
+       recurring,
+       armed,
+       status,
+       triggered_at,
+       created_at,
+       updated_at`
+
+type PriceAlertRepository struct {
+	db *sql.DB
+}
+
+func NewPriceAlertRepository(db *sql.DB) *PriceAlertRepository {
+	return &PriceAlertRepository{
+		db: db,
+	}
+}
+
+func (p *PriceAlertRepository) CreateAlert(ctx context.Context, alert *domain.PriceAlert) (id int64, err error) {
+	query := `INSERT INTO price_alerts (user_id,
+                          exchange,
+                          symbol,
+                          condition,
+                          value,
+                          base_price,
+                          recurring,
+                          armed,
+                          status,
+                          created_at,
+                          updated_at) VALUES ($1, $2, $3, $4, $5, NULLIF($6, '')::numeric, $7, $8, $9, $10, $10)
+                          RETURNING id`
+	err = p.db.QueryRowContext(ctx, query,
+		alert.UserId,
+		alert.Exchange,
+		alert.Symbol,
+		alert.Condition,
+		alert.Value,
+		alert.BasePrice,
+		alert.Recurring,
+		alert.Armed,
+		alert.Status,
+		time.Now()).Scan(&id)
+
+	return id, err
+}
+
+// UpdateAlert saves the evaluation state of the alert.
+// UpdateAlert saves a checked alert unless it was deleted or fired meanwhile.
+func (p *PriceAlertRepository) UpdateAlert(ctx context.Context, alert *domain.PriceAlert) error {
+	query := `UPDATE price_alerts SET base_price = NULLIF($1, '')::numeric,
+                        armed = $2,
+                        status = $3,
+                        triggered_at = $4,
+                        updated_at = $5 WHERE id = $6 AND status = $7`
+	_, err := p.db.ExecContext(ctx, query,
+		alert.BasePrice,
+		alert.Armed,
+		alert.Status,
+		alert.TriggeredAt,
+		time.Now(),
+		alert.Id,
+		consts.AlertStatusActive)
+
+	return err
+}
+
+func (p *PriceAlertRepository) SetStatus(ctx context.Context, id int64, status string) error {
+	query := `UPDATE price_alerts SET status = $1, updated_at = $2 WHERE id = $3`
+	_, err := p.db.ExecContext(ctx, query, status, time.Now(), id)
+
+	return err
+}
+
+func (p *PriceAlertRepository) GetAlert(ctx context.Context, id int64) (*domain.PriceAlert, error) {
+	query := `SELECT ` + priceAlertColumns + ` FROM price_alerts WHERE id = $1`
+
+	alert, err := p.scanAlert(p.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return alert, nil
+}
+
+func (p *PriceAlertRepository) GetActiveAlerts(ctx context.Context) ([]*domain.PriceAlert, error) {
+	query := `SELECT ` + priceAlertColumns + ` FROM price_alerts WHERE status = $1 ORDER BY id`
+
+	return p.getAlerts(ctx, query, consts.AlertStatusActive)
+}
+
+func (p *PriceAlertRepository) GetUserAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error) {
+	query := `SELECT ` + priceAlertColumns + ` FROM price_alerts WHERE user_id = $1 AND status = $2 ORDER BY id`
+
+	return p.getAlerts(ctx, query, userId, consts.AlertStatusActive)
+}
+
+func (p *PriceAlertRepository) GetAlertSymbols(ctx context.Context) ([]domain.Symbols, error) {
+	query := `SELECT DISTINCT exchange, symbol FROM price_alerts WHERE status = $1`
+	rows, err := p.db.QueryContext(ctx, query, consts.AlertStatusActive)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]domain.Symbols, 0)
+	for rows.Next() {
+		var symbol domain.Symbols
+		if err := rows.Scan(&symbol.Exchange, &symbol.Symbol); err != nil {
+			return nil, err
+		}
+		result = append(result, symbol)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *PriceAlertRepository) getAlerts(ctx context.Context, query string, args ...any) ([]*domain.PriceAlert, error) {
+	rows, err := p.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.PriceAlert, 0)
+	for rows.Next() {
+		alert, err := p.scanAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, alert)
+	}
+
+	return result, rows.Err()
+}
+
+func (p *PriceAlertRepository) scanAlert(row rowScanner) (*domain.PriceAlert, error) {
+	var triggeredAt sql.NullTime
+	alert := new(domain.PriceAlert)
+	if err := row.Scan(&alert.Id,
+		&alert.UserId,
+		&alert.Exchange,
+		&alert.Symbol,
+		&alert.Condition,
+		&alert.Value,
+		&alert.BasePrice,
+		&alert.Recurring,
+		&alert.Armed,
+		&alert.Status,
+		&triggeredAt,
+		&alert.CreatedAt,
+		&alert.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if triggeredAt.Valid {
+		alert.TriggeredAt = &triggeredAt.Time
+	}
+
+	return alert, nil
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+type RiskLimitsRepository struct {
+	db *sql.DB
+}
+
+func NewRiskLimitsRepository(db *sql.DB) *RiskLimitsRepository {
+	return &RiskLimitsRepository{
+		db: db,
+	}
+}
+
+const riskLimitsColumns = `user_id,
+       COALESCE(max_order_notional::text, ''),
+       max_open_deals,
+       COALESCE(max_daily_loss::text, ''),
+       allowed_symbols,
+       paused_until`
+
+func (r *RiskLimitsRepository) GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error) {
+	query := `SELECT ` + riskLimitsColumns + ` FROM user_risk_limits WHERE user_id = $1`
+
+	limits, err := scanRiskLimits(r.db.QueryRowContext(ctx, query, userId))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	return limits, nil
+}
+
+// GetDailyLossLimits returns the limits of users with a daily loss limit who are not paused at now.
+func (r *RiskLimitsRepository) GetDailyLossLimits(ctx context.Context, now time.Time) ([]*domain.RiskLimits, error) {
+	query := `SELECT ` + riskLimitsColumns + ` FROM user_risk_limits
+				WHERE max_daily_loss IS NOT NULL AND (paused_until IS NULL OR paused_until <= $1)`
+
+	rows, err := r.db.QueryContext(ctx, query, now)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []*domain.RiskLimits
+	for rows.Next() {
+		limits, err := scanRiskLimits(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, limits)
+	}
+
+	return result, rows.Err()
+}
+
+func scanRiskLimits(row rowScanner) (*domain.RiskLimits, error) {
+	var allowedSymbols string
+	var pausedUntil sql.NullTime
+	limits := new(domain.RiskLimits)
+	err := row.Scan(&limits.UserId,
+		&limits.MaxOrderNotional,
+		&limits.MaxOpenDeals,
+		&limits.MaxDailyLoss,
+		&allowedSymbols,
+		&pausedUntil)
+	if err != nil {
+		return nil, err
+	}
+	if allowedSymbols != "" {
+		limits.AllowedSymbols = strings.Split(allowedSymbols, ",")
+	}
+	if pausedUntil.Valid {
+		limits.PausedUntil = &pausedUntil.Time
+	}
+
+	return limits, nil
+}
+
+// SaveRiskLimits leaves paused_until as is, so saving limits does not lift an active pause.
+func (r *RiskLimitsRepository) SaveRiskLimits(ctx context.Context, limits *domain.RiskLimits) error {
+	query := `INSERT INTO user_risk_limits (user_id,
+                              max_order_notional,
+                              max_open_deals,
+                              max_daily_loss,
+                              allowed_symbols,
+                              created_at,
+                              updated_at) VALUES ($1, NULLIF($2, '')::numeric, $3, NULLIF($4, '')::numeric, $5, $6, $6)
+				ON CONFLICT (user_id) DO UPDATE SET max_order_notional = EXCLUDED.max_order_notional,
+				                                    max_open_deals = EXCLUDED.max_open_deals,
+				                                    max_daily_loss = EXCLUDED.max_daily_loss,
+				                                    allowed_symbols = EXCLUDED.allowed_symbols,
+				                                    updated_at = EXCLUDED.updated_at`
+	_, err := r.db.ExecContext(ctx, query,
+		limits.UserId,
+		limits.MaxOrderNotional,
+		limits.MaxOpenDeals,
+		limits.MaxDailyLoss,
+		strings.Join(limits.AllowedSymbols, ","),
+		time.Now())
+
+	return err
+}
+
+func (r *RiskLimitsRepository) SetPausedUntil(ctx context.Context, userId int64, pausedUntil time.Time) error {
+	query := `UPDATE user_risk_limits SET paused_until = $1, updated_at = $2 WHERE user_id = $3`
+	_, err := r.db.ExecContext(ctx, query, pausedUntil, time.Now(), userId)
+
+	return err
+}
@@ -0,0 +1,248 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+)
+
+type strategyParams struct {
+	Dca  *domain.DcaParams  `json:"dca,omitempty"`
+	Grid *domain.GridParams `json:"grid,omitempty"`
+}
+
+type StrategyRepository struct {
+	db *sql.DB
+}
+
+func NewStrategyRepository(db *sql.DB) *StrategyRepository {
+	return &StrategyRepository{
+		db: db,
+	}
+}
+
+func (s *StrategyRepository) CreateStrategy(ctx context.Context, strategy *domain.Strategy) (id int64, err error) {
+	params, err := json.Marshal(strategyParams{Dca: strategy.Dca, Grid: strategy.Grid})
+	if err != nil {
+		return 0, err
+	}
+
+	query := `INSERT INTO strategies (user_id,
+                        type,
+                        exchange,
+                        symbol,
+                        status,
+                        params,
+                        created_at,
+                        updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $7) RETURNING id`
+	err = s.db.QueryRowContext(ctx, query,
+		strategy.UserId,
+		strategy.Type,
+		strategy.Exchange,
+		strategy.Symbol,
+		strategy.Status,
+		string(params),
+		time.Now()).Scan(&id)
+
+	return id, err
+}
+
+func (s *StrategyRepository) SetStatus(ctx context.Context, id int64, status string) error {
+	query := `UPDATE strategies SET status = $1, updated_at = $2 WHERE id = $3`
+	_, err := s.db.ExecContext(ctx, query, status, time.Now(), id)
+
+	return err
+}
+
+func (s *StrategyRepository) AddOrder(ctx context.Context, order *domain.StrategyOrder) error {
+	query := `INSERT INTO strategy_orders (strategy_id,
+                             order_id,
+                             role,
+                             level,
+                             side,
+                             price,
+                             quantity,
+                             status,
+                             created_at,
+                             updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $9)`
+	_, err := s.db.ExecContext(ctx, query,
+		order.StrategyId,
+		order.OrderId,
+		order.Role,
+		order.Level,
+		order.Side,
+		order.Price,
+		order.Quantity,
+		order.Status,
+		time.Now())
+
+	return err
+}
+
+func (s *StrategyRepository) SetOrderStatus(ctx context.Context, strategyId, orderId int64, status string) error {
+	query := `UPDATE strategy_orders SET status = $1, updated_at = $2 WHERE strategy_id = $3 AND order_id = $4`
+	_, err := s.db.ExecContext(ctx, query, status, time.Now(), strategyId, orderId)
+
+	return err
+}
+
+func (s *StrategyRepository) GetStrategy(ctx context.Context, id int64) (*domain.Strategy, error) {
+	query := `SELECT id, user_id, type, exchange, symbol, status, params, created_at, updated_at
+				FROM strategies WHERE id = $1`
+
+	strategy, err := s.scanStrategy(s.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if strategy.Orders, err = s.getOrders(ctx, strategy.Id); err != nil {
+		return nil, err
+	}
+
+	return strategy, nil
+}
+
+// GetStrategyByOrderId returns the active strategy owning the bot order.
+func (s *StrategyRepository) GetStrategyByOrderId(ctx context.Context, orderId int64) (*domain.Strategy, error) {
+	query := `SELECT s.id, s.user_id, s.type, s.exchange, s.symbol, s.status, s.params, s.created_at, s.updated_at
+				FROM strategies s
+				    JOIN strategy_orders so ON so.strategy_id = s.id
+				WHERE so.order_id = $1 AND s.status = $2 LIMIT 1`
+
+	strategy, err := s.scanStrategy(s.db.QueryRowContext(ctx, query, orderId, consts.StrategyStatusActive))
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if strategy.Orders, err = s.getOrders(ctx, strategy.Id); err != nil {
+		return nil, err
+	}
+
+	return strategy, nil
+}
+
+func (s *StrategyRepository) GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error) {
+	query := `SELECT id, user_id, type, exchange, symbol, status, params, created_at, updated_at
+				FROM strategies WHERE user_id = $1 ORDER BY id DESC`
+	rows, err := s.db.QueryContext(ctx, query, userId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.Strategy, 0)
+	for rows.Next() {
+		strategy, err := s.scanStrategy(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, strategy)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, v := range result {
+		if v.Orders, err = s.getOrders(ctx, v.Id); err != nil {
+			return nil, err
+		}
+	}
+
+	return result, nil
+}
+
+// GetSettledOrders returns orders of active strategies which are still active in the strategy
+// but already filled or canceled in the orders table, with the orders table status.
+func (s *StrategyRepository) GetSettledOrders(ctx context.Context) ([]*domain.StrategyOrder, error) {
+	query := `SELECT so.strategy_id, so.order_id, o.status
+				FROM strategy_orders so
+				    JOIN strategies s ON s.id = so.strategy_id
+				    JOIN orders o ON o.id = so.order_id
+				WHERE s.status = $1 AND so.status = $2 AND o.status IN ($3, $4)
+				ORDER BY so.created_at, so.order_id`
+	rows, err := s.db.QueryContext(ctx, query,
+		consts.StrategyStatusActive,
+		consts.OrderStatusActive,
+		consts.OrderStatusFilled,
+		consts.OrderStatusCanceled)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.StrategyOrder, 0)
+	for rows.Next() {
+		order := new(domain.StrategyOrder)
+		if err := rows.Scan(&order.StrategyId, &order.OrderId, &order.Status); err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+
+	return result, rows.Err()
+}
+
+func (s *StrategyRepository) getOrders(ctx context.Context, strategyId int64) ([]*domain.StrategyOrder, error) {
+	query := `SELECT strategy_id, order_id, role, level, side, price::text, quantity::text, status
+				FROM strategy_orders WHERE strategy_id = $1 ORDER BY created_at, order_id`
+	rows, err := s.db.QueryContext(ctx, query, strategyId)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := make([]*domain.StrategyOrder, 0)
+	for rows.Next() {
+		order := new(domain.StrategyOrder)
+		if err := rows.Scan(&order.StrategyId,
+			&order.OrderId,
+			&order.Role,
+			&order.Level,
+			&order.Side,
+			&order.Price,
+			&order.Quantity,
+			&order.Status); err != nil {
+			return nil, err
+		}
+		result = append(result, order)
+	}
+
+	return result, rows.Err()
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func (s *StrategyRepository) scanStrategy(row rowScanner) (*domain.Strategy, error) {
+	var params string
+	strategy := new(domain.Strategy)
+	if err := row.Scan(&strategy.Id,
+		&strategy.UserId,
+		&strategy.Type,
+		&strategy.Exchange,
+		&strategy.Symbol,
+		&strategy.Status,
+		&params,
+		&strategy.CreatedAt,
+		&strategy.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	var decoded strategyParams
+	if err := json.Unmarshal([]byte(params), &decoded); err != nil {
+		return nil, err
+	}
+	strategy.Dca, strategy.Grid = decoded.Dca, decoded.Grid
+
+	return strategy, nil
+}
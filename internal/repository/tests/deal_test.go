@@ -0,0 +1,156 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	dealColumns = []string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price",
+		"exec_order_id", "time_in_force", "tp_sl", "protection", "executed_qty", "avg_price", "created_at", "updated_at",
+		"settings_id", "tp_percent", "sl_percent", "tp_price", "sl_price", "tp_type", "sl_type", "ts", "tp_levels",
+		"sl_breakeven", "total"}
+	dealLegColumns = []string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price",
+		"exec_order_id", "time_in_force", "tp_sl", "ts", "ts_price", "protection", "executed_qty", "avg_price",
+		"created_at", "updated_at"}
+)
+
+func TestDealRepository_GetDeals(t *testing.T) {
+	createdAt := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	updatedAt := createdAt.Add(time.Hour)
+
+	tests := []struct {
+		name    string
+		prepare func(mock sqlmock.Sqlmock)
+		check   func(t *testing.T, deals []*domain.Deal, total int, err error)
+	}{
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(dealColumns).
+					AddRow(2, 7, consts.Binance, "BTCUSDT", consts.OrderStatusFilled, consts.OrderSideBuy,
+						consts.OrderTypeMarket, "1", "100", "900", "", consts.BaseOrderType, consts.ProtectionVirtual,
+						"1", "100", createdAt, nil,
+						5, "", "5", "", "", "", "", "", `[{"percent":"2","price":"","share":"100"}]`, true, 3).
+					AddRow(1, 7, consts.Binance, "ETHUSDT", consts.OrderStatusActive, consts.OrderSideSell,
+						consts.OrderTypeLimit, "2", "2000", "901", "GTC", consts.BaseOrderType, consts.ProtectionVirtual,
+						"", "", createdAt, nil,
+						0, "", "", "", "", "", "", "", "", false, 3)
+				mock.ExpectQuery("^SELECT").
+					WithArgs(int64(7), consts.BaseOrderType, consts.Binance, consts.OrderStatusCanceled, 10, 0).
+					WillReturnRows(rows)
+
+				legs := sqlmock.NewRows(dealLegColumns).
+					AddRow(3, 7, consts.Binance, "BTCUSDT", consts.OrderStatusFilled, consts.OrderSideSell,
+						consts.OrderTypeMarket, "1", "102", "2", "", consts.TpOrderType, "", "", consts.ProtectionVirtual,
+						"1", "102", createdAt, updatedAt).
+					AddRow(4, 7, consts.Binance, "BTCUSDT", consts.OrderStatusCanceled, consts.OrderSideSell,
+						consts.OrderTypeMarket, "1", "95", "2", "", consts.SlOrderType, "", "", consts.ProtectionVirtual,
+						"", "", createdAt, updatedAt)
+				mock.ExpectQuery("^SELECT").WithArgs("2,1", consts.BaseOrderType).WillReturnRows(legs)
+			},
+			check: func(t *testing.T, deals []*domain.Deal, total int, err error) {
+				require.NoError(t, err)
+				assert.Equal(t, 3, total)
+				require.Len(t, deals, 2)
+
+				assert.Equal(t, int64(2), deals[0].BaseOrder.Id)
+				assert.Equal(t, createdAt, deals[0].BaseOrder.CreatedAt)
+				assert.True(t, deals[0].BaseOrder.UpdatedAt.IsZero())
+				require.Len(t, deals[0].TpOrders, 1)
+				assert.Equal(t, int64(3), deals[0].TpOrders[0].Id)
+				assert.Equal(t, updatedAt, deals[0].TpOrders[0].UpdatedAt)
+				require.NotNil(t, deals[0].SlOrder)
+				assert.Equal(t, int64(4), deals[0].SlOrder.Id)
+				assert.Equal(t, &domain.Settings{
+					Id:          5,
+					OrderId:     2,
+					SlPercent:   "5",
+					TpLevels:    []domain.TpLevel{{Percent: "2", Share: "100"}},
+					SlBreakeven: true,
+				}, deals[0].Settings)
+
+				assert.Equal(t, int64(1), deals[1].BaseOrder.Id)
+				assert.Nil(t, deals[1].Settings)
+				assert.Empty(t, deals[1].TpOrders)
+				assert.Nil(t, deals[1].SlOrder)
+			},
+		},
+		{
+			name: "empty",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT").WillReturnRows(sqlmock.NewRows(dealColumns))
+			},
+			check: func(t *testing.T, deals []*domain.Deal, total int, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, 0, total)
+				assert.Empty(t, deals)
+			},
+		},
+		{
+			name: "database_error",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT").WillReturnError(errors.New("database error"))
+			},
+			check: func(t *testing.T, deals []*domain.Deal, total int, err error) {
+				assert.Error(t, err)
+				assert.Nil(t, deals)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			repo := repository.NewDealRepository(db)
+			tt.prepare(mock)
+
+			deals, total, err := repo.GetDeals(context.Background(), 7, consts.Binance, 10, 0)
+
+			tt.check(t, deals, total, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDealRepository_GetDeal(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	repo := repository.NewDealRepository(db)
+
+	mock.ExpectQuery("^SELECT").
+		WithArgs(int64(7), consts.BaseOrderType, int64(3)).
+		WillReturnRows(sqlmock.NewRows(dealColumns))
+	deal, err := repo.GetDeal(context.Background(), 7, 3)
+	assert.NoError(t, err)
+	assert.Nil(t, deal)
+
+	mock.ExpectQuery("^SELECT").
+		WithArgs(int64(7), consts.BaseOrderType, int64(2)).
+		WillReturnRows(sqlmock.NewRows(dealColumns).
+			AddRow(2, 7, consts.Binance, "BTCUSDT", consts.OrderStatusFilled, consts.OrderSideBuy,
+				consts.OrderTypeMarket, "1", "100", "900", "", consts.BaseOrderType, consts.ProtectionVirtual,
+				"", "", nil, nil, 0, "", "", "", "", "", "", "", "", false, 1))
+	mock.ExpectQuery("^SELECT").WithArgs("2", consts.BaseOrderType).
+		WillReturnRows(sqlmock.NewRows(dealLegColumns))
+	deal, err = repo.GetDeal(context.Background(), 7, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), deal.BaseOrder.Id)
+	assert.Empty(t, deal.TpOrders)
+
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
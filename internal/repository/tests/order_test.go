@@ -6,6 +6,7 @@ import (
 	"errors"
 	"github.com/DATA-DOG/go-sqlmock"
 	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
 	"github.com/linnoxlewis/trade-bot/internal/repository"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,7 +22,7 @@ type OrderRepo interface {
 	GetTpSlOrderByBaseOrder(ctx context.Context, id int64, symbol, exchange, tpsl string) (*domain.Order, error)
 	GetTpSlOrdersByBaseOrder(ctx context.Context, id int64) ([]*domain.Order, error)
 	GetOpposingTpSlOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
-	GetActiveSymbols(ctx context.Context) (domain.SymbolList, error)
+	GetActiveSymbols(ctx context.Context) ([]domain.Symbols, error)
 	GetLimitOrders(ctx context.Context, exchange string) ([]*domain.Order, error)
 
 	Atomic(ctx context.Context, fn func(ctx context.Context, orderRepo OrderRepo) error) (err error)
@@ -30,13 +31,13 @@ type OrderRepo interface {
 func TestOrderRepository_CreateOrder(t *testing.T) {
 	tests := []struct {
 		name    string
-		order   domain.Order
+		order   *domain.Order
 		prepare func(mock sqlmock.Sqlmock)
 		check   func(t *testing.T, id int64, err error)
 	}{
 		{
 			name: "success",
-			order: domain.Order{
+			order: &domain.Order{
 				UserId:      123,
 				Exchange:    "exchangeA",
 				Symbol:      "BTC/USD",
@@ -53,7 +54,7 @@ func TestOrderRepository_CreateOrder(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				mock.ExpectQuery("^INSERT INTO orders").
 					WithArgs(
-						"user123", "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", "exec123", "GTC", "none", sqlmock.AnyArg(),
+						int64(123), "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", int64(123), "GTC", "none", "", "", "", sqlmock.AnyArg(),
 					).WillReturnRows(rows) // Вернуть строку с id
 			},
 			check: func(t *testing.T, id int64, err error) {
@@ -63,7 +64,7 @@ func TestOrderRepository_CreateOrder(t *testing.T) {
 		},
 		{
 			name: "database_error",
-			order: domain.Order{
+			order: &domain.Order{
 				UserId:      123,
 				Exchange:    "exchangeA",
 				Symbol:      "BTC/USD",
@@ -96,7 +97,7 @@ func TestOrderRepository_CreateOrder(t *testing.T) {
 			repo := repository.NewOrderRepository(db)
 			tt.prepare(mock)
 
-			id, err := repo.CreateOrder(context.Background(), &tt.order)
+			id, err := repo.CreateOrder(context.Background(), tt.order)
 
 			tt.check(t, id, err)
 			assert.NoError(t, mock.ExpectationsWereMet())
@@ -115,25 +116,32 @@ func TestOrderRepository_GetActiveTpSlOrders(t *testing.T) {
 			name:     "success",
 			exchange: "exchangeA",
 			prepare: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl"}).
-					AddRow(1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", "exec123", "GTC", "user123", "none").
-					AddRow(2, "exchangeA", "ETH/USD", "active", "sell", "limit", "2", "2500", "exec456", "GTC", "user456", "none")
-				mock.ExpectQuery("^SELECT id, exchange, symbol").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl", "ts", "ts_price"}).
+					AddRow(1, "exchangeA", "BTCUSDT", "active", "sell", "MARKET", "1", "45000", 123, "GTC", 7, "sl", "2", "50000").
+					AddRow(2, "exchangeA", "ETHUSDT", "active", "sell", "MARKET", "2", "2700", 456, "GTC", 8, "tp", "", "")
+				mock.ExpectQuery("^SELECT id,\\s+exchange,\\s+symbol").
+					WithArgs("exchangeA", consts.OrderStatusActive, consts.BaseOrderType, consts.ProtectionVirtual).
+					WillReturnRows(rows)
 			},
 			check: func(t *testing.T, orders []*domain.Order, err error) {
-				assert.NoError(t, err)
-				assert.Len(t, orders, 2)
+				require.NoError(t, err)
+				require.Len(t, orders, 2)
 				assert.Equal(t, int64(1), orders[0].Id)
 				assert.Equal(t, "exchangeA", orders[0].Exchange)
-				// проверить другие поля заказа
+				assert.Equal(t, int64(123), orders[0].ExecOrderId)
+				assert.Equal(t, int64(7), orders[0].UserId)
+				assert.Equal(t, "sl", orders[0].TpSl)
+				assert.Equal(t, "2", orders[0].Ts)
+				assert.Equal(t, "50000", orders[0].TsPrice)
+				assert.Equal(t, "", orders[1].Ts)
 			},
 		},
 		{
 			name:     "no_orders",
 			exchange: "exchangeA",
 			prepare: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl"})
-				mock.ExpectQuery("^SELECT id, exchange, symbol").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl", "ts", "ts_price"})
+				mock.ExpectQuery("^SELECT id,\\s+exchange,\\s+symbol").WillReturnRows(rows)
 			},
 			check: func(t *testing.T, orders []*domain.Order, err error) {
 				assert.NoError(t, err)
@@ -251,8 +259,9 @@ func TestOrderRepository_CreateOrderWithSettings(t *testing.T) {
 				rows := sqlmock.NewRows([]string{"id"}).AddRow(1)
 				mock.ExpectQuery("^INSERT INTO orders").WillReturnRows(rows)
 
-				rowsSettings := sqlmock.NewRows([]string{"id"}).AddRow(1)
-				mock.ExpectQuery("^INSERT INTO order_settings").WillReturnRows(rowsSettings)
+				mock.ExpectExec("^INSERT INTO order_settings").
+					WithArgs(int64(1), "10", "5", "51000", "49000", "5", "price", "price", nil, false, sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
 			},
 			check: func(t *testing.T, id int64, err error) {
 				assert.NoError(t, err)
@@ -378,6 +387,61 @@ func TestOrderRepository_UpdateTpSl(t *testing.T) {
 	}
 }
 
+func TestOrderRepository_UpdateTrailingStop(t *testing.T) {
+	tests := []struct {
+		name    string
+		id      int64
+		price   string
+		tsPrice string
+		prepare func(mock sqlmock.Sqlmock)
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name:    "success",
+			id:      1,
+			price:   "107.8",
+			tsPrice: "110",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("^UPDATE orders SET").
+					WithArgs("107.8", "110", sqlmock.AnyArg(), 1).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+			},
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:    "database_error",
+			id:      1,
+			price:   "107.8",
+			tsPrice: "110",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectExec("^UPDATE orders SET").WillReturnError(errors.New("database error"))
+			},
+			check: func(t *testing.T, err error) {
+				assert.Error(t, err)
+				assert.Equal(t, "database error", err.Error())
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			repo := repository.NewOrderRepository(db)
+			tt.prepare(mock)
+
+			err = repo.UpdateTrailingStop(context.Background(), tt.id, tt.price, tt.tsPrice)
+
+			tt.check(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
 func TestOrderRepository_ExecuteOrder(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -488,8 +552,8 @@ func TestOrderRepository_GetOrder(t *testing.T) {
 			symbol:   "BTC/USD",
 			exchange: "exchangeA",
 			queryRow: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "tp_sl"}).
-					AddRow(1, 1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", "none")
+				rows := sqlmock.NewRows([]string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "tp_sl", "protection", "oco_list_id", "oco_order_id", "executed_qty", "avg_price"}).
+					AddRow(1, 1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", "none", "", 0, 0, "", "")
 				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
 			},
 			want: &domain.Order{
@@ -568,8 +632,8 @@ func TestOrderRepository_GetTpSlOrderByBaseOrder(t *testing.T) {
 			exchange: "exchangeA",
 			tpsl:     "tpsl",
 			queryRow: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "tp_sl"}).
-					AddRow(1, 1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", "tpsl")
+				rows := sqlmock.NewRows([]string{"id", "user_id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "tp_sl", "protection", "oco_list_id", "oco_order_id"}).
+					AddRow(1, 1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", "tpsl", "", 0, 0)
 				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
 			},
 			want: &domain.Order{
@@ -730,20 +794,25 @@ func TestOrderRepository_GetActiveSymbols(t *testing.T) {
 	tests := []struct {
 		name        string
 		queryRows   func(mock sqlmock.Sqlmock)
-		wantSymbols domain.SymbolList
+		wantSymbols []domain.Symbols
 		wantErr     error
 	}{
 		{
 			name: "success",
 			queryRows: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"symbol"}).
-					AddRow("BTC/USD").
-					AddRow("ETH/USD").
-					AddRow("LTC/USD")
-				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
+				rows := sqlmock.NewRows([]string{"exchange", "symbol"}).
+					AddRow(consts.Binance, "BTCUSDT").
+					AddRow(consts.Binance, "ETHUSDT").
+					AddRow(consts.Okx, "BTC-USDT")
+				mock.ExpectQuery("^SELECT DISTINCT exchange, symbol FROM orders").
+					WithArgs(consts.OrderStatusActive).WillReturnRows(rows)
+			},
+			wantSymbols: []domain.Symbols{
+				{Symbol: "BTCUSDT", Exchange: consts.Binance},
+				{Symbol: "ETHUSDT", Exchange: consts.Binance},
+				{Symbol: "BTC-USDT", Exchange: consts.Okx},
 			},
-			wantSymbols: domain.SymbolList{"BTC/USD", "ETH/USD", "LTC/USD"},
-			wantErr:     nil,
+			wantErr: nil,
 		},
 		{
 			name: "no_rows",
@@ -793,9 +862,9 @@ func TestOrderRepository_GetTpSlOrdersByBaseOrder(t *testing.T) {
 			name: "success",
 			id:   1,
 			queryRows: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl"}).
-					AddRow(1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", 1, "tpsl").
-					AddRow(2, "exchangeB", "ETH/USD", "active", "sell", "limit", "2", "60000", 124, "GTC", 2, "sl")
+				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl", "ts", "ts_price", "protection", "oco_list_id", "oco_order_id"}).
+					AddRow(1, "exchangeA", "BTC/USD", "active", "buy", "market", "1", "50000", 123, "GTC", 1, "tpsl", "", "", "", 0, 0).
+					AddRow(2, "exchangeB", "ETH/USD", "active", "sell", "limit", "2", "60000", 124, "GTC", 2, "sl", "", "", "", 0, 0)
 				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
 			},
 			wantOrders: []*domain.Order{
@@ -880,9 +949,9 @@ func TestOrderRepository_GetLimitOrders(t *testing.T) {
 			name:     "success",
 			exchange: "exchangeA",
 			queryRows: func(mock sqlmock.Sqlmock) {
-				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl"}).
-					AddRow(1, "exchangeA", "BTC/USD", "active", "buy", "limit", "1", "50000", 123, "GTC", 1, "tpsl").
-					AddRow(2, "exchangeA", "ETH/USD", "active", "sell", "limit", "2", "60000", 124, "GTC", 2, "tpsl")
+				rows := sqlmock.NewRows([]string{"id", "exchange", "symbol", "status", "side", "order_type", "quantity", "price", "exec_order_id", "time_in_force", "user_id", "tp_sl", "protection", "oco_list_id", "oco_order_id", "executed_qty", "avg_price"}).
+					AddRow(1, "exchangeA", "BTC/USD", "active", "buy", "limit", "1", "50000", 123, "GTC", 1, "tpsl", "", 0, 0, "", "").
+					AddRow(2, "exchangeA", "ETH/USD", "active", "sell", "limit", "2", "60000", 124, "GTC", 2, "tpsl", "", 0, 0, "", "")
 				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
 			},
 			wantOrders: []*domain.Order{
@@ -954,3 +1023,87 @@ func TestOrderRepository_GetLimitOrders(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderRepository_GetSettings(t *testing.T) {
+	columns := []string{"id", "order_id", "tp_percent", "sl_percent", "tp_price", "sl_price", "tp_type", "sl_type", "ts",
+		"tp_levels", "sl_breakeven"}
+	tests := []struct {
+		name     string
+		orderID  int64
+		queryRow func(mock sqlmock.Sqlmock)
+		want     *domain.Settings
+		wantErr  bool
+	}{
+		{
+			name:    "success_with_tp_levels",
+			orderID: 1,
+			queryRow: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(10, 1, "", "5", "", "", "", "", "",
+						`[{"percent":"2","price":"","share":"30"},{"percent":"","price":"108","share":"70"}]`, true)
+				mock.ExpectQuery("^SELECT").WithArgs(int64(1)).WillReturnRows(rows)
+			},
+			want: &domain.Settings{
+				Id:        10,
+				OrderId:   1,
+				SlPercent: "5",
+				TpLevels: []domain.TpLevel{
+					{Percent: "2", Share: "30"},
+					{Price: "108", Share: "70"},
+				},
+				SlBreakeven: true,
+			},
+		},
+		{
+			name:    "success_without_tp_levels",
+			orderID: 2,
+			queryRow: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(11, 2, "10", "5", "", "", "", "", "", "", false)
+				mock.ExpectQuery("^SELECT").WithArgs(int64(2)).WillReturnRows(rows)
+			},
+			want: &domain.Settings{
+				Id:        11,
+				OrderId:   2,
+				TpPercent: "10",
+				SlPercent: "5",
+			},
+		},
+		{
+			name:    "not_found",
+			orderID: 3,
+			queryRow: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT").WillReturnError(sql.ErrNoRows)
+			},
+			want: nil,
+		},
+		{
+			name:    "invalid_tp_levels",
+			orderID: 4,
+			queryRow: func(mock sqlmock.Sqlmock) {
+				rows := sqlmock.NewRows(columns).
+					AddRow(12, 4, "", "5", "", "", "", "", "", "{", false)
+				mock.ExpectQuery("^SELECT").WillReturnRows(rows)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			repo := repository.NewOrderRepository(db)
+			tt.queryRow(mock)
+
+			got, err := repo.GetSettings(context.Background(), tt.orderID)
+
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantErr, err != nil)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
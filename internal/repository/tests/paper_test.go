@@ -0,0 +1,160 @@
+package tests
+
+import (
+	"context"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPaperRepository_PlaceOrder(t *testing.T) {
+	order := func(status string) *domain.PaperOrder {
+		return &domain.PaperOrder{
+			UserId:        7,
+			Symbol:        "BTCUSDT",
+			Side:          consts.OrderSideBuy,
+			OrderType:     consts.OrderTypeLimit,
+			Status:        status,
+			Quantity:      "0.1",
+			Price:         "30000",
+			ReserveAsset:  "USDT",
+			ReserveAmount: "3000",
+			ReceiveAsset:  "BTC",
+			ReceiveAmount: "0.1",
+		}
+	}
+
+	tests := []struct {
+		name    string
+		order   *domain.PaperOrder
+		prepare func(mock sqlmock.Sqlmock)
+		check   func(t *testing.T, id int64, err error)
+	}{
+		{
+			name:  "limit order reserves balance",
+			order: order(consts.OrderStatusActive),
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("^UPDATE paper_balances SET free = free - \\$1").
+					WithArgs("3000", sqlmock.AnyArg(), int64(7), "USDT").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("^INSERT INTO paper_orders").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectCommit()
+			},
+			check: func(t *testing.T, id int64, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), id)
+			},
+		},
+		{
+			name:  "market order settles immediately",
+			order: order(consts.OrderStatusFilled),
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("^UPDATE paper_balances SET free = free - \\$1").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectQuery("^INSERT INTO paper_orders").
+					WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+				mock.ExpectQuery("^UPDATE paper_orders SET status").
+					WithArgs(consts.OrderStatusFilled, sqlmock.AnyArg(), int64(5), consts.OrderStatusActive).
+					WillReturnRows(sqlmock.NewRows([]string{"user_id", "reserve_asset", "reserve_amount",
+						"receive_asset", "receive_amount"}).AddRow(7, "USDT", "3000", "BTC", "0.1"))
+				mock.ExpectExec("^UPDATE paper_balances SET locked = locked - \\$1").
+					WithArgs("3000", sqlmock.AnyArg(), int64(7), "USDT").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectExec("^INSERT INTO paper_balances").
+					WithArgs(int64(7), "BTC", "0.1", sqlmock.AnyArg()).
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			check: func(t *testing.T, id int64, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, int64(5), id)
+			},
+		},
+		{
+			name:  "insufficient balance",
+			order: order(consts.OrderStatusActive),
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectExec("^UPDATE paper_balances SET free = free - \\$1").
+					WillReturnResult(sqlmock.NewResult(0, 0))
+				mock.ExpectRollback()
+			},
+			check: func(t *testing.T, id int64, err error) {
+				assert.ErrorIs(t, err, domain.ErrPaperInsufficientBalance)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.prepare(mock)
+			id, err := repository.NewPaperRepository(db).PlaceOrder(context.Background(), tt.order)
+
+			tt.check(t, id, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPaperRepository_CancelOrder(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(mock sqlmock.Sqlmock)
+		check   func(t *testing.T, err error)
+	}{
+		{
+			name: "success",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^UPDATE paper_orders SET status").
+					WithArgs(consts.OrderStatusCanceled, sqlmock.AnyArg(), int64(5), int64(7), consts.OrderStatusActive).
+					WillReturnRows(sqlmock.NewRows([]string{"reserve_asset", "reserve_amount"}).AddRow("USDT", "3000"))
+				mock.ExpectExec("^UPDATE paper_balances SET free = free \\+ \\$1").
+					WithArgs("3000", sqlmock.AnyArg(), int64(7), "USDT").
+					WillReturnResult(sqlmock.NewResult(0, 1))
+				mock.ExpectCommit()
+			},
+			check: func(t *testing.T, err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "not active",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				mock.ExpectQuery("^UPDATE paper_orders SET status").
+					WillReturnRows(sqlmock.NewRows([]string{"reserve_asset", "reserve_amount"}))
+				mock.ExpectRollback()
+			},
+			check: func(t *testing.T, err error) {
+				assert.ErrorIs(t, err, domain.ErrPaperOrderNotFound)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.prepare(mock)
+			err = repository.NewPaperRepository(db).CancelOrder(context.Background(), 7, 5)
+
+			tt.check(t, err)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,129 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPriceAlertRepository_CreateAlert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("^INSERT INTO price_alerts").
+		WithArgs(int64(7), consts.Binance, "BTCUSDT", consts.AlertConditionAbove, "70000", "", true, true,
+			consts.AlertStatusActive, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(5))
+
+	id, err := repository.NewPriceAlertRepository(db).CreateAlert(context.Background(), &domain.PriceAlert{
+		UserId:    7,
+		Exchange:  consts.Binance,
+		Symbol:    "BTCUSDT",
+		Condition: consts.AlertConditionAbove,
+		Value:     "70000",
+		Recurring: true,
+		Armed:     true,
+		Status:    consts.AlertStatusActive,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(5), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPriceAlertRepository_UpdateAlert(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	triggeredAt := time.Date(2023, 12, 20, 12, 0, 0, 0, time.UTC)
+	mock.ExpectExec("^UPDATE price_alerts(.+)WHERE id = \\$6 AND status = \\$7").
+		WithArgs("100", false, consts.AlertStatusTriggered, &triggeredAt, sqlmock.AnyArg(), int64(5),
+			consts.AlertStatusActive).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.NewPriceAlertRepository(db).UpdateAlert(context.Background(), &domain.PriceAlert{
+		Id:          5,
+		BasePrice:   "100",
+		Status:      consts.AlertStatusTriggered,
+		TriggeredAt: &triggeredAt,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestPriceAlertRepository_GetAlert(t *testing.T) {
+	createdAt := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	columns := []string{"id", "user_id", "exchange", "symbol", "condition", "value", "base_price", "recurring",
+		"armed", "status", "triggered_at", "created_at", "updated_at"}
+
+	tests := []struct {
+		name     string
+		prepare  func(mock sqlmock.Sqlmock)
+		expected *domain.PriceAlert
+	}{
+		{
+			name: "fired recurring alert",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT id").WithArgs(int64(5)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(5, 7, consts.Binance, "BTCUSDT",
+						consts.AlertConditionMove, "5", "95", true, true, consts.AlertStatusActive, createdAt,
+						createdAt, createdAt))
+			},
+			expected: &domain.PriceAlert{Id: 5, UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Condition: consts.AlertConditionMove, Value: "5", BasePrice: "95", Recurring: true, Armed: true,
+				Status: consts.AlertStatusActive, TriggeredAt: &createdAt, CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+		{
+			name: "no alert",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT id").WithArgs(int64(5)).WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.prepare(mock)
+			alert, err := repository.NewPriceAlertRepository(db).GetAlert(context.Background(), 5)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, alert)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestPriceAlertRepository_GetAlertSymbols(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT DISTINCT exchange, symbol FROM price_alerts").WithArgs(consts.AlertStatusActive).
+		WillReturnRows(sqlmock.NewRows([]string{"exchange", "symbol"}).
+			AddRow(consts.Binance, "BTCUSDT").
+			AddRow(consts.Okx, "ETH-USDT"))
+
+	symbols, err := repository.NewPriceAlertRepository(db).GetAlertSymbols(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []domain.Symbols{
+		{Exchange: consts.Binance, Symbol: "BTCUSDT"},
+		{Exchange: consts.Okx, Symbol: "ETH-USDT"},
+	}, symbols)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -0,0 +1,118 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRiskLimitsRepository_GetRiskLimits(t *testing.T) {
+	pausedUntil := time.Date(2023, 12, 11, 0, 0, 0, 0, time.UTC)
+	columns := []string{"user_id", "max_order_notional", "max_open_deals", "max_daily_loss", "allowed_symbols",
+		"paused_until"}
+
+	tests := []struct {
+		name     string
+		prepare  func(mock sqlmock.Sqlmock)
+		expected *domain.RiskLimits
+	}{
+		{
+			name: "limits with pause",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT user_id").WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "1000", 3, "50", "BTCUSDT,ETHUSDT", pausedUntil))
+			},
+			expected: &domain.RiskLimits{UserId: 7, MaxOrderNotional: "1000", MaxOpenDeals: 3, MaxDailyLoss: "50",
+				AllowedSymbols: []string{"BTCUSDT", "ETHUSDT"}, PausedUntil: &pausedUntil},
+		},
+		{
+			name: "limits without symbols",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT user_id").WithArgs(int64(7)).
+					WillReturnRows(sqlmock.NewRows(columns).AddRow(7, "", 0, "50", "", nil))
+			},
+			expected: &domain.RiskLimits{UserId: 7, MaxDailyLoss: "50"},
+		},
+		{
+			name: "no limits",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT user_id").WithArgs(int64(7)).WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.prepare(mock)
+			limits, err := repository.NewRiskLimitsRepository(db).GetRiskLimits(context.Background(), 7)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, limits)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestRiskLimitsRepository_SaveRiskLimits(t *testing.T) {
+	keepsPause := sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		if strings.Contains(actualSQL, "paused_until") {
+			return errors.New("save must not touch paused_until")
+		}
+
+		return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+	})
+	db, mock, err := sqlmock.New(sqlmock.QueryMatcherOption(keepsPause))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("^INSERT INTO user_risk_limits").
+		WithArgs(int64(7), "1000", 3, "", "BTCUSDT,ETHUSDT", sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.NewRiskLimitsRepository(db).SaveRiskLimits(context.Background(), &domain.RiskLimits{
+		UserId:           7,
+		MaxOrderNotional: "1000",
+		MaxOpenDeals:     3,
+		AllowedSymbols:   []string{"BTCUSDT", "ETHUSDT"},
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestRiskLimitsRepository_GetDailyLossLimits(t *testing.T) {
+	now := time.Date(2023, 12, 10, 12, 0, 0, 0, time.UTC)
+	columns := []string{"user_id", "max_order_notional", "max_open_deals", "max_daily_loss", "allowed_symbols",
+		"paused_until"}
+
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT user_id(.+)WHERE max_daily_loss IS NOT NULL").WithArgs(now).
+		WillReturnRows(sqlmock.NewRows(columns).
+			AddRow(7, "", 0, "50", "", nil).
+			AddRow(8, "1000", 2, "20", "BTCUSDT", nil))
+
+	limits, err := repository.NewRiskLimitsRepository(db).GetDailyLossLimits(context.Background(), now)
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.RiskLimits{
+		{UserId: 7, MaxDailyLoss: "50"},
+		{UserId: 8, MaxOrderNotional: "1000", MaxOpenDeals: 2, MaxDailyLoss: "20", AllowedSymbols: []string{"BTCUSDT"}},
+	}, limits)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
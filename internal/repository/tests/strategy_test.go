@@ -0,0 +1,150 @@
+package tests
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/repository"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	strategyColumns = []string{"id", "user_id", "type", "exchange", "symbol", "status", "params", "created_at",
+		"updated_at"}
+	strategyOrderColumns = []string{"strategy_id", "order_id", "role", "level", "side", "price", "quantity", "status"}
+)
+
+func TestStrategyRepository_CreateStrategy(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("^INSERT INTO strategies").
+		WithArgs(int64(7), consts.StrategyTypeGrid, consts.Binance, "BTCUSDT", consts.StrategyStatusActive,
+			`{"grid":{"lower":"20","upper":"30","levels":5,"quantity":"1"}}`, sqlmock.AnyArg()).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(3))
+
+	id, err := repository.NewStrategyRepository(db).CreateStrategy(context.Background(), &domain.Strategy{
+		UserId:   7,
+		Type:     consts.StrategyTypeGrid,
+		Exchange: consts.Binance,
+		Symbol:   "BTCUSDT",
+		Status:   consts.StrategyStatusActive,
+		Grid:     &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "1"},
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), id)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStrategyRepository_GetStrategyByOrderId(t *testing.T) {
+	createdAt := time.Date(2023, 12, 15, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		prepare  func(mock sqlmock.Sqlmock)
+		expected *domain.Strategy
+	}{
+		{
+			name: "active dca strategy",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT s.id").WithArgs(int64(11), consts.StrategyStatusActive).
+					WillReturnRows(sqlmock.NewRows(strategyColumns).AddRow(3, 7, consts.StrategyTypeDca, consts.Binance,
+						"BTCUSDT", consts.StrategyStatusActive,
+						`{"dca":{"side":"BUY","baseQty":"1","safetyOrders":1,"deviation":"2","stepScale":"","volumeScale":"","tpPercent":"1"}}`,
+						createdAt, createdAt))
+				mock.ExpectQuery("^SELECT strategy_id").WithArgs(int64(3)).
+					WillReturnRows(sqlmock.NewRows(strategyOrderColumns).
+						AddRow(3, 10, consts.StrategyOrderBase, 0, consts.OrderSideBuy, "100", "1", consts.OrderStatusFilled).
+						AddRow(3, 11, consts.StrategyOrderSafety, 1, consts.OrderSideBuy, "98", "1", consts.OrderStatusActive))
+			},
+			expected: &domain.Strategy{Id: 3, UserId: 7, Type: consts.StrategyTypeDca, Exchange: consts.Binance,
+				Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+				Dca: &domain.DcaParams{Side: consts.OrderSideBuy, BaseQty: "1", SafetyOrders: 1, Deviation: "2",
+					TpPercent: "1"},
+				Orders: []*domain.StrategyOrder{
+					{StrategyId: 3, OrderId: 10, Role: consts.StrategyOrderBase, Side: consts.OrderSideBuy, Price: "100",
+						Quantity: "1", Status: consts.OrderStatusFilled},
+					{StrategyId: 3, OrderId: 11, Role: consts.StrategyOrderSafety, Level: 1, Side: consts.OrderSideBuy,
+						Price: "98", Quantity: "1", Status: consts.OrderStatusActive},
+				},
+				CreatedAt: createdAt, UpdatedAt: createdAt},
+		},
+		{
+			name: "order out of strategies",
+			prepare: func(mock sqlmock.Sqlmock) {
+				mock.ExpectQuery("^SELECT s.id").WithArgs(int64(11), consts.StrategyStatusActive).
+					WillReturnError(sql.ErrNoRows)
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+
+			tt.prepare(mock)
+			strategy, err := repository.NewStrategyRepository(db).GetStrategyByOrderId(context.Background(), 11)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.expected, strategy)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestStrategyRepository_AddOrder(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectExec("^INSERT INTO strategy_orders").
+		WithArgs(int64(3), int64(12), consts.StrategyOrderGrid, 2, consts.OrderSideSell, "24", "1",
+			consts.OrderStatusActive, sqlmock.AnyArg()).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err = repository.NewStrategyRepository(db).AddOrder(context.Background(), &domain.StrategyOrder{
+		StrategyId: 3,
+		OrderId:    12,
+		Role:       consts.StrategyOrderGrid,
+		Level:      2,
+		Side:       consts.OrderSideSell,
+		Price:      "24",
+		Quantity:   "1",
+		Status:     consts.OrderStatusActive,
+	})
+
+	assert.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestStrategyRepository_GetSettledOrders(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("^SELECT so.strategy_id, so.order_id, o.status").
+		WithArgs(consts.StrategyStatusActive, consts.OrderStatusActive, consts.OrderStatusFilled,
+			consts.OrderStatusCanceled).
+		WillReturnRows(sqlmock.NewRows([]string{"strategy_id", "order_id", "status"}).
+			AddRow(3, 12, consts.OrderStatusFilled).
+			AddRow(3, 13, consts.OrderStatusCanceled))
+
+	orders, err := repository.NewStrategyRepository(db).GetSettledOrders(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, []*domain.StrategyOrder{
+		{StrategyId: 3, OrderId: 12, Status: consts.OrderStatusFilled},
+		{StrategyId: 3, OrderId: 13, Status: consts.OrderStatusCanceled},
+	}, orders)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"github.com/linnoxlewis/trade-bot/config"
 	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
 	"github.com/linnoxlewis/trade-bot/internal/errors"
 	"github.com/linnoxlewis/trade-bot/internal/pkg/exchanger"
 	"github.com/linnoxlewis/trade-bot/pkg/i18n"
@@ -14,6 +15,7 @@ type AccountService struct {
 	cfg        *config.Config
 	apiKeyRepo ApiKeyRepo
 	exchanger  exchanger.Exchanger
+	prices     PriceSource
 	i18n       *i18n.I18n
 	logger     *log.Logger
 }
@@ -21,17 +23,22 @@ type AccountService struct {
 func NewAccountService(cfg *config.Config,
 	apiKeyRepo ApiKeyRepo,
 	exchanger exchanger.Exchanger,
+	prices PriceSource,
 	i18n *i18n.I18n,
 	logger *log.Logger) *AccountService {
 	return &AccountService{
 		cfg:        cfg,
 		apiKeyRepo: apiKeyRepo,
 		exchanger:  exchanger,
+		prices:     prices,
 		i18n:       i18n,
 		logger:     logger}
 }
 
 func (a *AccountService) GetBalance(ctx context.Context, userId int64, exchange string) (balance domain.Balance, err error) {
+	if exchange == "" {
+		return nil, errors.BadRequestError(a.i18n.T(errEmptyExchange, nil, "ru"))
+	}
 	keys, err := a.getApiKeys(ctx, userId, exchange)
 	if err != nil {
 		return nil, err
@@ -44,6 +51,86 @@ func (a *AccountService) GetBalance(ctx context.Context, userId int64, exchange
 	return result, nil
 }
 
+// GetPortfolio values balances in USDT. An empty exchange aggregates every exchange the user has keys for.
+func (a *AccountService) GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error) {
+	var keysList []*domain.ApiKeys
+	if exchange != "" {
+		keys, err := a.getApiKeys(ctx, userId, exchange)
+		if err != nil {
+			return nil, err
+		}
+		keysList = append(keysList, keys)
+	} else {
+		list, err := a.apiKeyRepo.GetApiKeysByUserId(ctx, userId)
+		if err != nil {
+			a.logger.ErrorLog.Println("err get api keys: ", err)
+
+			return nil, errors.InternalServerError(err)
+		}
+		if len(list) == 0 {
+			return nil, errors.BadRequestError(a.i18n.T(errApiKeysNotFound, nil, "ru"))
+		}
+		for _, v := range list {
+			keysList = append(keysList, a.decodeApiKeys(v))
+		}
+	}
+
+	portfolio := domain.NewPortfolio(a.cfg.GetBalanceDustValue())
+	for _, keys := range keysList {
+		balance, err := a.exchanger.Balance(keys, keys.Exchange)
+		if err != nil && exchange != "" {
+			return nil, errors.BadRequestError(err.Error())
+		}
+		if err != nil {
+			a.logger.ErrorLog.Println("err get balance "+keys.Exchange+": ", err)
+
+			continue
+		}
+
+		portfolio.AddExchange(keys.Exchange)
+		for _, v := range balance {
+			if v.IsEmpty() {
+				continue
+			}
+			portfolio.Add(keys.Exchange, v, a.getUsdtPrice(ctx, keys, v.Symbol))
+		}
+	}
+
+	return portfolio.Build(), nil
+}
+
+func (a *AccountService) getUsdtPrice(ctx context.Context, keys *domain.ApiKeys, asset string) string {
+	if domain.IsUsdStablecoin(asset) {
+		return "1"
+	}
+
+	symbol := domain.UsdtSymbol(asset, "")
+	if price, err := a.prices.GetPrice(ctx, keys.Exchange, symbol); err == nil && price != "" {
+		return price
+	}
+	price, err := a.exchanger.GetPrice(keys, keys.Exchange, symbol)
+	if err != nil {
+		a.logger.ErrorLog.Println("err get price "+keys.Exchange+" "+symbol+": ", err)
+
+		return ""
+	}
+
+	return price
+}
+
+func (a *AccountService) GetSymbols(ctx context.Context, userId int64, exchange string) ([]string, error) {
+	keys, err := a.getApiKeys(ctx, userId, exchange)
+	if err != nil {
+		return nil, err
+	}
+	result, err := a.exchanger.GetSymbols(keys, exchange)
+	if err != nil {
+		return nil, errors.BadRequestError(err.Error())
+	}
+
+	return result, nil
+}
+
 func (a *AccountService) getApiKeys(ctx context.Context, userId int64, exchange string) (*domain.ApiKeys, error) {
 	keys, err := a.apiKeyRepo.GetApiKeysByUserIdAndExchange(ctx, userId, exchange)
 	if err != nil {
@@ -51,9 +138,17 @@ func (a *AccountService) getApiKeys(ctx context.Context, userId int64, exchange
 
 		return nil, errors.InternalServerError(err)
 	}
+	if keys == nil && exchange == consts.Paper {
+		return domain.NewApiKeys(userId, exchange, "", "", ""), nil
+	}
 	if keys == nil {
 		return nil, errors.BadRequestError(a.i18n.T(errApiKeysNotFound, nil, "ru"))
 	}
+
+	return a.decodeApiKeys(keys), nil
+}
+
+func (a *AccountService) decodeApiKeys(keys *domain.ApiKeys) *domain.ApiKeys {
 	if keys.PrivKey != "" {
 		keys.DecodePrivKey(a.cfg.GetApiSecret())
 	}
@@ -61,5 +156,5 @@ func (a *AccountService) getApiKeys(ctx context.Context, userId int64, exchange
 		keys.DecodePassKey(a.cfg.GetApiSecret())
 	}
 
-	return keys, nil
+	return keys
 }
@@ -0,0 +1,236 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+const (
+	errAlertNotFound = "alertNotFound"
+	errAlertNoPrice  = "alertNoPrice"
+)
+
+type PriceAlertRepo interface {
+	CreateAlert(ctx context.Context, alert *domain.PriceAlert) (int64, error)
+	UpdateAlert(ctx context.Context, alert *domain.PriceAlert) error
+	SetStatus(ctx context.Context, id int64, status string) error
+	GetAlert(ctx context.Context, id int64) (*domain.PriceAlert, error)
+	GetActiveAlerts(ctx context.Context) ([]*domain.PriceAlert, error)
+	GetUserAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error)
+	GetAlertSymbols(ctx context.Context) ([]domain.Symbols, error)
+}
+
+// PriceFeed makes sure the price of a symbol is streamed into the cache while something needs it.
+type PriceFeed interface {
+	Hold(exchange, symbol string) (func(), error)
+	Release(exchange, symbol string)
+}
+
+type AlertNotifier interface {
+	NotifyPriceAlert(ctx context.Context, alert *domain.PriceAlert, price, change string)
+}
+
+// AlertService keeps active alerts in memory for the alert tickers and stores them in the database.
+type AlertService struct {
+	cfg       *config.Config
+	alertRepo PriceAlertRepo
+	prices    PriceSource
+	feed      PriceFeed
+	notifier  AlertNotifier
+	i18n      *i18n.I18n
+	logger    *log.Logger
+
+	mu     sync.Mutex
+	alerts map[int64]*domain.PriceAlert
+}
+
+func NewAlertService(cfg *config.Config,
+	alertRepo PriceAlertRepo,
+	prices PriceSource,
+	feed PriceFeed,
+	notifier AlertNotifier,
+	i18n *i18n.I18n,
+	logger *log.Logger) *AlertService {
+	return &AlertService{
+		cfg:       cfg,
+		alertRepo: alertRepo,
+		prices:    prices,
+		feed:      feed,
+		notifier:  notifier,
+		i18n:      i18n,
+		logger:    logger,
+		alerts:    make(map[int64]*domain.PriceAlert)}
+}
+
+// LoadAlerts fills the memory with active alerts from the database.
+func (a *AlertService) LoadAlerts(ctx context.Context) error {
+	alerts, err := a.alertRepo.GetActiveAlerts(ctx)
+	if err != nil {
+		a.logger.ErrorLog.Println("err get active alerts: ", err)
+
+		return errors.InternalServerError(err)
+	}
+
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, v := range alerts {
+		a.alerts[v.Id] = v
+	}
+
+	return nil
+}
+
+func (a *AlertService) CreateAlert(ctx context.Context, userId int64, alertDto *dto.PriceAlert) (*domain.PriceAlert, error) {
+	alert := &domain.PriceAlert{
+		UserId:    userId,
+		Exchange:  alertDto.Exchange,
+		Symbol:    strings.ToUpper(alertDto.Symbol),
+		Condition: alertDto.Condition,
+		Value:     alertDto.Value,
+		Recurring: alertDto.Recurring,
+		Armed:     true,
+		Status:    consts.AlertStatusActive,
+	}
+
+	release, err := a.feed.Hold(alert.Exchange, alert.Symbol)
+	if err != nil {
+		a.logger.ErrorLog.Println("err subscribe alert price: ", err)
+	}
+	defer release()
+
+	alert.BasePrice = getMarketPrice(ctx, a.prices, a.logger, alert.Exchange, alert.Symbol)
+	if alert.BasePrice == "" && alert.Condition == consts.AlertConditionMove {
+		return nil, errors.BadRequestError(a.i18n.T(errAlertNoPrice, nil, "ru"))
+	}
+
+	id, err := a.alertRepo.CreateAlert(ctx, alert)
+	if err != nil {
+		a.logger.ErrorLog.Println("err create alert: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	alert.Id = id
+
+	a.mu.Lock()
+	a.alerts[alert.Id] = alert
+	a.mu.Unlock()
+
+	return alert, nil
+}
+
+func (a *AlertService) GetAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error) {
+	alerts, err := a.alertRepo.GetUserAlerts(ctx, userId)
+	if err != nil {
+		a.logger.ErrorLog.Println("err get alerts: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	return alerts, nil
+}
+
+func (a *AlertService) DeleteAlert(ctx context.Context, userId, id int64) error {
+	alert, err := a.alertRepo.GetAlert(ctx, id)
+	if err != nil {
+		a.logger.ErrorLog.Println("err get alert: ", err)
+
+		return errors.InternalServerError(err)
+	}
+	if alert == nil || alert.UserId != userId || !alert.IsActive() {
+		return errors.BadRequestError(a.i18n.T(errAlertNotFound, nil, "ru"))
+	}
+
+	a.mu.Lock()
+	if err = a.alertRepo.SetStatus(ctx, id, consts.AlertStatusDeleted); err != nil {
+		a.mu.Unlock()
+		a.logger.ErrorLog.Println("err delete alert: ", err)
+
+		return errors.InternalServerError(err)
+	}
+	delete(a.alerts, id)
+	a.mu.Unlock()
+
+	a.feed.Release(alert.Exchange, alert.Symbol)
+
+	return nil
+}
+
+func (a *AlertService) HasSymbol(exchange, symbol string) bool {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	for _, v := range a.alerts {
+		if v.Exchange == exchange && v.Symbol == symbol {
+			return true
+		}
+	}
+
+	return false
+}
+
+// GetAlertSymbols lists symbols the active alerts watch, their prices have to be streamed.
+func (a *AlertService) GetAlertSymbols(ctx context.Context) ([]domain.Symbols, error) {
+	symbols, err := a.alertRepo.GetAlertSymbols(ctx)
+	if err != nil {
+		a.logger.ErrorLog.Println("err get alert symbols: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	return symbols, nil
+}
+
+func (a *AlertService) GetActiveAlerts(exchange string) []*domain.PriceAlert {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	result := make([]*domain.PriceAlert, 0, len(a.alerts))
+	for _, v := range a.alerts {
+		if v.Exchange == exchange {
+			result = append(result, v)
+		}
+	}
+
+	return result
+}
+
+// CheckAlert evaluates the alert at price, notifies the user when it fires and drops fired one-shot alerts.
+// The alert is saved outside of the lock, the repository skips alerts deleted meanwhile.
+func (a *AlertService) CheckAlert(ctx context.Context, alert *domain.PriceAlert, price string) {
+	a.mu.Lock()
+	if _, ok := a.alerts[alert.Id]; !ok {
+		a.mu.Unlock()
+
+		return
+	}
+	armed, change := alert.Armed, alert.Change(price)
+	triggered := alert.Check(price)
+	changed := triggered || armed != alert.Armed
+	saved := *alert
+	closed := triggered && !alert.IsActive()
+	if closed {
+		delete(a.alerts, alert.Id)
+	}
+	a.mu.Unlock()
+
+	if changed {
+		if err := a.alertRepo.UpdateAlert(ctx, &saved); err != nil {
+			a.logger.ErrorLog.Println("err update alert: ", alert.Id, err)
+		}
+	}
+	if triggered {
+		a.notifier.NotifyPriceAlert(ctx, &saved, price, change)
+	}
+	if closed {
+		a.feed.Release(alert.Exchange, alert.Symbol)
+	}
+}
@@ -0,0 +1,89 @@
+package service
+
+import (
+	"context"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+const (
+	errDealNotFound = "dealNotFound"
+
+	defaultDealsLimit = 10
+	maxDealsLimit     = 100
+)
+
+type DealRepo interface {
+	GetDeals(ctx context.Context, userId int64, exchange string, limit, offset int) ([]*domain.Deal, int, error)
+	GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error)
+	GetDealsSince(ctx context.Context, userId int64, from time.Time) ([]*domain.Deal, error)
+}
+
+type DealService struct {
+	cfg      *config.Config
+	dealRepo DealRepo
+	i18n     *i18n.I18n
+	logger   *log.Logger
+}
+
+func NewDealService(cfg *config.Config,
+	dealRepo DealRepo,
+	i18n *i18n.I18n,
+	logger *log.Logger) *DealService {
+	return &DealService{
+		cfg:      cfg,
+		dealRepo: dealRepo,
+		i18n:     i18n,
+		logger:   logger}
+}
+
+func (d *DealService) GetDeals(ctx context.Context, userId int64, exchange string, page, limit int) (*domain.DealPage, error) {
+	if limit <= 0 {
+		limit = defaultDealsLimit
+	}
+	if limit > maxDealsLimit {
+		limit = maxDealsLimit
+	}
+	if page <= 0 {
+		page = 1
+	}
+
+	deals, total, err := d.dealRepo.GetDeals(ctx, userId, exchange, limit, (page-1)*limit)
+	if err != nil {
+		d.logger.ErrorLog.Println("err get deals: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	now := time.Now()
+	for _, v := range deals {
+		v.Calculate(d.cfg.GetTradeFeePercent(), now)
+	}
+
+	return &domain.DealPage{
+		Deals: deals,
+		Page:  page,
+		Pages: (total + limit - 1) / limit,
+		Total: total,
+	}, nil
+}
+
+func (d *DealService) GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error) {
+	deal, err := d.dealRepo.GetDeal(ctx, userId, id)
+	if err != nil {
+		d.logger.ErrorLog.Println("err get deal: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	if deal == nil {
+		return nil, errors.NotFoundError(d.i18n.T(errDealNotFound, nil, "ru"))
+	}
+	deal.Calculate(d.cfg.GetTradeFeePercent(), time.Now())
+
+	return deal, nil
+}
@@ -22,57 +22,110 @@ var (
 	errOrderNotFound   = "orderNotFound"
 	errInvalidFormat   = "invalidFormat"
 	errApiKeysNotFound = "apiKeysNotFound"
+	errEmptyExchange   = "emptyExchange"
+	errOcoUpdateDenied = "ocoUpdateDenied"
+
+	errTpLadderUpdateDenied = "tpLadderUpdateDenied"
+
+	filterErrors = map[error]string{
+		domain.ErrFilterMinQty:       "filterMinQty",
+		domain.ErrFilterMaxQty:       "filterMaxQty",
+		domain.ErrFilterMinPrice:     "filterMinPrice",
+		domain.ErrFilterMaxPrice:     "filterMaxPrice",
+		domain.ErrFilterMinNotional:  "filterMinNotional",
+		domain.ErrFilterPercentPrice: "filterPercentPrice",
+	}
+
+	errRiskNoPrice = "riskNoPrice"
+	riskErrors     = map[error]string{
+		domain.ErrRiskNoBalance:    "riskNoBalance",
+		domain.ErrRiskStopDistance: "riskStopDistance",
+	}
 )
 
 type OrderRepo interface {
 	CreateOrderWithSettings(ctx context.Context, order *domain.Order, settings *domain.Settings) (id int64, err error)
 	CreateOrder(ctx context.Context, order *domain.Order) (id int64, err error)
 	UpdateTpSl(ctx context.Context, id int64, price string, settings *domain.Settings) error
+	UpdateTrailingStop(ctx context.Context, id int64, price, tsPrice string) error
 	CancelOrder(ctx context.Context, id int64, symbol, exchange string) error
 	ExecuteOrder(ctx context.Context, id int64) error
 	ActivateOrder(ctx context.Context, id int64) error
+	SetOcoOrder(ctx context.Context, id, ocoListId, ocoOrderId int64) error
+	SetProtection(ctx context.Context, id int64, protection string) error
+	SetExecutedQty(ctx context.Context, id int64, status, executedQty, avgPrice string) error
+	UpdateQuantity(ctx context.Context, id int64, quantity string) error
 
 	GetOrder(ctx context.Context, orderId int64, symbol, exchange string) (*domain.Order, error)
+	GetSettings(ctx context.Context, orderId int64) (*domain.Settings, error)
 	GetTpSlOrderByBaseOrder(ctx context.Context, id int64, symbol, exchange, tpsl string) (*domain.Order, error)
 	GetTpSlOrdersByBaseOrder(ctx context.Context, id int64) ([]*domain.Order, error)
 	GetOpposingTpSlOrder(ctx context.Context, order *domain.Order) (*domain.Order, error)
 	GetActiveTpSlOrders(ctx context.Context, exchange string) ([]*domain.Order, error)
-	GetActiveSymbols(ctx context.Context) (domain.SymbolList, error)
+	GetActiveSymbols(ctx context.Context) ([]domain.Symbols, error)
 	GetLimitOrders(ctx context.Context, exchange string) ([]*domain.Order, error)
 
 	Atomic(ctx context.Context, fn func(ctx context.Context, orderRepo OrderRepo) error) (err error)
 }
 
+type SymbolFilterSource interface {
+	GetFilters(exchange, symbol string) (*domain.SymbolFilters, error)
+}
+
+type RiskChecker interface {
+	CheckOrder(ctx context.Context, userId int64, order *dto.Order, price string) error
+}
+
+type OrderEventPublisher interface {
+	Publish(event domain.OrderEvent)
+}
+
+type OrderPriceFeed interface {
+	Hold(exchange, symbol string) (func(), error)
+}
+
 type Order struct {
-	cfg               *config.Config
-	exchanger         exchanger.Exchanger
-	apiKeyRepo        ApiKeyRepo
-	orderRepo         OrderRepo
-	i18n              *i18n.I18n
-	logger            *log.Logger
-	binanceQueue      *domain.OrdersQueue
-	limitBinanceQueue *domain.OrdersQueue
-	keyDbCli          *redis.Client
+	cfg         *config.Config
+	exchanger   exchanger.Exchanger
+	filters     SymbolFilterSource
+	risk        RiskChecker
+	apiKeyRepo  ApiKeyRepo
+	orderRepo   OrderRepo
+	i18n        *i18n.I18n
+	logger      *log.Logger
+	tpSlQueues  domain.ExchangeQueues
+	limitQueues domain.ExchangeQueues
+	keyDbCli    *redis.Client
+	events      OrderEventPublisher
+	feed        OrderPriceFeed
 }
 
 func NewOrder(cfg *config.Config,
 	exchanger exchanger.Exchanger,
+	filters SymbolFilterSource,
+	risk RiskChecker,
 	apiKeyRepo ApiKeyRepo,
 	orderRepo OrderRepo,
-	binanceQueue *domain.OrdersQueue,
-	limitBinanceQueue *domain.OrdersQueue,
+	tpSlQueues domain.ExchangeQueues,
+	limitQueues domain.ExchangeQueues,
 	keyDbCli *redis.Client,
+	events OrderEventPublisher,
+	feed OrderPriceFeed,
 	i18n *i18n.I18n,
 	logger *log.Logger) *Order {
 	return &Order{cfg: cfg,
-		exchanger:         exchanger,
-		apiKeyRepo:        apiKeyRepo,
-		orderRepo:         orderRepo,
-		binanceQueue:      binanceQueue,
-		limitBinanceQueue: limitBinanceQueue,
-		keyDbCli:          keyDbCli,
-		i18n:              i18n,
-		logger:            logger,
+		exchanger:   exchanger,
+		filters:     filters,
+		risk:        risk,
+		apiKeyRepo:  apiKeyRepo,
+		orderRepo:   orderRepo,
+		tpSlQueues:  tpSlQueues,
+		limitQueues: limitQueues,
+		keyDbCli:    keyDbCli,
+		events:      events,
+		feed:        feed,
+		i18n:        i18n,
+		logger:      logger,
 	}
 }
 
@@ -85,6 +138,7 @@ func (o *Order) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId i
 	if err != nil {
 		return nil, err
 	}
+	defer o.holdPrice(orderDto.Exchange, orderDto.Symbol)()
 	defer func() {
 		if err != nil && execOrderId != 0 && order.OrderType == consts.OrderTypeLimit {
 			if err := o.exchanger.CancelOrder(keys,
@@ -105,15 +159,44 @@ func (o *Order) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId i
 		if err != nil {
 			return nil, err
 		}
-		/*
-			price, err = o.validatePrice(price, order.Symbol)
-			if err != nil {
-				return nil, err
-			}
-		*/
 		orderDto.Price = price
 	}
 
+	var settings *domain.Settings
+	if !orderDto.IsEmptyTpSl() {
+		settings = &domain.Settings{
+			TpPercent:   orderDto.TpPercent,
+			SlPercent:   orderDto.SlPercent,
+			TpPrice:     orderDto.TpPrice,
+			SlPrice:     orderDto.SlPrice,
+			Ts:          orderDto.Ts,
+			TpType:      orderDto.TpType,
+			SlType:      orderDto.SlType,
+			SlBreakeven: orderDto.SlBreakeven,
+		}
+		for _, v := range orderDto.TpLevels {
+			settings.TpLevels = append(settings.TpLevels, domain.TpLevel{
+				Percent: v.Percent,
+				Price:   v.Price,
+				Share:   v.Share,
+			})
+		}
+	}
+
+	if orderDto.IsRiskSized() {
+		if err = o.setRiskQuantity(keys, orderDto, settings); err != nil {
+			return nil, err
+		}
+	}
+
+	if err = o.validateInputParams(orderDto, settings); err != nil {
+		return nil, err
+	}
+
+	if err = o.checkRiskLimits(ctx, tgUserId, orderDto); err != nil {
+		return nil, err
+	}
+
 	if err := o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
 		execOrderId, err = o.exchanger.CreateOrder(keys, orderDto)
 		if err != nil {
@@ -135,6 +218,10 @@ func (o *Order) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId i
 			UserId:      tgUserId,
 			Status:      consts.OrderStatusActive,
 			TpSl:        consts.BaseOrderType,
+			Protection:  consts.ProtectionVirtual,
+		}
+		if orderDto.IsOco() {
+			order.Protection = consts.ProtectionOco
 		}
 		if order.OrderType == consts.OrderTypeMarket {
 			var price string
@@ -152,19 +239,6 @@ func (o *Order) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId i
 			order.IcebergQty = iceberg
 		}
 
-		var settings *domain.Settings
-		if !orderDto.IsEmptyTpSl() {
-			settings = &domain.Settings{
-				TpPercent: orderDto.TpPercent,
-				SlPercent: orderDto.SlPercent,
-				TpPrice:   orderDto.TpPrice,
-				SlPrice:   orderDto.SlPrice,
-				Ts:        orderDto.Ts,
-				TpType:    orderDto.TpType,
-				SlType:    orderDto.SlType,
-			}
-		}
-
 		orderId, err = orderRepo.CreateOrderWithSettings(ctx, order, settings)
 		if err != nil {
 			o.logger.ErrorLog.Println("Can`t save order:", err)
@@ -173,30 +247,36 @@ func (o *Order) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId i
 		}
 		order.Id = orderId
 
-		if (settings.TpPercent != "" || settings.TpPrice != "") && order.TpSl == consts.BaseOrderType {
-			if err = o.createTpSlOrder(ctx, order, consts.TpOrderType, settings); err != nil {
+		tpSlOrders := make([]*domain.Order, 0, 2)
+		for _, leg := range settings.GetTpSlLegs(order) {
+			tpSlOrder, err := o.createTpSlOrder(ctx, order, leg.TpSl, leg.Price, leg.Quantity, settings)
+			if err != nil {
 				return err
 			}
+			tpSlOrders = append(tpSlOrders, tpSlOrder)
 		}
 
-		if (settings.SlPercent != "" || settings.SlPrice != "") && order.TpSl == consts.BaseOrderType {
-			if err = o.createTpSlOrder(ctx, order, consts.SlOrderType, settings); err != nil {
+		if order.IsOco() && order.OrderType == consts.OrderTypeMarket {
+			if err = o.placeOcoOrder(ctx, orderRepo, keys, order, tpSlOrders); err != nil {
 				return err
 			}
 		}
 		if order.OrderType == consts.OrderTypeLimit {
-			o.limitBinanceQueue.Add(order)
+			if queue := o.getLimitExchangeQueue(order.Exchange); queue != nil {
+				queue.Add(order)
+			}
 		}
 		return nil
 
 	}); err != nil {
 		return nil, err
 	}
+	o.publish(domain.NewOrderEvent(consts.OrderEventCreated, order))
 
 	return order, err
 }
 
-func (o *Order) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl) error {
+func (o *Order) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error {
 	updateTpsl := func(baseOrder *domain.Order,
 		settings *domain.Settings,
 		queue *domain.OrdersQueue,
@@ -214,7 +294,16 @@ func (o *Order) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl) e
 		if tpSlOrder == nil {
 			return errors.BadRequestError(o.i18n.T(errOrderNotFound, nil, "ru"))
 		}
-		price := o.getTpSlPrice(baseOrder, orderType, settings)
+		if tpSlOrder.IsOcoLeg() {
+			return errors.BadRequestError(o.i18n.T(errOcoUpdateDenied, nil, "ru"))
+		}
+		price := settings.GetTpSlPrice(baseOrder, orderType)
+		if filters := o.getSymbolFilters(baseOrder.Exchange, baseOrder.Symbol); filters != nil {
+			price = filters.RoundPrice(price)
+			if err := filters.Validate(price, tpSlOrder.Quantity, ""); err != nil {
+				return o.filterError(err, filters)
+			}
+		}
 
 		if err := o.orderRepo.UpdateTpSl(ctx, tpSlOrder.Id, price, settings); err != nil {
 			o.logger.ErrorLog.Println("err update tpsl order:", err)
@@ -240,13 +329,22 @@ func (o *Order) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl) e
 
 		return errors.InternalServerError(err)
 	}
-	if baseOrder == nil {
+	if baseOrder == nil || baseOrder.UserId != tgUserId {
 		return errors.BadRequestError(o.i18n.T(errOrderNotFound, nil, "ru"))
 	}
 
 	queue := o.getExchangeQueue(baseOrder.Exchange)
 
 	if !settings.IsTpEmpty() {
+		dealSettings, err := o.orderRepo.GetSettings(ctx, baseOrder.Id)
+		if err != nil {
+			o.logger.ErrorLog.Println("err get order settings:", err)
+
+			return errors.InternalServerError(err)
+		}
+		if dealSettings != nil && dealSettings.HasTpLadder() {
+			return errors.BadRequestError(o.i18n.T(errTpLadderUpdateDenied, nil, "ru"))
+		}
 		if err = updateTpsl(baseOrder, settings, queue, consts.TpOrderType); err != nil {
 			return err
 		}
@@ -273,15 +371,59 @@ func (o *Order) CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserI
 		return errors.BadRequestError(err.Error())
 	}
 
+	limitOrder := o.getLimitExchangeQueue(order.Exchange).Get(order.Symbol, order.Id)
+	if limitOrder != nil && limitOrder.UserId == tgUserId {
+		limitOrder.Lock()
+		defer limitOrder.Unlock()
+
+		return o.cancelLimitOrder(ctx, limitOrder)
+	}
+
 	if err = o.orderRepo.CancelOrder(ctx, order.Id, order.Symbol, order.Exchange); err != nil {
 		o.logger.ErrorLog.Println("err cancel order in database: ", err)
 
 		return errors.InternalServerError(err)
 	}
+	o.publish(domain.NewOrderEvent(consts.OrderEventCanceled, &domain.Order{
+		Id:       order.Id,
+		UserId:   tgUserId,
+		Symbol:   order.Symbol,
+		Exchange: order.Exchange,
+		Status:   consts.OrderStatusCanceled,
+	}))
 
 	return nil
 }
 
+// CancelOrderById cancels an open bot order, the exchange gets the exec order id of it.
+func (o *Order) CancelOrderById(ctx context.Context, orderId, tgUserId int64, symbol, exchange string) error {
+	order, err := o.GetOrder(ctx, orderId, tgUserId, symbol, exchange, false)
+	if err != nil {
+		return err
+	}
+	if order.Status != consts.OrderStatusActive && order.Status != consts.OrderStatusPartFilled {
+		return nil
+	}
+
+	keys, err := o.getApiKeys(ctx, tgUserId, exchange)
+	if err != nil {
+		return err
+	}
+	if err = o.exchanger.CancelOrder(keys, dto.NewCancelOrder(order.ExecOrderId, symbol, exchange)); err != nil {
+		o.logger.ErrorLog.Println("err cancel order: ", err)
+
+		return errors.BadRequestError(err.Error())
+	}
+
+	if limitOrder := o.getLimitExchangeQueue(exchange).Get(symbol, orderId); limitOrder != nil {
+		order = limitOrder
+	}
+	order.Lock()
+	defer order.Unlock()
+
+	return o.cancelLimitOrder(ctx, order)
+}
+
 func (o *Order) GetActiveTpSlOrders(ctx context.Context, exchange string) ([]*domain.Order, error) {
 	result, err := o.orderRepo.GetActiveTpSlOrders(ctx, exchange)
 	if err != nil {
@@ -307,51 +449,47 @@ func (o *Order) GetUserActiveOrders(ctx context.Context, userId int64, exchange
 	return result, nil
 }
 
-func (o *Order) ExecuteTpSlOrder(ctx context.Context, userId int64, order *domain.Order) (int64, error) {
+// ExecuteTpSlOrder sends the order to the exchange at the trade price that triggered it, the trigger price
+// of the order stays untouched. Only the caller that removed the order from the queue executes it,
+// a failed execution puts the order back to be triggered again.
+func (o *Order) ExecuteTpSlOrder(ctx context.Context, userId int64, order *domain.Order, price string) (int64, error) {
 	queue := o.getExchangeQueue(order.Exchange)
-	if !queue.Exist(order.Symbol, order.Id) {
+	if !queue.Remove(order.Symbol, order.Id) {
 		return 0, nil
 	}
 
 	keys, err := o.getApiKeys(ctx, userId, order.Exchange)
 	if err != nil {
+		queue.Add(order)
+		o.publishExecuteFailed(order, err)
+
 		return 0, err
 	}
 
 	execOrder := new(dto.Order)
 	conversion.FromDomainOrderToDtoOrder(order, execOrder)
-	if execOrder.OrderType == consts.OrderTypeMarket {
-		order.Status = consts.OrderStatusFilled
-	}
+	execOrder.Price = price
 	excId, err := o.exchanger.CreateOrder(keys, execOrder)
 	if err != nil {
 		o.logger.ErrorLog.Println("err execute order: " + err.Error())
+		queue.Add(order)
+		o.publishExecuteFailed(order, err)
 
 		return 0, errors.BadRequestError(err.Error())
 	}
-	queue.Remove(order.Symbol, order.Id)
+	if execOrder.OrderType == consts.OrderTypeMarket {
+		order.Status = consts.OrderStatusFilled
+	}
 
-	o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
-		opposingOrder, err := orderRepo.GetOpposingTpSlOrder(ctx, order)
+	var movedOrders []*domain.Order
+	err = o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
+		movedOrders, err = o.closeTpSlOrders(ctx, orderRepo, order, queue)
 		if err != nil {
-			o.logger.ErrorLog.Println("err get opposing order in db: " + err.Error())
-
-			return errors.InternalServerError(err)
-		}
-
-		if opposingOrder != nil {
-			if err := orderRepo.CancelOrder(ctx, opposingOrder.Id, opposingOrder.Symbol, opposingOrder.Exchange); err != nil {
-				o.logger.ErrorLog.Println("err cancel opposing order in db: " + err.Error())
-
-				return errors.InternalServerError(err)
-			}
-			if queue != nil {
-				queue.Remove(opposingOrder.Symbol, opposingOrder.Id)
-			}
+			return err
 		}
 
 		order.ExecOrderId = excId
-		if err := orderRepo.ExecuteOrder(ctx, order.Id); err != nil {
+		if err := orderRepo.SetExecutedQty(ctx, order.Id, consts.OrderStatusFilled, order.Quantity, price); err != nil {
 			o.logger.ErrorLog.Println("err exec order in db: " + err.Error())
 
 			return errors.InternalServerError(err)
@@ -359,10 +497,63 @@ func (o *Order) ExecuteTpSlOrder(ctx context.Context, userId int64, order *domai
 
 		return nil
 	})
+	if err != nil {
+		o.logger.ErrorLog.Println("err save executed tpsl order:", order.Id, err)
+
+		return 0, err
+	}
+	executed := domain.NewExecutedEvent(order)
+	executed.Price = price
+	o.publish(executed)
+	for _, v := range movedOrders {
+		o.publish(domain.NewOrderEvent(consts.OrderEventSlBreakeven, v))
+	}
 
 	return excId, nil
 }
 
+func (o *Order) MoveTrailingStop(ctx context.Context, order *domain.Order, tradePrice string) (bool, error) {
+	if order.TpSl != consts.SlOrderType || !order.IsTrailingStop() {
+		return false, nil
+	}
+
+	price := helper.StringToBigFloat(tradePrice)
+	if price == nil {
+		return false, errors.BadRequestError(o.i18n.T(errInvalidFormat, nil, "ru"))
+	}
+
+	stopPrice, isWatermark, moved := order.NextTrailingStop(price)
+	if !isWatermark {
+		return false, nil
+	}
+
+	// the stop moves in whole ticks, so a new watermark is written to the db only with the stop it moved
+	var newPrice string
+	if moved {
+		newPrice = o.roundPrice(order.Exchange, order.Symbol, stopPrice.String())
+		moved = order.IsStopImproved(helper.StringToBigFloat(newPrice))
+	}
+	if !moved {
+		order.TsPrice = price.String()
+
+		return false, nil
+	}
+
+	if err := o.orderRepo.UpdateTrailingStop(ctx, order.Id, newPrice, price.String()); err != nil {
+		o.logger.ErrorLog.Println("err update trailing stop:", err)
+
+		return false, errors.InternalServerError(err)
+	}
+
+	order.TsPrice = price.String()
+	if queue := o.getExchangeQueue(order.Exchange); queue == nil || !queue.UpdatePrice(order.Symbol, order.Id, newPrice) {
+		return false, nil
+	}
+	o.publish(domain.NewOrderEvent(consts.OrderEventTrailingMoved, order))
+
+	return true, nil
+}
+
 func (o *Order) GetOrder(ctx context.Context, orderId int64, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error) {
 	if inExchange {
 		keys, err := o.getApiKeys(ctx, tgUserId, exchange)
@@ -377,27 +568,29 @@ func (o *Order) GetOrder(ctx context.Context, orderId int64, tgUserId int64, sym
 		}
 		return res, nil
 	} else {
-		res, err := o.orderRepo.GetOrder(ctx, orderId, exchange, symbol)
+		res, err := o.orderRepo.GetOrder(ctx, orderId, symbol, exchange)
 		if err != nil {
 			o.logger.ErrorLog.Println("err get order: " + err.Error())
 
 			return nil, errors.BadRequestError(err.Error())
 		}
+		if res == nil || res.UserId != tgUserId {
+			return nil, errors.BadRequestError(o.i18n.T(errOrderNotFound, nil, "ru"))
+		}
 		return res, nil
 	}
 }
 
 func (o *Order) SetFilledLimitOrder(ctx context.Context, order *domain.Order) error {
 	limitQueue := o.getLimitExchangeQueue(order.Exchange)
-	if !limitQueue.Exist(order.Symbol, order.Id) {
+	if !limitQueue.Remove(order.Symbol, order.Id) {
 		o.logger.InfoLog.Println("not found in queue")
 
 		return nil
-
 	}
-	limitQueue.Remove(order.Symbol, order.Id)
+	activeOrders := make([]*domain.Order, 0, 2)
 	err := o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
-		if err := orderRepo.ExecuteOrder(ctx, order.Id); err != nil {
+		if err := orderRepo.SetExecutedQty(ctx, order.Id, consts.OrderStatusFilled, order.Quantity, order.AvgPrice); err != nil {
 			o.logger.ErrorLog.Println("err exec order in db: " + err.Error())
 
 			return errors.InternalServerError(err)
@@ -410,23 +603,258 @@ func (o *Order) SetFilledLimitOrder(ctx context.Context, order *domain.Order) er
 			return errors.InternalServerError(err)
 		}
 
-		tpSlQueue := o.getExchangeQueue(order.Exchange)
+		ocoOrders := make([]*domain.Order, 0, 2)
 		for _, v := range tpSlOrders {
-			if err = orderRepo.ActivateOrder(ctx, v.Id); err != nil {
-				o.logger.ErrorLog.Println("err activate orders in db: " + err.Error())
+			if v.IsOco() {
+				ocoOrders = append(ocoOrders, v)
 
-				return errors.InternalServerError(err)
+				continue
 			}
+			if v.Status != consts.OrderStatusTpSlInactive && v.Status != consts.OrderStatusActive {
+				continue
+			}
+			activeOrders = append(activeOrders, v)
+		}
+		if err = o.resizeTpSlOrders(ctx, orderRepo, activeOrders, order.Quantity); err != nil {
+			return err
+		}
 
-			tpSlQueue.Add(v)
+		if len(ocoOrders) != 0 {
+			keys, err := o.getApiKeys(ctx, order.UserId, order.Exchange)
+			if err != nil {
+				return err
+			}
+
+			return o.placeOcoOrder(ctx, orderRepo, keys, order, ocoOrders)
 		}
 
 		return nil
 	})
+	if err == nil {
+		o.enqueueTpSlOrders(order.Exchange, activeOrders)
+		order.Status = consts.OrderStatusFilled
+		order.ExecutedQty = order.Quantity
+		o.publish(domain.NewOrderEvent(consts.OrderEventFilled, order))
+	}
 
 	return err
 }
 
+func (o *Order) SetPartFilledLimitOrder(ctx context.Context, order *domain.Order, executedQty, avgPrice string) error {
+	if !o.getLimitExchangeQueue(order.Exchange).Exist(order.Symbol, order.Id) {
+		return nil
+	}
+	executed := helper.StringToBigFloat(executedQty)
+	if executed == nil || executed.Sign() <= 0 {
+		return nil
+	}
+	if prev := helper.StringToBigFloat(order.ExecutedQty); prev != nil && executed.Cmp(prev) <= 0 {
+		return nil
+	}
+
+	activeOrders := make([]*domain.Order, 0, 2)
+	err := o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
+		if err := orderRepo.SetExecutedQty(ctx, order.Id, consts.OrderStatusPartFilled, executedQty, avgPrice); err != nil {
+			o.logger.ErrorLog.Println("err set executed qty in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+
+		tpSlOrders, err := orderRepo.GetTpSlOrdersByBaseOrder(ctx, order.Id)
+		if err != nil {
+			o.logger.ErrorLog.Println("err get tpsl orders in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+
+		for _, v := range tpSlOrders {
+			if v.IsOco() || (v.Status != consts.OrderStatusTpSlInactive && v.Status != consts.OrderStatusActive) {
+				continue
+			}
+			activeOrders = append(activeOrders, v)
+		}
+
+		return o.resizeTpSlOrders(ctx, orderRepo, activeOrders, executedQty)
+	})
+	if err != nil {
+		return err
+	}
+
+	o.enqueueTpSlOrders(order.Exchange, activeOrders)
+	order.Status = consts.OrderStatusPartFilled
+	order.ExecutedQty = executedQty
+	order.AvgPrice = avgPrice
+	o.publish(domain.NewOrderEvent(consts.OrderEventPartFilled, order))
+
+	return nil
+}
+
+func (o *Order) SyncOcoOrder(ctx context.Context, order *domain.Order) error {
+	limitQueue := o.getLimitExchangeQueue(order.Exchange)
+	if !limitQueue.Exist(order.Symbol, order.Id) {
+		return nil
+	}
+
+	keys, err := o.getApiKeys(ctx, order.UserId, order.Exchange)
+	if err != nil {
+		return err
+	}
+
+	excOrder, err := o.exchanger.GetOrder(keys, order.Exchange, order.Symbol, order.OcoOrderId)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get oco order: " + err.Error())
+
+		return errors.BadRequestError(err.Error())
+	}
+
+	return o.applyOcoStatus(ctx, order, excOrder.Status, excOrder.Price)
+}
+
+func (o *Order) HandleExecutionReport(ctx context.Context, report *domain.ExecutionReport) error {
+	limitQueue := o.getLimitExchangeQueue(report.Exchange)
+	order := limitQueue.GetByExchangeOrderId(report.Symbol, report.OrderId)
+	if order == nil || order.UserId != report.UserId {
+		return nil
+	}
+
+	order.Lock()
+	defer order.Unlock()
+
+	if !order.IsOpen() {
+		return nil
+	}
+	if order.IsOcoLeg() {
+		return o.applyOcoStatus(ctx, order, report.Status, report.LastPrice)
+	}
+
+	switch report.Status {
+	case consts.OrderStatusFilled:
+		order.AvgPrice = report.AvgPrice
+
+		return o.SetFilledLimitOrder(ctx, order)
+	case consts.OrderStatusPartFilled:
+		return o.SetPartFilledLimitOrder(ctx, order, report.FilledQuantity, report.AvgPrice)
+	case consts.OrderStatusCanceled:
+		return o.cancelLimitOrder(ctx, order)
+	}
+
+	return nil
+}
+
+func (o *Order) GetApiKeys(ctx context.Context, userId int64, exchange string) (*domain.ApiKeys, error) {
+	return o.getApiKeys(ctx, userId, exchange)
+}
+
+func (o *Order) applyOcoStatus(ctx context.Context, order *domain.Order, status, price string) error {
+	limitQueue := o.getLimitExchangeQueue(order.Exchange)
+	switch status {
+	case consts.OrderStatusFilled:
+		err := o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
+			if err := orderRepo.ExecuteOrder(ctx, order.Id); err != nil {
+				o.logger.ErrorLog.Println("err exec oco order in db: " + err.Error())
+
+				return errors.InternalServerError(err)
+			}
+
+			opposingOrder, err := orderRepo.GetOpposingTpSlOrder(ctx, order)
+			if err != nil {
+				o.logger.ErrorLog.Println("err get opposing order in db: " + err.Error())
+
+				return errors.InternalServerError(err)
+			}
+			if opposingOrder != nil {
+				if err := orderRepo.CancelOrder(ctx, opposingOrder.Id, opposingOrder.Symbol, opposingOrder.Exchange); err != nil {
+					o.logger.ErrorLog.Println("err cancel opposing order in db: " + err.Error())
+
+					return errors.InternalServerError(err)
+				}
+				limitQueue.Remove(opposingOrder.Symbol, opposingOrder.Id)
+			}
+
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		limitQueue.Remove(order.Symbol, order.Id)
+		order.Status = consts.OrderStatusFilled
+		if execPrice := helper.StringToBigFloat(price); execPrice != nil && execPrice.Sign() > 0 {
+			order.Price = price
+		}
+		o.publish(domain.NewExecutedEvent(order))
+	case consts.OrderStatusCanceled:
+		if err := o.orderRepo.CancelOrder(ctx, order.Id, order.Symbol, order.Exchange); err != nil {
+			o.logger.ErrorLog.Println("err cancel oco order in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		limitQueue.Remove(order.Symbol, order.Id)
+		order.Status = consts.OrderStatusCanceled
+	}
+
+	return nil
+}
+
+func (o *Order) cancelLimitOrder(ctx context.Context, order *domain.Order) error {
+	keepProtection := order.HasExecutedQty()
+	err := o.orderRepo.Atomic(ctx, func(ctx context.Context, orderRepo OrderRepo) error {
+		if err := orderRepo.CancelOrder(ctx, order.Id, order.Symbol, order.Exchange); err != nil {
+			o.logger.ErrorLog.Println("err cancel limit order in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+
+		tpSlOrders, err := orderRepo.GetTpSlOrdersByBaseOrder(ctx, order.Id)
+		if err != nil {
+			o.logger.ErrorLog.Println("err get tpsl orders in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+
+		ocoOrders := make([]*domain.Order, 0, 2)
+		for _, v := range tpSlOrders {
+			if keepProtection {
+				if v.IsOco() && v.Status == consts.OrderStatusTpSlInactive {
+					if err = orderRepo.UpdateQuantity(ctx, v.Id, order.ExecutedQty); err != nil {
+						o.logger.ErrorLog.Println("err update tpsl quantity in db: " + err.Error())
+
+						return errors.InternalServerError(err)
+					}
+					v.Quantity = order.ExecutedQty
+					ocoOrders = append(ocoOrders, v)
+				}
+
+				continue
+			}
+			if err = orderRepo.CancelOrder(ctx, v.Id, v.Symbol, v.Exchange); err != nil {
+				o.logger.ErrorLog.Println("err cancel tpsl order in db: " + err.Error())
+
+				return errors.InternalServerError(err)
+			}
+		}
+
+		if len(ocoOrders) != 0 {
+			keys, err := o.getApiKeys(ctx, order.UserId, order.Exchange)
+			if err != nil {
+				return err
+			}
+
+			return o.placeOcoOrder(ctx, orderRepo, keys, order, ocoOrders)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	o.getLimitExchangeQueue(order.Exchange).Remove(order.Symbol, order.Id)
+	order.Status = consts.OrderStatusCanceled
+	o.publish(domain.NewOrderEvent(consts.OrderEventCanceled, order))
+
+	return nil
+}
+
 func (o *Order) GetLimitOrders(ctx context.Context, exchange string) ([]*domain.Order, error) {
 	orders, err := o.orderRepo.GetLimitOrders(ctx, exchange)
 	if err != nil {
@@ -438,13 +866,14 @@ func (o *Order) GetLimitOrders(ctx context.Context, exchange string) ([]*domain.
 	return orders, nil
 }
 
-func (o *Order) createTpSlOrder(ctx context.Context, order *domain.Order, tpSlType string, settings *domain.Settings) error {
-	price := o.getTpSlPrice(order, tpSlType, settings)
-	/*
-		TODO::
-			if err := o.validateInputParams(newPrice, order.Quantity, order.Symbol); err != nil {
-				return nil, err
-			}*/
+func (o *Order) createTpSlOrder(ctx context.Context,
+	order *domain.Order,
+	tpSlType, price, quantity string,
+	settings *domain.Settings) (*domain.Order, error) {
+	if filters := o.getSymbolFilters(order.Exchange, order.Symbol); filters != nil {
+		price = filters.RoundPrice(price)
+		quantity = filters.RoundQuantity(quantity)
+	}
 
 	ordertype := consts.OrderTypeMarket
 	if tpSlType == consts.SlOrderType && settings.SlType != "" {
@@ -465,31 +894,307 @@ func (o *Order) createTpSlOrder(ctx context.Context, order *domain.Order, tpSlTy
 		Status:      status,
 		OrderType:   ordertype,
 		TimeInForce: consts.TimeInForceGTC,
-		Quantity:    order.Quantity,
-		Side:        o.getTpSlSide(order.Side),
+		Quantity:    quantity,
+		Side:        domain.GetTpSlSide(order.Side),
 		TpSl:        tpSlType,
 		ExecOrderId: order.Id,
 		Exchange:    order.Exchange,
+		Protection:  order.Protection,
+	}
+	if tpSlType == consts.SlOrderType && settings.Ts != "" {
+		tpSlOrder.Ts = settings.Ts
+		tpSlOrder.TsPrice = order.Price
 	}
 
 	newOrdId, err := o.orderRepo.CreateOrder(ctx, tpSlOrder)
 	if err != nil {
 		o.logger.ErrorLog.Println("Can`t save tpsl order:", err)
 
-		return errors.InternalServerError(err)
+		return nil, errors.InternalServerError(err)
 	}
 	tpSlOrder.Id = newOrdId
 
-	if order.OrderType == consts.OrderTypeMarket {
+	if order.OrderType == consts.OrderTypeMarket && !tpSlOrder.IsOco() {
 		queue := o.getExchangeQueue(order.Exchange)
 		if queue != nil {
 			queue.Add(tpSlOrder)
 		}
 	}
 
+	return tpSlOrder, nil
+}
+
+func (o *Order) placeOcoOrder(ctx context.Context,
+	orderRepo OrderRepo,
+	keys *domain.ApiKeys,
+	baseOrder *domain.Order,
+	tpSlOrders []*domain.Order) error {
+	var tpOrder, slOrder *domain.Order
+	for _, v := range tpSlOrders {
+		if v.TpSl == consts.TpOrderType {
+			tpOrder = v
+		} else {
+			slOrder = v
+		}
+	}
+	if tpOrder == nil || slOrder == nil {
+		return o.fallbackToVirtual(ctx, orderRepo, baseOrder, tpSlOrders, domain.ErrOcoLegsNotFound)
+	}
+
+	ocoOrder, err := o.exchanger.CreateOcoOrder(keys, dto.NewOcoOrder(baseOrder.Exchange,
+		baseOrder.Symbol,
+		tpOrder.Side,
+		tpOrder.Quantity,
+		tpOrder.Price,
+		slOrder.Price))
+	if err != nil {
+		o.logger.ErrorLog.Println("err create oco order: " + err.Error())
+
+		return o.fallbackToVirtual(ctx, orderRepo, baseOrder, tpSlOrders, err)
+	}
+
+	limitQueue := o.getLimitExchangeQueue(baseOrder.Exchange)
+	for _, v := range []struct {
+		order      *domain.Order
+		ocoOrderId int64
+	}{{tpOrder, ocoOrder.TpOrderId}, {slOrder, ocoOrder.SlOrderId}} {
+		if err := orderRepo.SetOcoOrder(ctx, v.order.Id, ocoOrder.ListId, v.ocoOrderId); err != nil {
+			o.logger.ErrorLog.Println("err save oco order in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		v.order.OcoListId = ocoOrder.ListId
+		v.order.OcoOrderId = v.ocoOrderId
+		v.order.Status = consts.OrderStatusActive
+		if limitQueue != nil {
+			limitQueue.Add(v.order)
+		}
+	}
+
 	return nil
 }
 
+func (o *Order) fallbackToVirtual(ctx context.Context,
+	orderRepo OrderRepo,
+	baseOrder *domain.Order,
+	tpSlOrders []*domain.Order,
+	cause error) error {
+	tpSlQueue := o.getExchangeQueue(baseOrder.Exchange)
+	for _, v := range tpSlOrders {
+		if err := orderRepo.SetProtection(ctx, v.Id, consts.ProtectionVirtual); err != nil {
+			o.logger.ErrorLog.Println("err set virtual protection in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		if err := orderRepo.ActivateOrder(ctx, v.Id); err != nil {
+			o.logger.ErrorLog.Println("err activate orders in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		v.Protection = consts.ProtectionVirtual
+		v.Status = consts.OrderStatusActive
+		if tpSlQueue != nil {
+			tpSlQueue.Add(v)
+		}
+	}
+
+	event := domain.NewOrderEvent(consts.OrderEventOcoFailed, baseOrder)
+	event.Error = cause.Error()
+	o.publish(event)
+
+	return nil
+}
+
+func (o *Order) resizeTpSlOrder(ctx context.Context, orderRepo OrderRepo, order *domain.Order, quantity string) error {
+	if order.Quantity != quantity {
+		if err := orderRepo.UpdateQuantity(ctx, order.Id, quantity); err != nil {
+			o.logger.ErrorLog.Println("err update tpsl quantity in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		order.Quantity = quantity
+	}
+	if order.Status == consts.OrderStatusTpSlInactive {
+		if err := orderRepo.ActivateOrder(ctx, order.Id); err != nil {
+			o.logger.ErrorLog.Println("err activate orders in db: " + err.Error())
+
+			return errors.InternalServerError(err)
+		}
+		order.Status = consts.OrderStatusActive
+	}
+
+	return nil
+}
+
+func (o *Order) resizeTpSlOrders(ctx context.Context, orderRepo OrderRepo, orders []*domain.Order, quantity string) error {
+	tpOrders := make([]*domain.Order, 0, len(orders))
+	weights := make([]string, 0, len(orders))
+	for _, v := range orders {
+		if v.TpSl == consts.TpOrderType {
+			tpOrders = append(tpOrders, v)
+			weights = append(weights, v.Quantity)
+		}
+	}
+
+	var filters *domain.SymbolFilters
+	if len(tpOrders) > 0 {
+		filters = o.getSymbolFilters(tpOrders[0].Exchange, tpOrders[0].Symbol)
+	}
+	for i, qty := range domain.SplitQuantity(quantity, weights) {
+		if filters != nil {
+			qty = filters.RoundQuantity(qty)
+		}
+		if err := o.resizeTpSlOrder(ctx, orderRepo, tpOrders[i], qty); err != nil {
+			return err
+		}
+	}
+	for _, v := range orders {
+		if v.TpSl == consts.TpOrderType {
+			continue
+		}
+		if err := o.resizeTpSlOrder(ctx, orderRepo, v, quantity); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (o *Order) closeTpSlOrders(ctx context.Context,
+	orderRepo OrderRepo,
+	order *domain.Order,
+	queue *domain.OrdersQueue) ([]*domain.Order, error) {
+	tpSlOrders, err := orderRepo.GetTpSlOrdersByBaseOrder(ctx, order.ExecOrderId)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get tpsl orders in db: " + err.Error())
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	tpOrders := make([]*domain.Order, 0, len(tpSlOrders))
+	slOrders := make([]*domain.Order, 0, 1)
+	for _, v := range tpSlOrders {
+		if v.Id == order.Id || v.Status != consts.OrderStatusActive {
+			continue
+		}
+		if v.TpSl == consts.TpOrderType {
+			tpOrders = append(tpOrders, v)
+		} else {
+			slOrders = append(slOrders, v)
+		}
+	}
+
+	if order.TpSl == consts.TpOrderType && len(tpOrders) != 0 {
+		return o.shrinkSlOrders(ctx, orderRepo, order, domain.SumQuantity(tpOrders), slOrders, queue)
+	}
+
+	for _, v := range append(tpOrders, slOrders...) {
+		if err = orderRepo.CancelOrder(ctx, v.Id, v.Symbol, v.Exchange); err != nil {
+			o.logger.ErrorLog.Println("err cancel opposing order in db: " + err.Error())
+
+			return nil, errors.InternalServerError(err)
+		}
+		if queue != nil {
+			queue.Remove(v.Symbol, v.Id)
+		}
+	}
+
+	return nil, nil
+}
+
+func (o *Order) shrinkSlOrders(ctx context.Context,
+	orderRepo OrderRepo,
+	order *domain.Order,
+	quantity string,
+	slOrders []*domain.Order,
+	queue *domain.OrdersQueue) ([]*domain.Order, error) {
+	if len(slOrders) == 0 {
+		return nil, nil
+	}
+
+	breakeven, err := o.getBreakevenPrice(ctx, orderRepo, order)
+	if err != nil {
+		return nil, err
+	}
+	if breakeven != "" {
+		breakeven = o.roundPrice(order.Exchange, order.Symbol, breakeven)
+	}
+
+	movedOrders := make([]*domain.Order, 0, len(slOrders))
+	for _, v := range slOrders {
+		if err = o.resizeTpSlOrder(ctx, orderRepo, v, quantity); err != nil {
+			return nil, err
+		}
+
+		moved := v.IsTighterStop(helper.StringToBigFloat(breakeven))
+		if moved {
+			if err = orderRepo.UpdateTpSl(ctx, v.Id, breakeven, nil); err != nil {
+				o.logger.ErrorLog.Println("err move sl to breakeven in db: " + err.Error())
+
+				return nil, errors.InternalServerError(err)
+			}
+			v.Price = breakeven
+			movedOrders = append(movedOrders, v)
+		}
+		if queue == nil {
+			continue
+		}
+
+		if queued := queue.Get(v.Symbol, v.Id); queued != nil {
+			queued.Lock()
+			queued.Quantity = v.Quantity
+			if moved {
+				queue.UpdatePrice(v.Symbol, v.Id, v.Price)
+			}
+			queued.Unlock()
+		}
+	}
+
+	return movedOrders, nil
+}
+
+func (o *Order) getBreakevenPrice(ctx context.Context, orderRepo OrderRepo, order *domain.Order) (string, error) {
+	settings, err := orderRepo.GetSettings(ctx, order.ExecOrderId)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get order settings in db: " + err.Error())
+
+		return "", errors.InternalServerError(err)
+	}
+	if settings == nil || !settings.SlBreakeven {
+		return "", nil
+	}
+
+	baseOrder, err := orderRepo.GetOrder(ctx, order.ExecOrderId, order.Symbol, order.Exchange)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get base order in db: " + err.Error())
+
+		return "", errors.InternalServerError(err)
+	}
+	if baseOrder == nil {
+		return "", nil
+	}
+	if baseOrder.AvgPrice != "" {
+		return baseOrder.AvgPrice, nil
+	}
+
+	return baseOrder.Price, nil
+}
+
+func (o *Order) enqueueTpSlOrders(exchange string, orders []*domain.Order) {
+	tpSlQueue := o.getExchangeQueue(exchange)
+	for _, v := range orders {
+		queued := tpSlQueue.Get(v.Symbol, v.Id)
+		if queued == nil {
+			tpSlQueue.Add(v)
+
+			continue
+		}
+		queued.Lock()
+		queued.Quantity = v.Quantity
+		queued.Unlock()
+	}
+}
+
 func (o *Order) checkStopPercent(stopPercent string) error {
 	bigPer, ok := new(big.Float).SetString(stopPercent)
 	if !ok {
@@ -509,6 +1214,9 @@ func (o *Order) getApiKeys(ctx context.Context, userId int64, exchange string) (
 	if err != nil {
 		o.logger.ErrorLog.Println("err get api keys: ", err)
 	}
+	if keys == nil && exchange == consts.Paper {
+		return domain.NewApiKeys(userId, exchange, "", "", ""), nil
+	}
 	if keys == nil {
 		return nil, errors.BadRequestError(o.i18n.T(errApiKeysNotFound, nil, "ru"))
 	}
@@ -555,71 +1263,182 @@ func (o *Order) getPrice(stopPercent string, stopPrice string, side string) (str
 	return result.String(), nil
 }
 
-func (o *Order) getTpSlPrice(order *domain.Order, tpSlType string, settings *domain.Settings) string {
-	percentPrice := func(price, prct, ordSide string) string {
-		ordPrice := helper.StringToBigFloat(price)
-		percent := helper.StringToBigFloat(prct)
-		totalPrice := new(big.Float)
-		ordSide = strings.ToUpper(ordSide)
-		tpSlType = strings.ToLower(tpSlType)
-
-		if ordSide == consts.OrderSideBuy && tpSlType == consts.TpOrderType {
-			totalPrice = helper.BigSumWithPercent(ordPrice, percent)
-		} else if ordSide == consts.OrderSideSell && tpSlType == consts.TpOrderType {
-			totalPrice = helper.BigDiffWithPercent(ordPrice, percent)
-		} else if ordSide == consts.OrderSideBuy && tpSlType == consts.SlOrderType {
-			totalPrice = helper.BigDiffWithPercent(ordPrice, percent)
-		} else if ordSide == consts.OrderSideSell && tpSlType == consts.SlOrderType {
-			totalPrice = helper.BigSumWithPercent(ordPrice, percent)
-		}
-		/*
-			result, err := b.excInfo.GetPriceBySizeRules(totalPrice.String(), symbol)
-			if err != nil {
-				return err
-			}*/
+// setRiskQuantity sizes the order so that hitting the stop loss costs the requested percent of the free USDT balance.
+// The quantity is rounded to the lot step and checked against exchange minimums by validateInputParams.
+func (o *Order) setRiskQuantity(keys *domain.ApiKeys, orderDto *dto.Order, settings *domain.Settings) error {
+	entryPrice := orderDto.Price
+	if orderDto.OrderType == consts.OrderTypeMarket || entryPrice == "" {
+		entryPrice = o.getCachedPrice(orderDto.Exchange, orderDto.Symbol)
+	}
+	if entryPrice == "" {
+		return errors.BadRequestError(o.i18n.T(errRiskNoPrice, nil, "ru"))
+	}
 
-		return totalPrice.String()
+	var slPrice string
+	if settings != nil {
+		slPrice = settings.GetTpSlPrice(&domain.Order{
+			Side:  strings.ToUpper(orderDto.Side),
+			Price: entryPrice,
+		}, consts.SlOrderType)
 	}
 
-	if tpSlType == consts.TpOrderType {
-		if settings.TpPrice == "" && settings.TpPercent != "" {
-			return percentPrice(order.Price, settings.TpPercent, order.Side)
-		} else {
-			return settings.TpPrice
-		}
-	} else if tpSlType == consts.SlOrderType {
-		if settings.SlPrice == "" && settings.SlPercent != "" {
-			return percentPrice(order.Price, settings.SlPercent, order.Side)
-		} else {
-			return settings.SlPrice
+	balance, err := o.exchanger.Balance(keys, orderDto.Exchange)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get balance for risk sizing: ", err)
+
+		return errors.BadRequestError(err.Error())
+	}
+
+	quantity, err := domain.RiskQuantity(strings.ToUpper(orderDto.Side),
+		balance.GetFree(domain.UsdtAsset),
+		orderDto.RiskPercent,
+		entryPrice,
+		slPrice)
+	if err != nil {
+		return errors.BadRequestError(o.i18n.T(riskErrors[err], nil, "ru"))
+	}
+	orderDto.Quantity = quantity
+
+	return nil
+}
+
+// validateInputParams rounds the order to the symbol filters and checks it together with its tp/sl legs
+// before anything is sent to the exchange. Symbols without known filters are passed as is.
+func (o *Order) validateInputParams(orderDto *dto.Order, settings *domain.Settings) error {
+	filters := o.getSymbolFilters(orderDto.Exchange, orderDto.Symbol)
+	if filters == nil {
+		return nil
+	}
+
+	if orderDto.Price != "" {
+		orderDto.Price = filters.RoundPrice(orderDto.Price)
+	}
+	if orderDto.StopPrice != "" {
+		orderDto.StopPrice = filters.RoundPrice(orderDto.StopPrice)
+	}
+	orderDto.Quantity = filters.RoundQuantity(orderDto.Quantity)
+
+	markPrice := o.getCachedPrice(orderDto.Exchange, orderDto.Symbol)
+	if err := filters.Validate(orderDto.Price, orderDto.Quantity, markPrice); err != nil {
+		return o.filterError(err, filters)
+	}
+
+	baseOrder := &domain.Order{
+		Side:     strings.ToUpper(orderDto.Side),
+		Price:    orderDto.Price,
+		Quantity: orderDto.Quantity,
+	}
+	if baseOrder.Price == "" {
+		baseOrder.Price = markPrice
+	}
+	if baseOrder.Price == "" {
+		return nil
+	}
+	for _, leg := range settings.GetTpSlLegs(baseOrder) {
+		if err := filters.Validate(filters.RoundPrice(leg.Price), filters.RoundQuantity(leg.Quantity), ""); err != nil {
+			return o.filterError(err, filters)
 		}
 	}
 
-	return ""
+	return nil
 }
 
-func (o *Order) getTpSlSide(side string) string {
-	if side == consts.OrderSideSell {
-		return consts.OrderSideBuy
+func (o *Order) checkRiskLimits(ctx context.Context, userId int64, orderDto *dto.Order) error {
+	if o.risk == nil {
+		return nil
+	}
+
+	price := orderDto.Price
+	if orderDto.OrderType == consts.OrderTypeMarket || price == "" {
+		price = o.getCachedPrice(orderDto.Exchange, orderDto.Symbol)
 	}
 
-	return consts.OrderSideSell
+	return o.risk.CheckOrder(ctx, userId, orderDto, price)
 }
 
-func (o *Order) getExchangeQueue(exchange string) *domain.OrdersQueue {
-	switch exchange {
-	case consts.Binance:
-		return o.binanceQueue
-	default:
+func (o *Order) getSymbolFilters(exchange, symbol string) *domain.SymbolFilters {
+	if o.filters == nil {
+		return nil
+	}
+
+	filters, err := o.filters.GetFilters(exchange, symbol)
+	if err != nil {
+		o.logger.ErrorLog.Println("err get symbol filters: ", err)
+
 		return nil
 	}
+
+	return filters
+}
+
+func (o *Order) roundPrice(exchange, symbol, price string) string {
+	if filters := o.getSymbolFilters(exchange, symbol); filters != nil {
+		return filters.RoundPrice(price)
+	}
+
+	return price
+}
+
+func (o *Order) getCachedPrice(exchange, symbol string) string {
+	if o.keyDbCli == nil {
+		return ""
+	}
+
+	var price string
+	if err := o.keyDbCli.Get(context.Background(),
+		consts.TradePriceCacheKey+exchange+"_"+symbol).Scan(&price); err != nil {
+		return ""
+	}
+
+	return price
+}
+
+func (o *Order) filterError(err error, filters *domain.SymbolFilters) error {
+	key, ok := filterErrors[err]
+	if !ok {
+		return errors.BadRequestError(err.Error())
+	}
+
+	return errors.BadRequestError(o.i18n.T(key, map[string]interface{}{
+		"Symbol":      filters.Symbol,
+		"MinQty":      filters.MinQty,
+		"MaxQty":      filters.MaxQty,
+		"MinPrice":    filters.MinPrice,
+		"MaxPrice":    filters.MaxPrice,
+		"MinNotional": filters.MinNotional,
+	}, "ru"))
+}
+
+func (o *Order) getExchangeQueue(exchange string) *domain.OrdersQueue {
+	return o.tpSlQueues.Get(exchange)
 }
 
 func (o *Order) getLimitExchangeQueue(exchange string) *domain.OrdersQueue {
-	switch exchange {
-	case consts.Binance:
-		return o.limitBinanceQueue
-	default:
-		return nil
+	return o.limitQueues.Get(exchange)
+}
+
+// holdPrice subscribes the order symbol to the price feed until the order reaches its queue.
+func (o *Order) holdPrice(exchange, symbol string) func() {
+	if o.feed == nil {
+		return func() {}
+	}
+
+	release, err := o.feed.Hold(exchange, symbol)
+	if err != nil {
+		o.logger.ErrorLog.Println("err subscribe price feed: ", exchange, symbol, err)
 	}
+
+	return release
+}
+
+func (o *Order) publish(event domain.OrderEvent) {
+	if o.events != nil {
+		o.events.Publish(event)
+	}
+}
+
+func (o *Order) publishExecuteFailed(order *domain.Order, err error) {
+	event := domain.NewOrderEvent(consts.OrderEventExecuteFailed, order)
+	event.Error = err.Error()
+	o.publish(event)
 }
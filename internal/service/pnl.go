@@ -0,0 +1,108 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+const errInvalidPnlPeriod = "invalidPnlPeriod"
+
+type PriceSource interface {
+	GetPrice(ctx context.Context, exchange, symbol string) (string, error)
+}
+
+type PnlService struct {
+	cfg      *config.Config
+	dealRepo DealRepo
+	prices   PriceSource
+	i18n     *i18n.I18n
+	logger   *log.Logger
+}
+
+func NewPnlService(cfg *config.Config,
+	dealRepo DealRepo,
+	prices PriceSource,
+	i18n *i18n.I18n,
+	logger *log.Logger) *PnlService {
+	return &PnlService{
+		cfg:      cfg,
+		dealRepo: dealRepo,
+		prices:   prices,
+		i18n:     i18n,
+		logger:   logger}
+}
+
+func (p *PnlService) GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error) {
+	period = strings.ToLower(strings.TrimSpace(period))
+	if period == "" {
+		period = consts.PnlPeriodDay
+	}
+	now := time.Now()
+	from, ok := domain.PnlPeriodStart(period, now)
+	if !ok {
+		return nil, errors.BadRequestError(p.i18n.T(errInvalidPnlPeriod, nil, "ru"))
+	}
+
+	deals, err := p.dealRepo.GetDealsSince(ctx, userId, from)
+	if err != nil {
+		p.logger.ErrorLog.Println("err get deals for pnl: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	report := domain.NewPnlReport(period, from, now.UTC())
+	rates := make(map[string]string)
+	for _, v := range deals {
+		v.Calculate(p.cfg.GetTradeFeePercent(), now)
+
+		var markPrice string
+		if v.Status == consts.DealStatusOpen {
+			markPrice = getMarketPrice(ctx, p.prices, p.logger, v.BaseOrder.Exchange, v.BaseOrder.Symbol)
+		}
+		report.Add(v, markPrice, getUsdtRate(ctx, p.prices, p.logger, rates, v.BaseOrder.Exchange, v.BaseOrder.Symbol))
+	}
+
+	return report.Build(), nil
+}
+
+// getUsdtRate returns the USDT price of the symbol quote asset, rates keeps prices already fetched by the caller.
+func getUsdtRate(ctx context.Context,
+	prices PriceSource,
+	logger *log.Logger,
+	rates map[string]string,
+	exchange, symbol string) string {
+	quote := domain.QuoteAsset(symbol)
+	if quote == "" {
+		return ""
+	}
+	if domain.IsUsdStablecoin(quote) {
+		return "1"
+	}
+
+	key := exchange + "_" + quote
+	if rate, ok := rates[key]; ok {
+		return rate
+	}
+	rates[key] = getMarketPrice(ctx, prices, logger, exchange, domain.UsdtSymbol(quote, symbol))
+
+	return rates[key]
+}
+
+func getMarketPrice(ctx context.Context, prices PriceSource, logger *log.Logger, exchange, symbol string) string {
+	price, err := prices.GetPrice(ctx, exchange, symbol)
+	if err != nil {
+		logger.ErrorLog.Println("err get price "+exchange+" "+symbol+": ", err)
+
+		return ""
+	}
+
+	return price
+}
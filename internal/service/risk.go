@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+var riskLimitErrors = map[error]string{
+	domain.ErrRiskLimitPaused:      "riskLimitPaused",
+	domain.ErrRiskLimitSymbol:      "riskLimitSymbol",
+	domain.ErrRiskLimitNotional:    "riskLimitNotional",
+	domain.ErrRiskLimitOpenDeals:   "riskLimitOpenDeals",
+	domain.ErrRiskLimitDailyLoss:   "riskLimitDailyLoss",
+	domain.ErrRiskLimitNoUsdtPrice: "riskLimitNoUsdtPrice",
+	domain.ErrRiskLimitLoosen:      "riskLimitLoosen",
+}
+
+type RiskLimitsRepo interface {
+	GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error)
+	SaveRiskLimits(ctx context.Context, limits *domain.RiskLimits) error
+	SetPausedUntil(ctx context.Context, userId int64, pausedUntil time.Time) error
+	GetDailyLossLimits(ctx context.Context, now time.Time) ([]*domain.RiskLimits, error)
+}
+
+type AdminSource interface {
+	GetAdmins(ctx context.Context) ([]int, error)
+	IsAdmin(ctx context.Context, tgId int64) bool
+}
+
+type RiskNotifier interface {
+	NotifyTradingPaused(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string)
+}
+
+type RiskService struct {
+	cfg      *config.Config
+	riskRepo RiskLimitsRepo
+	dealRepo DealRepo
+	prices   PriceSource
+	admins   AdminSource
+	notifier RiskNotifier
+	i18n     *i18n.I18n
+	logger   *log.Logger
+
+	mu sync.Mutex
+}
+
+func NewRiskService(cfg *config.Config,
+	riskRepo RiskLimitsRepo,
+	dealRepo DealRepo,
+	prices PriceSource,
+	admins AdminSource,
+	notifier RiskNotifier,
+	i18n *i18n.I18n,
+	logger *log.Logger) *RiskService {
+	return &RiskService{
+		cfg:      cfg,
+		riskRepo: riskRepo,
+		dealRepo: dealRepo,
+		prices:   prices,
+		admins:   admins,
+		notifier: notifier,
+		i18n:     i18n,
+		logger:   logger}
+}
+
+func (r *RiskService) GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error) {
+	limits, err := r.riskRepo.GetRiskLimits(ctx, userId)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get risk limits: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	if limits == nil {
+		return &domain.RiskLimits{UserId: userId}, nil
+	}
+
+	return limits, nil
+}
+
+// SetRiskLimits lets a user only tighten the current limits, loosening them is up to the admins.
+// An active daily loss pause is kept.
+func (r *RiskService) SetRiskLimits(ctx context.Context, userId int64, limitsDto *dto.RiskLimits) (*domain.RiskLimits, error) {
+	limits := &domain.RiskLimits{
+		UserId:           userId,
+		MaxOrderNotional: limitsDto.MaxOrderNotional,
+		MaxOpenDeals:     limitsDto.MaxOpenDeals,
+		MaxDailyLoss:     limitsDto.MaxDailyLoss,
+	}
+	for _, v := range limitsDto.AllowedSymbols {
+		limits.AllowedSymbols = append(limits.AllowedSymbols, strings.ToUpper(strings.TrimSpace(v)))
+	}
+
+	current, err := r.riskRepo.GetRiskLimits(ctx, userId)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get risk limits: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	if current != nil && !limits.IsTighterThan(current) && !r.admins.IsAdmin(ctx, userId) {
+		return nil, r.limitError(domain.ErrRiskLimitLoosen, current)
+	}
+	if err := r.riskRepo.SaveRiskLimits(ctx, limits); err != nil {
+		r.logger.ErrorLog.Println("err save risk limits: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	return r.GetRiskLimits(ctx, userId)
+}
+
+// CheckOrder rejects an order breaking the user limits. price is the expected entry price of the order.
+// Reaching the daily loss limit here trips the circuit breaker as well.
+func (r *RiskService) CheckOrder(ctx context.Context, userId int64, order *dto.Order, price string) error {
+	limits, err := r.riskRepo.GetRiskLimits(ctx, userId)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get risk limits: ", err)
+
+		return errors.InternalServerError(err)
+	}
+	if limits == nil {
+		return nil
+	}
+
+	now := time.Now()
+	if limits.IsPaused(now) {
+		return r.limitError(domain.ErrRiskLimitPaused, limits)
+	}
+	if !limits.IsSymbolAllowed(order.Symbol) {
+		return r.limitError(domain.ErrRiskLimitSymbol, limits)
+	}
+	if limits.HasNotionalLimit() {
+		rate := getUsdtRate(ctx, r.prices, r.logger, make(map[string]string), order.Exchange, order.Symbol)
+		if err := limits.CheckNotional(price, order.Quantity, rate); err != nil {
+			return r.limitError(err, limits)
+		}
+	}
+	if limits.MaxOpenDeals == 0 && !limits.HasDailyLossLimit() {
+		return nil
+	}
+
+	openDeals, dayPnl, err := r.getDayStats(ctx, userId, now)
+	if err != nil {
+		return err
+	}
+	if limits.IsDailyLossReached(dayPnl) {
+		r.pause(ctx, limits, dayPnl, now)
+
+		return r.limitError(domain.ErrRiskLimitDailyLoss, limits)
+	}
+	if limits.IsOpenDealsReached(openDeals) {
+		return r.limitError(domain.ErrRiskLimitOpenDeals, limits)
+	}
+
+	return nil
+}
+
+// CheckDailyLoss trips the circuit breaker once closed deals of the day lose more than the user limit.
+func (r *RiskService) CheckDailyLoss(ctx context.Context, userId int64) {
+	limits, err := r.riskRepo.GetRiskLimits(ctx, userId)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get risk limits: ", err)
+
+		return
+	}
+	if limits == nil {
+		return
+	}
+
+	r.checkDailyLoss(ctx, limits, time.Now())
+}
+
+// CheckDailyLosses runs the daily loss check for every user with a daily loss limit,
+// so the circuit breaker does not depend on execution events reaching Listen.
+func (r *RiskService) CheckDailyLosses(ctx context.Context) {
+	now := time.Now()
+	limitsList, err := r.riskRepo.GetDailyLossLimits(ctx, now)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get daily loss limits: ", err)
+
+		return
+	}
+	for _, limits := range limitsList {
+		r.checkDailyLoss(ctx, limits, now)
+	}
+}
+
+// Listen re-checks the daily loss of a user after each executed take profit or stop loss
+// and of all limited users every period, as events may be dropped by the bus.
+func (r *RiskService) Listen(ctx context.Context, events <-chan domain.OrderEvent, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			r.CheckDailyLosses(ctx)
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			if event.Type == consts.OrderEventTpExecuted || event.Type == consts.OrderEventSlExecuted {
+				r.CheckDailyLoss(ctx, event.UserId)
+			}
+		}
+	}
+}
+
+func (r *RiskService) checkDailyLoss(ctx context.Context, limits *domain.RiskLimits, now time.Time) {
+	if !limits.HasDailyLossLimit() || limits.IsPaused(now) {
+		return
+	}
+
+	_, dayPnl, err := r.getDayStats(ctx, limits.UserId, now)
+	if err != nil {
+		return
+	}
+	if limits.IsDailyLossReached(dayPnl) {
+		r.pause(ctx, limits, dayPnl, now)
+	}
+}
+
+func (r *RiskService) getDayStats(ctx context.Context, userId int64, now time.Time) (int, string, error) {
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, now)
+	deals, err := r.dealRepo.GetDealsSince(ctx, userId, from)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get deals for risk limits: ", err)
+
+		return 0, "", errors.InternalServerError(err)
+	}
+
+	var openDeals int
+	report := domain.NewPnlReport(consts.PnlPeriodDay, from, now.UTC())
+	rates := make(map[string]string)
+	for _, v := range deals {
+		v.Calculate(r.cfg.GetTradeFeePercent(), now)
+		if v.Status == consts.DealStatusOpen || v.Status == consts.DealStatusPending {
+			openDeals++
+		}
+		report.Add(v, "", getUsdtRate(ctx, r.prices, r.logger, rates, v.BaseOrder.Exchange, v.BaseOrder.Symbol))
+	}
+
+	return openDeals, report.Build().TotalPnl, nil
+}
+
+// pause stops new orders of the user till the end of the utc day and notifies the user and the admins.
+func (r *RiskService) pause(ctx context.Context, limits *domain.RiskLimits, dayPnl string, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	current, err := r.riskRepo.GetRiskLimits(ctx, limits.UserId)
+	if err == nil && current != nil && current.IsPaused(now) {
+		return
+	}
+
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, now)
+	pausedUntil := from.AddDate(0, 0, 1)
+	if err := r.riskRepo.SetPausedUntil(ctx, limits.UserId, pausedUntil); err != nil {
+		r.logger.ErrorLog.Println("err pause trading: ", err)
+
+		return
+	}
+	limits.PausedUntil = &pausedUntil
+
+	admins, err := r.admins.GetAdmins(ctx)
+	if err != nil {
+		r.logger.ErrorLog.Println("err get admins for circuit breaker: ", err)
+	}
+	if r.notifier != nil {
+		r.notifier.NotifyTradingPaused(ctx, admins, limits, dayPnl)
+	}
+}
+
+func (r *RiskService) limitError(err error, limits *domain.RiskLimits) error {
+	data := map[string]interface{}{
+		"MaxOrderNotional": limits.MaxOrderNotional,
+		"MaxOpenDeals":     limits.MaxOpenDeals,
+		"MaxDailyLoss":     limits.MaxDailyLoss,
+		"AllowedSymbols":   strings.Join(limits.AllowedSymbols, ", "),
+	}
+	if limits.PausedUntil != nil {
+		data["PausedUntil"] = limits.PausedUntil.UTC().Format(time.DateTime)
+	}
+
+	return errors.BadRequestError(r.i18n.T(riskLimitErrors[err], data, "ru"))
+}
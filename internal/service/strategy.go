@@ -0,0 +1,545 @@
+package service
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+const (
+	errStrategyNotFound  = "strategyNotFound"
+	errStrategyNotActive = "strategyNotActive"
+	errStrategyNoPrice   = "strategyNoPrice"
+	errStrategyFailed    = "strategyFailed"
+)
+
+var strategyErrors = map[error]string{
+	domain.ErrStrategyDeviation: "strategyDeviation",
+	domain.ErrStrategyGridRange: "strategyGridRange",
+}
+
+type StrategyRepo interface {
+	CreateStrategy(ctx context.Context, strategy *domain.Strategy) (int64, error)
+	SetStatus(ctx context.Context, id int64, status string) error
+	AddOrder(ctx context.Context, order *domain.StrategyOrder) error
+	SetOrderStatus(ctx context.Context, strategyId, orderId int64, status string) error
+	GetStrategy(ctx context.Context, id int64) (*domain.Strategy, error)
+	GetStrategyByOrderId(ctx context.Context, orderId int64) (*domain.Strategy, error)
+	GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error)
+	GetSettledOrders(ctx context.Context) ([]*domain.StrategyOrder, error)
+}
+
+type StrategyOrderSource interface {
+	CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId int64) (*domain.Order, error)
+	CancelOrderById(ctx context.Context, orderId, tgUserId int64, symbol, exchange string) error
+}
+
+// StrategyService runs DCA and grid bots on top of regular bot orders. Fills come from the order events
+// and from a periodic reconcile against the orders table, so the strategies continue after dropped events
+// and restarts.
+type StrategyService struct {
+	cfg          *config.Config
+	strategyRepo StrategyRepo
+	orders       StrategyOrderSource
+	prices       PriceSource
+	i18n         *i18n.I18n
+	logger       *log.Logger
+
+	mu    sync.Mutex
+	locks map[int64]*strategyLock
+}
+
+type strategyLock struct {
+	sync.Mutex
+	refs int
+}
+
+func NewStrategyService(cfg *config.Config,
+	strategyRepo StrategyRepo,
+	orders StrategyOrderSource,
+	prices PriceSource,
+	i18n *i18n.I18n,
+	logger *log.Logger) *StrategyService {
+	return &StrategyService{
+		cfg:          cfg,
+		strategyRepo: strategyRepo,
+		orders:       orders,
+		prices:       prices,
+		i18n:         i18n,
+		logger:       logger,
+		locks:        make(map[int64]*strategyLock)}
+}
+
+func (s *StrategyService) Start(ctx context.Context, userId int64, strategyDto *dto.Strategy) (*domain.Strategy, error) {
+	strategy := &domain.Strategy{
+		UserId:   userId,
+		Type:     strategyDto.Type,
+		Exchange: strategyDto.Exchange,
+		Symbol:   strings.ToUpper(strategyDto.Symbol),
+		Status:   consts.StrategyStatusActive,
+		Orders:   make([]*domain.StrategyOrder, 0),
+	}
+
+	price := getMarketPrice(ctx, s.prices, s.logger, strategy.Exchange, strategy.Symbol)
+	if price == "" {
+		return nil, errors.BadRequestError(s.i18n.T(errStrategyNoPrice, nil, "ru"))
+	}
+
+	var gridLevels []domain.StrategyLevel
+	switch strategy.Type {
+	case consts.StrategyTypeDca:
+		strategy.Dca = &domain.DcaParams{
+			Side:         strings.ToUpper(strategyDto.Side),
+			BaseQty:      strategyDto.Quantity,
+			SafetyOrders: strategyDto.SafetyOrders,
+			Deviation:    strategyDto.Deviation,
+			StepScale:    strategyDto.StepScale,
+			VolumeScale:  strategyDto.VolumeScale,
+			TpPercent:    strategyDto.TpPercent,
+		}
+		if err := strategy.Dca.CheckLevels(price); err != nil {
+			return nil, s.strategyError(err)
+		}
+	case consts.StrategyTypeGrid:
+		strategy.Grid = &domain.GridParams{
+			Lower:    strategyDto.Lower,
+			Upper:    strategyDto.Upper,
+			Levels:   strategyDto.Levels,
+			Quantity: strategyDto.Quantity,
+		}
+		if gridLevels = strategy.Grid.InitialLevels(price); len(gridLevels) == 0 {
+			return nil, s.strategyError(domain.ErrStrategyGridRange)
+		}
+	}
+
+	id, err := s.strategyRepo.CreateStrategy(ctx, strategy)
+	if err != nil {
+		s.logger.ErrorLog.Println("err create strategy: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	strategy.Id = id
+	unlock := s.lock(strategy.Id)
+	defer unlock()
+
+	if strategy.Type == consts.StrategyTypeDca {
+		if err = s.startDca(ctx, strategy); err != nil {
+			return nil, err
+		}
+
+		return strategy, nil
+	}
+
+	for _, v := range gridLevels {
+		if _, err = s.placeOrder(ctx, strategy, v, consts.StrategyOrderGrid, consts.OrderTypeLimit); err != nil {
+			break
+		}
+	}
+	if err != nil {
+		s.stop(ctx, strategy)
+
+		return nil, err
+	}
+
+	return strategy, nil
+}
+
+// Stop cancels open orders of the strategy. Filled orders and positions are left as they are.
+func (s *StrategyService) Stop(ctx context.Context, userId, id int64) (*domain.Strategy, error) {
+	unlock := s.lock(id)
+	defer unlock()
+
+	strategy, err := s.GetStrategy(ctx, userId, id)
+	if err != nil {
+		return nil, err
+	}
+	if !strategy.IsActive() {
+		return nil, errors.BadRequestError(s.i18n.T(errStrategyNotActive, nil, "ru"))
+	}
+	if err = s.stop(ctx, strategy); err != nil {
+		return nil, err
+	}
+
+	return strategy, nil
+}
+
+func (s *StrategyService) GetStrategy(ctx context.Context, userId, id int64) (*domain.Strategy, error) {
+	strategy, err := s.strategyRepo.GetStrategy(ctx, id)
+	if err != nil {
+		s.logger.ErrorLog.Println("err get strategy: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+	if strategy == nil || strategy.UserId != userId {
+		return nil, errors.BadRequestError(s.i18n.T(errStrategyNotFound, nil, "ru"))
+	}
+
+	return strategy, nil
+}
+
+func (s *StrategyService) GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error) {
+	strategies, err := s.strategyRepo.GetStrategies(ctx, userId)
+	if err != nil {
+		s.logger.ErrorLog.Println("err get strategies: ", err)
+
+		return nil, errors.InternalServerError(err)
+	}
+
+	return strategies, nil
+}
+
+// Listen moves strategies forward on filled orders and tracks orders canceled outside of the strategy.
+// The bus drops events when a subscriber is slow, so settled orders are also reconciled every period.
+func (s *StrategyService) Listen(ctx context.Context, events <-chan domain.OrderEvent, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+	s.Reconcile(ctx)
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.Reconcile(ctx)
+		case event, ok := <-events:
+			if !ok {
+				return
+			}
+			var err error
+			switch event.Type {
+			case consts.OrderEventFilled:
+				err = s.HandleFilled(ctx, event.OrderId)
+			case consts.OrderEventCanceled:
+				err = s.HandleCanceled(ctx, event.OrderId)
+			}
+			if err != nil {
+				s.logger.ErrorLog.Println("err handle strategy order event: ", event.Type, event.OrderId, err)
+			}
+		}
+	}
+}
+
+// Reconcile handles strategy orders which were filled or canceled in the orders table
+// without the strategy seeing the event.
+func (s *StrategyService) Reconcile(ctx context.Context) {
+	orders, err := s.strategyRepo.GetSettledOrders(ctx)
+	if err != nil {
+		s.logger.ErrorLog.Println("err get settled strategy orders: ", err)
+
+		return
+	}
+
+	for _, v := range orders {
+		switch v.Status {
+		case consts.OrderStatusFilled:
+			err = s.HandleFilled(ctx, v.OrderId)
+		case consts.OrderStatusCanceled:
+			err = s.HandleCanceled(ctx, v.OrderId)
+		}
+		if err != nil {
+			s.logger.ErrorLog.Println("err reconcile strategy order: ", v.StrategyId, v.OrderId, err)
+		}
+	}
+}
+
+// HandleFilled re-places the take profit on a DCA safety fill, completes a DCA bot on its take profit
+// and places the opposite grid order on a grid fill. The fill is saved last, so the order stays active
+// in the strategy and is retried by Reconcile until its follow-up succeeds.
+func (s *StrategyService) HandleFilled(ctx context.Context, orderId int64) error {
+	strategy, order, unlock, err := s.lockActiveOrder(ctx, orderId)
+	if err != nil || order == nil {
+		return err
+	}
+	defer unlock()
+
+	order.Status = consts.OrderStatusFilled
+	switch {
+	case strategy.Grid != nil:
+		if level, ok := strategy.Grid.OppositeLevel(order); ok && !strategy.HasActiveLevel(level) {
+			_, err = s.placeOrder(ctx, strategy, level, consts.StrategyOrderGrid, consts.OrderTypeLimit)
+		}
+	case strategy.Dca != nil && order.Role == consts.StrategyOrderSafety:
+		if err = s.cancelOrders(ctx, strategy, strategy.GetActiveOrders(consts.StrategyOrderTp)); err == nil {
+			err = s.placeDcaTp(ctx, strategy)
+		}
+	case strategy.Dca != nil && order.Role == consts.StrategyOrderTp:
+		if err = s.cancelOrders(ctx, strategy, strategy.GetActiveOrders(consts.StrategyOrderSafety)); err == nil {
+			err = s.setStatus(ctx, strategy, consts.StrategyStatusCompleted)
+		}
+	}
+	if err != nil {
+		order.Status = consts.OrderStatusActive
+
+		return err
+	}
+
+	return s.setOrderStatus(ctx, order, consts.OrderStatusFilled)
+}
+
+func (s *StrategyService) HandleCanceled(ctx context.Context, orderId int64) error {
+	_, order, unlock, err := s.lockActiveOrder(ctx, orderId)
+	if err != nil || order == nil {
+		return err
+	}
+	defer unlock()
+
+	return s.setOrderStatus(ctx, order, consts.OrderStatusCanceled)
+}
+
+// startDca stops the strategy when the base order is not placed. Once the base order is executed
+// the position can not be rolled back, so a later error marks the strategy failed and tells the user.
+func (s *StrategyService) startDca(ctx context.Context, strategy *domain.Strategy) error {
+	base, err := s.createOrder(ctx, strategy, domain.StrategyLevel{
+		Side:     strategy.Dca.Side,
+		Quantity: strategy.Dca.BaseQty,
+	}, consts.StrategyOrderBase, consts.OrderTypeMarket)
+	if err != nil {
+		s.stop(ctx, strategy)
+
+		return err
+	}
+
+	if err = s.addOrder(ctx, strategy, base); err == nil {
+		err = s.placeDcaOrders(ctx, strategy, base)
+	}
+	if err != nil {
+		return s.fail(ctx, strategy, base)
+	}
+
+	return nil
+}
+
+func (s *StrategyService) placeDcaOrders(ctx context.Context, strategy *domain.Strategy, base *domain.StrategyOrder) error {
+	for _, v := range strategy.Dca.SafetyLevels(base.Price) {
+		if _, err := s.placeOrder(ctx, strategy, v, consts.StrategyOrderSafety, consts.OrderTypeLimit); err != nil {
+			return err
+		}
+	}
+
+	return s.placeDcaTp(ctx, strategy)
+}
+
+// fail cancels the placed orders and keeps the strategy as failed with the executed base order.
+func (s *StrategyService) fail(ctx context.Context, strategy *domain.Strategy, base *domain.StrategyOrder) error {
+	if err := s.cancelOrders(ctx, strategy, strategy.GetActiveOrders()); err != nil {
+		s.logger.ErrorLog.Println("err cancel orders of failed strategy: ", strategy.Id, err)
+	}
+	if err := s.setStatus(ctx, strategy, consts.StrategyStatusFailed); err != nil {
+		s.logger.ErrorLog.Println("err mark strategy failed: ", strategy.Id, err)
+	}
+
+	return errors.BadRequestError(s.i18n.T(errStrategyFailed, map[string]interface{}{
+		"Id":       strategy.Id,
+		"OrderId":  base.OrderId,
+		"Side":     base.Side,
+		"Quantity": base.Quantity,
+		"Symbol":   strategy.Symbol,
+		"Price":    base.Price,
+	}, "ru"))
+}
+
+func (s *StrategyService) placeDcaTp(ctx context.Context, strategy *domain.Strategy) error {
+	avgPrice, quantity := strategy.AverageEntry()
+	if avgPrice == "" {
+		return nil
+	}
+	_, err := s.placeOrder(ctx, strategy, domain.StrategyLevel{
+		Side:     strategy.Dca.TpSide(),
+		Price:    strategy.Dca.TakeProfitPrice(avgPrice),
+		Quantity: quantity,
+	}, consts.StrategyOrderTp, consts.OrderTypeLimit)
+
+	return err
+}
+
+func (s *StrategyService) placeOrder(ctx context.Context,
+	strategy *domain.Strategy,
+	level domain.StrategyLevel,
+	role, orderType string) (*domain.StrategyOrder, error) {
+	strategyOrder, err := s.createOrder(ctx, strategy, level, role, orderType)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.addOrder(ctx, strategy, strategyOrder); err != nil {
+		if cancelErr := s.orders.CancelOrderById(ctx, strategyOrder.OrderId, strategy.UserId, strategy.Symbol,
+			strategy.Exchange); cancelErr != nil {
+			s.logger.ErrorLog.Println("err cancel unsaved strategy order: ", strategy.Id, strategyOrder.OrderId, cancelErr)
+		}
+
+		return nil, err
+	}
+
+	return strategyOrder, nil
+}
+
+// createOrder places the order on the exchange. The result is not a part of the strategy till addOrder.
+func (s *StrategyService) createOrder(ctx context.Context,
+	strategy *domain.Strategy,
+	level domain.StrategyLevel,
+	role, orderType string) (*domain.StrategyOrder, error) {
+	orderDto := &dto.Order{
+		Exchange:  strategy.Exchange,
+		Symbol:    strategy.Symbol,
+		Side:      level.Side,
+		OrderType: orderType,
+		Quantity:  level.Quantity,
+	}
+	if orderType == consts.OrderTypeLimit {
+		orderDto.Price = level.Price
+		orderDto.TimeInForce = consts.TimeInForceGTC
+	}
+
+	order, err := s.orders.CreateOrder(ctx, orderDto, strategy.UserId)
+	if err != nil {
+		s.logger.ErrorLog.Println("err create strategy order: ", strategy.Id, role, err)
+
+		return nil, err
+	}
+
+	return &domain.StrategyOrder{
+		StrategyId: strategy.Id,
+		OrderId:    order.Id,
+		Role:       role,
+		Level:      level.Level,
+		Side:       order.Side,
+		Price:      order.Price,
+		Quantity:   order.Quantity,
+		Status:     order.Status,
+	}, nil
+}
+
+func (s *StrategyService) addOrder(ctx context.Context, strategy *domain.Strategy, order *domain.StrategyOrder) error {
+	if err := s.strategyRepo.AddOrder(ctx, order); err != nil {
+		s.logger.ErrorLog.Println("err save strategy order: ", strategy.Id, order.OrderId, err)
+
+		return errors.InternalServerError(err)
+	}
+	strategy.Orders = append(strategy.Orders, order)
+
+	return nil
+}
+
+func (s *StrategyService) stop(ctx context.Context, strategy *domain.Strategy) error {
+	if err := s.cancelOrders(ctx, strategy, strategy.GetActiveOrders()); err != nil {
+		s.logger.ErrorLog.Println("err cancel orders of stopped strategy: ", strategy.Id, err)
+	}
+
+	return s.setStatus(ctx, strategy, consts.StrategyStatusStopped)
+}
+
+// cancelOrders keeps going on exchange errors, an order may be already filled or canceled there,
+// and returns the first error once all orders are tried. Orders failed to cancel stay active.
+func (s *StrategyService) cancelOrders(ctx context.Context, strategy *domain.Strategy, orders []*domain.StrategyOrder) error {
+	var result error
+	for _, v := range orders {
+		err := s.orders.CancelOrderById(ctx, v.OrderId, strategy.UserId, strategy.Symbol, strategy.Exchange)
+		if err != nil {
+			s.logger.ErrorLog.Println("err cancel strategy order: ", strategy.Id, v.OrderId, err)
+		} else {
+			err = s.setOrderStatus(ctx, v, consts.OrderStatusCanceled)
+		}
+		if err != nil && result == nil {
+			result = err
+		}
+	}
+
+	return result
+}
+
+// lock serializes work on one strategy, so exchange calls of one strategy do not block the others.
+func (s *StrategyService) lock(id int64) func() {
+	s.mu.Lock()
+	l, ok := s.locks[id]
+	if !ok {
+		l = new(strategyLock)
+		s.locks[id] = l
+	}
+	l.refs++
+	s.mu.Unlock()
+
+	l.Lock()
+
+	return func() {
+		l.Unlock()
+		s.mu.Lock()
+		if l.refs--; l.refs == 0 {
+			delete(s.locks, id)
+		}
+		s.mu.Unlock()
+	}
+}
+
+// lockActiveOrder locks the strategy owning the order and reads it again under the lock,
+// as another fill of the strategy may have been handled meanwhile.
+func (s *StrategyService) lockActiveOrder(ctx context.Context, orderId int64) (*domain.Strategy, *domain.StrategyOrder, func(), error) {
+	strategy, order, err := s.getActiveOrder(ctx, orderId)
+	if err != nil || order == nil {
+		return nil, nil, nil, err
+	}
+
+	unlock := s.lock(strategy.Id)
+	strategy, order, err = s.getActiveOrder(ctx, orderId)
+	if err != nil || order == nil {
+		unlock()
+
+		return nil, nil, nil, err
+	}
+
+	return strategy, order, unlock, nil
+}
+
+func (s *StrategyService) getActiveOrder(ctx context.Context, orderId int64) (*domain.Strategy, *domain.StrategyOrder, error) {
+	strategy, err := s.strategyRepo.GetStrategyByOrderId(ctx, orderId)
+	if err != nil {
+		s.logger.ErrorLog.Println("err get strategy by order: ", err)
+
+		return nil, nil, errors.InternalServerError(err)
+	}
+	if strategy == nil {
+		return nil, nil, nil
+	}
+	order := strategy.GetOrder(orderId)
+	if order == nil || order.Status != consts.OrderStatusActive {
+		return strategy, nil, nil
+	}
+
+	return strategy, order, nil
+}
+
+func (s *StrategyService) setOrderStatus(ctx context.Context, order *domain.StrategyOrder, status string) error {
+	if err := s.strategyRepo.SetOrderStatus(ctx, order.StrategyId, order.OrderId, status); err != nil {
+		s.logger.ErrorLog.Println("err set strategy order status: ", err)
+
+		return errors.InternalServerError(err)
+	}
+	order.Status = status
+
+	return nil
+}
+
+func (s *StrategyService) setStatus(ctx context.Context, strategy *domain.Strategy, status string) error {
+	if err := s.strategyRepo.SetStatus(ctx, strategy.Id, status); err != nil {
+		s.logger.ErrorLog.Println("err set strategy status: ", err)
+
+		return errors.InternalServerError(err)
+	}
+	strategy.Status = status
+
+	return nil
+}
+
+func (s *StrategyService) strategyError(err error) error {
+	if key, ok := strategyErrors[err]; ok {
+		return errors.BadRequestError(s.i18n.T(key, nil, "ru"))
+	}
+
+	return errors.BadRequestError(err.Error())
+}
@@ -26,7 +26,7 @@ func NewSymbolService(orderRepo OrderRepo, symbolsRepo SymbolRepo, logger *log.L
 	}
 }
 
-func (s *SymbolService) GetActiveSymbols(ctx context.Context) (list domain.SymbolList, err error) {
+func (s *SymbolService) GetActiveSymbols(ctx context.Context) (list []domain.Symbols, err error) {
 	list, err = s.orderRepo.GetActiveSymbols(ctx)
 	if err != nil {
 		s.logger.ErrorLog.Println("err get active symbols: ", err)
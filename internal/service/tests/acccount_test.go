@@ -26,7 +26,7 @@ func TestAccountService_GetBalance(t *testing.T) {
 	mockLogger := log.NewLogger()
 	cfg := &config.Config{}
 	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
-	accountService := service.NewAccountService(cfg, mockApiKeyRepo, mockExchanger, i18nSrv, mockLogger)
+	accountService := service.NewAccountService(cfg, mockApiKeyRepo, mockExchanger, nil, i18nSrv, mockLogger)
 
 	testCases := []struct {
 		name              string
@@ -37,7 +37,6 @@ func TestAccountService_GetBalance(t *testing.T) {
 		expectedErrorCode int
 		apiKeyRepoError   error
 		exchangerError    error
-		apiKeysRepoError  error
 		emptyApiKeys      bool
 		invalidPrivKey    bool
 		invalidPassKey    bool
@@ -51,7 +50,6 @@ func TestAccountService_GetBalance(t *testing.T) {
 			expectedErrorCode: 200,
 			apiKeyRepoError:   nil,
 			exchangerError:    nil,
-			apiKeysRepoError:  nil,
 			emptyApiKeys:      false,
 			invalidPrivKey:    false,
 			invalidPassKey:    false,
@@ -65,7 +63,6 @@ func TestAccountService_GetBalance(t *testing.T) {
 			expectedErrorCode: 500,
 			apiKeyRepoError:   errors.New("error getting API keys"),
 			exchangerError:    nil,
-			apiKeysRepoError:  nil,
 			emptyApiKeys:      true,
 			invalidPrivKey:    false,
 			invalidPassKey:    false,
@@ -75,11 +72,10 @@ func TestAccountService_GetBalance(t *testing.T) {
 			apiKeys:           nil,
 			exchange:          "",
 			expectedBalance:   nil,
-			expectedError:     srvErr.BadRequestError("empty exchange name"),
+			expectedError:     srvErr.BadRequestError(i18nSrv.T("emptyExchange", nil, "ru")),
 			expectedErrorCode: 400,
 			apiKeyRepoError:   nil,
 			exchangerError:    nil,
-			apiKeysRepoError:  nil,
 			emptyApiKeys:      true,
 			invalidPrivKey:    false,
 			invalidPassKey:    false,
@@ -93,7 +89,6 @@ func TestAccountService_GetBalance(t *testing.T) {
 			expectedErrorCode: 400,
 			apiKeyRepoError:   nil,
 			exchangerError:    nil,
-			apiKeysRepoError:  nil,
 			emptyApiKeys:      false,
 			invalidPrivKey:    true,
 			invalidPassKey:    false,
@@ -107,7 +102,6 @@ func TestAccountService_GetBalance(t *testing.T) {
 			expectedErrorCode: 400,
 			apiKeyRepoError:   nil,
 			exchangerError:    nil,
-			apiKeysRepoError:  nil,
 			emptyApiKeys:      false,
 			invalidPrivKey:    false,
 			invalidPassKey:    true,
@@ -116,7 +110,10 @@ func TestAccountService_GetBalance(t *testing.T) {
 
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
-			mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), gomock.Any(), tc.exchange).Return(tc.apiKeys, tc.apiKeysRepoError)
+			if tc.exchange != "" {
+				mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), gomock.Any(), tc.exchange).
+					Return(tc.apiKeys, tc.apiKeyRepoError)
+			}
 			if !tc.emptyApiKeys {
 				if tc.invalidPrivKey {
 					tc.apiKeys.DecodePrivKey("")
@@ -140,3 +137,113 @@ func TestAccountService_GetBalance(t *testing.T) {
 		})
 	}
 }
+
+func TestAccountService_GetPortfolio(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+	mockExchanger := mock_service.NewMockExchanger(ctrl)
+	mockPrices := mock_service.NewMockPriceSource(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	accountService := service.NewAccountService(config.NewConfig(), mockApiKeyRepo, mockExchanger, mockPrices,
+		i18nSrv, log.NewLogger())
+
+	binanceKeys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+	paperKeys := domain.NewApiKeys(7, consts.Paper, "", "", "")
+
+	testCases := []struct {
+		name          string
+		exchange      string
+		prepare       func()
+		expected      *domain.Portfolio
+		expectedError error
+	}{
+		{
+			name:     "aggregate all exchanges",
+			exchange: "",
+			prepare: func() {
+				mockApiKeyRepo.EXPECT().GetApiKeysByUserId(gomock.Any(), int64(7)).
+					Return([]*domain.ApiKeys{binanceKeys, paperKeys}, nil)
+				mockExchanger.EXPECT().Balance(binanceKeys, consts.Binance).Return(domain.Balance{
+					domain.NewBalanceSymbol("USDT", "80", "20"),
+					domain.NewBalanceSymbol("BTC", "0.01", "0"),
+					domain.NewBalanceSymbol("DOGE", "1", "0"),
+					domain.NewBalanceSymbol("XYZ", "5", ""),
+					domain.NewBalanceSymbol("ETH", "0.00000000", "0.00000000"),
+				}, nil)
+				mockExchanger.EXPECT().Balance(paperKeys, consts.Paper).Return(nil, errors.New("db is down"))
+				mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return("30000", nil)
+				mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "DOGEUSDT").Return("", errors.New("redis: nil"))
+				mockExchanger.EXPECT().GetPrice(binanceKeys, consts.Binance, "DOGEUSDT").Return("0.1", nil)
+				mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "XYZUSDT").Return("", errors.New("redis: nil"))
+				mockExchanger.EXPECT().GetPrice(binanceKeys, consts.Binance, "XYZUSDT").
+					Return("", errors.New("invalid symbol"))
+			},
+			expected: &domain.Portfolio{
+				Currency:  domain.UsdtAsset,
+				Total:     "400.00",
+				Exchanges: []string{consts.Binance},
+				Assets: []*domain.PortfolioAsset{
+					{Exchange: consts.Binance, Asset: "BTC", Free: "0.01", Locked: "0", Total: "0.01",
+						Price: "30000", Value: "300.00", Share: "75.00"},
+					{Exchange: consts.Binance, Asset: "USDT", Free: "80", Locked: "20", Total: "100",
+						Price: "1", Value: "100.00", Share: "25.00"},
+					{Exchange: consts.Binance, Asset: "XYZ", Free: "5", Locked: "0", Total: "5"},
+				},
+			},
+		},
+		{
+			name:     "single exchange error",
+			exchange: consts.Binance,
+			prepare: func() {
+				mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).
+					Return(binanceKeys, nil)
+				mockExchanger.EXPECT().Balance(binanceKeys, consts.Binance).Return(nil, errors.New("invalid api key"))
+			},
+			expectedError: srvErr.BadRequestError("invalid api key"),
+		},
+		{
+			name:     "no api keys",
+			exchange: "",
+			prepare: func() {
+				mockApiKeyRepo.EXPECT().GetApiKeysByUserId(gomock.Any(), int64(7)).Return(nil, nil)
+			},
+			expectedError: srvErr.BadRequestError(i18nSrv.T("apiKeysNotFound", nil, "ru")),
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tc.prepare()
+
+			portfolio, err := accountService.GetPortfolio(context.Background(), 7, tc.exchange)
+			if tc.expectedError != nil {
+				assert.Equal(t, tc.expectedError, err)
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expected.Total, portfolio.Total)
+			assert.Equal(t, tc.expected.Exchanges, portfolio.Exchanges)
+			assert.Equal(t, len(tc.expected.Assets), len(portfolio.Assets))
+			for i, v := range tc.expected.Assets {
+				assert.Equal(t, *v, withoutValue(*portfolio.Assets[i]))
+			}
+		})
+	}
+}
+
+func withoutValue(asset domain.PortfolioAsset) domain.PortfolioAsset {
+	return domain.PortfolioAsset{
+		Exchange: asset.Exchange,
+		Asset:    asset.Asset,
+		Free:     asset.Free,
+		Locked:   asset.Locked,
+		Total:    asset.Total,
+		Price:    asset.Price,
+		Value:    asset.Value,
+		Share:    asset.Share,
+	}
+}
@@ -0,0 +1,231 @@
+package tests_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type alertMocks struct {
+	repo     *mock_service.MockPriceAlertRepo
+	prices   *mock_service.MockPriceSource
+	feed     *mock_service.MockPriceFeed
+	notifier *mock_service.MockAlertNotifier
+}
+
+func newAlertService(t *testing.T) (*service.AlertService, alertMocks, *i18n.I18n) {
+	ctrl := gomock.NewController(t)
+	m := alertMocks{
+		repo:     mock_service.NewMockPriceAlertRepo(ctrl),
+		prices:   mock_service.NewMockPriceSource(ctrl),
+		feed:     mock_service.NewMockPriceFeed(ctrl),
+		notifier: mock_service.NewMockAlertNotifier(ctrl),
+	}
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+
+	return service.NewAlertService(&config.Config{}, m.repo, m.prices, m.feed, m.notifier, i18nSrv, log.NewLogger()),
+		m, i18nSrv
+}
+
+func TestAlertService_CheckAlert(t *testing.T) {
+	testCases := []struct {
+		name          string
+		alert         *domain.PriceAlert
+		prices        []string
+		expectedFired []string
+		expectedLeft  bool
+	}{
+		{
+			name:          "One-shot above fires once",
+			alert:         &domain.PriceAlert{Condition: consts.AlertConditionAbove, Value: "70000"},
+			prices:        []string{"69000", "70000", "71000"},
+			expectedFired: []string{"70000"},
+		},
+		{
+			name: "Recurring above re-arms below the level",
+			alert: &domain.PriceAlert{Condition: consts.AlertConditionAbove, Value: "70000",
+				Recurring: true},
+			prices:        []string{"70500", "71000", "69000", "70100"},
+			expectedFired: []string{"70500", "70100"},
+			expectedLeft:  true,
+		},
+		{
+			name:          "Below fires under the level",
+			alert:         &domain.PriceAlert{Condition: consts.AlertConditionBelow, Value: "60000"},
+			prices:        []string{"61000", "59000"},
+			expectedFired: []string{"59000"},
+		},
+		{
+			name: "Recurring crosses fires both ways",
+			alert: &domain.PriceAlert{Condition: consts.AlertConditionCrosses, Value: "65000", BasePrice: "64000",
+				Recurring: true},
+			prices:        []string{"64500", "65500", "66000", "64900"},
+			expectedFired: []string{"65500", "64900"},
+			expectedLeft:  true,
+		},
+		{
+			name: "Recurring move measures from the last alert",
+			alert: &domain.PriceAlert{Condition: consts.AlertConditionMove, Value: "5", BasePrice: "100",
+				Recurring: true},
+			prices:        []string{"104", "95", "99", "90"},
+			expectedFired: []string{"95", "90"},
+			expectedLeft:  true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alertService, m, _ := newAlertService(t)
+
+			alert := tc.alert
+			alert.Id, alert.UserId, alert.Exchange, alert.Symbol = 1, 7, consts.Binance, "BTCUSDT"
+			alert.Armed, alert.Status = true, consts.AlertStatusActive
+			m.repo.EXPECT().GetActiveAlerts(gomock.Any()).Return([]*domain.PriceAlert{alert}, nil)
+			m.repo.EXPECT().UpdateAlert(gomock.Any(), alert).Return(nil).AnyTimes()
+			var fired []string
+			m.notifier.EXPECT().NotifyPriceAlert(gomock.Any(), alert, gomock.Any(), gomock.Any()).
+				Do(func(ctx context.Context, alert *domain.PriceAlert, price, change string) {
+					fired = append(fired, price)
+				}).AnyTimes()
+			if !tc.expectedLeft {
+				m.feed.EXPECT().Release(consts.Binance, "BTCUSDT")
+			}
+
+			require.NoError(t, alertService.LoadAlerts(context.Background()))
+			for _, price := range tc.prices {
+				alertService.CheckAlert(context.Background(), alert, price)
+			}
+
+			assert.Equal(t, tc.expectedFired, fired)
+			assert.Equal(t, tc.expectedLeft, len(alertService.GetActiveAlerts(consts.Binance)) == 1)
+			assert.Equal(t, tc.expectedLeft, alertService.HasSymbol(consts.Binance, "BTCUSDT"))
+			assert.Empty(t, alertService.GetActiveAlerts(consts.Kucoin))
+		})
+	}
+}
+
+func TestAlertService_CheckAlertSavesOutsideLock(t *testing.T) {
+	alertService, m, _ := newAlertService(t)
+
+	alert := &domain.PriceAlert{Id: 1, UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT",
+		Condition: consts.AlertConditionAbove, Value: "70000", Armed: true, Status: consts.AlertStatusActive}
+	m.repo.EXPECT().GetActiveAlerts(gomock.Any()).Return([]*domain.PriceAlert{alert}, nil)
+	m.repo.EXPECT().UpdateAlert(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, saved *domain.PriceAlert) error {
+			done := make(chan struct{})
+			go func() {
+				alertService.GetActiveAlerts(consts.Binance)
+				close(done)
+			}()
+			select {
+			case <-done:
+			case <-time.After(time.Second):
+				t.Error("alert is saved under the service lock")
+			}
+			assert.Equal(t, consts.AlertStatusTriggered, saved.Status)
+
+			return nil
+		})
+	m.notifier.EXPECT().NotifyPriceAlert(gomock.Any(), gomock.Any(), "70000", gomock.Any())
+	m.feed.EXPECT().Release(consts.Binance, "BTCUSDT")
+
+	require.NoError(t, alertService.LoadAlerts(context.Background()))
+	alertService.CheckAlert(context.Background(), alert, "70000")
+}
+
+func TestAlertService_CreateAlert(t *testing.T) {
+	testCases := []struct {
+		name           string
+		alertDto       *dto.PriceAlert
+		price          string
+		expectedErrKey string
+	}{
+		{
+			name: "Level alert keeps the current price",
+			alertDto: &dto.PriceAlert{Exchange: consts.Binance, Symbol: "btcusdt",
+				Condition: consts.AlertConditionAbove, Value: "70000"},
+			price: "65000",
+		},
+		{
+			name: "Level alert without price yet",
+			alertDto: &dto.PriceAlert{Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Condition: consts.AlertConditionBelow, Value: "60000"},
+		},
+		{
+			name: "Move alert needs a price",
+			alertDto: &dto.PriceAlert{Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Condition: consts.AlertConditionMove, Value: "5"},
+			expectedErrKey: "alertNoPrice",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			alertService, m, i18nSrv := newAlertService(t)
+
+			released := false
+			m.feed.EXPECT().Hold(consts.Binance, "BTCUSDT").Return(func() { released = true }, nil)
+			m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return(tc.price, nil)
+			if tc.expectedErrKey == "" {
+				m.repo.EXPECT().CreateAlert(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, alert *domain.PriceAlert) (int64, error) {
+						assert.Equal(t, "BTCUSDT", alert.Symbol)
+						assert.Equal(t, tc.price, alert.BasePrice)
+						assert.True(t, alert.Armed)
+
+						return 5, nil
+					})
+			}
+
+			alert, err := alertService.CreateAlert(context.Background(), 7, tc.alertDto)
+			assert.True(t, released)
+			if tc.expectedErrKey != "" {
+				require.Error(t, err)
+				assert.True(t, err.(srvErr.Error).IsBadRequestError())
+				assert.Equal(t, i18nSrv.T(tc.expectedErrKey, nil, "ru"), err.Error())
+				assert.Empty(t, alertService.GetActiveAlerts(consts.Binance))
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(5), alert.Id)
+			assert.Equal(t, []*domain.PriceAlert{alert}, alertService.GetActiveAlerts(consts.Binance))
+		})
+	}
+}
+
+func TestAlertService_DeleteAlert(t *testing.T) {
+	alertService, m, i18nSrv := newAlertService(t)
+
+	alert := &domain.PriceAlert{Id: 5, UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT",
+		Status: consts.AlertStatusActive}
+	m.repo.EXPECT().GetActiveAlerts(gomock.Any()).Return([]*domain.PriceAlert{alert}, nil)
+	m.repo.EXPECT().GetAlert(gomock.Any(), int64(5)).Return(alert, nil).Times(2)
+	m.repo.EXPECT().SetStatus(gomock.Any(), int64(5), consts.AlertStatusDeleted).Return(nil)
+	m.feed.EXPECT().Release(consts.Binance, "BTCUSDT")
+	require.NoError(t, alertService.LoadAlerts(context.Background()))
+
+	err := alertService.DeleteAlert(context.Background(), 8, 5)
+	require.Error(t, err)
+	assert.Equal(t, i18nSrv.T("alertNotFound", nil, "ru"), err.Error())
+	assert.Len(t, alertService.GetActiveAlerts(consts.Binance), 1)
+
+	require.NoError(t, alertService.DeleteAlert(context.Background(), 7, 5))
+	assert.Empty(t, alertService.GetActiveAlerts(consts.Binance))
+	assert.False(t, alertService.HasSymbol(consts.Binance, "BTCUSDT"))
+}
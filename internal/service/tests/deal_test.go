@@ -0,0 +1,173 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDealService_GetDeals(t *testing.T) {
+	openedAt := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name     string
+		page     int
+		limit    int
+		offset   int
+		repoLim  int
+		deal     *domain.Deal
+		total    int
+		expected domain.Deal
+		pages    int
+	}{
+		{
+			name:    "Buy deal closed by take profit ladder",
+			page:    1,
+			repoLim: 10,
+			deal: domain.NewDeal(&domain.Order{Id: 1, Side: consts.OrderSideBuy, Status: consts.OrderStatusFilled,
+				Quantity: "1", ExecutedQty: "1", AvgPrice: "100", Price: "99", CreatedAt: openedAt},
+				[]*domain.Order{
+					{Id: 2, TpSl: consts.TpOrderType, Status: consts.OrderStatusFilled, Quantity: "0.4", Price: "110",
+						UpdatedAt: openedAt.Add(time.Hour)},
+					{Id: 3, TpSl: consts.TpOrderType, Status: consts.OrderStatusFilled, Quantity: "0.6", Price: "119",
+						AvgPrice: "120", UpdatedAt: openedAt.Add(2 * time.Hour)},
+					{Id: 4, TpSl: consts.SlOrderType, Status: consts.OrderStatusCanceled, Quantity: "1", Price: "90"},
+				}, nil),
+			total: 1,
+			expected: domain.Deal{Status: consts.DealStatusClosed, EntryPrice: "100", EntryQty: "1", ExitPrice: "116",
+				ClosedQty: "1", Pnl: "16", Fee: "0.216", NetPnl: "15.784", OpenedAt: openedAt,
+				ClosedAt: openedAt.Add(2 * time.Hour), DurationSec: 7200},
+			pages: 1,
+		},
+		{
+			name:    "Sell deal closed by stop loss",
+			page:    3,
+			limit:   5,
+			offset:  10,
+			repoLim: 5,
+			deal: domain.NewDeal(&domain.Order{Id: 5, Side: consts.OrderSideSell, Status: consts.OrderStatusFilled,
+				Quantity: "2", Price: "50", CreatedAt: openedAt},
+				[]*domain.Order{
+					{Id: 6, TpSl: consts.SlOrderType, Status: consts.OrderStatusFilled, Quantity: "2", Price: "55",
+						UpdatedAt: openedAt.Add(time.Minute)},
+				}, nil),
+			total: 11,
+			expected: domain.Deal{Status: consts.DealStatusClosed, EntryPrice: "50", EntryQty: "2", ExitPrice: "55",
+				ClosedQty: "2", Pnl: "-10", Fee: "0.21", NetPnl: "-10.21", OpenedAt: openedAt,
+				ClosedAt: openedAt.Add(time.Minute), DurationSec: 60},
+			pages: 3,
+		},
+		{
+			name:    "Open deal",
+			page:    0,
+			limit:   500,
+			repoLim: 100,
+			deal: domain.NewDeal(&domain.Order{Id: 7, Side: consts.OrderSideBuy, Status: consts.OrderStatusFilled,
+				Quantity: "1", Price: "100"},
+				[]*domain.Order{
+					{Id: 8, TpSl: consts.TpOrderType, Status: consts.OrderStatusActive, Quantity: "1", Price: "110"},
+				}, nil),
+			total: 1,
+			expected: domain.Deal{Status: consts.DealStatusOpen, EntryPrice: "100", EntryQty: "1", ClosedQty: "0",
+				Pnl: "0", Fee: "0.1", NetPnl: "-0.1"},
+			pages: 1,
+		},
+		{
+			name:    "Pending limit deal",
+			page:    1,
+			repoLim: 10,
+			deal: domain.NewDeal(&domain.Order{Id: 9, Side: consts.OrderSideBuy, Status: consts.OrderStatusActive,
+				OrderType: consts.OrderTypeLimit, Quantity: "1", Price: "100"}, nil, nil),
+			total: 1,
+			expected: domain.Deal{Status: consts.DealStatusPending, EntryPrice: "100", EntryQty: "0", ClosedQty: "0",
+				Pnl: "0", Fee: "0", NetPnl: "0"},
+			pages: 1,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			dealService := service.NewDealService(config.NewConfig(), mockDealRepo, i18nSrv, log.NewLogger())
+
+			mockDealRepo.EXPECT().GetDeals(gomock.Any(), int64(7), consts.Binance, tc.repoLim, tc.offset).
+				Return([]*domain.Deal{tc.deal}, tc.total, nil)
+
+			result, err := dealService.GetDeals(context.Background(), 7, consts.Binance, tc.page, tc.limit)
+			require.NoError(t, err)
+			require.Len(t, result.Deals, 1)
+			assert.Equal(t, tc.pages, result.Pages)
+			assert.Equal(t, tc.total, result.Total)
+
+			deal := result.Deals[0]
+			assert.Equal(t, tc.expected.Status, deal.Status)
+			assert.Equal(t, tc.expected.EntryPrice, deal.EntryPrice)
+			assert.Equal(t, tc.expected.EntryQty, deal.EntryQty)
+			assert.Equal(t, tc.expected.ExitPrice, deal.ExitPrice)
+			assert.Equal(t, tc.expected.ClosedQty, deal.ClosedQty)
+			assert.Equal(t, tc.expected.Pnl, deal.Pnl)
+			assert.Equal(t, tc.expected.Fee, deal.Fee)
+			assert.Equal(t, tc.expected.NetPnl, deal.NetPnl)
+			assert.Equal(t, tc.expected.OpenedAt, deal.OpenedAt)
+			assert.Equal(t, tc.expected.ClosedAt, deal.ClosedAt)
+			if !tc.expected.ClosedAt.IsZero() {
+				assert.Equal(t, tc.expected.DurationSec, deal.DurationSec)
+			}
+		})
+	}
+}
+
+func TestDealService_GetDealsError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	dealService := service.NewDealService(config.NewConfig(), mockDealRepo, nil, log.NewLogger())
+
+	repoErr := errors.New("deal repository error")
+	mockDealRepo.EXPECT().GetDeals(gomock.Any(), int64(7), "", 10, 0).Return(nil, 0, repoErr)
+
+	result, err := dealService.GetDeals(context.Background(), 7, "", 1, 0)
+	assert.Nil(t, result)
+	assert.Equal(t, srvErr.InternalServerError(repoErr), err)
+}
+
+func TestDealService_GetDeal(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	dealService := service.NewDealService(config.NewConfig(), mockDealRepo, i18nSrv, log.NewLogger())
+
+	mockDealRepo.EXPECT().GetDeal(gomock.Any(), int64(7), int64(3)).Return(nil, nil)
+	deal, err := dealService.GetDeal(context.Background(), 7, 3)
+	assert.Nil(t, deal)
+	assert.Equal(t, srvErr.NotFoundError(i18nSrv.T("dealNotFound", nil, "ru")), err)
+
+	mockDealRepo.EXPECT().GetDeal(gomock.Any(), int64(7), int64(1)).
+		Return(domain.NewDeal(&domain.Order{Id: 1, Side: consts.OrderSideBuy, Status: consts.OrderStatusFilled,
+			Quantity: "1", Price: "100"}, nil, nil), nil)
+	deal, err = dealService.GetDeal(context.Background(), 7, 1)
+	require.NoError(t, err)
+	assert.Equal(t, consts.DealStatusOpen, deal.Status)
+	assert.Equal(t, "0.1", deal.Fee)
+}
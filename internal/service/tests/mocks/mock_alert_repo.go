@@ -0,0 +1,224 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: alert.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockPriceAlertRepo is a mock of PriceAlertRepo interface.
+type MockPriceAlertRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceAlertRepoMockRecorder
+}
+
+// MockPriceAlertRepoMockRecorder is the mock recorder for MockPriceAlertRepo.
+type MockPriceAlertRepoMockRecorder struct {
+	mock *MockPriceAlertRepo
+}
+
+// NewMockPriceAlertRepo creates a new mock instance.
+func NewMockPriceAlertRepo(ctrl *gomock.Controller) *MockPriceAlertRepo {
+	mock := &MockPriceAlertRepo{ctrl: ctrl}
+	mock.recorder = &MockPriceAlertRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceAlertRepo) EXPECT() *MockPriceAlertRepoMockRecorder {
+	return m.recorder
+}
+
+// CreateAlert mocks base method.
+func (m *MockPriceAlertRepo) CreateAlert(ctx context.Context, alert *domain.PriceAlert) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateAlert", ctx, alert)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateAlert indicates an expected call of CreateAlert.
+func (mr *MockPriceAlertRepoMockRecorder) CreateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateAlert", reflect.TypeOf((*MockPriceAlertRepo)(nil).CreateAlert), ctx, alert)
+}
+
+// GetActiveAlerts mocks base method.
+func (m *MockPriceAlertRepo) GetActiveAlerts(ctx context.Context) ([]*domain.PriceAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActiveAlerts", ctx)
+	ret0, _ := ret[0].([]*domain.PriceAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActiveAlerts indicates an expected call of GetActiveAlerts.
+func (mr *MockPriceAlertRepoMockRecorder) GetActiveAlerts(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActiveAlerts", reflect.TypeOf((*MockPriceAlertRepo)(nil).GetActiveAlerts), ctx)
+}
+
+// GetAlert mocks base method.
+func (m *MockPriceAlertRepo) GetAlert(ctx context.Context, id int64) (*domain.PriceAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlert", ctx, id)
+	ret0, _ := ret[0].(*domain.PriceAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlert indicates an expected call of GetAlert.
+func (mr *MockPriceAlertRepoMockRecorder) GetAlert(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlert", reflect.TypeOf((*MockPriceAlertRepo)(nil).GetAlert), ctx, id)
+}
+
+// GetAlertSymbols mocks base method.
+func (m *MockPriceAlertRepo) GetAlertSymbols(ctx context.Context) ([]domain.Symbols, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAlertSymbols", ctx)
+	ret0, _ := ret[0].([]domain.Symbols)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAlertSymbols indicates an expected call of GetAlertSymbols.
+func (mr *MockPriceAlertRepoMockRecorder) GetAlertSymbols(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAlertSymbols", reflect.TypeOf((*MockPriceAlertRepo)(nil).GetAlertSymbols), ctx)
+}
+
+// GetUserAlerts mocks base method.
+func (m *MockPriceAlertRepo) GetUserAlerts(ctx context.Context, userId int64) ([]*domain.PriceAlert, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserAlerts", ctx, userId)
+	ret0, _ := ret[0].([]*domain.PriceAlert)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserAlerts indicates an expected call of GetUserAlerts.
+func (mr *MockPriceAlertRepoMockRecorder) GetUserAlerts(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserAlerts", reflect.TypeOf((*MockPriceAlertRepo)(nil).GetUserAlerts), ctx, userId)
+}
+
+// SetStatus mocks base method.
+func (m *MockPriceAlertRepo) SetStatus(ctx context.Context, id int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockPriceAlertRepoMockRecorder) SetStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockPriceAlertRepo)(nil).SetStatus), ctx, id, status)
+}
+
+// UpdateAlert mocks base method.
+func (m *MockPriceAlertRepo) UpdateAlert(ctx context.Context, alert *domain.PriceAlert) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateAlert", ctx, alert)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateAlert indicates an expected call of UpdateAlert.
+func (mr *MockPriceAlertRepoMockRecorder) UpdateAlert(ctx, alert interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateAlert", reflect.TypeOf((*MockPriceAlertRepo)(nil).UpdateAlert), ctx, alert)
+}
+
+// MockPriceFeed is a mock of PriceFeed interface.
+type MockPriceFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockPriceFeedMockRecorder
+}
+
+// MockPriceFeedMockRecorder is the mock recorder for MockPriceFeed.
+type MockPriceFeedMockRecorder struct {
+	mock *MockPriceFeed
+}
+
+// NewMockPriceFeed creates a new mock instance.
+func NewMockPriceFeed(ctrl *gomock.Controller) *MockPriceFeed {
+	mock := &MockPriceFeed{ctrl: ctrl}
+	mock.recorder = &MockPriceFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPriceFeed) EXPECT() *MockPriceFeedMockRecorder {
+	return m.recorder
+}
+
+// Hold mocks base method.
+func (m *MockPriceFeed) Hold(exchange, symbol string) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", exchange, symbol)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockPriceFeedMockRecorder) Hold(exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockPriceFeed)(nil).Hold), exchange, symbol)
+}
+
+// Release mocks base method.
+func (m *MockPriceFeed) Release(exchange, symbol string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Release", exchange, symbol)
+}
+
+// Release indicates an expected call of Release.
+func (mr *MockPriceFeedMockRecorder) Release(exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Release", reflect.TypeOf((*MockPriceFeed)(nil).Release), exchange, symbol)
+}
+
+// MockAlertNotifier is a mock of AlertNotifier interface.
+type MockAlertNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockAlertNotifierMockRecorder
+}
+
+// MockAlertNotifierMockRecorder is the mock recorder for MockAlertNotifier.
+type MockAlertNotifierMockRecorder struct {
+	mock *MockAlertNotifier
+}
+
+// NewMockAlertNotifier creates a new mock instance.
+func NewMockAlertNotifier(ctrl *gomock.Controller) *MockAlertNotifier {
+	mock := &MockAlertNotifier{ctrl: ctrl}
+	mock.recorder = &MockAlertNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAlertNotifier) EXPECT() *MockAlertNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyPriceAlert mocks base method.
+func (m *MockAlertNotifier) NotifyPriceAlert(ctx context.Context, alert *domain.PriceAlert, price, change string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyPriceAlert", ctx, alert, price, change)
+}
+
+// NotifyPriceAlert indicates an expected call of NotifyPriceAlert.
+func (mr *MockAlertNotifierMockRecorder) NotifyPriceAlert(ctx, alert, price, change interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyPriceAlert", reflect.TypeOf((*MockAlertNotifier)(nil).NotifyPriceAlert), ctx, alert, price, change)
+}
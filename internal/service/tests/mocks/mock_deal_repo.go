@@ -0,0 +1,83 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deal.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockDealRepo is a mock of DealRepo interface.
+type MockDealRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockDealRepoMockRecorder
+}
+
+// MockDealRepoMockRecorder is the mock recorder for MockDealRepo.
+type MockDealRepoMockRecorder struct {
+	mock *MockDealRepo
+}
+
+// NewMockDealRepo creates a new mock instance.
+func NewMockDealRepo(ctrl *gomock.Controller) *MockDealRepo {
+	mock := &MockDealRepo{ctrl: ctrl}
+	mock.recorder = &MockDealRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDealRepo) EXPECT() *MockDealRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDeal mocks base method.
+func (m *MockDealRepo) GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeal", ctx, userId, id)
+	ret0, _ := ret[0].(*domain.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeal indicates an expected call of GetDeal.
+func (mr *MockDealRepoMockRecorder) GetDeal(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeal", reflect.TypeOf((*MockDealRepo)(nil).GetDeal), ctx, userId, id)
+}
+
+// GetDeals mocks base method.
+func (m *MockDealRepo) GetDeals(ctx context.Context, userId int64, exchange string, limit, offset int) ([]*domain.Deal, int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeals", ctx, userId, exchange, limit, offset)
+	ret0, _ := ret[0].([]*domain.Deal)
+	ret1, _ := ret[1].(int)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// GetDeals indicates an expected call of GetDeals.
+func (mr *MockDealRepoMockRecorder) GetDeals(ctx, userId, exchange, limit, offset interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeals", reflect.TypeOf((*MockDealRepo)(nil).GetDeals), ctx, userId, exchange, limit, offset)
+}
+
+// GetDealsSince mocks base method.
+func (m *MockDealRepo) GetDealsSince(ctx context.Context, userId int64, from time.Time) ([]*domain.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDealsSince", ctx, userId, from)
+	ret0, _ := ret[0].([]*domain.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDealsSince indicates an expected call of GetDealsSince.
+func (mr *MockDealRepoMockRecorder) GetDealsSince(ctx, userId, from interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDealsSince", reflect.TypeOf((*MockDealRepo)(nil).GetDealsSince), ctx, userId, from)
+}
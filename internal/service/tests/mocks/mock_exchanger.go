@@ -5,10 +5,11 @@
 package mocks
 
 import (
-	"github.com/golang/mock/gomock"
-	"github.com/linnoxlewis/trade-bot/internal/domain"
-	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
-	"reflect"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
 )
 
 // MockExchanger is a mock of Exchanger interface.
@@ -63,6 +64,21 @@ func (mr *MockExchangerMockRecorder) CancelOrder(keys, order interface{}) *gomoc
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockExchanger)(nil).CancelOrder), keys, order)
 }
 
+// CreateOcoOrder mocks base method.
+func (m *MockExchanger) CreateOcoOrder(keys *domain.ApiKeys, order *dto.OcoOrder) (*domain.OcoOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOcoOrder", keys, order)
+	ret0, _ := ret[0].(*domain.OcoOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOcoOrder indicates an expected call of CreateOcoOrder.
+func (mr *MockExchangerMockRecorder) CreateOcoOrder(keys, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOcoOrder", reflect.TypeOf((*MockExchanger)(nil).CreateOcoOrder), keys, order)
+}
+
 // CreateOrder mocks base method.
 func (m *MockExchanger) CreateOrder(keys *domain.ApiKeys, order *dto.Order) (int64, error) {
 	m.ctrl.T.Helper()
@@ -108,6 +124,36 @@ func (mr *MockExchangerMockRecorder) GetOrder(keys, exchange, symbol, orderId in
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockExchanger)(nil).GetOrder), keys, exchange, symbol, orderId)
 }
 
+// GetPrice mocks base method.
+func (m *MockExchanger) GetPrice(keys *domain.ApiKeys, exchange, symbol string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", keys, exchange, symbol)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockExchangerMockRecorder) GetPrice(keys, exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockExchanger)(nil).GetPrice), keys, exchange, symbol)
+}
+
+// GetSymbolFilters mocks base method.
+func (m *MockExchanger) GetSymbolFilters(keys *domain.ApiKeys, exchange string) ([]domain.SymbolFilters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbolFilters", keys, exchange)
+	ret0, _ := ret[0].([]domain.SymbolFilters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbolFilters indicates an expected call of GetSymbolFilters.
+func (mr *MockExchangerMockRecorder) GetSymbolFilters(keys, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbolFilters", reflect.TypeOf((*MockExchanger)(nil).GetSymbolFilters), keys, exchange)
+}
+
 // GetSymbols mocks base method.
 func (m *MockExchanger) GetSymbols(keys *domain.ApiKeys, exchange string) ([]string, error) {
 	m.ctrl.T.Helper()
@@ -235,6 +281,36 @@ func (mr *MockExchangerCliMockRecorder) GetOrder(pubKey, secKey, passPhrase, sym
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockExchangerCli)(nil).GetOrder), pubKey, secKey, passPhrase, symbol, orderId)
 }
 
+// GetPrice mocks base method.
+func (m *MockExchangerCli) GetPrice(pubKey, secKey, passPhrase, symbol string) (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPrice", pubKey, secKey, passPhrase, symbol)
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPrice indicates an expected call of GetPrice.
+func (mr *MockExchangerCliMockRecorder) GetPrice(pubKey, secKey, passPhrase, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPrice", reflect.TypeOf((*MockExchangerCli)(nil).GetPrice), pubKey, secKey, passPhrase, symbol)
+}
+
+// GetSymbolFilters mocks base method.
+func (m *MockExchangerCli) GetSymbolFilters(pubKey, secKey, passPhrase string) ([]domain.SymbolFilters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbolFilters", pubKey, secKey, passPhrase)
+	ret0, _ := ret[0].([]domain.SymbolFilters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbolFilters indicates an expected call of GetSymbolFilters.
+func (mr *MockExchangerCliMockRecorder) GetSymbolFilters(pubKey, secKey, passPhrase interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbolFilters", reflect.TypeOf((*MockExchangerCli)(nil).GetSymbolFilters), pubKey, secKey, passPhrase)
+}
+
 // GetSymbols mocks base method.
 func (m *MockExchangerCli) GetSymbols(pubKey, secKey, passPhrase string) ([]string, error) {
 	m.ctrl.T.Helper()
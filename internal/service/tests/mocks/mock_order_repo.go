@@ -10,6 +10,7 @@ import (
 
 	gomock "github.com/golang/mock/gomock"
 	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
 	service "github.com/linnoxlewis/trade-bot/internal/service"
 )
 
@@ -123,10 +124,10 @@ func (mr *MockOrderRepoMockRecorder) ExecuteOrder(ctx, id interface{}) *gomock.C
 }
 
 // GetActiveSymbols mocks base method.
-func (m *MockOrderRepo) GetActiveSymbols(ctx context.Context) (domain.SymbolList, error) {
+func (m *MockOrderRepo) GetActiveSymbols(ctx context.Context) ([]domain.Symbols, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "GetActiveSymbols", ctx)
-	ret0, _ := ret[0].(domain.SymbolList)
+	ret0, _ := ret[0].([]domain.Symbols)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -197,6 +198,21 @@ func (mr *MockOrderRepoMockRecorder) GetOrder(ctx, orderId, symbol, exchange int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderRepo)(nil).GetOrder), ctx, orderId, symbol, exchange)
 }
 
+// GetSettings mocks base method.
+func (m *MockOrderRepo) GetSettings(ctx context.Context, orderId int64) (*domain.Settings, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettings", ctx, orderId)
+	ret0, _ := ret[0].(*domain.Settings)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettings indicates an expected call of GetSettings.
+func (mr *MockOrderRepoMockRecorder) GetSettings(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettings", reflect.TypeOf((*MockOrderRepo)(nil).GetSettings), ctx, orderId)
+}
+
 // GetTpSlOrderByBaseOrder mocks base method.
 func (m *MockOrderRepo) GetTpSlOrderByBaseOrder(ctx context.Context, id int64, symbol, exchange, tpsl string) (*domain.Order, error) {
 	m.ctrl.T.Helper()
@@ -227,6 +243,62 @@ func (mr *MockOrderRepoMockRecorder) GetTpSlOrdersByBaseOrder(ctx, id interface{
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTpSlOrdersByBaseOrder", reflect.TypeOf((*MockOrderRepo)(nil).GetTpSlOrdersByBaseOrder), ctx, id)
 }
 
+// SetExecutedQty mocks base method.
+func (m *MockOrderRepo) SetExecutedQty(ctx context.Context, id int64, status, executedQty, avgPrice string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetExecutedQty", ctx, id, status, executedQty, avgPrice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetExecutedQty indicates an expected call of SetExecutedQty.
+func (mr *MockOrderRepoMockRecorder) SetExecutedQty(ctx, id, status, executedQty, avgPrice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetExecutedQty", reflect.TypeOf((*MockOrderRepo)(nil).SetExecutedQty), ctx, id, status, executedQty, avgPrice)
+}
+
+// SetOcoOrder mocks base method.
+func (m *MockOrderRepo) SetOcoOrder(ctx context.Context, id, ocoListId, ocoOrderId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOcoOrder", ctx, id, ocoListId, ocoOrderId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOcoOrder indicates an expected call of SetOcoOrder.
+func (mr *MockOrderRepoMockRecorder) SetOcoOrder(ctx, id, ocoListId, ocoOrderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOcoOrder", reflect.TypeOf((*MockOrderRepo)(nil).SetOcoOrder), ctx, id, ocoListId, ocoOrderId)
+}
+
+// SetProtection mocks base method.
+func (m *MockOrderRepo) SetProtection(ctx context.Context, id int64, protection string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetProtection", ctx, id, protection)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetProtection indicates an expected call of SetProtection.
+func (mr *MockOrderRepoMockRecorder) SetProtection(ctx, id, protection interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetProtection", reflect.TypeOf((*MockOrderRepo)(nil).SetProtection), ctx, id, protection)
+}
+
+// UpdateQuantity mocks base method.
+func (m *MockOrderRepo) UpdateQuantity(ctx context.Context, id int64, quantity string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateQuantity", ctx, id, quantity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateQuantity indicates an expected call of UpdateQuantity.
+func (mr *MockOrderRepoMockRecorder) UpdateQuantity(ctx, id, quantity interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateQuantity", reflect.TypeOf((*MockOrderRepo)(nil).UpdateQuantity), ctx, id, quantity)
+}
+
 // UpdateTpSl mocks base method.
 func (m *MockOrderRepo) UpdateTpSl(ctx context.Context, id int64, price string, settings *domain.Settings) error {
 	m.ctrl.T.Helper()
@@ -240,3 +312,165 @@ func (mr *MockOrderRepoMockRecorder) UpdateTpSl(ctx, id, price, settings interfa
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTpSl", reflect.TypeOf((*MockOrderRepo)(nil).UpdateTpSl), ctx, id, price, settings)
 }
+
+// UpdateTrailingStop mocks base method.
+func (m *MockOrderRepo) UpdateTrailingStop(ctx context.Context, id int64, price, tsPrice string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTrailingStop", ctx, id, price, tsPrice)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTrailingStop indicates an expected call of UpdateTrailingStop.
+func (mr *MockOrderRepoMockRecorder) UpdateTrailingStop(ctx, id, price, tsPrice interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTrailingStop", reflect.TypeOf((*MockOrderRepo)(nil).UpdateTrailingStop), ctx, id, price, tsPrice)
+}
+
+// MockSymbolFilterSource is a mock of SymbolFilterSource interface.
+type MockSymbolFilterSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockSymbolFilterSourceMockRecorder
+}
+
+// MockSymbolFilterSourceMockRecorder is the mock recorder for MockSymbolFilterSource.
+type MockSymbolFilterSourceMockRecorder struct {
+	mock *MockSymbolFilterSource
+}
+
+// NewMockSymbolFilterSource creates a new mock instance.
+func NewMockSymbolFilterSource(ctrl *gomock.Controller) *MockSymbolFilterSource {
+	mock := &MockSymbolFilterSource{ctrl: ctrl}
+	mock.recorder = &MockSymbolFilterSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSymbolFilterSource) EXPECT() *MockSymbolFilterSourceMockRecorder {
+	return m.recorder
+}
+
+// GetFilters mocks base method.
+func (m *MockSymbolFilterSource) GetFilters(exchange, symbol string) (*domain.SymbolFilters, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFilters", exchange, symbol)
+	ret0, _ := ret[0].(*domain.SymbolFilters)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFilters indicates an expected call of GetFilters.
+func (mr *MockSymbolFilterSourceMockRecorder) GetFilters(exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFilters", reflect.TypeOf((*MockSymbolFilterSource)(nil).GetFilters), exchange, symbol)
+}
+
+// MockRiskChecker is a mock of RiskChecker interface.
+type MockRiskChecker struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskCheckerMockRecorder
+}
+
+// MockRiskCheckerMockRecorder is the mock recorder for MockRiskChecker.
+type MockRiskCheckerMockRecorder struct {
+	mock *MockRiskChecker
+}
+
+// NewMockRiskChecker creates a new mock instance.
+func NewMockRiskChecker(ctrl *gomock.Controller) *MockRiskChecker {
+	mock := &MockRiskChecker{ctrl: ctrl}
+	mock.recorder = &MockRiskCheckerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskChecker) EXPECT() *MockRiskCheckerMockRecorder {
+	return m.recorder
+}
+
+// CheckOrder mocks base method.
+func (m *MockRiskChecker) CheckOrder(ctx context.Context, userId int64, order *dto.Order, price string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CheckOrder", ctx, userId, order, price)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CheckOrder indicates an expected call of CheckOrder.
+func (mr *MockRiskCheckerMockRecorder) CheckOrder(ctx, userId, order, price interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CheckOrder", reflect.TypeOf((*MockRiskChecker)(nil).CheckOrder), ctx, userId, order, price)
+}
+
+// MockOrderEventPublisher is a mock of OrderEventPublisher interface.
+type MockOrderEventPublisher struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEventPublisherMockRecorder
+}
+
+// MockOrderEventPublisherMockRecorder is the mock recorder for MockOrderEventPublisher.
+type MockOrderEventPublisherMockRecorder struct {
+	mock *MockOrderEventPublisher
+}
+
+// NewMockOrderEventPublisher creates a new mock instance.
+func NewMockOrderEventPublisher(ctrl *gomock.Controller) *MockOrderEventPublisher {
+	mock := &MockOrderEventPublisher{ctrl: ctrl}
+	mock.recorder = &MockOrderEventPublisherMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEventPublisher) EXPECT() *MockOrderEventPublisherMockRecorder {
+	return m.recorder
+}
+
+// Publish mocks base method.
+func (m *MockOrderEventPublisher) Publish(event domain.OrderEvent) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Publish", event)
+}
+
+// Publish indicates an expected call of Publish.
+func (mr *MockOrderEventPublisherMockRecorder) Publish(event interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Publish", reflect.TypeOf((*MockOrderEventPublisher)(nil).Publish), event)
+}
+
+// MockOrderPriceFeed is a mock of OrderPriceFeed interface.
+type MockOrderPriceFeed struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderPriceFeedMockRecorder
+}
+
+// MockOrderPriceFeedMockRecorder is the mock recorder for MockOrderPriceFeed.
+type MockOrderPriceFeedMockRecorder struct {
+	mock *MockOrderPriceFeed
+}
+
+// NewMockOrderPriceFeed creates a new mock instance.
+func NewMockOrderPriceFeed(ctrl *gomock.Controller) *MockOrderPriceFeed {
+	mock := &MockOrderPriceFeed{ctrl: ctrl}
+	mock.recorder = &MockOrderPriceFeedMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderPriceFeed) EXPECT() *MockOrderPriceFeedMockRecorder {
+	return m.recorder
+}
+
+// Hold mocks base method.
+func (m *MockOrderPriceFeed) Hold(exchange, symbol string) (func(), error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Hold", exchange, symbol)
+	ret0, _ := ret[0].(func())
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Hold indicates an expected call of Hold.
+func (mr *MockOrderPriceFeedMockRecorder) Hold(exchange, symbol interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Hold", reflect.TypeOf((*MockOrderPriceFeed)(nil).Hold), exchange, symbol)
+}
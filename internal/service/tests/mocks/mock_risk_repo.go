@@ -0,0 +1,182 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: risk.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockRiskLimitsRepo is a mock of RiskLimitsRepo interface.
+type MockRiskLimitsRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskLimitsRepoMockRecorder
+}
+
+// MockRiskLimitsRepoMockRecorder is the mock recorder for MockRiskLimitsRepo.
+type MockRiskLimitsRepoMockRecorder struct {
+	mock *MockRiskLimitsRepo
+}
+
+// NewMockRiskLimitsRepo creates a new mock instance.
+func NewMockRiskLimitsRepo(ctrl *gomock.Controller) *MockRiskLimitsRepo {
+	mock := &MockRiskLimitsRepo{ctrl: ctrl}
+	mock.recorder = &MockRiskLimitsRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskLimitsRepo) EXPECT() *MockRiskLimitsRepoMockRecorder {
+	return m.recorder
+}
+
+// GetDailyLossLimits mocks base method.
+func (m *MockRiskLimitsRepo) GetDailyLossLimits(ctx context.Context, now time.Time) ([]*domain.RiskLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDailyLossLimits", ctx, now)
+	ret0, _ := ret[0].([]*domain.RiskLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDailyLossLimits indicates an expected call of GetDailyLossLimits.
+func (mr *MockRiskLimitsRepoMockRecorder) GetDailyLossLimits(ctx, now interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDailyLossLimits", reflect.TypeOf((*MockRiskLimitsRepo)(nil).GetDailyLossLimits), ctx, now)
+}
+
+// GetRiskLimits mocks base method.
+func (m *MockRiskLimitsRepo) GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskLimits", ctx, userId)
+	ret0, _ := ret[0].(*domain.RiskLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskLimits indicates an expected call of GetRiskLimits.
+func (mr *MockRiskLimitsRepoMockRecorder) GetRiskLimits(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskLimits", reflect.TypeOf((*MockRiskLimitsRepo)(nil).GetRiskLimits), ctx, userId)
+}
+
+// SaveRiskLimits mocks base method.
+func (m *MockRiskLimitsRepo) SaveRiskLimits(ctx context.Context, limits *domain.RiskLimits) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SaveRiskLimits", ctx, limits)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SaveRiskLimits indicates an expected call of SaveRiskLimits.
+func (mr *MockRiskLimitsRepoMockRecorder) SaveRiskLimits(ctx, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SaveRiskLimits", reflect.TypeOf((*MockRiskLimitsRepo)(nil).SaveRiskLimits), ctx, limits)
+}
+
+// SetPausedUntil mocks base method.
+func (m *MockRiskLimitsRepo) SetPausedUntil(ctx context.Context, userId int64, pausedUntil time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetPausedUntil", ctx, userId, pausedUntil)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetPausedUntil indicates an expected call of SetPausedUntil.
+func (mr *MockRiskLimitsRepoMockRecorder) SetPausedUntil(ctx, userId, pausedUntil interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetPausedUntil", reflect.TypeOf((*MockRiskLimitsRepo)(nil).SetPausedUntil), ctx, userId, pausedUntil)
+}
+
+// MockAdminSource is a mock of AdminSource interface.
+type MockAdminSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockAdminSourceMockRecorder
+}
+
+// MockAdminSourceMockRecorder is the mock recorder for MockAdminSource.
+type MockAdminSourceMockRecorder struct {
+	mock *MockAdminSource
+}
+
+// NewMockAdminSource creates a new mock instance.
+func NewMockAdminSource(ctrl *gomock.Controller) *MockAdminSource {
+	mock := &MockAdminSource{ctrl: ctrl}
+	mock.recorder = &MockAdminSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAdminSource) EXPECT() *MockAdminSourceMockRecorder {
+	return m.recorder
+}
+
+// GetAdmins mocks base method.
+func (m *MockAdminSource) GetAdmins(ctx context.Context) ([]int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetAdmins", ctx)
+	ret0, _ := ret[0].([]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetAdmins indicates an expected call of GetAdmins.
+func (mr *MockAdminSourceMockRecorder) GetAdmins(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAdmins", reflect.TypeOf((*MockAdminSource)(nil).GetAdmins), ctx)
+}
+
+// IsAdmin mocks base method.
+func (m *MockAdminSource) IsAdmin(ctx context.Context, tgId int64) bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "IsAdmin", ctx, tgId)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// IsAdmin indicates an expected call of IsAdmin.
+func (mr *MockAdminSourceMockRecorder) IsAdmin(ctx, tgId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsAdmin", reflect.TypeOf((*MockAdminSource)(nil).IsAdmin), ctx, tgId)
+}
+
+// MockRiskNotifier is a mock of RiskNotifier interface.
+type MockRiskNotifier struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskNotifierMockRecorder
+}
+
+// MockRiskNotifierMockRecorder is the mock recorder for MockRiskNotifier.
+type MockRiskNotifierMockRecorder struct {
+	mock *MockRiskNotifier
+}
+
+// NewMockRiskNotifier creates a new mock instance.
+func NewMockRiskNotifier(ctrl *gomock.Controller) *MockRiskNotifier {
+	mock := &MockRiskNotifier{ctrl: ctrl}
+	mock.recorder = &MockRiskNotifierMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskNotifier) EXPECT() *MockRiskNotifierMockRecorder {
+	return m.recorder
+}
+
+// NotifyTradingPaused mocks base method.
+func (m *MockRiskNotifier) NotifyTradingPaused(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "NotifyTradingPaused", ctx, admins, limits, dayPnl)
+}
+
+// NotifyTradingPaused indicates an expected call of NotifyTradingPaused.
+func (mr *MockRiskNotifierMockRecorder) NotifyTradingPaused(ctx, admins, limits, dayPnl interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NotifyTradingPaused", reflect.TypeOf((*MockRiskNotifier)(nil).NotifyTradingPaused), ctx, admins, limits, dayPnl)
+}
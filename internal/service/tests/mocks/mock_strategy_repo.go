@@ -0,0 +1,206 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: strategy.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+// MockStrategyRepo is a mock of StrategyRepo interface.
+type MockStrategyRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockStrategyRepoMockRecorder
+}
+
+// MockStrategyRepoMockRecorder is the mock recorder for MockStrategyRepo.
+type MockStrategyRepoMockRecorder struct {
+	mock *MockStrategyRepo
+}
+
+// NewMockStrategyRepo creates a new mock instance.
+func NewMockStrategyRepo(ctrl *gomock.Controller) *MockStrategyRepo {
+	mock := &MockStrategyRepo{ctrl: ctrl}
+	mock.recorder = &MockStrategyRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStrategyRepo) EXPECT() *MockStrategyRepoMockRecorder {
+	return m.recorder
+}
+
+// AddOrder mocks base method.
+func (m *MockStrategyRepo) AddOrder(ctx context.Context, order *domain.StrategyOrder) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddOrder", ctx, order)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddOrder indicates an expected call of AddOrder.
+func (mr *MockStrategyRepoMockRecorder) AddOrder(ctx, order interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddOrder", reflect.TypeOf((*MockStrategyRepo)(nil).AddOrder), ctx, order)
+}
+
+// CreateStrategy mocks base method.
+func (m *MockStrategyRepo) CreateStrategy(ctx context.Context, strategy *domain.Strategy) (int64, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateStrategy", ctx, strategy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateStrategy indicates an expected call of CreateStrategy.
+func (mr *MockStrategyRepoMockRecorder) CreateStrategy(ctx, strategy interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateStrategy", reflect.TypeOf((*MockStrategyRepo)(nil).CreateStrategy), ctx, strategy)
+}
+
+// GetSettledOrders mocks base method.
+func (m *MockStrategyRepo) GetSettledOrders(ctx context.Context) ([]*domain.StrategyOrder, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSettledOrders", ctx)
+	ret0, _ := ret[0].([]*domain.StrategyOrder)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSettledOrders indicates an expected call of GetSettledOrders.
+func (mr *MockStrategyRepoMockRecorder) GetSettledOrders(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSettledOrders", reflect.TypeOf((*MockStrategyRepo)(nil).GetSettledOrders), ctx)
+}
+
+// GetStrategies mocks base method.
+func (m *MockStrategyRepo) GetStrategies(ctx context.Context, userId int64) ([]*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrategies", ctx, userId)
+	ret0, _ := ret[0].([]*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStrategies indicates an expected call of GetStrategies.
+func (mr *MockStrategyRepoMockRecorder) GetStrategies(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategies", reflect.TypeOf((*MockStrategyRepo)(nil).GetStrategies), ctx, userId)
+}
+
+// GetStrategy mocks base method.
+func (m *MockStrategyRepo) GetStrategy(ctx context.Context, id int64) (*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrategy", ctx, id)
+	ret0, _ := ret[0].(*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStrategy indicates an expected call of GetStrategy.
+func (mr *MockStrategyRepoMockRecorder) GetStrategy(ctx, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategy", reflect.TypeOf((*MockStrategyRepo)(nil).GetStrategy), ctx, id)
+}
+
+// GetStrategyByOrderId mocks base method.
+func (m *MockStrategyRepo) GetStrategyByOrderId(ctx context.Context, orderId int64) (*domain.Strategy, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetStrategyByOrderId", ctx, orderId)
+	ret0, _ := ret[0].(*domain.Strategy)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetStrategyByOrderId indicates an expected call of GetStrategyByOrderId.
+func (mr *MockStrategyRepoMockRecorder) GetStrategyByOrderId(ctx, orderId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetStrategyByOrderId", reflect.TypeOf((*MockStrategyRepo)(nil).GetStrategyByOrderId), ctx, orderId)
+}
+
+// SetOrderStatus mocks base method.
+func (m *MockStrategyRepo) SetOrderStatus(ctx context.Context, strategyId, orderId int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetOrderStatus", ctx, strategyId, orderId, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetOrderStatus indicates an expected call of SetOrderStatus.
+func (mr *MockStrategyRepoMockRecorder) SetOrderStatus(ctx, strategyId, orderId, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetOrderStatus", reflect.TypeOf((*MockStrategyRepo)(nil).SetOrderStatus), ctx, strategyId, orderId, status)
+}
+
+// SetStatus mocks base method.
+func (m *MockStrategyRepo) SetStatus(ctx context.Context, id int64, status string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetStatus", ctx, id, status)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetStatus indicates an expected call of SetStatus.
+func (mr *MockStrategyRepoMockRecorder) SetStatus(ctx, id, status interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetStatus", reflect.TypeOf((*MockStrategyRepo)(nil).SetStatus), ctx, id, status)
+}
+
+// MockStrategyOrderSource is a mock of StrategyOrderSource interface.
+type MockStrategyOrderSource struct {
+	ctrl     *gomock.Controller
+	recorder *MockStrategyOrderSourceMockRecorder
+}
+
+// MockStrategyOrderSourceMockRecorder is the mock recorder for MockStrategyOrderSource.
+type MockStrategyOrderSourceMockRecorder struct {
+	mock *MockStrategyOrderSource
+}
+
+// NewMockStrategyOrderSource creates a new mock instance.
+func NewMockStrategyOrderSource(ctrl *gomock.Controller) *MockStrategyOrderSource {
+	mock := &MockStrategyOrderSource{ctrl: ctrl}
+	mock.recorder = &MockStrategyOrderSourceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockStrategyOrderSource) EXPECT() *MockStrategyOrderSourceMockRecorder {
+	return m.recorder
+}
+
+// CancelOrderById mocks base method.
+func (m *MockStrategyOrderSource) CancelOrderById(ctx context.Context, orderId, tgUserId int64, symbol, exchange string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrderById", ctx, orderId, tgUserId, symbol, exchange)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrderById indicates an expected call of CancelOrderById.
+func (mr *MockStrategyOrderSourceMockRecorder) CancelOrderById(ctx, orderId, tgUserId, symbol, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrderById", reflect.TypeOf((*MockStrategyOrderSource)(nil).CancelOrderById), ctx, orderId, tgUserId, symbol, exchange)
+}
+
+// CreateOrder mocks base method.
+func (m *MockStrategyOrderSource) CreateOrder(ctx context.Context, orderDto *dto.Order, tgUserId int64) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, orderDto, tgUserId)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockStrategyOrderSourceMockRecorder) CreateOrder(ctx, orderDto, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockStrategyOrderSource)(nil).CreateOrder), ctx, orderDto, tgUserId)
+}
@@ -0,0 +1,1222 @@
+package tests
+
+import (
+	"context"
+	"errors"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"testing"
+)
+
+func TestOrder_MoveTrailingStop(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+	mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+	mockFilters := mock_service.NewMockSymbolFilterSource(ctrl)
+	mockFilters.EXPECT().GetFilters(consts.Binance, "BTCUSDT").
+		Return(&domain.SymbolFilters{Symbol: "BTCUSDT", TickSize: "0.1"}, nil).AnyTimes()
+	cfg := &config.Config{}
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	exchanges := []string{consts.Binance}
+	tpSlQueues := domain.NewExchangeQueues(exchanges)
+	orderService := service.NewOrder(cfg,
+		nil,
+		mockFilters,
+		nil,
+		nil,
+		mockOrderRepo,
+		tpSlQueues,
+		domain.NewExchangeQueues(exchanges),
+		nil,
+		mockEvents,
+		nil,
+		i18nSrv,
+		log.NewLogger())
+
+	testCases := []struct {
+		name            string
+		order           *domain.Order
+		tradePrice      string
+		unqueued        bool
+		repoCalled      bool
+		repoError       error
+		expectedMoved   bool
+		expectedPrice   string
+		expectedTsPrice string
+		expectedError   error
+	}{
+		{
+			name: "Long position new high moves stop",
+			order: &domain.Order{Id: 1, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95", Ts: "2", TsPrice: "100"},
+			tradePrice:      "110",
+			repoCalled:      true,
+			expectedMoved:   true,
+			expectedPrice:   "107.8",
+			expectedTsPrice: "110",
+		},
+		{
+			name: "Long position price below high keeps stop",
+			order: &domain.Order{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95", Ts: "2", TsPrice: "100"},
+			tradePrice:      "99",
+			expectedMoved:   false,
+			expectedPrice:   "95",
+			expectedTsPrice: "100",
+		},
+		{
+			name: "Long position new high below stop distance keeps high in memory",
+			order: &domain.Order{Id: 3, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95", Ts: "10", TsPrice: "100"},
+			tradePrice:      "101",
+			expectedMoved:   false,
+			expectedPrice:   "95",
+			expectedTsPrice: "101",
+		},
+		{
+			name: "Long position stop move below tick size is not saved",
+			order: &domain.Order{Id: 7, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "107.8", Ts: "2", TsPrice: "110"},
+			tradePrice:      "110.05",
+			expectedMoved:   false,
+			expectedPrice:   "107.8",
+			expectedTsPrice: "110.05",
+		},
+		{
+			name: "Long position stop move by a tick is saved",
+			order: &domain.Order{Id: 8, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "107.8", Ts: "2", TsPrice: "110"},
+			tradePrice:      "110.1",
+			repoCalled:      true,
+			expectedMoved:   true,
+			expectedPrice:   "107.9",
+			expectedTsPrice: "110.1",
+		},
+		{
+			name: "Short position new low moves stop",
+			order: &domain.Order{Id: 4, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideBuy,
+				TpSl: consts.SlOrderType, Price: "105", Ts: "2", TsPrice: "100"},
+			tradePrice:      "90",
+			repoCalled:      true,
+			expectedMoved:   true,
+			expectedPrice:   "91.8",
+			expectedTsPrice: "90",
+		},
+		{
+			name: "Order left the queue keeps stop",
+			order: &domain.Order{Id: 9, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95", Ts: "2", TsPrice: "100"},
+			unqueued:        true,
+			tradePrice:      "110",
+			repoCalled:      true,
+			expectedMoved:   false,
+			expectedPrice:   "95",
+			expectedTsPrice: "110",
+		},
+		{
+			name: "Order without trailing stop",
+			order: &domain.Order{Id: 5, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95"},
+			tradePrice:    "110",
+			expectedMoved: false,
+			expectedPrice: "95",
+		},
+		{
+			name: "Repository error",
+			order: &domain.Order{Id: 6, Symbol: "BTCUSDT", Exchange: consts.Binance, Side: consts.OrderSideSell,
+				TpSl: consts.SlOrderType, Price: "95", Ts: "2", TsPrice: "100"},
+			tradePrice:      "110",
+			repoCalled:      true,
+			repoError:       errors.New("database error"),
+			expectedMoved:   false,
+			expectedPrice:   "95",
+			expectedTsPrice: "100",
+			expectedError:   srvErr.InternalServerError(errors.New("database error")),
+		},
+	}
+
+	queue := tpSlQueues.Get(consts.Binance)
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if !tc.unqueued {
+				queue.Add(tc.order)
+				defer queue.Remove(tc.order.Symbol, tc.order.Id)
+			}
+			if tc.repoCalled {
+				mockOrderRepo.EXPECT().
+					UpdateTrailingStop(gomock.Any(), tc.order.Id, gomock.Any(), gomock.Any()).
+					Return(tc.repoError)
+			}
+			if tc.expectedMoved {
+				mockEvents.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventTrailingMoved, event.Type)
+					assert.Equal(t, tc.expectedPrice, event.Price)
+				})
+			}
+
+			moved, err := orderService.MoveTrailingStop(context.Background(), tc.order, tc.tradePrice)
+
+			assert.Equal(t, tc.expectedError, err)
+			assert.Equal(t, tc.expectedMoved, moved)
+			assert.Equal(t, tc.expectedPrice, tc.order.Price)
+			assert.Equal(t, tc.expectedTsPrice, tc.order.TsPrice)
+		})
+	}
+}
+
+func TestOrder_GetOrder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+	cfg := &config.Config{}
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	exchanges := []string{consts.Binance}
+	orderService := service.NewOrder(cfg,
+		nil,
+		nil,
+		nil,
+		nil,
+		mockOrderRepo,
+		domain.NewExchangeQueues(exchanges),
+		domain.NewExchangeQueues(exchanges),
+		nil,
+		nil,
+		nil,
+		i18nSrv,
+		log.NewLogger())
+
+	testCases := []struct {
+		name          string
+		repoOrder     *domain.Order
+		expectedError bool
+	}{
+		{
+			name:      "Own order",
+			repoOrder: &domain.Order{Id: 1, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance},
+		},
+		{
+			name:          "Order of another user",
+			repoOrder:     &domain.Order{Id: 1, UserId: 8, Symbol: "BTCUSDT", Exchange: consts.Binance},
+			expectedError: true,
+		},
+		{
+			name:          "Order not found",
+			expectedError: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			mockOrderRepo.EXPECT().GetOrder(gomock.Any(), int64(1), "BTCUSDT", consts.Binance).Return(tc.repoOrder, nil)
+
+			order, err := orderService.GetOrder(context.Background(), 1, 7, "BTCUSDT", consts.Binance, false)
+			if tc.expectedError {
+				assert.Nil(t, order)
+				assert.True(t, err.(srvErr.Error).IsBadRequestError())
+
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.repoOrder, order)
+		})
+	}
+}
+
+func TestOrder_SyncOcoOrder(t *testing.T) {
+	testCases := []struct {
+		name           string
+		exchangeStatus string
+		exchangePrice  string
+		expectedStatus string
+		expectedPrice  string
+		inQueue        bool
+	}{
+		{
+			name:           "Filled leg executes order and cancels opposing leg",
+			exchangeStatus: consts.OrderStatusFilled,
+			exchangePrice:  "111",
+			expectedStatus: consts.OrderStatusFilled,
+			expectedPrice:  "111",
+		},
+		{
+			name:           "Filled stop leg keeps order price",
+			exchangeStatus: consts.OrderStatusFilled,
+			exchangePrice:  "0.00000000",
+			expectedStatus: consts.OrderStatusFilled,
+			expectedPrice:  "110",
+		},
+		{
+			name:           "Canceled leg cancels order",
+			exchangeStatus: consts.OrderStatusCanceled,
+			expectedStatus: consts.OrderStatusCanceled,
+			expectedPrice:  "110",
+		},
+		{
+			name:           "Active leg stays in queue",
+			exchangeStatus: consts.OrderStatusActive,
+			expectedStatus: consts.OrderStatusActive,
+			expectedPrice:  "110",
+			inQueue:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			limitQueues := domain.NewExchangeQueues(exchanges)
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				nil,
+				nil,
+				mockApiKeyRepo,
+				mockOrderRepo,
+				domain.NewExchangeQueues(exchanges),
+				limitQueues,
+				nil,
+				mockEvents,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			order := &domain.Order{Id: 2, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance,
+				Side: consts.OrderSideSell, TpSl: consts.TpOrderType, Price: "110", Status: consts.OrderStatusActive,
+				Protection: consts.ProtectionOco, OcoListId: 10, OcoOrderId: 100}
+			opposingOrder := &domain.Order{Id: 3, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance,
+				Side: consts.OrderSideSell, TpSl: consts.SlOrderType, Price: "90", Status: consts.OrderStatusActive,
+				Protection: consts.ProtectionOco, OcoListId: 10, OcoOrderId: 101}
+			limitQueues.Get(consts.Binance).Add(order)
+			limitQueues.Get(consts.Binance).Add(opposingOrder)
+
+			keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+			mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+			mockExchanger.EXPECT().GetOrder(keys, consts.Binance, "BTCUSDT", int64(100)).
+				Return(&domain.Order{Status: tc.exchangeStatus, Price: tc.exchangePrice}, nil)
+
+			switch tc.exchangeStatus {
+			case consts.OrderStatusFilled:
+				mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+						return fn(ctx, mockOrderRepo)
+					})
+				mockOrderRepo.EXPECT().ExecuteOrder(gomock.Any(), int64(2)).Return(nil)
+				mockOrderRepo.EXPECT().GetOpposingTpSlOrder(gomock.Any(), order).Return(opposingOrder, nil)
+				mockOrderRepo.EXPECT().CancelOrder(gomock.Any(), int64(3), "BTCUSDT", consts.Binance).Return(nil)
+				mockEvents.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventTpExecuted, event.Type)
+					assert.Equal(t, tc.expectedPrice, event.Price)
+				})
+			case consts.OrderStatusCanceled:
+				mockOrderRepo.EXPECT().CancelOrder(gomock.Any(), int64(2), "BTCUSDT", consts.Binance).Return(nil)
+			}
+
+			err := orderService.SyncOcoOrder(context.Background(), order)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, order.Status)
+			assert.Equal(t, tc.expectedPrice, order.Price)
+			assert.Equal(t, tc.inQueue, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 2))
+			if tc.exchangeStatus == consts.OrderStatusFilled {
+				assert.False(t, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 3))
+			}
+		})
+	}
+}
+
+func TestOrder_HandleExecutionReport(t *testing.T) {
+	testCases := []struct {
+		name           string
+		executedQty    string
+		report         *domain.ExecutionReport
+		prepare        func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher)
+		expectedStatus string
+		expectedTpSl   string
+		inQueue        bool
+	}{
+		{
+			name: "Filled limit order activates tpsl",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusFilled, FilledQuantity: "0.1", AvgPrice: "99.5"},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().SetExecutedQty(gomock.Any(), int64(1), consts.OrderStatusFilled, "0.1", "99.5").Return(nil)
+				orderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).
+					Return([]*domain.Order{{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance, Quantity: "0.1",
+						Status: consts.OrderStatusTpSlInactive}}, nil)
+				orderRepo.EXPECT().ActivateOrder(gomock.Any(), int64(2)).Return(nil)
+				events.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventFilled, event.Type)
+					assert.Equal(t, "99.5", event.AvgPrice)
+				})
+			},
+			expectedStatus: consts.OrderStatusFilled,
+			expectedTpSl:   "0.1",
+		},
+		{
+			name: "Partially filled order activates tpsl for filled part",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusPartFilled, FilledQuantity: "0.04", AvgPrice: "99.8"},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().SetExecutedQty(gomock.Any(), int64(1), consts.OrderStatusPartFilled, "0.04", "99.8").
+					Return(nil)
+				orderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).
+					Return([]*domain.Order{{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance, Quantity: "0.1",
+						Status: consts.OrderStatusTpSlInactive}}, nil)
+				orderRepo.EXPECT().UpdateQuantity(gomock.Any(), int64(2), "0.04").Return(nil)
+				orderRepo.EXPECT().ActivateOrder(gomock.Any(), int64(2)).Return(nil)
+				events.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventPartFilled, event.Type)
+					assert.Equal(t, "0.04", event.ExecutedQty)
+				})
+			},
+			expectedStatus: consts.OrderStatusPartFilled,
+			expectedTpSl:   "0.04",
+			inQueue:        true,
+		},
+		{
+			name:        "Next partial fill resizes active tpsl",
+			executedQty: "0.04",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusPartFilled, FilledQuantity: "0.07", AvgPrice: "99.9"},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().SetExecutedQty(gomock.Any(), int64(1), consts.OrderStatusPartFilled, "0.07", "99.9").
+					Return(nil)
+				orderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).
+					Return([]*domain.Order{{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance, Quantity: "0.04",
+						Status: consts.OrderStatusActive}}, nil)
+				orderRepo.EXPECT().UpdateQuantity(gomock.Any(), int64(2), "0.07").Return(nil)
+				events.EXPECT().Publish(gomock.Any())
+			},
+			expectedStatus: consts.OrderStatusPartFilled,
+			expectedTpSl:   "0.07",
+			inQueue:        true,
+		},
+		{
+			name:        "Stale partial fill is ignored",
+			executedQty: "0.04",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusPartFilled, FilledQuantity: "0.04", AvgPrice: "99.8"},
+			prepare:        func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {},
+			expectedStatus: consts.OrderStatusPartFilled,
+			inQueue:        true,
+		},
+		{
+			name: "Canceled limit order cancels tpsl",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusCanceled},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().CancelOrder(gomock.Any(), int64(1), "BTCUSDT", consts.Binance).Return(nil)
+				orderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).
+					Return([]*domain.Order{{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance}}, nil)
+				orderRepo.EXPECT().CancelOrder(gomock.Any(), int64(2), "BTCUSDT", consts.Binance).Return(nil)
+				events.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventCanceled, event.Type)
+				})
+			},
+			expectedStatus: consts.OrderStatusCanceled,
+		},
+		{
+			name:        "Canceled partially filled order keeps tpsl",
+			executedQty: "0.04",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusCanceled},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().CancelOrder(gomock.Any(), int64(1), "BTCUSDT", consts.Binance).Return(nil)
+				orderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).
+					Return([]*domain.Order{{Id: 2, Symbol: "BTCUSDT", Exchange: consts.Binance, Quantity: "0.04",
+						Status: consts.OrderStatusActive, Protection: consts.ProtectionVirtual}}, nil)
+				events.EXPECT().Publish(gomock.Any())
+			},
+			expectedStatus: consts.OrderStatusCanceled,
+		},
+		{
+			name: "Report of another user is ignored",
+			report: &domain.ExecutionReport{UserId: 8, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 500,
+				Status: consts.OrderStatusFilled},
+			prepare:        func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {},
+			expectedStatus: consts.OrderStatusActive,
+			inQueue:        true,
+		},
+		{
+			name: "Unknown order is ignored",
+			report: &domain.ExecutionReport{UserId: 7, Exchange: consts.Binance, Symbol: "BTCUSDT", OrderId: 501,
+				Status: consts.OrderStatusFilled},
+			prepare:        func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {},
+			expectedStatus: consts.OrderStatusActive,
+			inQueue:        true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			tpSlQueues := domain.NewExchangeQueues(exchanges)
+			limitQueues := domain.NewExchangeQueues(exchanges)
+			orderService := service.NewOrder(&config.Config{},
+				nil,
+				nil,
+				nil,
+				nil,
+				mockOrderRepo,
+				tpSlQueues,
+				limitQueues,
+				nil,
+				mockEvents,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			status := consts.OrderStatusActive
+			if tc.executedQty != "" {
+				status = consts.OrderStatusPartFilled
+			}
+			order := &domain.Order{Id: 1, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance,
+				Side: consts.OrderSideBuy, OrderType: consts.OrderTypeLimit, Price: "100", Quantity: "0.1",
+				ExecOrderId: 500, Status: status, ExecutedQty: tc.executedQty}
+			limitQueues.Get(consts.Binance).Add(order)
+
+			mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).AnyTimes().
+				DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+					return fn(ctx, mockOrderRepo)
+				})
+			tc.prepare(mockOrderRepo, mockEvents)
+
+			err := orderService.HandleExecutionReport(context.Background(), tc.report)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tc.expectedStatus, order.Status)
+			assert.Equal(t, tc.inQueue, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 1))
+			if tc.expectedTpSl != "" {
+				tpSlOrder := tpSlQueues.Get(consts.Binance).Get("BTCUSDT", 2)
+				require.NotNil(t, tpSlOrder)
+				assert.Equal(t, tc.expectedTpSl, tpSlOrder.Quantity)
+				assert.Equal(t, consts.OrderStatusActive, tpSlOrder.Status)
+			}
+		})
+	}
+}
+
+func TestOrder_CreateOrderTpLadder(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+	mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+	mockExchanger := mock_service.NewMockExchanger(ctrl)
+	mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+	mockFeed := mock_service.NewMockOrderPriceFeed(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	exchanges := []string{consts.Binance}
+	limitQueues := domain.NewExchangeQueues(exchanges)
+	orderService := service.NewOrder(&config.Config{},
+		mockExchanger,
+		nil,
+		nil,
+		mockApiKeyRepo,
+		mockOrderRepo,
+		domain.NewExchangeQueues(exchanges),
+		limitQueues,
+		nil,
+		mockEvents,
+		mockFeed,
+		i18nSrv,
+		log.NewLogger())
+
+	orderDto := &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+		Side: consts.OrderSideBuy, Quantity: "0.1", Price: "100", TimeInForce: consts.TimeInForceGTC, SlPercent: "5",
+		SlBreakeven: true, TpLevels: []dto.TpLevel{
+			{Percent: "2", Share: "30"},
+			{Percent: "4", Share: "30"},
+			{Price: "108", Share: "40"},
+		}}
+	keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+	mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+	released := false
+	mockFeed.EXPECT().Hold(consts.Binance, "BTCUSDT").Return(func() {
+		assert.True(t, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 1))
+		released = true
+	}, nil)
+	mockExchanger.EXPECT().CreateOrder(keys, orderDto).Return(int64(500), nil)
+	mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+			return fn(ctx, mockOrderRepo)
+		})
+	mockOrderRepo.EXPECT().CreateOrderWithSettings(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, order *domain.Order, settings *domain.Settings) (int64, error) {
+			assert.True(t, settings.SlBreakeven)
+			assert.Equal(t, []string{"30", "30", "40"}, settings.GetTpShares())
+
+			return 1, nil
+		})
+
+	expected := []struct {
+		tpSl     string
+		price    string
+		quantity string
+	}{
+		{consts.TpOrderType, "102", "0.03"},
+		{consts.TpOrderType, "104", "0.03"},
+		{consts.TpOrderType, "108", "0.04"},
+		{consts.SlOrderType, "95", "0.1"},
+	}
+	var created []*domain.Order
+	mockOrderRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Times(len(expected)).
+		DoAndReturn(func(ctx context.Context, order *domain.Order) (int64, error) {
+			created = append(created, order)
+
+			return int64(len(created) + 1), nil
+		})
+	mockEvents.EXPECT().Publish(gomock.Any())
+
+	order, err := orderService.CreateOrder(context.Background(), orderDto, 7)
+
+	require.NoError(t, err)
+	assert.True(t, released)
+	assert.Equal(t, int64(1), order.Id)
+	assert.True(t, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 1))
+	require.Len(t, created, len(expected))
+	for i, v := range expected {
+		assert.Equal(t, v.tpSl, created[i].TpSl)
+		assert.Equal(t, v.quantity, created[i].Quantity)
+		assert.Equal(t, 0, helper.StringToBigFloat(v.price).Cmp(helper.StringToBigFloat(created[i].Price)))
+		assert.Equal(t, consts.OrderStatusTpSlInactive, created[i].Status)
+		assert.Equal(t, int64(1), created[i].ExecOrderId)
+	}
+}
+
+func TestOrder_CreateOrderSymbolFilters(t *testing.T) {
+	filters := &domain.SymbolFilters{Symbol: "BTCUSDT", TickSize: "0.01000000", StepSize: "0.00100000",
+		MinQty: "0.00100000", MinNotional: "10"}
+	testCases := []struct {
+		name           string
+		orderDto       *dto.Order
+		filters        *domain.SymbolFilters
+		expectedPrice  string
+		expectedQty    string
+		expectedTpSl   []string
+		expectedErrKey string
+	}{
+		{
+			name: "Order and tpsl prices are rounded",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, Quantity: "0.12345", Price: "100.123", TimeInForce: consts.TimeInForceGTC,
+				TpPercent: "3.3333", SlPercent: "5"},
+			filters:       filters,
+			expectedPrice: "100.12",
+			expectedQty:   "0.123",
+			expectedTpSl:  []string{"103.46", "95.11"},
+		},
+		{
+			name: "Min notional is rejected before the exchange",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, Quantity: "0.05", Price: "100", TimeInForce: consts.TimeInForceGTC},
+			filters:        filters,
+			expectedErrKey: "filterMinNotional",
+		},
+		{
+			name: "Take profit level below min quantity is rejected",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, Quantity: "0.002", Price: "100", TimeInForce: consts.TimeInForceGTC,
+				TpLevels: []dto.TpLevel{{Percent: "2", Share: "30"}, {Percent: "4", Share: "70"}}},
+			filters:        &domain.SymbolFilters{Symbol: "BTCUSDT", TickSize: "0.01", StepSize: "0.001", MinQty: "0.001"},
+			expectedErrKey: "filterMinQty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockFilters := mock_service.NewMockSymbolFilterSource(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				mockFilters,
+				nil,
+				mockApiKeyRepo,
+				mockOrderRepo,
+				domain.NewExchangeQueues(exchanges),
+				domain.NewExchangeQueues(exchanges),
+				nil,
+				nil,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+			mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+			mockFilters.EXPECT().GetFilters(consts.Binance, "BTCUSDT").Return(tc.filters, nil).AnyTimes()
+
+			var created []*domain.Order
+			if tc.expectedErrKey == "" {
+				mockExchanger.EXPECT().CreateOrder(keys, gomock.Any()).
+					DoAndReturn(func(keys *domain.ApiKeys, order *dto.Order) (int64, error) {
+						assert.Equal(t, tc.expectedPrice, order.Price)
+						assert.Equal(t, tc.expectedQty, order.Quantity)
+
+						return 500, nil
+					})
+				mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+						return fn(ctx, mockOrderRepo)
+					})
+				mockOrderRepo.EXPECT().CreateOrderWithSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				mockOrderRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Times(len(tc.expectedTpSl)).
+					DoAndReturn(func(ctx context.Context, order *domain.Order) (int64, error) {
+						created = append(created, order)
+
+						return int64(len(created) + 1), nil
+					})
+			}
+
+			_, err := orderService.CreateOrder(context.Background(), tc.orderDto, 7)
+			if tc.expectedErrKey != "" {
+				require.Error(t, err)
+				assert.True(t, err.(srvErr.Error).IsBadRequestError())
+				assert.Equal(t, i18nSrv.T(tc.expectedErrKey, map[string]interface{}{
+					"Symbol":      tc.filters.Symbol,
+					"MinQty":      tc.filters.MinQty,
+					"MinNotional": tc.filters.MinNotional,
+				}, "ru"), err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			require.Len(t, created, len(tc.expectedTpSl))
+			for i, price := range tc.expectedTpSl {
+				assert.Equal(t, price, created[i].Price)
+				assert.Equal(t, tc.expectedQty, created[i].Quantity)
+			}
+		})
+	}
+}
+
+func TestOrder_CreateOrderRiskSizing(t *testing.T) {
+	filters := &domain.SymbolFilters{Symbol: "BTCUSDT", TickSize: "0.01", StepSize: "0.001", MinQty: "0.1"}
+	testCases := []struct {
+		name           string
+		orderDto       *dto.Order
+		balance        domain.Balance
+		expectedQty    string
+		expectedErrKey string
+	}{
+		{
+			name: "Buy quantity is sized by stop loss price",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, RiskPercent: "1", Price: "100", TimeInForce: consts.TimeInForceGTC,
+				SlPrice: "97"},
+			balance:     domain.Balance{domain.NewBalanceSymbol("BTC", "1", ""), domain.NewBalanceSymbol("USDT", "1000", "50")},
+			expectedQty: "3.333",
+		},
+		{
+			name: "Sell quantity is sized by stop loss percent",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideSell, RiskPercent: "2", Price: "200", TimeInForce: consts.TimeInForceGTC,
+				SlPercent: "2"},
+			balance:     domain.Balance{domain.NewBalanceSymbol("USDT", "500", "")},
+			expectedQty: "2.500",
+		},
+		{
+			name: "Stop loss above buy entry is rejected",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, RiskPercent: "1", Price: "100", TimeInForce: consts.TimeInForceGTC,
+				SlPrice: "105"},
+			balance:        domain.Balance{domain.NewBalanceSymbol("USDT", "1000", "")},
+			expectedErrKey: "riskStopDistance",
+		},
+		{
+			name: "No USDT balance is rejected",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, RiskPercent: "1", Price: "100", TimeInForce: consts.TimeInForceGTC,
+				SlPrice: "97"},
+			balance:        domain.Balance{domain.NewBalanceSymbol("BTC", "1", "")},
+			expectedErrKey: "riskNoBalance",
+		},
+		{
+			name: "Size below exchange minimum is rejected",
+			orderDto: &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+				Side: consts.OrderSideBuy, RiskPercent: "1", Price: "100", TimeInForce: consts.TimeInForceGTC,
+				SlPrice: "97"},
+			balance:        domain.Balance{domain.NewBalanceSymbol("USDT", "10", "")},
+			expectedErrKey: "filterMinQty",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockFilters := mock_service.NewMockSymbolFilterSource(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				mockFilters,
+				nil,
+				mockApiKeyRepo,
+				mockOrderRepo,
+				domain.NewExchangeQueues(exchanges),
+				domain.NewExchangeQueues(exchanges),
+				nil,
+				nil,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+			mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+			mockExchanger.EXPECT().Balance(keys, consts.Binance).Return(tc.balance, nil)
+			mockFilters.EXPECT().GetFilters(consts.Binance, "BTCUSDT").Return(filters, nil).AnyTimes()
+
+			if tc.expectedErrKey == "" {
+				mockExchanger.EXPECT().CreateOrder(keys, gomock.Any()).
+					DoAndReturn(func(keys *domain.ApiKeys, order *dto.Order) (int64, error) {
+						assert.Equal(t, tc.expectedQty, order.Quantity)
+
+						return 500, nil
+					})
+				mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+						return fn(ctx, mockOrderRepo)
+					})
+				mockOrderRepo.EXPECT().CreateOrderWithSettings(gomock.Any(), gomock.Any(), gomock.Any()).Return(int64(1), nil)
+				mockOrderRepo.EXPECT().CreateOrder(gomock.Any(), gomock.Any()).Return(int64(2), nil)
+			}
+
+			order, err := orderService.CreateOrder(context.Background(), tc.orderDto, 7)
+			if tc.expectedErrKey != "" {
+				require.Error(t, err)
+				assert.True(t, err.(srvErr.Error).IsBadRequestError())
+				assert.Equal(t, i18nSrv.T(tc.expectedErrKey, map[string]interface{}{
+					"Symbol": filters.Symbol,
+					"MinQty": filters.MinQty,
+				}, "ru"), err.Error())
+
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, tc.expectedQty, order.Quantity)
+		})
+	}
+}
+
+func TestOrder_CreateOrderRiskLimits(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+	mockExchanger := mock_service.NewMockExchanger(ctrl)
+	mockRisk := mock_service.NewMockRiskChecker(ctrl)
+	exchanges := []string{consts.Binance}
+	orderService := service.NewOrder(&config.Config{},
+		mockExchanger,
+		nil,
+		mockRisk,
+		mockApiKeyRepo,
+		mock_service.NewMockOrderRepo(ctrl),
+		domain.NewExchangeQueues(exchanges),
+		domain.NewExchangeQueues(exchanges),
+		nil,
+		nil,
+		nil,
+		i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList()),
+		log.NewLogger())
+
+	orderDto := &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", OrderType: consts.OrderTypeLimit,
+		Side: consts.OrderSideBuy, Quantity: "1", Price: "100", TimeInForce: consts.TimeInForceGTC, SlPrice: "95"}
+	keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+	mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+	mockRisk.EXPECT().CheckOrder(gomock.Any(), int64(7), orderDto, "100").
+		Return(srvErr.BadRequestError("riskLimitNotional"))
+
+	order, err := orderService.CreateOrder(context.Background(), orderDto, 7)
+
+	assert.Nil(t, order)
+	require.Error(t, err)
+	assert.True(t, err.(srvErr.Error).IsBadRequestError())
+}
+
+func TestOrder_CancelOrderById(t *testing.T) {
+	testCases := []struct {
+		name     string
+		order    *domain.Order
+		canceled bool
+	}{
+		{
+			name: "Open limit order is canceled by exec order id",
+			order: &domain.Order{Id: 3, ExecOrderId: 900, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance,
+				OrderType: consts.OrderTypeLimit, Status: consts.OrderStatusActive},
+			canceled: true,
+		},
+		{
+			name: "Filled order is left as is",
+			order: &domain.Order{Id: 3, ExecOrderId: 900, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance,
+				OrderType: consts.OrderTypeLimit, Status: consts.OrderStatusFilled},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+			exchanges := []string{consts.Binance}
+			limitQueues := domain.NewExchangeQueues(exchanges)
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				nil,
+				nil,
+				mockApiKeyRepo,
+				mockOrderRepo,
+				domain.NewExchangeQueues(exchanges),
+				limitQueues,
+				nil,
+				mockEvents,
+				nil,
+				i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList()),
+				log.NewLogger())
+
+			mockOrderRepo.EXPECT().GetOrder(gomock.Any(), int64(3), "BTCUSDT", consts.Binance).Return(tc.order, nil)
+			if tc.canceled {
+				limitQueues.Get(consts.Binance).Add(tc.order)
+				keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+				mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+				mockExchanger.EXPECT().CancelOrder(keys, dto.NewCancelOrder(900, "BTCUSDT", consts.Binance)).Return(nil)
+				mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+					DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+						return fn(ctx, mockOrderRepo)
+					})
+				mockOrderRepo.EXPECT().CancelOrder(gomock.Any(), int64(3), "BTCUSDT", consts.Binance).Return(nil)
+				mockOrderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(3)).Return(nil, nil)
+				mockEvents.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventCanceled, event.Type)
+					assert.Equal(t, int64(3), event.OrderId)
+				})
+			}
+
+			err := orderService.CancelOrderById(context.Background(), 3, 7, "BTCUSDT", consts.Binance)
+
+			require.NoError(t, err)
+			assert.False(t, limitQueues.Get(consts.Binance).Exist("BTCUSDT", 3))
+		})
+	}
+}
+
+func TestOrder_ExecuteTpSlOrder(t *testing.T) {
+	testCases := []struct {
+		name          string
+		order         *domain.Order
+		tpSlOrders    []*domain.Order
+		prepare       func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher)
+		canceled      []int64
+		slQuantity    string
+		slPrice       string
+		expectedEvent string
+	}{
+		{
+			name:  "Last take profit cancels stop loss",
+			order: &domain.Order{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.4"},
+			tpSlOrders: []*domain.Order{
+				{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.4", Status: consts.OrderStatusActive},
+				{Id: 3, TpSl: consts.TpOrderType, Quantity: "0.6", Status: consts.OrderStatusFilled},
+				{Id: 5, TpSl: consts.SlOrderType, Quantity: "0.4", Price: "90", Status: consts.OrderStatusActive},
+			},
+			prepare:  func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {},
+			canceled: []int64{5},
+		},
+		{
+			name:  "Stop loss cancels all take profits",
+			order: &domain.Order{Id: 5, TpSl: consts.SlOrderType, Quantity: "1"},
+			tpSlOrders: []*domain.Order{
+				{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.3", Status: consts.OrderStatusActive},
+				{Id: 3, TpSl: consts.TpOrderType, Quantity: "0.7", Status: consts.OrderStatusActive},
+				{Id: 5, TpSl: consts.SlOrderType, Quantity: "1", Price: "90", Status: consts.OrderStatusActive},
+			},
+			prepare:  func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {},
+			canceled: []int64{2, 3},
+		},
+		{
+			name:  "Take profit level shrinks stop loss",
+			order: &domain.Order{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.3"},
+			tpSlOrders: []*domain.Order{
+				{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.3", Status: consts.OrderStatusActive},
+				{Id: 3, TpSl: consts.TpOrderType, Quantity: "0.3", Status: consts.OrderStatusActive},
+				{Id: 4, TpSl: consts.TpOrderType, Quantity: "0.4", Status: consts.OrderStatusActive},
+				{Id: 5, TpSl: consts.SlOrderType, Quantity: "1", Price: "90", Status: consts.OrderStatusActive},
+			},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().GetSettings(gomock.Any(), int64(1)).Return(&domain.Settings{OrderId: 1}, nil)
+				orderRepo.EXPECT().UpdateQuantity(gomock.Any(), int64(5), "0.7").Return(nil)
+			},
+			slQuantity: "0.7",
+			slPrice:    "90",
+		},
+		{
+			name:  "Take profit level moves stop loss to breakeven",
+			order: &domain.Order{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.3"},
+			tpSlOrders: []*domain.Order{
+				{Id: 2, TpSl: consts.TpOrderType, Quantity: "0.3", Status: consts.OrderStatusActive},
+				{Id: 3, TpSl: consts.TpOrderType, Quantity: "0.3", Status: consts.OrderStatusActive},
+				{Id: 4, TpSl: consts.TpOrderType, Quantity: "0.4", Status: consts.OrderStatusActive},
+				{Id: 5, TpSl: consts.SlOrderType, Quantity: "1", Price: "90", Status: consts.OrderStatusActive},
+			},
+			prepare: func(orderRepo *mock_service.MockOrderRepo, events *mock_service.MockOrderEventPublisher) {
+				orderRepo.EXPECT().GetSettings(gomock.Any(), int64(1)).
+					Return(&domain.Settings{OrderId: 1, SlBreakeven: true}, nil)
+				orderRepo.EXPECT().GetOrder(gomock.Any(), int64(1), "BTCUSDT", consts.Binance).
+					Return(&domain.Order{Id: 1, Price: "100", AvgPrice: "100.5"}, nil)
+				orderRepo.EXPECT().UpdateQuantity(gomock.Any(), int64(5), "0.7").Return(nil)
+				orderRepo.EXPECT().UpdateTpSl(gomock.Any(), int64(5), "100.5", nil).Return(nil)
+			},
+			slQuantity:    "0.7",
+			slPrice:       "100.5",
+			expectedEvent: consts.OrderEventSlBreakeven,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			tpSlQueues := domain.NewExchangeQueues(exchanges)
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				nil,
+				nil,
+				mockApiKeyRepo,
+				mockOrderRepo,
+				tpSlQueues,
+				domain.NewExchangeQueues(exchanges),
+				nil,
+				mockEvents,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			queue := tpSlQueues.Get(consts.Binance)
+			for _, v := range tc.tpSlOrders {
+				v.UserId, v.Symbol, v.Exchange, v.ExecOrderId = 7, "BTCUSDT", consts.Binance, 1
+				v.Side, v.OrderType = consts.OrderSideSell, consts.OrderTypeMarket
+				if v.Status == consts.OrderStatusActive && v.Id != tc.order.Id {
+					queue.Add(&domain.Order{Id: v.Id, Symbol: v.Symbol, Quantity: v.Quantity, Price: v.Price})
+				}
+			}
+			order := tc.order
+			order.UserId, order.Symbol, order.Exchange, order.ExecOrderId = 7, "BTCUSDT", consts.Binance, 1
+			order.Side, order.OrderType, order.Status = consts.OrderSideSell, consts.OrderTypeMarket, consts.OrderStatusActive
+			queue.Add(order)
+
+			keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+			mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+			mockExchanger.EXPECT().CreateOrder(keys, gomock.Any()).
+				DoAndReturn(func(_ *domain.ApiKeys, execOrder *dto.Order) (int64, error) {
+					assert.Equal(t, "89", execOrder.Price)
+
+					return 900, nil
+				})
+			mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+				DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+					return fn(ctx, mockOrderRepo)
+				})
+			mockOrderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).Return(tc.tpSlOrders, nil)
+			for _, id := range tc.canceled {
+				mockOrderRepo.EXPECT().CancelOrder(gomock.Any(), id, "BTCUSDT", consts.Binance).Return(nil)
+			}
+			mockOrderRepo.EXPECT().SetExecutedQty(gomock.Any(), order.Id, consts.OrderStatusFilled, order.Quantity, "89").Return(nil)
+			tc.prepare(mockOrderRepo, mockEvents)
+
+			var events []string
+			mockEvents.EXPECT().Publish(gomock.Any()).AnyTimes().Do(func(event domain.OrderEvent) {
+				events = append(events, event.Type)
+			})
+
+			triggerPrice := order.Price
+			excId, err := orderService.ExecuteTpSlOrder(context.Background(), 7, order, "89")
+
+			require.NoError(t, err)
+			assert.Equal(t, triggerPrice, order.Price)
+			assert.Equal(t, int64(900), excId)
+			assert.False(t, queue.Exist("BTCUSDT", order.Id))
+			for _, id := range tc.canceled {
+				assert.False(t, queue.Exist("BTCUSDT", id))
+			}
+			if tc.slQuantity != "" {
+				slOrder := queue.Get("BTCUSDT", 5)
+				require.NotNil(t, slOrder)
+				assert.Equal(t, tc.slQuantity, slOrder.Quantity)
+				assert.Equal(t, tc.slPrice, slOrder.Price)
+			}
+			require.NotEmpty(t, events)
+			assert.NotEqual(t, consts.OrderEventExecuteFailed, events[0])
+			if tc.expectedEvent != "" {
+				assert.Contains(t, events, tc.expectedEvent)
+			}
+		})
+	}
+}
+
+func TestOrder_ExecuteTpSlOrderDbError(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockOrderRepo := mock_service.NewMockOrderRepo(ctrl)
+	mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+	mockExchanger := mock_service.NewMockExchanger(ctrl)
+	mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	exchanges := []string{consts.Binance}
+	tpSlQueues := domain.NewExchangeQueues(exchanges)
+	orderService := service.NewOrder(&config.Config{},
+		mockExchanger,
+		nil,
+		nil,
+		mockApiKeyRepo,
+		mockOrderRepo,
+		tpSlQueues,
+		domain.NewExchangeQueues(exchanges),
+		nil,
+		mockEvents,
+		nil,
+		i18nSrv,
+		log.NewLogger())
+
+	order := &domain.Order{Id: 5, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance, ExecOrderId: 1,
+		Side: consts.OrderSideSell, OrderType: consts.OrderTypeMarket, TpSl: consts.SlOrderType,
+		Quantity: "1", Price: "90", Status: consts.OrderStatusActive}
+	tpSlQueues.Get(consts.Binance).Add(order)
+
+	keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+	mockApiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+	mockExchanger.EXPECT().CreateOrder(keys, gomock.Any()).Return(int64(900), nil)
+	mockOrderRepo.EXPECT().Atomic(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, fn func(context.Context, service.OrderRepo) error) error {
+			return fn(ctx, mockOrderRepo)
+		})
+	mockOrderRepo.EXPECT().GetTpSlOrdersByBaseOrder(gomock.Any(), int64(1)).Return(nil, errors.New("database error"))
+	mockEvents.EXPECT().Publish(gomock.Any()).Times(0)
+
+	excId, err := orderService.ExecuteTpSlOrder(context.Background(), 7, order, "89")
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), excId)
+}
+
+func TestOrder_ExecuteTpSlOrderOwnership(t *testing.T) {
+	testCases := []struct {
+		name     string
+		queued   bool
+		prepare  func(apiKeyRepo *mock_service.MockApiKeyRepo, exchanger *mock_service.MockExchanger, events *mock_service.MockOrderEventPublisher)
+		hasError bool
+	}{
+		{
+			name:   "Exchange error keeps the order queued and active",
+			queued: true,
+			prepare: func(apiKeyRepo *mock_service.MockApiKeyRepo, exchanger *mock_service.MockExchanger, events *mock_service.MockOrderEventPublisher) {
+				keys := domain.NewApiKeys(7, consts.Binance, "pub", "", "")
+				apiKeyRepo.EXPECT().GetApiKeysByUserIdAndExchange(gomock.Any(), int64(7), consts.Binance).Return(keys, nil)
+				exchanger.EXPECT().CreateOrder(keys, gomock.Any()).Return(int64(0), errors.New("exchange unavailable"))
+				events.EXPECT().Publish(gomock.Any()).Do(func(event domain.OrderEvent) {
+					assert.Equal(t, consts.OrderEventExecuteFailed, event.Type)
+				})
+			},
+			hasError: true,
+		},
+		{
+			name: "Order taken by another caller is not sent",
+			prepare: func(apiKeyRepo *mock_service.MockApiKeyRepo, exchanger *mock_service.MockExchanger, events *mock_service.MockOrderEventPublisher) {
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			mockApiKeyRepo := mock_service.NewMockApiKeyRepo(ctrl)
+			mockExchanger := mock_service.NewMockExchanger(ctrl)
+			mockEvents := mock_service.NewMockOrderEventPublisher(ctrl)
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			exchanges := []string{consts.Binance}
+			tpSlQueues := domain.NewExchangeQueues(exchanges)
+			orderService := service.NewOrder(&config.Config{},
+				mockExchanger,
+				nil,
+				nil,
+				mockApiKeyRepo,
+				mock_service.NewMockOrderRepo(ctrl),
+				tpSlQueues,
+				domain.NewExchangeQueues(exchanges),
+				nil,
+				mockEvents,
+				nil,
+				i18nSrv,
+				log.NewLogger())
+
+			order := &domain.Order{Id: 5, UserId: 7, Symbol: "BTCUSDT", Exchange: consts.Binance, ExecOrderId: 1,
+				Side: consts.OrderSideSell, OrderType: consts.OrderTypeMarket, TpSl: consts.SlOrderType,
+				Quantity: "1", Price: "90", Status: consts.OrderStatusActive}
+			queue := tpSlQueues.Get(consts.Binance)
+			if tc.queued {
+				queue.Add(order)
+			}
+			tc.prepare(mockApiKeyRepo, mockExchanger, mockEvents)
+
+			excId, err := orderService.ExecuteTpSlOrder(context.Background(), 7, order, "89")
+
+			assert.Equal(t, tc.hasError, err != nil)
+			assert.Equal(t, int64(0), excId)
+			assert.Equal(t, tc.queued, queue.Exist("BTCUSDT", order.Id))
+			assert.Equal(t, consts.OrderStatusActive, order.Status)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPnlService_GetPnl(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	mockPrices := mock_service.NewMockPriceSource(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	pnlService := service.NewPnlService(config.NewConfig(), mockDealRepo, mockPrices, i18nSrv, log.NewLogger())
+
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, time.Now())
+	closedAt := time.Now().UTC()
+	deals := []*domain.Deal{
+		domain.NewDeal(&domain.Order{Id: 1, Exchange: consts.Binance, Symbol: "BTCUSDT", Side: consts.OrderSideBuy,
+			Status: consts.OrderStatusFilled, Quantity: "1", Price: "100"},
+			[]*domain.Order{
+				{Id: 2, TpSl: consts.TpOrderType, Status: consts.OrderStatusFilled, Quantity: "1", Price: "110",
+					UpdatedAt: closedAt},
+				{Id: 3, TpSl: consts.SlOrderType, Status: consts.OrderStatusCanceled, Quantity: "1", Price: "95"},
+			}, &domain.Settings{SlPercent: "5"}),
+		domain.NewDeal(&domain.Order{Id: 4, Exchange: consts.Binance, Symbol: "ETHBTC", Side: consts.OrderSideBuy,
+			Status: consts.OrderStatusFilled, Quantity: "2", Price: "0.05"},
+			[]*domain.Order{
+				{Id: 5, TpSl: consts.TpOrderType, Status: consts.OrderStatusActive, Quantity: "2", Price: "0.07"},
+			}, nil),
+		domain.NewDeal(&domain.Order{Id: 6, Exchange: consts.Binance, Symbol: "BTCUSDT", Side: consts.OrderSideSell,
+			Status: consts.OrderStatusFilled, Quantity: "1", Price: "100"},
+			[]*domain.Order{
+				{Id: 7, TpSl: consts.SlOrderType, Status: consts.OrderStatusFilled, Quantity: "1", Price: "120",
+					UpdatedAt: from.Add(-48 * time.Hour)},
+			}, nil),
+		domain.NewDeal(&domain.Order{Id: 8, Exchange: consts.Kucoin, Symbol: "XYZ-EUR", Side: consts.OrderSideSell,
+			Status: consts.OrderStatusFilled, Quantity: "10", Price: "3"}, nil, nil),
+	}
+
+	mockDealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), from).Return(deals, nil)
+	mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "ETHBTC").Return("0.06", nil)
+	mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return("40000", nil)
+	mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Kucoin, "XYZ-EUR").Return("2", nil)
+	mockPrices.EXPECT().GetPrice(gomock.Any(), consts.Kucoin, "EUR-USDT").Return("", errors.New("redis: nil"))
+
+	report, err := pnlService.GetPnl(context.Background(), 7, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, consts.PnlPeriodDay, report.Period)
+	assert.Equal(t, from, report.From)
+	assert.Equal(t, domain.UsdtAsset, report.Currency)
+	assert.Equal(t, "10", report.RealizedPnl)
+	assert.Equal(t, "800", report.UnrealizedPnl)
+	assert.Equal(t, "4.21", report.Fees)
+	assert.Equal(t, "805.79", report.TotalPnl)
+	assert.Equal(t, 1, report.ClosedDeals)
+	assert.Equal(t, 2, report.OpenDeals)
+	assert.Equal(t, 1, report.Wins)
+	assert.Equal(t, "100.00", report.WinRate)
+	assert.Equal(t, "1.96", report.AvgR)
+
+	require.Len(t, report.Symbols, 3)
+	assert.Equal(t, &domain.PnlSymbol{Exchange: consts.Binance, Symbol: "BTCUSDT", Currency: domain.UsdtAsset,
+		RealizedPnl: "10", UnrealizedPnl: "0", Fees: "0.21", TotalPnl: "9.79", ClosedDeals: 1, Wins: 1,
+		WinRate: "100.00", AvgR: "1.96"}, withoutStats(report.Symbols[0]))
+	assert.Equal(t, &domain.PnlSymbol{Exchange: consts.Binance, Symbol: "ETHBTC", Currency: domain.UsdtAsset,
+		RealizedPnl: "0", UnrealizedPnl: "800", Fees: "4", TotalPnl: "796", OpenDeals: 1, WinRate: "0",
+		AvgR: "0"}, withoutStats(report.Symbols[1]))
+	assert.Equal(t, &domain.PnlSymbol{Exchange: consts.Kucoin, Symbol: "XYZ-EUR", Currency: "EUR",
+		RealizedPnl: "0", UnrealizedPnl: "10", Fees: "0.03", TotalPnl: "9.97", OpenDeals: 1, WinRate: "0",
+		AvgR: "0"}, withoutStats(report.Symbols[2]))
+}
+
+func TestPnlService_GetPnlErrors(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+	pnlService := service.NewPnlService(config.NewConfig(), mockDealRepo, nil, i18nSrv, log.NewLogger())
+
+	report, err := pnlService.GetPnl(context.Background(), 7, "year")
+	assert.Nil(t, report)
+	assert.Equal(t, srvErr.BadRequestError(i18nSrv.T("invalidPnlPeriod", nil, "ru")), err)
+
+	repoErr := errors.New("deal repository error")
+	mockDealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), gomock.Any()).Return(nil, repoErr)
+	report, err = pnlService.GetPnl(context.Background(), 7, "Month")
+	assert.Nil(t, report)
+	assert.Equal(t, srvErr.InternalServerError(repoErr), err)
+}
+
+func withoutStats(symbol *domain.PnlSymbol) *domain.PnlSymbol {
+	return &domain.PnlSymbol{
+		Exchange:      symbol.Exchange,
+		Symbol:        symbol.Symbol,
+		Currency:      symbol.Currency,
+		RealizedPnl:   symbol.RealizedPnl,
+		UnrealizedPnl: symbol.UnrealizedPnl,
+		Fees:          symbol.Fees,
+		TotalPnl:      symbol.TotalPnl,
+		ClosedDeals:   symbol.ClosedDeals,
+		OpenDeals:     symbol.OpenDeals,
+		Wins:          symbol.Wins,
+		WinRate:       symbol.WinRate,
+		AvgR:          symbol.AvgR,
+	}
+}
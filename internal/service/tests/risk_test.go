@@ -0,0 +1,439 @@
+package tests_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type riskMocks struct {
+	riskRepo *mock_service.MockRiskLimitsRepo
+	dealRepo *mock_service.MockDealRepo
+	prices   *mock_service.MockPriceSource
+	admins   *mock_service.MockAdminSource
+	notifier *mock_service.MockRiskNotifier
+}
+
+func TestRiskService_CheckOrder(t *testing.T) {
+	pausedUntil := time.Now().Add(time.Hour)
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, time.Now())
+	losingDeal := func() *domain.Deal {
+		return domain.NewDeal(&domain.Order{Id: 1, Exchange: consts.Binance, Symbol: "BTCUSDT",
+			Side: consts.OrderSideBuy, Status: consts.OrderStatusFilled, Quantity: "1", Price: "100"},
+			[]*domain.Order{{Id: 2, TpSl: consts.SlOrderType, Status: consts.OrderStatusFilled, Quantity: "1",
+				Price: "80", UpdatedAt: time.Now().UTC()}}, nil)
+	}
+	openDeal := func() *domain.Deal {
+		return domain.NewDeal(&domain.Order{Id: 3, Exchange: consts.Binance, Symbol: "ETHUSDT",
+			Side: consts.OrderSideBuy, Status: consts.OrderStatusFilled, Quantity: "1", Price: "10"},
+			[]*domain.Order{{Id: 4, TpSl: consts.SlOrderType, Status: consts.OrderStatusActive, Quantity: "1",
+				Price: "9"}}, nil)
+	}
+	order := &dto.Order{Exchange: consts.Binance, Symbol: "BTCUSDT", Side: consts.OrderSideBuy,
+		OrderType: consts.OrderTypeLimit, Quantity: "0.5", Price: "100"}
+
+	testCases := []struct {
+		name           string
+		order          *dto.Order
+		limits         *domain.RiskLimits
+		prepare        func(m riskMocks)
+		expectedErrKey string
+	}{
+		{
+			name:    "No limits",
+			order:   order,
+			prepare: func(m riskMocks) {},
+		},
+		{
+			name:           "Paused user is rejected",
+			order:          order,
+			limits:         &domain.RiskLimits{UserId: 7, PausedUntil: &pausedUntil},
+			prepare:        func(m riskMocks) {},
+			expectedErrKey: "riskLimitPaused",
+		},
+		{
+			name:           "Symbol out of allowed list is rejected",
+			order:          order,
+			limits:         &domain.RiskLimits{UserId: 7, AllowedSymbols: []string{"ETHUSDT"}},
+			prepare:        func(m riskMocks) {},
+			expectedErrKey: "riskLimitSymbol",
+		},
+		{
+			name: "Order value above limit is rejected",
+			order: &dto.Order{Exchange: consts.Binance, Symbol: "ETHBTC", Side: consts.OrderSideBuy,
+				OrderType: consts.OrderTypeLimit, Quantity: "2", Price: "0.05"},
+			limits: &domain.RiskLimits{UserId: 7, MaxOrderNotional: "3000"},
+			prepare: func(m riskMocks) {
+				m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return("40000", nil)
+			},
+			expectedErrKey: "riskLimitNotional",
+		},
+		{
+			name:    "Order value within limit passes",
+			order:   order,
+			limits:  &domain.RiskLimits{UserId: 7, MaxOrderNotional: "50", AllowedSymbols: []string{"BTCUSDT"}},
+			prepare: func(m riskMocks) {},
+		},
+		{
+			name:   "Open deals limit is rejected",
+			order:  order,
+			limits: &domain.RiskLimits{UserId: 7, MaxOpenDeals: 1, MaxDailyLoss: "50"},
+			prepare: func(m riskMocks) {
+				m.dealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), from).
+					Return([]*domain.Deal{losingDeal(), openDeal()}, nil)
+			},
+			expectedErrKey: "riskLimitOpenDeals",
+		},
+		{
+			name:   "Daily loss trips the circuit breaker",
+			order:  order,
+			limits: &domain.RiskLimits{UserId: 7, MaxDailyLoss: "15"},
+			prepare: func(m riskMocks) {
+				m.dealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), from).
+					Return([]*domain.Deal{losingDeal(), openDeal()}, nil)
+				m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+					Return(&domain.RiskLimits{UserId: 7, MaxDailyLoss: "15"}, nil)
+				m.riskRepo.EXPECT().SetPausedUntil(gomock.Any(), int64(7), from.AddDate(0, 0, 1)).Return(nil)
+				m.admins.EXPECT().GetAdmins(gomock.Any()).Return([]int{1, 2}, nil)
+				m.notifier.EXPECT().NotifyTradingPaused(gomock.Any(), []int{1, 2}, gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string) {
+						require.NotNil(t, limits.PausedUntil)
+						assert.Equal(t, from.AddDate(0, 0, 1), *limits.PausedUntil)
+					})
+			},
+			expectedErrKey: "riskLimitDailyLoss",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := riskMocks{
+				riskRepo: mock_service.NewMockRiskLimitsRepo(ctrl),
+				dealRepo: mock_service.NewMockDealRepo(ctrl),
+				prices:   mock_service.NewMockPriceSource(ctrl),
+				admins:   mock_service.NewMockAdminSource(ctrl),
+				notifier: mock_service.NewMockRiskNotifier(ctrl),
+			}
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			riskService := service.NewRiskService(&config.Config{},
+				m.riskRepo,
+				m.dealRepo,
+				m.prices,
+				m.admins,
+				m.notifier,
+				i18nSrv,
+				log.NewLogger())
+
+			m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).Return(tc.limits, nil)
+			tc.prepare(m)
+
+			err := riskService.CheckOrder(context.Background(), 7, tc.order, tc.order.Price)
+			if tc.expectedErrKey == "" {
+				assert.NoError(t, err)
+
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, err.(srvErr.Error).IsBadRequestError())
+			assert.Equal(t, riskErrKeys(i18nSrv, tc.limits)[tc.expectedErrKey], err.Error())
+		})
+	}
+}
+
+func TestRiskService_CheckDailyLoss(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRiskRepo := mock_service.NewMockRiskLimitsRepo(ctrl)
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	mockAdmins := mock_service.NewMockAdminSource(ctrl)
+	mockNotifier := mock_service.NewMockRiskNotifier(ctrl)
+	riskService := service.NewRiskService(&config.Config{},
+		mockRiskRepo,
+		mockDealRepo,
+		mock_service.NewMockPriceSource(ctrl),
+		mockAdmins,
+		mockNotifier,
+		i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList()),
+		log.NewLogger())
+
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, time.Now())
+	mockRiskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+		Return(&domain.RiskLimits{UserId: 7, MaxDailyLoss: "10"}, nil).Times(2)
+	mockDealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), from).Return([]*domain.Deal{
+		domain.NewDeal(&domain.Order{Id: 1, Exchange: consts.Binance, Symbol: "BTCUSDT",
+			Side: consts.OrderSideSell, Status: consts.OrderStatusFilled, Quantity: "1", Price: "100"},
+			[]*domain.Order{{Id: 2, TpSl: consts.SlOrderType, Status: consts.OrderStatusFilled, Quantity: "1",
+				Price: "120", UpdatedAt: time.Now().UTC()}}, nil),
+	}, nil)
+	mockRiskRepo.EXPECT().SetPausedUntil(gomock.Any(), int64(7), from.AddDate(0, 0, 1)).Return(nil)
+	mockAdmins.EXPECT().GetAdmins(gomock.Any()).Return([]int{1}, nil)
+	mockNotifier.EXPECT().NotifyTradingPaused(gomock.Any(), []int{1}, gomock.Any(), gomock.Any()).
+		Do(func(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string) {
+			assert.Equal(t, int64(7), limits.UserId)
+			assert.True(t, strings.HasPrefix(dayPnl, "-20"))
+		})
+
+	riskService.CheckDailyLoss(context.Background(), 7)
+}
+
+func TestRiskService_SetRiskLimits(t *testing.T) {
+	pausedUntil := time.Now().Add(time.Hour)
+	current := &domain.RiskLimits{UserId: 7, MaxOrderNotional: "1000", MaxOpenDeals: 3, MaxDailyLoss: "50",
+		AllowedSymbols: []string{"BTCUSDT", "ETHUSDT"}, PausedUntil: &pausedUntil}
+
+	testCases := []struct {
+		name     string
+		current  *domain.RiskLimits
+		limits   *dto.RiskLimits
+		prepare  func(m riskMocks)
+		expected *domain.RiskLimits
+		denied   bool
+	}{
+		{
+			name:    "First limits",
+			current: nil,
+			limits:  &dto.RiskLimits{MaxOrderNotional: "500", AllowedSymbols: []string{" btcusdt"}},
+			prepare: func(m riskMocks) {
+				m.riskRepo.EXPECT().SaveRiskLimits(gomock.Any(), &domain.RiskLimits{UserId: 7,
+					MaxOrderNotional: "500", AllowedSymbols: []string{"BTCUSDT"}}).Return(nil)
+				m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+					Return(&domain.RiskLimits{UserId: 7, MaxOrderNotional: "500"}, nil)
+			},
+			expected: &domain.RiskLimits{UserId: 7, MaxOrderNotional: "500"},
+		},
+		{
+			name:    "Tighten while paused",
+			current: current,
+			limits: &dto.RiskLimits{MaxOrderNotional: "800", MaxOpenDeals: 2, MaxDailyLoss: "50",
+				AllowedSymbols: []string{"BTCUSDT"}},
+			prepare: func(m riskMocks) {
+				m.riskRepo.EXPECT().SaveRiskLimits(gomock.Any(), gomock.Any()).Return(nil)
+				m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).Return(current, nil)
+			},
+			expected: current,
+		},
+		{
+			name:    "Raise daily loss",
+			current: current,
+			limits: &dto.RiskLimits{MaxOrderNotional: "1000", MaxOpenDeals: 3, MaxDailyLoss: "100",
+				AllowedSymbols: []string{"BTCUSDT", "ETHUSDT"}},
+			prepare: func(m riskMocks) {
+				m.admins.EXPECT().IsAdmin(gomock.Any(), int64(7)).Return(false)
+			},
+			denied: true,
+		},
+		{
+			name:    "Clear limits",
+			current: current,
+			limits:  &dto.RiskLimits{},
+			prepare: func(m riskMocks) {
+				m.admins.EXPECT().IsAdmin(gomock.Any(), int64(7)).Return(false)
+			},
+			denied: true,
+		},
+		{
+			name:    "Add symbol",
+			current: current,
+			limits: &dto.RiskLimits{MaxOrderNotional: "1000", MaxOpenDeals: 3, MaxDailyLoss: "50",
+				AllowedSymbols: []string{"BTCUSDT", "SOLUSDT"}},
+			prepare: func(m riskMocks) {
+				m.admins.EXPECT().IsAdmin(gomock.Any(), int64(7)).Return(false)
+			},
+			denied: true,
+		},
+		{
+			name:    "Admin clears limits",
+			current: current,
+			limits:  &dto.RiskLimits{},
+			prepare: func(m riskMocks) {
+				m.admins.EXPECT().IsAdmin(gomock.Any(), int64(7)).Return(true)
+				m.riskRepo.EXPECT().SaveRiskLimits(gomock.Any(), &domain.RiskLimits{UserId: 7}).Return(nil)
+				m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+					Return(&domain.RiskLimits{UserId: 7, PausedUntil: &pausedUntil}, nil)
+			},
+			expected: &domain.RiskLimits{UserId: 7, PausedUntil: &pausedUntil},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := riskMocks{
+				riskRepo: mock_service.NewMockRiskLimitsRepo(ctrl),
+				admins:   mock_service.NewMockAdminSource(ctrl),
+			}
+			i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+			riskService := service.NewRiskService(&config.Config{}, m.riskRepo, nil, nil, m.admins, nil, i18nSrv,
+				log.NewLogger())
+
+			m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).Return(tc.current, nil)
+			tc.prepare(m)
+
+			limits, err := riskService.SetRiskLimits(context.Background(), 7, tc.limits)
+			if tc.denied {
+				require.Error(t, err)
+				assert.True(t, err.(srvErr.Error).IsBadRequestError())
+				assert.Equal(t, i18nSrv.T("riskLimitLoosen", nil, "ru"), err.Error())
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, limits)
+		})
+	}
+}
+
+func TestRiskService_CheckDailyLosses(t *testing.T) {
+	from, _ := domain.PnlPeriodStart(consts.PnlPeriodDay, time.Now())
+	losingDeal := func(exitPrice string) []*domain.Deal {
+		return []*domain.Deal{
+			domain.NewDeal(&domain.Order{Id: 1, Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Side: consts.OrderSideSell, Status: consts.OrderStatusFilled, Quantity: "1", Price: "100"},
+				[]*domain.Order{{Id: 2, TpSl: consts.SlOrderType, Status: consts.OrderStatusFilled, Quantity: "1",
+					Price: exitPrice, UpdatedAt: time.Now().UTC()}}, nil),
+		}
+	}
+
+	tests := []struct {
+		name    string
+		prepare func(m *riskMocks)
+	}{
+		{
+			name: "Only the user over the limit is paused",
+			prepare: func(m *riskMocks) {
+				m.riskRepo.EXPECT().GetDailyLossLimits(gomock.Any(), gomock.Any()).Return([]*domain.RiskLimits{
+					{UserId: 7, MaxDailyLoss: "10"},
+					{UserId: 8, MaxDailyLoss: "10"},
+				}, nil)
+				m.dealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(7), from).Return(losingDeal("120"), nil)
+				m.dealRepo.EXPECT().GetDealsSince(gomock.Any(), int64(8), from).Return(losingDeal("105"), nil)
+				m.riskRepo.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+					Return(&domain.RiskLimits{UserId: 7, MaxDailyLoss: "10"}, nil)
+				m.riskRepo.EXPECT().SetPausedUntil(gomock.Any(), int64(7), from.AddDate(0, 0, 1)).Return(nil)
+				m.admins.EXPECT().GetAdmins(gomock.Any()).Return([]int{1}, nil)
+				m.notifier.EXPECT().NotifyTradingPaused(gomock.Any(), []int{1}, gomock.Any(), gomock.Any()).
+					Do(func(ctx context.Context, admins []int, limits *domain.RiskLimits, dayPnl string) {
+						assert.Equal(t, int64(7), limits.UserId)
+					})
+			},
+		},
+		{
+			name: "No limited users",
+			prepare: func(m *riskMocks) {
+				m.riskRepo.EXPECT().GetDailyLossLimits(gomock.Any(), gomock.Any()).Return(nil, nil)
+			},
+		},
+		{
+			name: "Repository error",
+			prepare: func(m *riskMocks) {
+				m.riskRepo.EXPECT().GetDailyLossLimits(gomock.Any(), gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			m := &riskMocks{
+				riskRepo: mock_service.NewMockRiskLimitsRepo(ctrl),
+				dealRepo: mock_service.NewMockDealRepo(ctrl),
+				prices:   mock_service.NewMockPriceSource(ctrl),
+				admins:   mock_service.NewMockAdminSource(ctrl),
+				notifier: mock_service.NewMockRiskNotifier(ctrl),
+			}
+			tc.prepare(m)
+			riskService := service.NewRiskService(&config.Config{}, m.riskRepo, m.dealRepo, m.prices, m.admins,
+				m.notifier, i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList()), log.NewLogger())
+
+			riskService.CheckDailyLosses(context.Background())
+		})
+	}
+}
+
+func TestRiskService_ListenWithoutEvents(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRiskRepo := mock_service.NewMockRiskLimitsRepo(ctrl)
+	mockDealRepo := mock_service.NewMockDealRepo(ctrl)
+	riskService := service.NewRiskService(&config.Config{},
+		mockRiskRepo,
+		mockDealRepo,
+		mock_service.NewMockPriceSource(ctrl),
+		mock_service.NewMockAdminSource(ctrl),
+		mock_service.NewMockRiskNotifier(ctrl),
+		i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList()),
+		log.NewLogger())
+
+	checked := make(chan struct{})
+	mockRiskRepo.EXPECT().GetDailyLossLimits(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, now time.Time) ([]*domain.RiskLimits, error) {
+			select {
+			case checked <- struct{}{}:
+			default:
+			}
+
+			return nil, nil
+		}).MinTimes(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		riskService.Listen(ctx, make(chan domain.OrderEvent), 10*time.Millisecond)
+		close(stopped)
+	}()
+
+	select {
+	case <-checked:
+	case <-time.After(time.Second):
+		t.Error("daily losses were not checked without events")
+	}
+	cancel()
+	<-stopped
+}
+
+func riskErrKeys(i18nSrv *i18n.I18n, limits *domain.RiskLimits) map[string]string {
+	if limits == nil {
+		return nil
+	}
+	data := map[string]interface{}{
+		"MaxOrderNotional": limits.MaxOrderNotional,
+		"MaxOpenDeals":     limits.MaxOpenDeals,
+		"MaxDailyLoss":     limits.MaxDailyLoss,
+		"AllowedSymbols":   "ETHUSDT",
+	}
+	if limits.PausedUntil != nil {
+		data["PausedUntil"] = limits.PausedUntil.UTC().Format(time.DateTime)
+	}
+
+	keys := make(map[string]string)
+	for _, key := range []string{"riskLimitPaused", "riskLimitSymbol", "riskLimitNotional", "riskLimitOpenDeals",
+		"riskLimitDailyLoss"} {
+		keys[key] = i18nSrv.T(key, data, "ru")
+	}
+
+	return keys
+}
@@ -0,0 +1,548 @@
+package tests_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/config"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	srvErr "github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/service"
+	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg/i18n"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type strategyMocks struct {
+	repo   *mock_service.MockStrategyRepo
+	orders *mock_service.MockStrategyOrderSource
+	prices *mock_service.MockPriceSource
+}
+
+type placedOrder struct {
+	Side      string
+	OrderType string
+	Price     string
+	Quantity  string
+}
+
+func newStrategyService(t *testing.T) (*service.StrategyService, strategyMocks, *i18n.I18n) {
+	ctrl := gomock.NewController(t)
+	m := strategyMocks{
+		repo:   mock_service.NewMockStrategyRepo(ctrl),
+		orders: mock_service.NewMockStrategyOrderSource(ctrl),
+		prices: mock_service.NewMockPriceSource(ctrl),
+	}
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
+
+	return service.NewStrategyService(&config.Config{}, m.repo, m.orders, m.prices, i18nSrv, log.NewLogger()), m, i18nSrv
+}
+
+// expectOrders makes every created order fill market orders at marketPrice and keep limit orders open.
+func expectOrders(m strategyMocks, firstId int64, marketPrice string, placed *[]placedOrder) {
+	nextId := firstId
+	m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+		DoAndReturn(func(ctx context.Context, orderDto *dto.Order, userId int64) (*domain.Order, error) {
+			*placed = append(*placed, placedOrder{orderDto.Side, orderDto.OrderType, orderDto.Price, orderDto.Quantity})
+			order := &domain.Order{Id: nextId, Side: orderDto.Side, OrderType: orderDto.OrderType,
+				Price: orderDto.Price, Quantity: orderDto.Quantity, Status: consts.OrderStatusActive}
+			if orderDto.OrderType == consts.OrderTypeMarket {
+				order.Price, order.Status = marketPrice, consts.OrderStatusFilled
+			}
+			nextId++
+
+			return order, nil
+		}).AnyTimes()
+	m.repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+}
+
+func TestStrategyService_Start(t *testing.T) {
+	testCases := []struct {
+		name     string
+		dto      *dto.Strategy
+		price    string
+		expected []placedOrder
+	}{
+		{
+			name: "DCA places base, scaled safety orders and take profit",
+			dto: &dto.Strategy{Type: consts.StrategyTypeDca, Exchange: consts.Binance, Symbol: "btcusdt",
+				Quantity: "1", Side: "buy", SafetyOrders: 2, Deviation: "2", StepScale: "1.5", VolumeScale: "2",
+				TpPercent: "1.5"},
+			price: "100",
+			expected: []placedOrder{
+				{consts.OrderSideBuy, consts.OrderTypeMarket, "", "1"},
+				{consts.OrderSideBuy, consts.OrderTypeLimit, "98", "2"},
+				{consts.OrderSideBuy, consts.OrderTypeLimit, "95", "4"},
+				{consts.OrderSideSell, consts.OrderTypeLimit, "101.5", "1"},
+			},
+		},
+		{
+			name: "Grid places buys below the current price",
+			dto: &dto.Strategy{Type: consts.StrategyTypeGrid, Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Quantity: "0.5", Lower: "20", Upper: "30", Levels: 5},
+			price: "25.5",
+			expected: []placedOrder{
+				{consts.OrderSideBuy, consts.OrderTypeLimit, "20", "0.5"},
+				{consts.OrderSideBuy, consts.OrderTypeLimit, "22", "0.5"},
+				{consts.OrderSideBuy, consts.OrderTypeLimit, "24", "0.5"},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategyService, m, _ := newStrategyService(t)
+
+			var placed []placedOrder
+			m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return(tc.price, nil)
+			m.repo.EXPECT().CreateStrategy(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+			expectOrders(m, 1, tc.price, &placed)
+
+			strategy, err := strategyService.Start(context.Background(), 7, tc.dto)
+
+			require.NoError(t, err)
+			assert.Equal(t, int64(3), strategy.Id)
+			assert.Equal(t, consts.StrategyStatusActive, strategy.Status)
+			assert.Equal(t, tc.expected, placed)
+			assert.Len(t, strategy.Orders, len(tc.expected))
+		})
+	}
+}
+
+func TestStrategyService_StartRejected(t *testing.T) {
+	testCases := []struct {
+		name           string
+		dto            *dto.Strategy
+		price          string
+		expectedErrKey string
+	}{
+		{
+			name:           "No market price",
+			dto:            &dto.Strategy{Type: consts.StrategyTypeGrid, Exchange: consts.Binance, Symbol: "BTCUSDT"},
+			expectedErrKey: "strategyNoPrice",
+		},
+		{
+			name: "Safety orders reach zero price",
+			dto: &dto.Strategy{Type: consts.StrategyTypeDca, Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Quantity: "1", Side: consts.OrderSideBuy, SafetyOrders: 3, Deviation: "40", TpPercent: "1"},
+			price:          "100",
+			expectedErrKey: "strategyDeviation",
+		},
+		{
+			name: "Price below the grid",
+			dto: &dto.Strategy{Type: consts.StrategyTypeGrid, Exchange: consts.Binance, Symbol: "BTCUSDT",
+				Quantity: "1", Lower: "20", Upper: "30", Levels: 5},
+			price:          "19",
+			expectedErrKey: "strategyGridRange",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategyService, m, i18nSrv := newStrategyService(t)
+			m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return(tc.price, nil)
+
+			_, err := strategyService.Start(context.Background(), 7, tc.dto)
+
+			require.Error(t, err)
+			assert.True(t, err.(srvErr.Error).IsBadRequestError())
+			assert.Equal(t, i18nSrv.T(tc.expectedErrKey, nil, "ru"), err.Error())
+		})
+	}
+}
+
+func TestStrategyService_StartSaveFailed(t *testing.T) {
+	strategyService, m, _ := newStrategyService(t)
+
+	m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return("25.5", nil)
+	m.repo.EXPECT().CreateStrategy(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	for _, id := range []int64{1, 2} {
+		m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+			Return(&domain.Order{Id: id, Status: consts.OrderStatusActive}, nil)
+	}
+	gomock.InOrder(
+		m.repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil),
+		m.repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(errors.New("db is down")),
+	)
+	m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(2), int64(7), "BTCUSDT", consts.Binance).Return(nil)
+	m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(1), int64(7), "BTCUSDT", consts.Binance).Return(nil)
+	m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), int64(1), consts.OrderStatusCanceled).Return(nil)
+	m.repo.EXPECT().SetStatus(gomock.Any(), int64(3), consts.StrategyStatusStopped).Return(nil)
+
+	_, err := strategyService.Start(context.Background(), 7, &dto.Strategy{Type: consts.StrategyTypeGrid,
+		Exchange: consts.Binance, Symbol: "BTCUSDT", Quantity: "0.5", Lower: "20", Upper: "30", Levels: 5})
+
+	require.Error(t, err)
+	assert.True(t, err.(srvErr.Error).IsInternalServerError())
+}
+
+func TestStrategyService_StartDcaFailed(t *testing.T) {
+	strategyService, m, i18nSrv := newStrategyService(t)
+
+	m.prices.EXPECT().GetPrice(gomock.Any(), consts.Binance, "BTCUSDT").Return("100", nil)
+	m.repo.EXPECT().CreateStrategy(gomock.Any(), gomock.Any()).Return(int64(3), nil)
+	gomock.InOrder(
+		m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+			Return(&domain.Order{Id: 1, Side: consts.OrderSideBuy, Price: "100", Quantity: "1",
+				Status: consts.OrderStatusFilled}, nil),
+		m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+			Return(&domain.Order{Id: 2, Side: consts.OrderSideBuy, Price: "98", Quantity: "1",
+				Status: consts.OrderStatusActive}, nil),
+		m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).Return(nil, errors.New("timeout")),
+	)
+	m.repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil).Times(2)
+	m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(2), int64(7), "BTCUSDT", consts.Binance).Return(nil)
+	m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), int64(2), consts.OrderStatusCanceled).Return(nil)
+	m.repo.EXPECT().SetStatus(gomock.Any(), int64(3), consts.StrategyStatusFailed).Return(nil)
+
+	_, err := strategyService.Start(context.Background(), 7, &dto.Strategy{Type: consts.StrategyTypeDca,
+		Exchange: consts.Binance, Symbol: "BTCUSDT", Quantity: "1", Side: consts.OrderSideBuy, SafetyOrders: 2,
+		Deviation: "2", TpPercent: "1"})
+
+	require.Error(t, err)
+	assert.True(t, err.(srvErr.Error).IsBadRequestError())
+	assert.Equal(t, i18nSrv.T("strategyFailed", map[string]interface{}{"Id": int64(3), "OrderId": int64(1),
+		"Side": consts.OrderSideBuy, "Quantity": "1", "Symbol": "BTCUSDT", "Price": "100"}, "ru"), err.Error())
+}
+
+func TestStrategyService_HandleFilled(t *testing.T) {
+	dcaStrategy := func() *domain.Strategy {
+		return &domain.Strategy{Id: 3, UserId: 7, Type: consts.StrategyTypeDca, Exchange: consts.Binance,
+			Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+			Dca: &domain.DcaParams{Side: consts.OrderSideBuy, BaseQty: "1", SafetyOrders: 2, Deviation: "4",
+				TpPercent: "1"},
+			Orders: []*domain.StrategyOrder{
+				{StrategyId: 3, OrderId: 1, Role: consts.StrategyOrderBase, Side: consts.OrderSideBuy, Price: "100",
+					Quantity: "1", Status: consts.OrderStatusFilled},
+				{StrategyId: 3, OrderId: 2, Role: consts.StrategyOrderSafety, Level: 1, Side: consts.OrderSideBuy,
+					Price: "96", Quantity: "1", Status: consts.OrderStatusActive},
+				{StrategyId: 3, OrderId: 3, Role: consts.StrategyOrderSafety, Level: 2, Side: consts.OrderSideBuy,
+					Price: "92", Quantity: "1", Status: consts.OrderStatusActive},
+				{StrategyId: 3, OrderId: 4, Role: consts.StrategyOrderTp, Side: consts.OrderSideSell, Price: "101",
+					Quantity: "1", Status: consts.OrderStatusActive},
+			}}
+	}
+	gridStrategy := func() *domain.Strategy {
+		return &domain.Strategy{Id: 5, UserId: 7, Type: consts.StrategyTypeGrid, Exchange: consts.Binance,
+			Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+			Grid: &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "0.5"},
+			Orders: []*domain.StrategyOrder{
+				{StrategyId: 5, OrderId: 1, Role: consts.StrategyOrderGrid, Level: 1, Side: consts.OrderSideBuy,
+					Price: "22", Quantity: "0.5", Status: consts.OrderStatusActive},
+				{StrategyId: 5, OrderId: 2, Role: consts.StrategyOrderGrid, Level: 3, Side: consts.OrderSideSell,
+					Price: "26", Quantity: "0.5", Status: consts.OrderStatusActive},
+			}}
+	}
+
+	testCases := []struct {
+		name     string
+		orderId  int64
+		strategy *domain.Strategy
+		prepare  func(m strategyMocks)
+		expected []placedOrder
+	}{
+		{
+			name:     "Order out of strategies",
+			orderId:  9,
+			prepare:  func(m strategyMocks) {},
+			expected: nil,
+		},
+		{
+			name:     "DCA safety fill moves take profit to the new average",
+			orderId:  2,
+			strategy: dcaStrategy(),
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), int64(2), consts.OrderStatusFilled).Return(nil)
+				m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(4), int64(7), "BTCUSDT", consts.Binance).Return(nil)
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), int64(4), consts.OrderStatusCanceled).Return(nil)
+			},
+			expected: []placedOrder{{consts.OrderSideSell, consts.OrderTypeLimit, "98.98", "2"}},
+		},
+		{
+			name:     "DCA take profit completes the strategy",
+			orderId:  4,
+			strategy: dcaStrategy(),
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), int64(4), consts.OrderStatusFilled).Return(nil)
+				for _, id := range []int64{2, 3} {
+					m.orders.EXPECT().CancelOrderById(gomock.Any(), id, int64(7), "BTCUSDT", consts.Binance).Return(nil)
+					m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(3), id, consts.OrderStatusCanceled).Return(nil)
+				}
+				m.repo.EXPECT().SetStatus(gomock.Any(), int64(3), consts.StrategyStatusCompleted).Return(nil)
+			},
+		},
+		{
+			name:     "Grid buy fill places sell one level above",
+			orderId:  1,
+			strategy: gridStrategy(),
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(1), consts.OrderStatusFilled).Return(nil)
+			},
+			expected: []placedOrder{{consts.OrderSideSell, consts.OrderTypeLimit, "24", "0.5"}},
+		},
+		{
+			name:     "Grid sell fill places buy one level below",
+			orderId:  2,
+			strategy: gridStrategy(),
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(2), consts.OrderStatusFilled).Return(nil)
+			},
+			expected: []placedOrder{{consts.OrderSideBuy, consts.OrderTypeLimit, "24", "0.5"}},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategyService, m, _ := newStrategyService(t)
+
+			var placed []placedOrder
+			reads := 1
+			if tc.strategy != nil {
+				reads = 2
+			}
+			m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), tc.orderId).Return(tc.strategy, nil).Times(reads)
+			expectOrders(m, 10, "", &placed)
+			tc.prepare(m)
+
+			require.NoError(t, strategyService.HandleFilled(context.Background(), tc.orderId))
+			assert.Equal(t, tc.expected, placed)
+		})
+	}
+}
+
+func TestStrategyService_HandleFilledRetry(t *testing.T) {
+	dcaStrategy := &domain.Strategy{Id: 3, UserId: 7, Type: consts.StrategyTypeDca, Exchange: consts.Binance,
+		Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+		Dca: &domain.DcaParams{Side: consts.OrderSideBuy, BaseQty: "1", SafetyOrders: 1, Deviation: "4",
+			TpPercent: "1"},
+		Orders: []*domain.StrategyOrder{
+			{StrategyId: 3, OrderId: 1, Role: consts.StrategyOrderBase, Side: consts.OrderSideBuy, Price: "100",
+				Quantity: "1", Status: consts.OrderStatusFilled},
+			{StrategyId: 3, OrderId: 2, Role: consts.StrategyOrderSafety, Level: 1, Side: consts.OrderSideBuy,
+				Price: "96", Quantity: "1", Status: consts.OrderStatusActive},
+			{StrategyId: 3, OrderId: 4, Role: consts.StrategyOrderTp, Side: consts.OrderSideSell, Price: "101",
+				Quantity: "1", Status: consts.OrderStatusActive},
+		}}
+	gridStrategy := func(orders ...*domain.StrategyOrder) *domain.Strategy {
+		return &domain.Strategy{Id: 5, UserId: 7, Type: consts.StrategyTypeGrid, Exchange: consts.Binance,
+			Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+			Grid: &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "0.5"},
+			Orders: append([]*domain.StrategyOrder{{StrategyId: 5, OrderId: 1, Role: consts.StrategyOrderGrid,
+				Level: 1, Side: consts.OrderSideBuy, Price: "22", Quantity: "0.5", Status: consts.OrderStatusActive}},
+				orders...)}
+	}
+
+	testCases := []struct {
+		name     string
+		orderId  int64
+		strategy *domain.Strategy
+		prepare  func(m strategyMocks)
+		hasError bool
+	}{
+		{
+			name:     "Take profit cancel fails",
+			orderId:  2,
+			strategy: dcaStrategy,
+			prepare: func(m strategyMocks) {
+				m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(4), int64(7), "BTCUSDT", consts.Binance).
+					Return(errors.New("timeout"))
+			},
+			hasError: true,
+		},
+		{
+			name:     "Opposite grid order fails",
+			orderId:  1,
+			strategy: gridStrategy(),
+			prepare: func(m strategyMocks) {
+				m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).Return(nil, errors.New("timeout"))
+			},
+			hasError: true,
+		},
+		{
+			name:    "Opposite grid order placed before",
+			orderId: 1,
+			strategy: gridStrategy(&domain.StrategyOrder{StrategyId: 5, OrderId: 10, Role: consts.StrategyOrderGrid,
+				Level: 2, Side: consts.OrderSideSell, Price: "24", Quantity: "0.5", Status: consts.OrderStatusActive}),
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(1), consts.OrderStatusFilled).Return(nil)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategyService, m, _ := newStrategyService(t)
+			m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), tc.orderId).Return(tc.strategy, nil).Times(2)
+			tc.prepare(m)
+
+			err := strategyService.HandleFilled(context.Background(), tc.orderId)
+			if tc.hasError {
+				require.Error(t, err)
+				assert.Equal(t, consts.OrderStatusActive, tc.strategy.GetOrder(tc.orderId).Status)
+
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestStrategyService_Stop(t *testing.T) {
+	strategyService, m, i18nSrv := newStrategyService(t)
+
+	m.repo.EXPECT().GetStrategy(gomock.Any(), int64(5)).Return(&domain.Strategy{Id: 5, UserId: 7,
+		Exchange: consts.Binance, Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+		Orders: []*domain.StrategyOrder{
+			{StrategyId: 5, OrderId: 1, Status: consts.OrderStatusFilled},
+			{StrategyId: 5, OrderId: 2, Status: consts.OrderStatusActive},
+		}}, nil).Times(2)
+	m.orders.EXPECT().CancelOrderById(gomock.Any(), int64(2), int64(7), "BTCUSDT", consts.Binance).Return(nil)
+	m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(2), consts.OrderStatusCanceled).Return(nil)
+	m.repo.EXPECT().SetStatus(gomock.Any(), int64(5), consts.StrategyStatusStopped).Return(nil)
+
+	strategy, err := strategyService.Stop(context.Background(), 7, 5)
+	require.NoError(t, err)
+	assert.Equal(t, consts.StrategyStatusStopped, strategy.Status)
+
+	_, err = strategyService.Stop(context.Background(), 8, 5)
+	require.Error(t, err)
+	assert.Equal(t, i18nSrv.T("strategyNotFound", nil, "ru"), err.Error())
+}
+
+func TestStrategyService_Reconcile(t *testing.T) {
+	gridStrategy := func() *domain.Strategy {
+		return &domain.Strategy{Id: 5, UserId: 7, Type: consts.StrategyTypeGrid, Exchange: consts.Binance,
+			Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+			Grid: &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "0.5"},
+			Orders: []*domain.StrategyOrder{
+				{StrategyId: 5, OrderId: 1, Role: consts.StrategyOrderGrid, Level: 1, Side: consts.OrderSideBuy,
+					Price: "22", Quantity: "0.5", Status: consts.OrderStatusActive},
+				{StrategyId: 5, OrderId: 2, Role: consts.StrategyOrderGrid, Level: 3, Side: consts.OrderSideSell,
+					Price: "26", Quantity: "0.5", Status: consts.OrderStatusActive},
+			}}
+	}
+
+	testCases := []struct {
+		name     string
+		prepare  func(m strategyMocks)
+		expected []placedOrder
+	}{
+		{
+			name: "Missed fill places the next grid order",
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().GetSettledOrders(gomock.Any()).Return([]*domain.StrategyOrder{
+					{StrategyId: 5, OrderId: 1, Status: consts.OrderStatusFilled},
+				}, nil)
+				m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(1)).Return(gridStrategy(), nil).Times(2)
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(1), consts.OrderStatusFilled).Return(nil)
+			},
+			expected: []placedOrder{{consts.OrderSideSell, consts.OrderTypeLimit, "24", "0.5"}},
+		},
+		{
+			name: "Missed cancel is tracked",
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().GetSettledOrders(gomock.Any()).Return([]*domain.StrategyOrder{
+					{StrategyId: 5, OrderId: 2, Status: consts.OrderStatusCanceled},
+				}, nil)
+				m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(2)).Return(gridStrategy(), nil).Times(2)
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(2), consts.OrderStatusCanceled).Return(nil)
+			},
+		},
+		{
+			name: "Error of one order does not stop the others",
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().GetSettledOrders(gomock.Any()).Return([]*domain.StrategyOrder{
+					{StrategyId: 3, OrderId: 9, Status: consts.OrderStatusFilled},
+					{StrategyId: 5, OrderId: 2, Status: consts.OrderStatusFilled},
+				}, nil)
+				m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(9)).Return(nil, assert.AnError)
+				m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(2)).Return(gridStrategy(), nil).Times(2)
+				m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(2), consts.OrderStatusFilled).Return(nil)
+			},
+			expected: []placedOrder{{consts.OrderSideBuy, consts.OrderTypeLimit, "24", "0.5"}},
+		},
+		{
+			name: "Repository error",
+			prepare: func(m strategyMocks) {
+				m.repo.EXPECT().GetSettledOrders(gomock.Any()).Return(nil, assert.AnError)
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			strategyService, m, _ := newStrategyService(t)
+
+			var placed []placedOrder
+			expectOrders(m, 10, "", &placed)
+			tc.prepare(m)
+
+			strategyService.Reconcile(context.Background())
+			assert.Equal(t, tc.expected, placed)
+		})
+	}
+}
+
+func TestStrategyService_LockPerStrategy(t *testing.T) {
+	strategyService, m, _ := newStrategyService(t)
+
+	gridStrategy := &domain.Strategy{Id: 5, UserId: 7, Type: consts.StrategyTypeGrid, Exchange: consts.Binance,
+		Symbol: "BTCUSDT", Status: consts.StrategyStatusActive,
+		Grid: &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "0.5"},
+		Orders: []*domain.StrategyOrder{
+			{StrategyId: 5, OrderId: 1, Role: consts.StrategyOrderGrid, Level: 1, Side: consts.OrderSideBuy,
+				Price: "22", Quantity: "0.5", Status: consts.OrderStatusActive},
+		}}
+	otherStrategy := &domain.Strategy{Id: 6, UserId: 7, Type: consts.StrategyTypeGrid, Exchange: consts.Binance,
+		Symbol: "ETHUSDT", Status: consts.StrategyStatusActive,
+		Grid: &domain.GridParams{Lower: "20", Upper: "30", Levels: 5, Quantity: "0.5"},
+		Orders: []*domain.StrategyOrder{
+			{StrategyId: 6, OrderId: 2, Role: consts.StrategyOrderGrid, Level: 1, Side: consts.OrderSideBuy,
+				Price: "22", Quantity: "0.5", Status: consts.OrderStatusActive},
+		}}
+
+	m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(1)).Return(gridStrategy, nil).Times(2)
+	m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(5), int64(1), consts.OrderStatusFilled).Return(nil)
+	m.repo.EXPECT().GetStrategyByOrderId(gomock.Any(), int64(2)).Return(otherStrategy, nil).Times(2)
+	m.repo.EXPECT().SetOrderStatus(gomock.Any(), int64(6), int64(2), consts.OrderStatusCanceled).Return(nil)
+	m.repo.EXPECT().AddOrder(gomock.Any(), gomock.Any()).Return(nil)
+
+	inExchange := make(chan struct{})
+	release := make(chan struct{})
+	m.orders.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+		DoAndReturn(func(ctx context.Context, orderDto *dto.Order, userId int64) (*domain.Order, error) {
+			close(inExchange)
+			<-release
+
+			return &domain.Order{Id: 10, Side: orderDto.Side, Price: orderDto.Price, Quantity: orderDto.Quantity,
+				Status: consts.OrderStatusActive}, nil
+		})
+
+	filled := make(chan error)
+	go func() {
+		filled <- strategyService.HandleFilled(context.Background(), 1)
+	}()
+	<-inExchange
+
+	canceled := make(chan error)
+	go func() {
+		canceled <- strategyService.HandleCanceled(context.Background(), 2)
+	}()
+	select {
+	case err := <-canceled:
+		require.NoError(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("strategy is blocked by an exchange call of another strategy")
+	}
+
+	close(release)
+	require.NoError(t, <-filled)
+}
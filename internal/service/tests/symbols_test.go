@@ -23,18 +23,20 @@ func TestSymbolService_GetActiveSymbols(t *testing.T) {
 
 	symbolService := service.NewSymbolService(mockOrderRepo, nil, mockLogger)
 
+	symbols := []domain.Symbols{{Symbol: "BTCUSDT", Exchange: "binance"}, {Symbol: "BNB-USDT", Exchange: "okx"}}
+
 	testCases := []struct {
 		name             string
-		repoResponse     domain.SymbolList
+		repoResponse     []domain.Symbols
 		repoError        error
-		expectedResponse domain.SymbolList
+		expectedResponse []domain.Symbols
 		expectedError    error
 	}{
 		{
 			name:             "Success case",
-			repoResponse:     domain.SymbolList{"BTC_USDT", "BNB_USDT"},
+			repoResponse:     symbols,
 			repoError:        nil,
-			expectedResponse: domain.SymbolList{"BTC_USDT", "BNB_USDT"},
+			expectedResponse: symbols,
 			expectedError:    nil,
 		},
 		{
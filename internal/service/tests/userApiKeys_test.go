@@ -12,6 +12,7 @@ import (
 	mock_service "github.com/linnoxlewis/trade-bot/internal/service/tests/mocks"
 	"github.com/linnoxlewis/trade-bot/pkg/i18n"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"testing"
 )
@@ -24,7 +25,13 @@ func TestApiKeysService_AddApiKeys(t *testing.T) {
 	mockUserRepo := mock_service.NewMockUserRepo(ctrl)
 	mockLogger := log.NewLogger()
 	cfg := &config.Config{}
-	i18nSrv := i18n.NewI18n(consts.LocaleDataPath, helper.GetLanguageList())
+	viper.Set("CLIENT_SECRET_KEY", "client-secret")
+	viper.Set("API_KEY_SECRET", "0123456789abcdef0123456789abcdef")
+	privKey, err := helper.EncryptClientMessage("TestPrivKey", cfg.GetClientSecretKey())
+	assert.NoError(t, err)
+	passPhrase, err := helper.EncryptClientMessage("TestPassPhrase", cfg.GetClientSecretKey())
+	assert.NoError(t, err)
+	i18nSrv := i18n.NewI18n("../../../"+consts.LocaleDataPath, helper.GetLanguageList())
 	apiKeyService := service.NewApiKeysService(cfg, mockApiKeyRepo, mockUserRepo, i18nSrv, mockLogger)
 
 	testCases := []struct {
@@ -36,15 +43,15 @@ func TestApiKeysService_AddApiKeys(t *testing.T) {
 	}{
 		{
 			name:              "AddApiKeys success",
-			apiKeys:           &dto.ApiKeys{UserId: 1, Exchange: "TestExchange", PubKey: "TestPubKey", PrivKey: "TestPrivKey", PassPhrase: "TestPassPhrase"},
+			apiKeys:           &dto.ApiKeys{UserId: 1, Exchange: "TestExchange", PubKey: "TestPubKey", PrivKey: privKey, PassPhrase: passPhrase},
 			expectedError:     nil,
 			expectedErrorCode: 0,
 			apiKeyRepoError:   nil,
 		},
 		{
 			name:              "AddApiKeys missing user",
-			apiKeys:           &dto.ApiKeys{UserId: 0, Exchange: "TestExchange", PubKey: "TestPubKey", PrivKey: "TestPrivKey", PassPhrase: "TestPassPhrase"},
-			expectedError:     errors.BadRequestError("userNotFound"),
+			apiKeys:           &dto.ApiKeys{UserId: 0, Exchange: "TestExchange", PubKey: "TestPubKey", PrivKey: privKey, PassPhrase: passPhrase},
+			expectedError:     errors.BadRequestError(i18nSrv.T("userNotFound", nil, "ru")),
 			expectedErrorCode: 400,
 			apiKeyRepoError:   nil,
 		},
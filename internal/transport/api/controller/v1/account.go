@@ -0,0 +1,113 @@
+package v1
+
+import (
+	"context"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type AccountService interface {
+	GetBalance(ctx context.Context, userId int64, exchange string) (domain.Balance, error)
+	GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error)
+	GetSymbols(ctx context.Context, userId int64, exchange string) ([]string, error)
+}
+
+type AccountController struct {
+	accountSrv AccountService
+	logger     *log.Logger
+}
+
+func NewAccountController(accountSrv AccountService, logger *log.Logger) *AccountController {
+	return &AccountController{
+		accountSrv: accountSrv,
+		logger:     logger,
+	}
+}
+
+// GetBalance godoc
+// @Summary      Get balance
+// @Description  Returns non-empty balances of the user on the exchange
+// @Tags         account
+// @Produce      json
+// @Param        exchange  path      string  true  "Exchange"
+// @Success      200       {object}  helper.ApiResponse{data=[]domain.BalanceSymbol}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/balance/{exchange} [get]
+func (a *AccountController) GetBalance(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	balance, err := a.accountSrv.GetBalance(c, userId, strings.ToLower(c.Param("exchange")))
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, balance)
+}
+
+// GetPortfolio godoc
+// @Summary      Get portfolio
+// @Description  Returns balances valued in USDT without dust. Without exchange all exchanges with api keys are aggregated
+// @Tags         account
+// @Produce      json
+// @Param        exchange  query     string  false  "Exchange"
+// @Success      200       {object}  helper.ApiResponse{data=domain.Portfolio}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/portfolio [get]
+func (a *AccountController) GetPortfolio(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	portfolio, err := a.accountSrv.GetPortfolio(c, userId, strings.ToLower(c.Query("exchange")))
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, portfolio)
+}
+
+// ListSymbols godoc
+// @Summary      List symbols
+// @Description  Returns tradable symbols of the exchange
+// @Tags         account
+// @Produce      json
+// @Param        exchange  query     string  true  "Exchange"
+// @Success      200       {object}  helper.ApiResponse{data=[]string}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/symbols [get]
+func (a *AccountController) ListSymbols(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	symbols, err := a.accountSrv.GetSymbols(c, userId, strings.ToLower(c.Query("exchange")))
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, symbols)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
 	"github.com/linnoxlewis/trade-bot/internal/helper"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"strings"
 )
 
 type ApiKeyService interface {
@@ -28,13 +29,30 @@ func NewApiKeyController(apiKeySrv ApiKeyService, logger *log.Logger) *ApiKeyCon
 	}
 }
 
+// AddApiKey godoc
+// @Summary      Add api keys
+// @Tags         api-key
+// @Accept       json
+// @Produce      json
+// @Param        apiKeys  body      dto.ApiKeys  true  "Api keys"
+// @Success      200      {object}  helper.ApiResponse{data=string}
+// @Failure      400      {object}  helper.ApiResponse
+// @Failure      401      {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/api-key [post]
 func (a *ApiKeyController) AddApiKey(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
 	rqt := &dto.ApiKeys{}
 	if err := c.BindJSON(rqt); err != nil {
 		helper.JsonErrorResponse(c)
 
 		return
 	}
+	rqt.UserId = userId
 
 	if err := rqt.Validate(); err != nil {
 		helper.BadRequestErrorResponse(c, err)
@@ -43,7 +61,7 @@ func (a *ApiKeyController) AddApiKey(c *gin.Context) {
 	}
 
 	if err := a.apiKeySrv.AddApiKeys(c, rqt); err != nil {
-		helper.BadRequestErrorResponse(c, err)
+		helper.ErrorResponse(c, err)
 
 		return
 	}
@@ -51,6 +69,28 @@ func (a *ApiKeyController) AddApiKey(c *gin.Context) {
 	helper.SuccessResponse(c, "apiKeys added")
 }
 
+// RemoveApiKey godoc
+// @Summary      Remove api keys
+// @Tags         api-key
+// @Produce      json
+// @Param        exchange  path      string  true  "Exchange"
+// @Success      200       {object}  helper.ApiResponse{data=string}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/api-key/{exchange} [delete]
 func (a *ApiKeyController) RemoveApiKey(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	if err := a.apiKeySrv.DeleteApiKey(c, userId, strings.ToLower(c.Param("exchange"))); err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
 	helper.SuccessResponse(c, "apiKeys removed")
 }
@@ -0,0 +1,92 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type DealService interface {
+	GetDeals(ctx context.Context, userId int64, exchange string, page, limit int) (*domain.DealPage, error)
+	GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error)
+}
+
+type DealController struct {
+	dealSrv DealService
+	logger  *log.Logger
+}
+
+func NewDealController(dealSrv DealService, logger *log.Logger) *DealController {
+	return &DealController{
+		dealSrv: dealSrv,
+		logger:  logger,
+	}
+}
+
+// ListDeals godoc
+// @Summary      List deals
+// @Description  Returns deals of the user (base order with its TP/SL orders) with realized PnL, fees and duration, newest first
+// @Tags         deals
+// @Produce      json
+// @Param        exchange  query     string  false  "Exchange"
+// @Param        page      query     int     false  "Page number, starts from 1"
+// @Param        limit     query     int     false  "Page size, 10 by default"
+// @Success      200       {object}  helper.ApiResponse{data=domain.DealPage}
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/deals [get]
+func (d *DealController) ListDeals(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	page, _ := strconv.Atoi(c.Query("page"))
+	limit, _ := strconv.Atoi(c.Query("limit"))
+	deals, err := d.dealSrv.GetDeals(c, userId, strings.ToLower(c.Query("exchange")), page, limit)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, deals)
+}
+
+// GetDeal godoc
+// @Summary      Get deal
+// @Description  Returns the deal of the base order with its TP/SL orders, realized PnL, fees and duration
+// @Tags         deals
+// @Produce      json
+// @Param        id  path      int  true  "Base order id"
+// @Success      200 {object}  helper.ApiResponse{data=domain.Deal}
+// @Failure      401 {object}  helper.ApiResponse
+// @Failure      404 {object}  helper.ApiResponse
+// @Failure      500 {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/deals/{id} [get]
+func (d *DealController) GetDeal(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+	dealId, ok := getOrderId(c)
+	if !ok {
+		return
+	}
+
+	deal, err := d.dealSrv.GetDeal(c, userId, dealId)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, deal)
+}
@@ -0,0 +1,262 @@
+package v1
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order *dto.Order, tgUserId int64) (*domain.Order, error)
+	CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserId int64) error
+	UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error
+	GetUserActiveOrders(ctx context.Context, userId int64, exchange string) ([]domain.Order, error)
+	GetOrder(ctx context.Context, orderId int64, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error)
+}
+
+type OrderController struct {
+	orderSrv OrderService
+	logger   *log.Logger
+}
+
+func NewOrderController(orderSrv OrderService, logger *log.Logger) *OrderController {
+	return &OrderController{
+		orderSrv: orderSrv,
+		logger:   logger,
+	}
+}
+
+// CreateOrder godoc
+// @Summary      Create order
+// @Description  Places an order on the exchange with optional TP/SL legs
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        order    body      dto.Order  true  "Order"
+// @Success      200      {object}  helper.ApiResponse{data=domain.Order}
+// @Failure      400      {object}  helper.ApiResponse
+// @Failure      401      {object}  helper.ApiResponse
+// @Failure      500      {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders [post]
+func (o *OrderController) CreateOrder(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	rqt := &dto.Order{}
+	if err := c.BindJSON(rqt); err != nil {
+		helper.JsonErrorResponse(c)
+
+		return
+	}
+	rqt.Exchange = strings.ToLower(rqt.Exchange)
+	rqt.Symbol = strings.TrimSpace(strings.ToUpper(rqt.Symbol))
+	rqt.Side = strings.ToUpper(rqt.Side)
+	rqt.OrderType = strings.ToUpper(rqt.OrderType)
+	rqt.TimeInForce = strings.ToUpper(rqt.TimeInForce)
+	rqt.TpType = strings.ToUpper(rqt.TpType)
+	rqt.SlType = strings.ToUpper(rqt.SlType)
+	rqt.Protection = strings.ToLower(rqt.Protection)
+
+	if err := rqt.Validate(); err != nil {
+		helper.BadRequestErrorResponse(c, err)
+
+		return
+	}
+
+	order, err := o.orderSrv.CreateOrder(c, rqt, userId)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, order)
+}
+
+// ListOrders godoc
+// @Summary      List open orders
+// @Description  Returns open orders of the user on the exchange
+// @Tags         orders
+// @Produce      json
+// @Param        exchange  query     string  true  "Exchange"
+// @Success      200       {object}  helper.ApiResponse{data=[]domain.Order}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders [get]
+func (o *OrderController) ListOrders(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	orders, err := o.orderSrv.GetUserActiveOrders(c, userId, strings.ToLower(c.Query("exchange")))
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, orders)
+}
+
+// GetOrder godoc
+// @Summary      Get order
+// @Description  Returns the order from the bot database or, with in_exchange, from the exchange
+// @Tags         orders
+// @Produce      json
+// @Param        id           path      int     true   "Order id"
+// @Param        exchange     query     string  true   "Exchange"
+// @Param        symbol       query     string  true   "Symbol"
+// @Param        in_exchange  query     bool    false  "Read the order from the exchange"
+// @Success      200          {object}  helper.ApiResponse{data=domain.Order}
+// @Failure      400          {object}  helper.ApiResponse
+// @Failure      401          {object}  helper.ApiResponse
+// @Failure      500          {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders/{id} [get]
+func (o *OrderController) GetOrder(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+	orderId, ok := getOrderId(c)
+	if !ok {
+		return
+	}
+	inExchange, _ := strconv.ParseBool(c.Query("in_exchange"))
+
+	order, err := o.orderSrv.GetOrder(c,
+		orderId,
+		userId,
+		strings.TrimSpace(strings.ToUpper(c.Query("symbol"))),
+		strings.ToLower(c.Query("exchange")),
+		inExchange)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, order)
+}
+
+// CancelOrder godoc
+// @Summary      Cancel order
+// @Tags         orders
+// @Produce      json
+// @Param        id        path      int     true  "Order id"
+// @Param        exchange  query     string  true  "Exchange"
+// @Param        symbol    query     string  true  "Symbol"
+// @Success      200       {object}  helper.ApiResponse{data=string}
+// @Failure      400       {object}  helper.ApiResponse
+// @Failure      401       {object}  helper.ApiResponse
+// @Failure      500       {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders/{id} [delete]
+func (o *OrderController) CancelOrder(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+	orderId, ok := getOrderId(c)
+	if !ok {
+		return
+	}
+
+	rqt := dto.NewCancelOrder(orderId, c.Query("symbol"), strings.ToLower(c.Query("exchange")))
+	if err := rqt.Validate(); err != nil {
+		helper.BadRequestErrorResponse(c, err)
+
+		return
+	}
+
+	if err := o.orderSrv.CancelOrder(c, rqt, userId); err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, "order canceled")
+}
+
+// UpdateTpSl godoc
+// @Summary      Update TP/SL
+// @Description  Moves take profit and stop loss legs of the base order
+// @Tags         orders
+// @Accept       json
+// @Produce      json
+// @Param        id       path      int             true  "Order id"
+// @Param        tpSl     body      dto.UpdateTpSl  true  "New TP/SL"
+// @Success      200      {object}  helper.ApiResponse{data=string}
+// @Failure      400      {object}  helper.ApiResponse
+// @Failure      401      {object}  helper.ApiResponse
+// @Failure      500      {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/orders/{id}/tpsl [put]
+func (o *OrderController) UpdateTpSl(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+	orderId, ok := getOrderId(c)
+	if !ok {
+		return
+	}
+
+	rqt := &dto.UpdateTpSl{}
+	if err := c.BindJSON(rqt); err != nil {
+		helper.JsonErrorResponse(c)
+
+		return
+	}
+	rqt.Id = orderId
+	rqt.Exchange = strings.ToLower(rqt.Exchange)
+	rqt.Symbol = strings.TrimSpace(strings.ToUpper(rqt.Symbol))
+
+	if err := rqt.Validate(); err != nil {
+		helper.BadRequestErrorResponse(c, err)
+
+		return
+	}
+
+	if err := o.orderSrv.UpdateTpslOrder(c, rqt, userId); err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, "tp/sl updated")
+}
+
+func getOrderId(c *gin.Context) (int64, bool) {
+	orderId, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil || orderId <= 0 {
+		helper.NotFoundErrorResponse(c)
+
+		return 0, false
+	}
+
+	return orderId, true
+}
+
+func getUserId(c *gin.Context) (int64, bool) {
+	userId, ok := helper.UserFromContext(c.Request.Context())
+	if !ok || userId == 0 {
+		helper.UnauthorizedErrorResponse(c)
+
+		return 0, false
+	}
+
+	return userId, true
+}
@@ -0,0 +1,54 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type PnlService interface {
+	GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error)
+}
+
+type PnlController struct {
+	pnlSrv PnlService
+	logger *log.Logger
+}
+
+func NewPnlController(pnlSrv PnlService, logger *log.Logger) *PnlController {
+	return &PnlController{
+		pnlSrv: pnlSrv,
+		logger: logger,
+	}
+}
+
+// GetPnl godoc
+// @Summary      Get PnL
+// @Description  Returns realized and unrealized PnL, fees, win rate and average R per symbol for the period, converted to USDT
+// @Tags         pnl
+// @Produce      json
+// @Param        period  query     string  false  "Period: day, week or month. Day by default"
+// @Success      200     {object}  helper.ApiResponse{data=domain.PnlReport}
+// @Failure      400     {object}  helper.ApiResponse
+// @Failure      401     {object}  helper.ApiResponse
+// @Failure      500     {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/pnl [get]
+func (p *PnlController) GetPnl(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	report, err := p.pnlSrv.GetPnl(c, userId, c.Query("period"))
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, report)
+}
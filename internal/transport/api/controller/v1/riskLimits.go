@@ -0,0 +1,96 @@
+package v1
+
+import (
+	"context"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+)
+
+type RiskService interface {
+	GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error)
+	SetRiskLimits(ctx context.Context, userId int64, limits *dto.RiskLimits) (*domain.RiskLimits, error)
+}
+
+type RiskLimitsController struct {
+	riskSrv RiskService
+	logger  *log.Logger
+}
+
+func NewRiskLimitsController(riskSrv RiskService, logger *log.Logger) *RiskLimitsController {
+	return &RiskLimitsController{
+		riskSrv: riskSrv,
+		logger:  logger,
+	}
+}
+
+// GetRiskLimits godoc
+// @Summary      Get risk limits
+// @Description  Returns the user limits checked before every new order and the circuit breaker pause, if any
+// @Tags         risk-limits
+// @Produce      json
+// @Success      200  {object}  helper.ApiResponse{data=domain.RiskLimits}
+// @Failure      401  {object}  helper.ApiResponse
+// @Failure      500  {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/risk-limits [get]
+func (r *RiskLimitsController) GetRiskLimits(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	limits, err := r.riskSrv.GetRiskLimits(c, userId)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, limits)
+}
+
+// SetRiskLimits godoc
+// @Summary      Set risk limits
+// @Description  Replaces the user limits: max order value and daily loss in USDT, max open deals and allowed symbols. Empty or zero values turn a limit off. Users can only tighten their limits, loosening them is left to the admins. An active pause is kept
+// @Tags         risk-limits
+// @Accept       json
+// @Produce      json
+// @Param        limits  body      dto.RiskLimits  true  "Risk limits"
+// @Success      200     {object}  helper.ApiResponse{data=domain.RiskLimits}
+// @Failure      400     {object}  helper.ApiResponse
+// @Failure      401     {object}  helper.ApiResponse
+// @Failure      500     {object}  helper.ApiResponse
+// @Security     BearerAuth
+// @Router       /api/v1/risk-limits [put]
+func (r *RiskLimitsController) SetRiskLimits(c *gin.Context) {
+	userId, ok := getUserId(c)
+	if !ok {
+		return
+	}
+
+	rqt := &dto.RiskLimits{}
+	if err := c.BindJSON(rqt); err != nil {
+		helper.JsonErrorResponse(c)
+
+		return
+	}
+
+	if err := rqt.Validate(); err != nil {
+		helper.BadRequestErrorResponse(c, err)
+
+		return
+	}
+
+	limits, err := r.riskSrv.SetRiskLimits(c, userId, rqt)
+	if err != nil {
+		helper.ErrorResponse(c, err)
+
+		return
+	}
+
+	helper.SuccessResponse(c, limits)
+}
@@ -4,12 +4,16 @@ import (
 	"bytes"
 	"encoding/json"
 	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
 	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
 	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
 	"github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
 	"net/http"
 	"net/http/httptest"
+	"time"
 
 	"testing"
 
@@ -17,10 +21,21 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
+const testSecret = "secret"
+
+func setToken(t *testing.T, request *http.Request, userId int64) {
+	token, err := pkg.NewTokenString(userId, time.Minute, testSecret)
+	if err != nil {
+		t.Fatal(err)
+	}
+	request.Header.Set("Authorization", "Bearer "+token)
+}
+
 func TestApiKeyController_AddApiKey(t *testing.T) {
 	tests := []struct {
 		name         string
 		requestBody  interface{}
+		prepare      func(apiKeySrv *mocks.MockApiKeyService)
 		expectedCode int
 	}{
 		{
@@ -32,6 +47,15 @@ func TestApiKeyController_AddApiKey(t *testing.T) {
 				Exchange:   "Binance",
 				UserId:     123,
 			},
+			prepare: func(apiKeySrv *mocks.MockApiKeyService) {
+				apiKeySrv.EXPECT().AddApiKeys(gomock.Any(), &dto.ApiKeys{
+					PubKey:     "test",
+					PrivKey:    "test",
+					PassPhrase: "TestTest",
+					Exchange:   "Binance",
+					UserId:     7,
+				}).Return(nil)
+			},
 			expectedCode: http.StatusOK,
 		},
 		{
@@ -42,6 +66,7 @@ func TestApiKeyController_AddApiKey(t *testing.T) {
 				Exchange:   "Binance",
 				UserId:     123,
 			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -52,6 +77,7 @@ func TestApiKeyController_AddApiKey(t *testing.T) {
 				Exchange:   "Binance",
 				UserId:     123,
 			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
 			expectedCode: http.StatusBadRequest,
 		},
 		{
@@ -62,16 +88,7 @@ func TestApiKeyController_AddApiKey(t *testing.T) {
 				PassPhrase: "TestTest",
 				UserId:     123,
 			},
-			expectedCode: http.StatusBadRequest,
-		},
-		{
-			name: "InvalidRequestUserId",
-			requestBody: dto.ApiKeys{
-				PubKey:     "",
-				PrivKey:    "test",
-				PassPhrase: "TestTest",
-				Exchange:   "Binance",
-			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
 			expectedCode: http.StatusBadRequest,
 		},
 	}
@@ -83,15 +100,89 @@ func TestApiKeyController_AddApiKey(t *testing.T) {
 	mockLogger := log.NewLogger()
 	apiKeyController := v1.NewApiKeyController(mockService, mockLogger)
 
-	router.POST("api/v1/api-key/", apiKeyController.AddApiKey)
+	router.Use(middleware.Auth(testSecret))
+	router.POST("/api/v1/api-key/", apiKeyController.AddApiKey)
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare(mockService)
 			requestBody, err := json.Marshal(tt.requestBody)
 			if err != nil {
 				t.Fatal(err)
 			}
-			request := httptest.NewRequest(http.MethodPost, "api/v1/api-key/", bytes.NewBuffer(requestBody))
+			request := httptest.NewRequest(http.MethodPost, "/api/v1/api-key/", bytes.NewBuffer(requestBody))
 			request.Header.Set("Content-Type", "application/json")
+			setToken(t, request, 7)
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
+
+func TestApiKeyController_RemoveApiKey(t *testing.T) {
+	tests := []struct {
+		name         string
+		token        func(request *http.Request)
+		prepare      func(apiKeySrv *mocks.MockApiKeyService)
+		expectedCode int
+	}{
+		{
+			name:  "ValidRequest",
+			token: func(request *http.Request) { setToken(t, request, 7) },
+			prepare: func(apiKeySrv *mocks.MockApiKeyService) {
+				apiKeySrv.EXPECT().DeleteApiKey(gomock.Any(), int64(7), consts.Binance).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "WithoutToken",
+			token:        func(request *http.Request) {},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "InvalidToken",
+			token: func(request *http.Request) {
+				request.Header.Set("Authorization", "Bearer invalid")
+			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "ExpiredToken",
+			token: func(request *http.Request) {
+				token, _ := pkg.NewTokenString(7, -time.Minute, testSecret)
+				request.Header.Set("Authorization", "Bearer "+token)
+			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name: "ForeignSecret",
+			token: func(request *http.Request) {
+				token, _ := pkg.NewTokenString(7, time.Minute, "other")
+				request.Header.Set("Authorization", "Bearer "+token)
+			},
+			prepare:      func(apiKeySrv *mocks.MockApiKeyService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			mockService := mocks.NewMockApiKeyService(ctrl)
+			tt.prepare(mockService)
+
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.DELETE("/api/v1/api-key/:exchange", v1.NewApiKeyController(mockService, log.NewLogger()).RemoveApiKey)
+
+			request := httptest.NewRequest(http.MethodDelete, "/api/v1/api-key/Binance", nil)
+			tt.token(request)
 			response := httptest.NewRecorder()
 
 			router.ServeHTTP(response, request)
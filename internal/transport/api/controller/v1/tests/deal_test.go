@@ -0,0 +1,117 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDealController(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		userId       int64
+		prepare      func(dealSrv *mocks.MockDealService)
+		expectedCode int
+	}{
+		{
+			name:   "ListDeals",
+			url:    "/api/v1/deals?exchange=Binance&page=2&limit=5",
+			userId: 7,
+			prepare: func(dealSrv *mocks.MockDealService) {
+				dealSrv.EXPECT().GetDeals(gomock.Any(), int64(7), consts.Binance, 2, 5).
+					Return(&domain.DealPage{Page: 2, Pages: 2, Total: 6}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "ListDealsDefaultPage",
+			url:    "/api/v1/deals",
+			userId: 7,
+			prepare: func(dealSrv *mocks.MockDealService) {
+				dealSrv.EXPECT().GetDeals(gomock.Any(), int64(7), "", 0, 0).
+					Return(&domain.DealPage{Page: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "ListDealsWithoutUser",
+			url:          "/api/v1/deals",
+			prepare:      func(dealSrv *mocks.MockDealService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "ListDealsInternalError",
+			url:    "/api/v1/deals",
+			userId: 7,
+			prepare: func(dealSrv *mocks.MockDealService) {
+				dealSrv.EXPECT().GetDeals(gomock.Any(), int64(7), "", 0, 0).
+					Return(nil, errors.InternalServerError(assert.AnError))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+		{
+			name:   "GetDeal",
+			url:    "/api/v1/deals/3",
+			userId: 7,
+			prepare: func(dealSrv *mocks.MockDealService) {
+				dealSrv.EXPECT().GetDeal(gomock.Any(), int64(7), int64(3)).
+					Return(&domain.Deal{BaseOrder: &domain.Order{Id: 3}, Status: consts.DealStatusClosed}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "GetDealNotFound",
+			url:    "/api/v1/deals/3",
+			userId: 7,
+			prepare: func(dealSrv *mocks.MockDealService) {
+				dealSrv.EXPECT().GetDeal(gomock.Any(), int64(7), int64(3)).
+					Return(nil, errors.NotFoundError("dealNotFound"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "GetDealWrongId",
+			url:          "/api/v1/deals/abc",
+			userId:       7,
+			prepare:      func(dealSrv *mocks.MockDealService) {},
+			expectedCode: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			dealSrv := mocks.NewMockDealService(ctrl)
+			tt.prepare(dealSrv)
+
+			dealCtrl := v1.NewDealController(dealSrv, log.NewLogger())
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.GET("/api/v1/deals", dealCtrl.ListDeals)
+			router.GET("/api/v1/deals/:id", dealCtrl.GetDeal)
+
+			request := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.userId != 0 {
+				setToken(t, request, tt.userId)
+			}
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: deal.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockDealService is a mock of DealService interface.
+type MockDealService struct {
+	ctrl     *gomock.Controller
+	recorder *MockDealServiceMockRecorder
+}
+
+// MockDealServiceMockRecorder is the mock recorder for MockDealService.
+type MockDealServiceMockRecorder struct {
+	mock *MockDealService
+}
+
+// NewMockDealService creates a new mock instance.
+func NewMockDealService(ctrl *gomock.Controller) *MockDealService {
+	mock := &MockDealService{ctrl: ctrl}
+	mock.recorder = &MockDealServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockDealService) EXPECT() *MockDealServiceMockRecorder {
+	return m.recorder
+}
+
+// GetDeal mocks base method.
+func (m *MockDealService) GetDeal(ctx context.Context, userId, id int64) (*domain.Deal, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeal", ctx, userId, id)
+	ret0, _ := ret[0].(*domain.Deal)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeal indicates an expected call of GetDeal.
+func (mr *MockDealServiceMockRecorder) GetDeal(ctx, userId, id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeal", reflect.TypeOf((*MockDealService)(nil).GetDeal), ctx, userId, id)
+}
+
+// GetDeals mocks base method.
+func (m *MockDealService) GetDeals(ctx context.Context, userId int64, exchange string, page, limit int) (*domain.DealPage, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetDeals", ctx, userId, exchange, page, limit)
+	ret0, _ := ret[0].(*domain.DealPage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetDeals indicates an expected call of GetDeals.
+func (mr *MockDealServiceMockRecorder) GetDeals(ctx, userId, exchange, page, limit interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDeals", reflect.TypeOf((*MockDealService)(nil).GetDeals), ctx, userId, exchange, page, limit)
+}
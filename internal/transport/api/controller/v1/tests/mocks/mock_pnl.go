@@ -0,0 +1,51 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: pnl.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+)
+
+// MockPnlService is a mock of PnlService interface.
+type MockPnlService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPnlServiceMockRecorder
+}
+
+// MockPnlServiceMockRecorder is the mock recorder for MockPnlService.
+type MockPnlServiceMockRecorder struct {
+	mock *MockPnlService
+}
+
+// NewMockPnlService creates a new mock instance.
+func NewMockPnlService(ctrl *gomock.Controller) *MockPnlService {
+	mock := &MockPnlService{ctrl: ctrl}
+	mock.recorder = &MockPnlServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPnlService) EXPECT() *MockPnlServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPnl mocks base method.
+func (m *MockPnlService) GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPnl", ctx, userId, period)
+	ret0, _ := ret[0].(*domain.PnlReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPnl indicates an expected call of GetPnl.
+func (mr *MockPnlServiceMockRecorder) GetPnl(ctx, userId, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPnl", reflect.TypeOf((*MockPnlService)(nil).GetPnl), ctx, userId, period)
+}
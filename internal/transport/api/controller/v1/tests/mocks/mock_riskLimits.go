@@ -0,0 +1,67 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: riskLimits.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+// MockRiskService is a mock of RiskService interface.
+type MockRiskService struct {
+	ctrl     *gomock.Controller
+	recorder *MockRiskServiceMockRecorder
+}
+
+// MockRiskServiceMockRecorder is the mock recorder for MockRiskService.
+type MockRiskServiceMockRecorder struct {
+	mock *MockRiskService
+}
+
+// NewMockRiskService creates a new mock instance.
+func NewMockRiskService(ctrl *gomock.Controller) *MockRiskService {
+	mock := &MockRiskService{ctrl: ctrl}
+	mock.recorder = &MockRiskServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRiskService) EXPECT() *MockRiskServiceMockRecorder {
+	return m.recorder
+}
+
+// GetRiskLimits mocks base method.
+func (m *MockRiskService) GetRiskLimits(ctx context.Context, userId int64) (*domain.RiskLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRiskLimits", ctx, userId)
+	ret0, _ := ret[0].(*domain.RiskLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRiskLimits indicates an expected call of GetRiskLimits.
+func (mr *MockRiskServiceMockRecorder) GetRiskLimits(ctx, userId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRiskLimits", reflect.TypeOf((*MockRiskService)(nil).GetRiskLimits), ctx, userId)
+}
+
+// SetRiskLimits mocks base method.
+func (m *MockRiskService) SetRiskLimits(ctx context.Context, userId int64, limits *dto.RiskLimits) (*domain.RiskLimits, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetRiskLimits", ctx, userId, limits)
+	ret0, _ := ret[0].(*domain.RiskLimits)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SetRiskLimits indicates an expected call of SetRiskLimits.
+func (mr *MockRiskServiceMockRecorder) SetRiskLimits(ctx, userId, limits interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetRiskLimits", reflect.TypeOf((*MockRiskService)(nil).SetRiskLimits), ctx, userId, limits)
+}
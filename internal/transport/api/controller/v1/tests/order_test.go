@@ -0,0 +1,273 @@
+package tests
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOrderController_CreateOrder(t *testing.T) {
+	validOrder := dto.Order{
+		Exchange:  "Binance",
+		Symbol:    "btcusdt",
+		Side:      "buy",
+		OrderType: "market",
+		Quantity:  "0.1",
+		TpPercent: "5",
+		SlPercent: "2",
+	}
+
+	tests := []struct {
+		name         string
+		url          string
+		userId       int64
+		requestBody  interface{}
+		prepare      func(orderSrv *mocks.MockOrderService)
+		expectedCode int
+	}{
+		{
+			name:        "ValidRequest",
+			url:         "/api/v1/orders",
+			userId:      7,
+			requestBody: validOrder,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), &dto.Order{
+					Exchange:  consts.Binance,
+					Symbol:    "BTCUSDT",
+					Side:      consts.OrderSideBuy,
+					OrderType: consts.OrderTypeMarket,
+					Quantity:  "0.1",
+					TpPercent: "5",
+					SlPercent: "2",
+				}, int64(7)).Return(&domain.Order{Id: 1}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "WithoutUser",
+			url:          "/api/v1/orders",
+			requestBody:  validOrder,
+			prepare:      func(orderSrv *mocks.MockOrderService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "InvalidRequest",
+			url:          "/api/v1/orders",
+			userId:       7,
+			requestBody:  dto.Order{Exchange: consts.Binance},
+			prepare:      func(orderSrv *mocks.MockOrderService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "ServiceBadRequest",
+			url:         "/api/v1/orders",
+			userId:      7,
+			requestBody: validOrder,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+					Return(nil, errors.BadRequestError("apiKeysNotFound"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:        "ServiceInternalError",
+			url:         "/api/v1/orders",
+			userId:      7,
+			requestBody: validOrder,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+					Return(nil, errors.InternalServerError(assert.AnError))
+			},
+			expectedCode: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			orderSrv := mocks.NewMockOrderService(ctrl)
+			tt.prepare(orderSrv)
+
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.POST("/api/v1/orders", v1.NewOrderController(orderSrv, log.NewLogger()).CreateOrder)
+
+			requestBody, err := json.Marshal(tt.requestBody)
+			if err != nil {
+				t.Fatal(err)
+			}
+			request := httptest.NewRequest(http.MethodPost, tt.url, bytes.NewBuffer(requestBody))
+			request.Header.Set("Content-Type", "application/json")
+			if tt.userId != 0 {
+				setToken(t, request, tt.userId)
+			}
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
+
+func TestOrderController_Orders(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		url          string
+		body         string
+		prepare      func(orderSrv *mocks.MockOrderService)
+		expectedCode int
+	}{
+		{
+			name:   "ListOrders",
+			method: http.MethodGet,
+			url:    "/api/v1/orders?exchange=binance",
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().GetUserActiveOrders(gomock.Any(), int64(7), consts.Binance).
+					Return([]domain.Order{{Id: 1}}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "GetOrder",
+			method: http.MethodGet,
+			url:    "/api/v1/orders/3?exchange=binance&symbol=btcusdt&in_exchange=true",
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().GetOrder(gomock.Any(), int64(3), int64(7), "BTCUSDT", consts.Binance, true).
+					Return(&domain.Order{Id: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "GetOrderNotFound",
+			method: http.MethodGet,
+			url:    "/api/v1/orders/3?exchange=binance&symbol=BTCUSDT",
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().GetOrder(gomock.Any(), int64(3), int64(7), "BTCUSDT", consts.Binance, false).
+					Return(nil, errors.NotFoundError("orderNotFound"))
+			},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:         "GetOrderWrongId",
+			method:       http.MethodGet,
+			url:          "/api/v1/orders/abc",
+			prepare:      func(orderSrv *mocks.MockOrderService) {},
+			expectedCode: http.StatusNotFound,
+		},
+		{
+			name:   "CancelOrder",
+			method: http.MethodDelete,
+			url:    "/api/v1/orders/3?exchange=binance&symbol=btcusdt",
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CancelOrder(gomock.Any(), dto.NewCancelOrder(3, "BTCUSDT", consts.Binance), int64(7)).
+					Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "UpdateTpSl",
+			method: http.MethodPut,
+			url:    "/api/v1/orders/3/tpsl",
+			body:   `{"exchange":"binance","symbol":"btcusdt","tpPrice":"110","slPrice":"90"}`,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().UpdateTpslOrder(gomock.Any(), &dto.UpdateTpSl{Id: 3, Symbol: "BTCUSDT",
+					Exchange: consts.Binance, TpPrice: "110", SlPrice: "90"}, int64(7)).Return(nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "UpdateTpSlWrongJson",
+			method:       http.MethodPut,
+			url:          "/api/v1/orders/3/tpsl",
+			body:         `{`,
+			prepare:      func(orderSrv *mocks.MockOrderService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			orderSrv := mocks.NewMockOrderService(ctrl)
+			tt.prepare(orderSrv)
+
+			orderCtrl := v1.NewOrderController(orderSrv, log.NewLogger())
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.GET("/api/v1/orders", orderCtrl.ListOrders)
+			router.GET("/api/v1/orders/:id", orderCtrl.GetOrder)
+			router.DELETE("/api/v1/orders/:id", orderCtrl.CancelOrder)
+			router.PUT("/api/v1/orders/:id/tpsl", orderCtrl.UpdateTpSl)
+
+			request := httptest.NewRequest(tt.method, tt.url, bytes.NewBufferString(tt.body))
+			request.Header.Set("Content-Type", "application/json")
+			setToken(t, request, 7)
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
+
+func TestAccountController(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	accountSrv := mocks.NewMockAccountService(ctrl)
+
+	accountCtrl := v1.NewAccountController(accountSrv, log.NewLogger())
+	router := gin.New()
+	router.Use(middleware.Auth(testSecret))
+	router.GET("/api/v1/balance/:exchange", accountCtrl.GetBalance)
+	router.GET("/api/v1/portfolio", accountCtrl.GetPortfolio)
+	router.GET("/api/v1/symbols", accountCtrl.ListSymbols)
+
+	accountSrv.EXPECT().GetBalance(gomock.Any(), int64(7), consts.Binance).
+		Return(domain.Balance{domain.NewBalanceSymbol("USDT", "100", "")}, nil)
+	request := httptest.NewRequest(http.MethodGet, "/api/v1/balance/Binance", nil)
+	setToken(t, request, 7)
+	response := httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"data":[{"symbol":"USDT","quantity":"100"}],"errors":null}`, response.Body.String())
+
+	accountSrv.EXPECT().GetPortfolio(gomock.Any(), int64(7), "").
+		Return(&domain.Portfolio{Currency: domain.UsdtAsset, Total: "100.00", Exchanges: []string{consts.Binance},
+			Assets: []*domain.PortfolioAsset{{Exchange: consts.Binance, Asset: "USDT", Free: "80", Locked: "20",
+				Total: "100", Price: "1", Value: "100.00", Share: "100.00"}}}, nil)
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/portfolio", nil)
+	setToken(t, request, 7)
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.JSONEq(t, `{"data":{"currency":"USDT","total":"100.00","exchanges":["binance"],"assets":[
+		{"exchange":"binance","asset":"USDT","free":"80","locked":"20","total":"100","price":"1","value":"100.00","share":"100.00"}]},
+		"errors":null}`, response.Body.String())
+
+	accountSrv.EXPECT().GetSymbols(gomock.Any(), int64(7), consts.Binance).
+		Return(nil, errors.BadRequestError("apiKeysNotFound"))
+	request = httptest.NewRequest(http.MethodGet, "/api/v1/symbols?exchange=binance", nil)
+	setToken(t, request, 7)
+	response = httptest.NewRecorder()
+	router.ServeHTTP(response, request)
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+}
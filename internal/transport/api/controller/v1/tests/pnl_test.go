@@ -0,0 +1,78 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPnlController(t *testing.T) {
+	tests := []struct {
+		name         string
+		url          string
+		userId       int64
+		prepare      func(pnlSrv *mocks.MockPnlService)
+		expectedCode int
+	}{
+		{
+			name:   "GetPnl",
+			url:    "/api/v1/pnl?period=week",
+			userId: 7,
+			prepare: func(pnlSrv *mocks.MockPnlService) {
+				pnlSrv.EXPECT().GetPnl(gomock.Any(), int64(7), consts.PnlPeriodWeek).
+					Return(&domain.PnlReport{Period: consts.PnlPeriodWeek}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "GetPnlWrongPeriod",
+			url:    "/api/v1/pnl?period=year",
+			userId: 7,
+			prepare: func(pnlSrv *mocks.MockPnlService) {
+				pnlSrv.EXPECT().GetPnl(gomock.Any(), int64(7), "year").
+					Return(nil, errors.BadRequestError("invalidPnlPeriod"))
+			},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "GetPnlWithoutUser",
+			url:          "/api/v1/pnl",
+			prepare:      func(pnlSrv *mocks.MockPnlService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			pnlSrv := mocks.NewMockPnlService(ctrl)
+			tt.prepare(pnlSrv)
+
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.GET("/api/v1/pnl", v1.NewPnlController(pnlSrv, log.NewLogger()).GetPnl)
+
+			request := httptest.NewRequest(http.MethodGet, tt.url, nil)
+			if tt.userId != 0 {
+				setToken(t, request, tt.userId)
+			}
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,91 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRiskLimitsController(t *testing.T) {
+	tests := []struct {
+		name         string
+		method       string
+		body         string
+		userId       int64
+		prepare      func(riskSrv *mocks.MockRiskService)
+		expectedCode int
+	}{
+		{
+			name:   "GetRiskLimits",
+			method: http.MethodGet,
+			userId: 7,
+			prepare: func(riskSrv *mocks.MockRiskService) {
+				riskSrv.EXPECT().GetRiskLimits(gomock.Any(), int64(7)).
+					Return(&domain.RiskLimits{UserId: 7, MaxOpenDeals: 3}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:   "SetRiskLimits",
+			method: http.MethodPut,
+			body:   `{"maxOrderNotional":"1000","maxOpenDeals":3,"maxDailyLoss":"50","allowedSymbols":["BTCUSDT"]}`,
+			userId: 7,
+			prepare: func(riskSrv *mocks.MockRiskService) {
+				riskSrv.EXPECT().SetRiskLimits(gomock.Any(), int64(7), &dto.RiskLimits{MaxOrderNotional: "1000",
+					MaxOpenDeals: 3, MaxDailyLoss: "50", AllowedSymbols: []string{"BTCUSDT"}}).
+					Return(&domain.RiskLimits{UserId: 7}, nil)
+			},
+			expectedCode: http.StatusOK,
+		},
+		{
+			name:         "SetRiskLimitsInvalid",
+			method:       http.MethodPut,
+			body:         `{"maxDailyLoss":"-5","maxOpenDeals":-1}`,
+			userId:       7,
+			prepare:      func(riskSrv *mocks.MockRiskService) {},
+			expectedCode: http.StatusBadRequest,
+		},
+		{
+			name:         "GetRiskLimitsWithoutUser",
+			method:       http.MethodGet,
+			prepare:      func(riskSrv *mocks.MockRiskService) {},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			riskSrv := mocks.NewMockRiskService(ctrl)
+			tt.prepare(riskSrv)
+
+			controller := v1.NewRiskLimitsController(riskSrv, log.NewLogger())
+			router := gin.New()
+			router.Use(middleware.Auth(testSecret))
+			router.GET("/api/v1/risk-limits", controller.GetRiskLimits)
+			router.PUT("/api/v1/risk-limits", controller.SetRiskLimits)
+
+			request := httptest.NewRequest(tt.method, "/api/v1/risk-limits", strings.NewReader(tt.body))
+			if tt.userId != 0 {
+				setToken(t, request, tt.userId)
+			}
+			response := httptest.NewRecorder()
+
+			router.ServeHTTP(response, request)
+
+			assert.Equal(t, tt.expectedCode, response.Code)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/pkg"
+)
+
+const bearerPrefix = "bearer "
+
+func Auth(secret string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if secret == "" {
+			helper.UnauthorizedErrorResponse(c)
+			c.Abort()
+
+			return
+		}
+
+		header := c.GetHeader("Authorization")
+		if len(header) <= len(bearerPrefix) || !strings.EqualFold(header[:len(bearerPrefix)], bearerPrefix) {
+			helper.UnauthorizedErrorResponse(c)
+			c.Abort()
+
+			return
+		}
+
+		claims, err := pkg.ParseToken(header[len(bearerPrefix):], secret)
+		if err != nil || claims.UserID == 0 {
+			helper.UnauthorizedErrorResponse(c)
+			c.Abort()
+
+			return
+		}
+
+		c.Request = c.Request.WithContext(helper.UserToContext(c.Request.Context(), claims.UserID))
+		c.Next()
+	}
+}
@@ -0,0 +1,128 @@
+package tests
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/gin-gonic/gin"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
+	"github.com/linnoxlewis/trade-bot/pkg"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSecret = "secret"
+
+func newToken(t *testing.T, userId int64, secret string) string {
+	token, err := pkg.NewTokenString(userId, time.Minute, secret)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func forgedToken(t *testing.T, userId int64) string {
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &pkg.Jwt{
+		StandardClaims: jwt.StandardClaims{ExpiresAt: time.Now().Add(time.Minute).Unix()},
+		UserID:         userId,
+	}).SignedString([]byte(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return token
+}
+
+func TestAuth(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	tests := []struct {
+		name         string
+		secret       string
+		header       func(t *testing.T) string
+		expectedCode int
+		expectedUser int64
+	}{
+		{
+			name:   "valid token",
+			secret: testSecret,
+			header: func(t *testing.T) string {
+				return "Bearer " + newToken(t, 7, testSecret)
+			},
+			expectedCode: http.StatusOK,
+			expectedUser: 7,
+		},
+		{
+			name:         "missing header",
+			secret:       testSecret,
+			header:       func(t *testing.T) string { return "" },
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "wrong prefix",
+			secret: testSecret,
+			header: func(t *testing.T) string {
+				return "Token " + newToken(t, 7, testSecret)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "token signed with another secret",
+			secret: testSecret,
+			header: func(t *testing.T) string {
+				return "Bearer " + newToken(t, 7, "other")
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "token signed with empty secret",
+			secret: testSecret,
+			header: func(t *testing.T) string {
+				return "Bearer " + forgedToken(t, 7)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "empty secret rejects forged token",
+			secret: "",
+			header: func(t *testing.T) string {
+				return "Bearer " + forgedToken(t, 7)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+		{
+			name:   "empty secret rejects any token",
+			secret: "",
+			header: func(t *testing.T) string {
+				return "Bearer " + newToken(t, 7, testSecret)
+			},
+			expectedCode: http.StatusUnauthorized,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var userId int64
+			router := gin.New()
+			router.Use(middleware.Auth(tt.secret))
+			router.GET("/test", func(c *gin.Context) {
+				userId, _ = helper.UserFromContext(c.Request.Context())
+				c.Status(http.StatusOK)
+			})
+
+			request, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			if header := tt.header(t); header != "" {
+				request.Header.Set("Authorization", header)
+			}
+			recorder := httptest.NewRecorder()
+			router.ServeHTTP(recorder, request)
+
+			assert.Equal(t, tt.expectedCode, recorder.Code)
+			assert.Equal(t, tt.expectedUser, userId)
+		})
+	}
+}
@@ -0,0 +1,12 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+)
+
+func RegisterAccountRoutes(router *gin.RouterGroup, accountCtrl *v1.AccountController) {
+	router.GET("/balance/:exchange", accountCtrl.GetBalance)
+	router.GET("/portfolio", accountCtrl.GetPortfolio)
+	router.GET("/symbols", accountCtrl.ListSymbols)
+}
@@ -12,8 +12,8 @@ type ApiKeyService interface {
 	DeleteApiKey(ctx context.Context, userId int64, exchange string) error
 }
 
-func RegisterApiKeyRoutes(engine *gin.Engine, orderCtrl *v1.ApiKeyController) {
-	group := engine.Group("api/v1/api-key")
+func RegisterApiKeyRoutes(router *gin.RouterGroup, orderCtrl *v1.ApiKeyController) {
+	group := router.Group("/api-key")
 	group.POST("/", orderCtrl.AddApiKey)
-	group.DELETE("/{exchange}}", orderCtrl.RemoveApiKey)
+	group.DELETE("/:exchange", orderCtrl.RemoveApiKey)
 }
@@ -0,0 +1,11 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+)
+
+func RegisterDealRoutes(router *gin.RouterGroup, dealCtrl *v1.DealController) {
+	router.GET("/deals", dealCtrl.ListDeals)
+	router.GET("/deals/:id", dealCtrl.GetDeal)
+}
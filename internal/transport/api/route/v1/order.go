@@ -0,0 +1,15 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+)
+
+func RegisterOrderRoutes(router *gin.RouterGroup, orderCtrl *v1.OrderController) {
+	group := router.Group("/orders")
+	group.POST("", orderCtrl.CreateOrder)
+	group.GET("", orderCtrl.ListOrders)
+	group.GET("/:id", orderCtrl.GetOrder)
+	group.DELETE("/:id", orderCtrl.CancelOrder)
+	group.PUT("/:id/tpsl", orderCtrl.UpdateTpSl)
+}
@@ -0,0 +1,10 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+)
+
+func RegisterPnlRoutes(router *gin.RouterGroup, pnlCtrl *v1.PnlController) {
+	router.GET("/pnl", pnlCtrl.GetPnl)
+}
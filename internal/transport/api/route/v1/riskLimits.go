@@ -0,0 +1,11 @@
+package v1
+
+import (
+	"github.com/gin-gonic/gin"
+	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+)
+
+func RegisterRiskLimitsRoutes(router *gin.RouterGroup, riskCtrl *v1.RiskLimitsController) {
+	router.GET("/risk-limits", riskCtrl.GetRiskLimits)
+	router.PUT("/risk-limits", riskCtrl.SetRiskLimits)
+}
@@ -3,8 +3,11 @@ package server
 import (
 	"context"
 	"github.com/gin-gonic/gin"
+	_ "github.com/linnoxlewis/trade-bot/docs"
 	ctrl "github.com/linnoxlewis/trade-bot/internal/transport/api/controller/v1"
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/middleware"
 	v1 "github.com/linnoxlewis/trade-bot/internal/transport/api/route/v1"
+	"github.com/linnoxlewis/trade-bot/pkg"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
 	swaggerFiles "github.com/swaggo/files"
 	ginSwagger "github.com/swaggo/gin-swagger"
@@ -22,12 +25,30 @@ type ApiServer struct {
 	logger *log.Logger
 }
 
+// @title        Trade bot API
+// @version      1.0
+// @description  REST API of the trade bot: api keys, orders with TP/SL, deals, PnL, risk limits, balances and symbols.
+// @BasePath     /
+// @securityDefinitions.apikey  BearerAuth
+// @in                          header
+// @name                        Authorization
+// @description                 JWT issued by the telegram /token command, as "Bearer <token>"
 func NewApiServer(
 	port string,
 	serverMode string,
+	jwtSecret string,
 	apikeySrv ctrl.ApiKeyService,
+	orderSrv ctrl.OrderService,
+	accountSrv ctrl.AccountService,
+	dealSrv ctrl.DealService,
+	pnlSrv ctrl.PnlService,
+	riskSrv ctrl.RiskService,
 	logger *log.Logger,
-) *ApiServer {
+) (*ApiServer, error) {
+	if jwtSecret == "" {
+		return nil, pkg.ErrEmptySecret
+	}
+
 	engine := gin.Default()
 	gin.SetMode(serverMode)
 	engine.GET("/health", func(c *gin.Context) {
@@ -42,15 +63,27 @@ func NewApiServer(
 	})
 	engine.GET("/swagger/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
 
+	apiV1 := engine.Group("api/v1", middleware.Auth(jwtSecret))
 	apiKeyCtrl := ctrl.NewApiKeyController(apikeySrv, logger)
-	v1.RegisterApiKeyRoutes(engine, apiKeyCtrl)
+	v1.RegisterApiKeyRoutes(apiV1, apiKeyCtrl)
+	orderCtrl := ctrl.NewOrderController(orderSrv, logger)
+	v1.RegisterOrderRoutes(apiV1, orderCtrl)
+	accountCtrl := ctrl.NewAccountController(accountSrv, logger)
+	v1.RegisterAccountRoutes(apiV1, accountCtrl)
+	dealCtrl := ctrl.NewDealController(dealSrv, logger)
+	v1.RegisterDealRoutes(apiV1, dealCtrl)
+	pnlCtrl := ctrl.NewPnlController(pnlSrv, logger)
+	v1.RegisterPnlRoutes(apiV1, pnlCtrl)
+	riskCtrl := ctrl.NewRiskLimitsController(riskSrv, logger)
+	v1.RegisterRiskLimitsRoutes(apiV1, riskCtrl)
+
 	server := &http.Server{
 		Addr:     port,
 		Handler:  engine,
 		ErrorLog: logger.ErrorLog,
 	}
 
-	return &ApiServer{server: server, logger: logger}
+	return &ApiServer{server: server, logger: logger}, nil
 }
 
 func (r *ApiServer) StartServer() {
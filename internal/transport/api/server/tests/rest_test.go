@@ -0,0 +1,24 @@
+package tests
+
+import (
+	"testing"
+
+	"github.com/linnoxlewis/trade-bot/internal/transport/api/server"
+	"github.com/linnoxlewis/trade-bot/pkg"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewApiServer_EmptySecret(t *testing.T) {
+	srv, err := server.NewApiServer(":0", "test", "", nil, nil, nil, nil, nil, nil, log.NewLogger())
+
+	assert.ErrorIs(t, err, pkg.ErrEmptySecret)
+	assert.Nil(t, srv)
+}
+
+func TestNewApiServer(t *testing.T) {
+	srv, err := server.NewApiServer(":0", "test", "secret", nil, nil, nil, nil, nil, nil, log.NewLogger())
+
+	assert.NoError(t, err)
+	assert.NotNil(t, srv)
+}
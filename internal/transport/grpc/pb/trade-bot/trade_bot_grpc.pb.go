@@ -20,91 +20,489 @@ import (
 const _ = grpc.SupportPackageIsVersion7
 
 const (
-	PaySystemService_Ping_FullMethodName = "/TradeBot.PaySystemService/Ping"
+	TradeBotService_Ping_FullMethodName         = "/TradeBot.TradeBotService/Ping"
+	TradeBotService_CreateOrder_FullMethodName  = "/TradeBot.TradeBotService/CreateOrder"
+	TradeBotService_CancelOrder_FullMethodName  = "/TradeBot.TradeBotService/CancelOrder"
+	TradeBotService_UpdateTpSl_FullMethodName   = "/TradeBot.TradeBotService/UpdateTpSl"
+	TradeBotService_GetOrder_FullMethodName     = "/TradeBot.TradeBotService/GetOrder"
+	TradeBotService_ListOrders_FullMethodName   = "/TradeBot.TradeBotService/ListOrders"
+	TradeBotService_WatchOrders_FullMethodName  = "/TradeBot.TradeBotService/WatchOrders"
+	TradeBotService_GetBalance_FullMethodName   = "/TradeBot.TradeBotService/GetBalance"
+	TradeBotService_GetPortfolio_FullMethodName = "/TradeBot.TradeBotService/GetPortfolio"
+	TradeBotService_ListSymbols_FullMethodName  = "/TradeBot.TradeBotService/ListSymbols"
+	TradeBotService_GetPnl_FullMethodName       = "/TradeBot.TradeBotService/GetPnl"
 )
 
-// PaySystemServiceClient is the client API for PaySystemService service.
+// TradeBotServiceClient is the client API for TradeBotService service.
 //
 // For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
-type PaySystemServiceClient interface {
+type TradeBotServiceClient interface {
 	Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	UpdateTpSl(ctx context.Context, in *UpdateTpSlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error)
+	ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error)
+	WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (TradeBotService_WatchOrdersClient, error)
+	GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error)
+	GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*Portfolio, error)
+	ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error)
+	GetPnl(ctx context.Context, in *GetPnlRequest, opts ...grpc.CallOption) (*PnlReport, error)
 }
 
-type paySystemServiceClient struct {
+type tradeBotServiceClient struct {
 	cc grpc.ClientConnInterface
 }
 
-func NewPaySystemServiceClient(cc grpc.ClientConnInterface) PaySystemServiceClient {
-	return &paySystemServiceClient{cc}
+func NewTradeBotServiceClient(cc grpc.ClientConnInterface) TradeBotServiceClient {
+	return &tradeBotServiceClient{cc}
 }
 
-func (c *paySystemServiceClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+func (c *tradeBotServiceClient) Ping(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*emptypb.Empty, error) {
 	out := new(emptypb.Empty)
-	err := c.cc.Invoke(ctx, PaySystemService_Ping_FullMethodName, in, out, opts...)
+	err := c.cc.Invoke(ctx, TradeBotService_Ping_FullMethodName, in, out, opts...)
 	if err != nil {
 		return nil, err
 	}
 	return out, nil
 }
 
-// PaySystemServiceServer is the server API for PaySystemService service.
-// All implementations must embed UnimplementedPaySystemServiceServer
+func (c *tradeBotServiceClient) CreateOrder(ctx context.Context, in *CreateOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, TradeBotService_CreateOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) CancelOrder(ctx context.Context, in *CancelOrderRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TradeBotService_CancelOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) UpdateTpSl(ctx context.Context, in *UpdateTpSlRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, TradeBotService_UpdateTpSl_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) GetOrder(ctx context.Context, in *GetOrderRequest, opts ...grpc.CallOption) (*Order, error) {
+	out := new(Order)
+	err := c.cc.Invoke(ctx, TradeBotService_GetOrder_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) ListOrders(ctx context.Context, in *ListOrdersRequest, opts ...grpc.CallOption) (*ListOrdersResponse, error) {
+	out := new(ListOrdersResponse)
+	err := c.cc.Invoke(ctx, TradeBotService_ListOrders_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) WatchOrders(ctx context.Context, in *WatchOrdersRequest, opts ...grpc.CallOption) (TradeBotService_WatchOrdersClient, error) {
+	stream, err := c.cc.NewStream(ctx, &TradeBotService_ServiceDesc.Streams[0], TradeBotService_WatchOrders_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &tradeBotServiceWatchOrdersClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type TradeBotService_WatchOrdersClient interface {
+	Recv() (*OrderEvent, error)
+	grpc.ClientStream
+}
+
+type tradeBotServiceWatchOrdersClient struct {
+	grpc.ClientStream
+}
+
+func (x *tradeBotServiceWatchOrdersClient) Recv() (*OrderEvent, error) {
+	m := new(OrderEvent)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *tradeBotServiceClient) GetBalance(ctx context.Context, in *GetBalanceRequest, opts ...grpc.CallOption) (*GetBalanceResponse, error) {
+	out := new(GetBalanceResponse)
+	err := c.cc.Invoke(ctx, TradeBotService_GetBalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) GetPortfolio(ctx context.Context, in *GetPortfolioRequest, opts ...grpc.CallOption) (*Portfolio, error) {
+	out := new(Portfolio)
+	err := c.cc.Invoke(ctx, TradeBotService_GetPortfolio_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) ListSymbols(ctx context.Context, in *ListSymbolsRequest, opts ...grpc.CallOption) (*ListSymbolsResponse, error) {
+	out := new(ListSymbolsResponse)
+	err := c.cc.Invoke(ctx, TradeBotService_ListSymbols_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *tradeBotServiceClient) GetPnl(ctx context.Context, in *GetPnlRequest, opts ...grpc.CallOption) (*PnlReport, error) {
+	out := new(PnlReport)
+	err := c.cc.Invoke(ctx, TradeBotService_GetPnl_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// TradeBotServiceServer is the server API for TradeBotService service.
+// All implementations must embed UnimplementedTradeBotServiceServer
 // for forward compatibility
-type PaySystemServiceServer interface {
+type TradeBotServiceServer interface {
 	Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error)
-	mustEmbedUnimplementedPaySystemServiceServer()
+	CreateOrder(context.Context, *CreateOrderRequest) (*Order, error)
+	CancelOrder(context.Context, *CancelOrderRequest) (*emptypb.Empty, error)
+	UpdateTpSl(context.Context, *UpdateTpSlRequest) (*emptypb.Empty, error)
+	GetOrder(context.Context, *GetOrderRequest) (*Order, error)
+	ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error)
+	WatchOrders(*WatchOrdersRequest, TradeBotService_WatchOrdersServer) error
+	GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error)
+	GetPortfolio(context.Context, *GetPortfolioRequest) (*Portfolio, error)
+	ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error)
+	GetPnl(context.Context, *GetPnlRequest) (*PnlReport, error)
+	mustEmbedUnimplementedTradeBotServiceServer()
 }
 
-// UnimplementedPaySystemServiceServer must be embedded to have forward compatible implementations.
-type UnimplementedPaySystemServiceServer struct {
+// UnimplementedTradeBotServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedTradeBotServiceServer struct {
 }
 
-func (UnimplementedPaySystemServiceServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
+func (UnimplementedTradeBotServiceServer) Ping(context.Context, *emptypb.Empty) (*emptypb.Empty, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method Ping not implemented")
 }
-func (UnimplementedPaySystemServiceServer) mustEmbedUnimplementedPaySystemServiceServer() {}
+func (UnimplementedTradeBotServiceServer) CreateOrder(context.Context, *CreateOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateOrder not implemented")
+}
+func (UnimplementedTradeBotServiceServer) CancelOrder(context.Context, *CancelOrderRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelOrder not implemented")
+}
+func (UnimplementedTradeBotServiceServer) UpdateTpSl(context.Context, *UpdateTpSlRequest) (*emptypb.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method UpdateTpSl not implemented")
+}
+func (UnimplementedTradeBotServiceServer) GetOrder(context.Context, *GetOrderRequest) (*Order, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOrder not implemented")
+}
+func (UnimplementedTradeBotServiceServer) ListOrders(context.Context, *ListOrdersRequest) (*ListOrdersResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListOrders not implemented")
+}
+func (UnimplementedTradeBotServiceServer) WatchOrders(*WatchOrdersRequest, TradeBotService_WatchOrdersServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchOrders not implemented")
+}
+func (UnimplementedTradeBotServiceServer) GetBalance(context.Context, *GetBalanceRequest) (*GetBalanceResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBalance not implemented")
+}
+func (UnimplementedTradeBotServiceServer) GetPortfolio(context.Context, *GetPortfolioRequest) (*Portfolio, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPortfolio not implemented")
+}
+func (UnimplementedTradeBotServiceServer) ListSymbols(context.Context, *ListSymbolsRequest) (*ListSymbolsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ListSymbols not implemented")
+}
+func (UnimplementedTradeBotServiceServer) GetPnl(context.Context, *GetPnlRequest) (*PnlReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPnl not implemented")
+}
+func (UnimplementedTradeBotServiceServer) mustEmbedUnimplementedTradeBotServiceServer() {}
 
-// UnsafePaySystemServiceServer may be embedded to opt out of forward compatibility for this service.
-// Use of this interface is not recommended, as added methods to PaySystemServiceServer will
+// UnsafeTradeBotServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to TradeBotServiceServer will
 // result in compilation errors.
-type UnsafePaySystemServiceServer interface {
-	mustEmbedUnimplementedPaySystemServiceServer()
+type UnsafeTradeBotServiceServer interface {
+	mustEmbedUnimplementedTradeBotServiceServer()
 }
 
-func RegisterPaySystemServiceServer(s grpc.ServiceRegistrar, srv PaySystemServiceServer) {
-	s.RegisterService(&PaySystemService_ServiceDesc, srv)
+func RegisterTradeBotServiceServer(s grpc.ServiceRegistrar, srv TradeBotServiceServer) {
+	s.RegisterService(&TradeBotService_ServiceDesc, srv)
 }
 
-func _PaySystemService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _TradeBotService_Ping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(PaySystemServiceServer).Ping(ctx, in)
+		return srv.(TradeBotServiceServer).Ping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_Ping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).Ping(ctx, req.(*emptypb.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_CreateOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).CreateOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_CreateOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).CreateOrder(ctx, req.(*CreateOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_CancelOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).CancelOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_CancelOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).CancelOrder(ctx, req.(*CancelOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_UpdateTpSl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UpdateTpSlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).UpdateTpSl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_UpdateTpSl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).UpdateTpSl(ctx, req.(*UpdateTpSlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_GetOrder_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOrderRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).GetOrder(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_GetOrder_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).GetOrder(ctx, req.(*GetOrderRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_ListOrders_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListOrdersRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).ListOrders(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: PaySystemService_Ping_FullMethodName,
+		FullMethod: TradeBotService_ListOrders_FullMethodName,
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(PaySystemServiceServer).Ping(ctx, req.(*emptypb.Empty))
+		return srv.(TradeBotServiceServer).ListOrders(ctx, req.(*ListOrdersRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-// PaySystemService_ServiceDesc is the grpc.ServiceDesc for PaySystemService service.
+func _TradeBotService_WatchOrders_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchOrdersRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(TradeBotServiceServer).WatchOrders(m, &tradeBotServiceWatchOrdersServer{stream})
+}
+
+type TradeBotService_WatchOrdersServer interface {
+	Send(*OrderEvent) error
+	grpc.ServerStream
+}
+
+type tradeBotServiceWatchOrdersServer struct {
+	grpc.ServerStream
+}
+
+func (x *tradeBotServiceWatchOrdersServer) Send(m *OrderEvent) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _TradeBotService_GetBalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).GetBalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_GetBalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).GetBalance(ctx, req.(*GetBalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_GetPortfolio_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPortfolioRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).GetPortfolio(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_GetPortfolio_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).GetPortfolio(ctx, req.(*GetPortfolioRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_ListSymbols_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListSymbolsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).ListSymbols(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_ListSymbols_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).ListSymbols(ctx, req.(*ListSymbolsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _TradeBotService_GetPnl_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPnlRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(TradeBotServiceServer).GetPnl(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: TradeBotService_GetPnl_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(TradeBotServiceServer).GetPnl(ctx, req.(*GetPnlRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// TradeBotService_ServiceDesc is the grpc.ServiceDesc for TradeBotService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
-var PaySystemService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "TradeBot.PaySystemService",
-	HandlerType: (*PaySystemServiceServer)(nil),
+var TradeBotService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "TradeBot.TradeBotService",
+	HandlerType: (*TradeBotServiceServer)(nil),
 	Methods: []grpc.MethodDesc{
 		{
 			MethodName: "Ping",
-			Handler:    _PaySystemService_Ping_Handler,
+			Handler:    _TradeBotService_Ping_Handler,
+		},
+		{
+			MethodName: "CreateOrder",
+			Handler:    _TradeBotService_CreateOrder_Handler,
+		},
+		{
+			MethodName: "CancelOrder",
+			Handler:    _TradeBotService_CancelOrder_Handler,
+		},
+		{
+			MethodName: "UpdateTpSl",
+			Handler:    _TradeBotService_UpdateTpSl_Handler,
+		},
+		{
+			MethodName: "GetOrder",
+			Handler:    _TradeBotService_GetOrder_Handler,
+		},
+		{
+			MethodName: "ListOrders",
+			Handler:    _TradeBotService_ListOrders_Handler,
+		},
+		{
+			MethodName: "GetBalance",
+			Handler:    _TradeBotService_GetBalance_Handler,
+		},
+		{
+			MethodName: "GetPortfolio",
+			Handler:    _TradeBotService_GetPortfolio_Handler,
+		},
+		{
+			MethodName: "ListSymbols",
+			Handler:    _TradeBotService_ListSymbols_Handler,
+		},
+		{
+			MethodName: "GetPnl",
+			Handler:    _TradeBotService_GetPnl_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchOrders",
+			Handler:       _TradeBotService_WatchOrders_Handler,
+			ServerStreams: true,
 		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "trade_bot.proto",
 }
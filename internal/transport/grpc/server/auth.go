@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/helper"
+	pb "github.com/linnoxlewis/trade-bot/internal/transport/grpc/pb/trade-bot"
+	"github.com/linnoxlewis/trade-bot/pkg"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	authorizationHeader = "authorization"
+	bearerPrefix        = "bearer "
+)
+
+var publicMethods = map[string]bool{
+	pb.TradeBotService_Ping_FullMethodName: true,
+}
+
+func AuthUnaryInterceptor(secret string) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context,
+		req interface{},
+		info *grpc.UnaryServerInfo,
+		handler grpc.UnaryHandler) (interface{}, error) {
+		if publicMethods[info.FullMethod] {
+			return handler(ctx, req)
+		}
+
+		userId, err := authenticate(ctx, secret)
+		if err != nil {
+			return nil, err
+		}
+
+		return handler(helper.UserToContext(ctx, userId), req)
+	}
+}
+
+func AuthStreamInterceptor(secret string) grpc.StreamServerInterceptor {
+	return func(srv interface{},
+		stream grpc.ServerStream,
+		info *grpc.StreamServerInfo,
+		handler grpc.StreamHandler) error {
+		if publicMethods[info.FullMethod] {
+			return handler(srv, stream)
+		}
+
+		userId, err := authenticate(stream.Context(), secret)
+		if err != nil {
+			return err
+		}
+
+		return handler(srv, &authServerStream{
+			ServerStream: stream,
+			ctx:          helper.UserToContext(stream.Context(), userId),
+		})
+	}
+}
+
+type authServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *authServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func authenticate(ctx context.Context, secret string) (int64, error) {
+	if secret == "" {
+		return 0, status.Error(codes.Unauthenticated, consts.ErrUnauthorized)
+	}
+
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, consts.ErrUnauthorized)
+	}
+
+	values := md.Get(authorizationHeader)
+	if len(values) == 0 || len(values[0]) <= len(bearerPrefix) ||
+		!strings.EqualFold(values[0][:len(bearerPrefix)], bearerPrefix) {
+		return 0, status.Error(codes.Unauthenticated, consts.ErrUnauthorized)
+	}
+
+	claims, err := pkg.ParseToken(values[0][len(bearerPrefix):], secret)
+	if err != nil || claims.UserID == 0 {
+		return 0, status.Error(codes.Unauthenticated, consts.ErrUnauthorized)
+	}
+
+	return claims.UserID, nil
+}
+
+func userFromContext(ctx context.Context) (int64, error) {
+	userId, ok := helper.UserFromContext(ctx)
+	if !ok {
+		return 0, status.Error(codes.Unauthenticated, consts.ErrUnauthorized)
+	}
+
+	return userId, nil
+}
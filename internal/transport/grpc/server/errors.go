@@ -0,0 +1,41 @@
+package server
+
+import (
+	stdErrors "errors"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func toStatusError(err error) error {
+	if err == nil {
+		return nil
+	}
+	if _, ok := status.FromError(err); ok {
+		return err
+	}
+
+	var appErr errors.Error
+	if !stdErrors.As(err, &appErr) {
+		return status.Error(codes.Internal, consts.ErrServer)
+	}
+
+	switch {
+	case appErr.IsValidationError(), appErr.IsBadRequestError():
+		return status.Error(codes.InvalidArgument, appErr.Error())
+	case appErr.IsUnauthorizedError():
+		return status.Error(codes.Unauthenticated, appErr.Error())
+	case appErr.IsAccessDeniedError():
+		return status.Error(codes.PermissionDenied, appErr.Error())
+	case appErr.IsNotFoundError():
+		return status.Error(codes.NotFound, appErr.Error())
+	case appErr.IsTooManyRequestError():
+		return status.Error(codes.ResourceExhausted, appErr.Error())
+	case appErr.IsBadGatewayError():
+		return status.Error(codes.Unavailable, appErr.Error())
+	default:
+		return status.Error(codes.Internal, consts.ErrServer)
+	}
+}
@@ -2,6 +2,7 @@ package server
 
 import (
 	pb "github.com/linnoxlewis/trade-bot/internal/transport/grpc/pb/trade-bot"
+	"github.com/linnoxlewis/trade-bot/pkg"
 	"github.com/linnoxlewis/trade-bot/pkg/log"
 	"google.golang.org/grpc"
 	"net"
@@ -18,12 +19,23 @@ type Grpc struct {
 	logger *log.Logger
 }
 
-func NewGrpc(port string, logger log.Logger) *Grpc {
-	srv := grpc.NewServer()
-	server := NewTradeBotServer()
-	pb.RegisterPaySystemServiceServer(srv, server)
+func NewGrpc(port string,
+	jwtSecret string,
+	orderSrv OrderService,
+	accountSrv AccountService,
+	pnlSrv PnlService,
+	events OrderEventSubscriber,
+	logger log.Logger) (*Grpc, error) {
+	if jwtSecret == "" {
+		return nil, pkg.ErrEmptySecret
+	}
+
+	srv := grpc.NewServer(grpc.UnaryInterceptor(AuthUnaryInterceptor(jwtSecret)),
+		grpc.StreamInterceptor(AuthStreamInterceptor(jwtSecret)))
+	server := NewTradeBotServer(orderSrv, accountSrv, pnlSrv, events)
+	pb.RegisterTradeBotServiceServer(srv, server)
 
-	return &Grpc{server: srv, port: port, logger: &logger}
+	return &Grpc{server: srv, port: port, logger: &logger}, nil
 }
 
 func (g *Grpc) StartServer() {
@@ -0,0 +1,254 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: tradeBot.go
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+	domain "github.com/linnoxlewis/trade-bot/internal/domain"
+	dto "github.com/linnoxlewis/trade-bot/internal/domain/dto"
+)
+
+// MockOrderService is a mock of OrderService interface.
+type MockOrderService struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderServiceMockRecorder
+}
+
+// MockOrderServiceMockRecorder is the mock recorder for MockOrderService.
+type MockOrderServiceMockRecorder struct {
+	mock *MockOrderService
+}
+
+// NewMockOrderService creates a new mock instance.
+func NewMockOrderService(ctrl *gomock.Controller) *MockOrderService {
+	mock := &MockOrderService{ctrl: ctrl}
+	mock.recorder = &MockOrderServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderService) EXPECT() *MockOrderServiceMockRecorder {
+	return m.recorder
+}
+
+// CancelOrder mocks base method.
+func (m *MockOrderService) CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CancelOrder", ctx, order, tgUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CancelOrder indicates an expected call of CancelOrder.
+func (mr *MockOrderServiceMockRecorder) CancelOrder(ctx, order, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CancelOrder", reflect.TypeOf((*MockOrderService)(nil).CancelOrder), ctx, order, tgUserId)
+}
+
+// CreateOrder mocks base method.
+func (m *MockOrderService) CreateOrder(ctx context.Context, order *dto.Order, tgUserId int64) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateOrder", ctx, order, tgUserId)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateOrder indicates an expected call of CreateOrder.
+func (mr *MockOrderServiceMockRecorder) CreateOrder(ctx, order, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateOrder", reflect.TypeOf((*MockOrderService)(nil).CreateOrder), ctx, order, tgUserId)
+}
+
+// GetOrder mocks base method.
+func (m *MockOrderService) GetOrder(ctx context.Context, orderId, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetOrder", ctx, orderId, tgUserId, symbol, exchange, inExchange)
+	ret0, _ := ret[0].(*domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetOrder indicates an expected call of GetOrder.
+func (mr *MockOrderServiceMockRecorder) GetOrder(ctx, orderId, tgUserId, symbol, exchange, inExchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetOrder", reflect.TypeOf((*MockOrderService)(nil).GetOrder), ctx, orderId, tgUserId, symbol, exchange, inExchange)
+}
+
+// GetUserActiveOrders mocks base method.
+func (m *MockOrderService) GetUserActiveOrders(ctx context.Context, userId int64, exchange string) ([]domain.Order, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetUserActiveOrders", ctx, userId, exchange)
+	ret0, _ := ret[0].([]domain.Order)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUserActiveOrders indicates an expected call of GetUserActiveOrders.
+func (mr *MockOrderServiceMockRecorder) GetUserActiveOrders(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUserActiveOrders", reflect.TypeOf((*MockOrderService)(nil).GetUserActiveOrders), ctx, userId, exchange)
+}
+
+// UpdateTpslOrder mocks base method.
+func (m *MockOrderService) UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateTpslOrder", ctx, orderDto, tgUserId)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateTpslOrder indicates an expected call of UpdateTpslOrder.
+func (mr *MockOrderServiceMockRecorder) UpdateTpslOrder(ctx, orderDto, tgUserId interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTpslOrder", reflect.TypeOf((*MockOrderService)(nil).UpdateTpslOrder), ctx, orderDto, tgUserId)
+}
+
+// MockAccountService is a mock of AccountService interface.
+type MockAccountService struct {
+	ctrl     *gomock.Controller
+	recorder *MockAccountServiceMockRecorder
+}
+
+// MockAccountServiceMockRecorder is the mock recorder for MockAccountService.
+type MockAccountServiceMockRecorder struct {
+	mock *MockAccountService
+}
+
+// NewMockAccountService creates a new mock instance.
+func NewMockAccountService(ctrl *gomock.Controller) *MockAccountService {
+	mock := &MockAccountService{ctrl: ctrl}
+	mock.recorder = &MockAccountServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockAccountService) EXPECT() *MockAccountServiceMockRecorder {
+	return m.recorder
+}
+
+// GetBalance mocks base method.
+func (m *MockAccountService) GetBalance(ctx context.Context, userId int64, exchange string) (domain.Balance, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetBalance", ctx, userId, exchange)
+	ret0, _ := ret[0].(domain.Balance)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetBalance indicates an expected call of GetBalance.
+func (mr *MockAccountServiceMockRecorder) GetBalance(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetBalance", reflect.TypeOf((*MockAccountService)(nil).GetBalance), ctx, userId, exchange)
+}
+
+// GetPortfolio mocks base method.
+func (m *MockAccountService) GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPortfolio", ctx, userId, exchange)
+	ret0, _ := ret[0].(*domain.Portfolio)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPortfolio indicates an expected call of GetPortfolio.
+func (mr *MockAccountServiceMockRecorder) GetPortfolio(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPortfolio", reflect.TypeOf((*MockAccountService)(nil).GetPortfolio), ctx, userId, exchange)
+}
+
+// GetSymbols mocks base method.
+func (m *MockAccountService) GetSymbols(ctx context.Context, userId int64, exchange string) ([]string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSymbols", ctx, userId, exchange)
+	ret0, _ := ret[0].([]string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSymbols indicates an expected call of GetSymbols.
+func (mr *MockAccountServiceMockRecorder) GetSymbols(ctx, userId, exchange interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSymbols", reflect.TypeOf((*MockAccountService)(nil).GetSymbols), ctx, userId, exchange)
+}
+
+// MockPnlService is a mock of PnlService interface.
+type MockPnlService struct {
+	ctrl     *gomock.Controller
+	recorder *MockPnlServiceMockRecorder
+}
+
+// MockPnlServiceMockRecorder is the mock recorder for MockPnlService.
+type MockPnlServiceMockRecorder struct {
+	mock *MockPnlService
+}
+
+// NewMockPnlService creates a new mock instance.
+func NewMockPnlService(ctrl *gomock.Controller) *MockPnlService {
+	mock := &MockPnlService{ctrl: ctrl}
+	mock.recorder = &MockPnlServiceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPnlService) EXPECT() *MockPnlServiceMockRecorder {
+	return m.recorder
+}
+
+// GetPnl mocks base method.
+func (m *MockPnlService) GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPnl", ctx, userId, period)
+	ret0, _ := ret[0].(*domain.PnlReport)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPnl indicates an expected call of GetPnl.
+func (mr *MockPnlServiceMockRecorder) GetPnl(ctx, userId, period interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPnl", reflect.TypeOf((*MockPnlService)(nil).GetPnl), ctx, userId, period)
+}
+
+// MockOrderEventSubscriber is a mock of OrderEventSubscriber interface.
+type MockOrderEventSubscriber struct {
+	ctrl     *gomock.Controller
+	recorder *MockOrderEventSubscriberMockRecorder
+}
+
+// MockOrderEventSubscriberMockRecorder is the mock recorder for MockOrderEventSubscriber.
+type MockOrderEventSubscriberMockRecorder struct {
+	mock *MockOrderEventSubscriber
+}
+
+// NewMockOrderEventSubscriber creates a new mock instance.
+func NewMockOrderEventSubscriber(ctrl *gomock.Controller) *MockOrderEventSubscriber {
+	mock := &MockOrderEventSubscriber{ctrl: ctrl}
+	mock.recorder = &MockOrderEventSubscriberMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockOrderEventSubscriber) EXPECT() *MockOrderEventSubscriberMockRecorder {
+	return m.recorder
+}
+
+// Subscribe mocks base method.
+func (m *MockOrderEventSubscriber) Subscribe(bufferSize int) (<-chan domain.OrderEvent, func()) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Subscribe", bufferSize)
+	ret0, _ := ret[0].(<-chan domain.OrderEvent)
+	ret1, _ := ret[1].(func())
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe.
+func (mr *MockOrderEventSubscriberMockRecorder) Subscribe(bufferSize interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockOrderEventSubscriber)(nil).Subscribe), bufferSize)
+}
@@ -0,0 +1,331 @@
+package tests
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/golang/mock/gomock"
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/consts"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
+	"github.com/linnoxlewis/trade-bot/internal/pkg/eventbus"
+	pb "github.com/linnoxlewis/trade-bot/internal/transport/grpc/pb/trade-bot"
+	"github.com/linnoxlewis/trade-bot/internal/transport/grpc/server"
+	"github.com/linnoxlewis/trade-bot/internal/transport/grpc/server/tests/mocks"
+	"github.com/linnoxlewis/trade-bot/pkg"
+	"github.com/linnoxlewis/trade-bot/pkg/log"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+const testSecret = "secret"
+
+func newTestClient(t *testing.T,
+	orderSrv server.OrderService,
+	accountSrv server.AccountService,
+	pnlSrv server.PnlService,
+	events server.OrderEventSubscriber) pb.TradeBotServiceClient {
+	return newSecretTestClient(t, testSecret, orderSrv, accountSrv, pnlSrv, events)
+}
+
+func newSecretTestClient(t *testing.T,
+	secret string,
+	orderSrv server.OrderService,
+	accountSrv server.AccountService,
+	pnlSrv server.PnlService,
+	events server.OrderEventSubscriber) pb.TradeBotServiceClient {
+	listener := bufconn.Listen(1024 * 1024)
+	srv := grpc.NewServer(grpc.UnaryInterceptor(server.AuthUnaryInterceptor(secret)),
+		grpc.StreamInterceptor(server.AuthStreamInterceptor(secret)))
+	pb.RegisterTradeBotServiceServer(srv, server.NewTradeBotServer(orderSrv, accountSrv, pnlSrv, events))
+	go srv.Serve(listener)
+	t.Cleanup(srv.Stop)
+
+	conn, err := grpc.DialContext(context.Background(), "bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()))
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+
+	return pb.NewTradeBotServiceClient(conn)
+}
+
+func authContext(t *testing.T, userId int64, secret string) context.Context {
+	token, err := pkg.NewTokenString(userId, time.Minute, secret)
+	require.NoError(t, err)
+
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+func TestTradeBotServer_Auth(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newTestClient(t, mocks.NewMockOrderService(ctrl), mocks.NewMockAccountService(ctrl), nil, nil)
+
+	_, err := client.Ping(context.Background(), &emptypb.Empty{})
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name string
+		ctx  context.Context
+	}{
+		{
+			name: "without token",
+			ctx:  context.Background(),
+		},
+		{
+			name: "wrong secret",
+			ctx:  authContext(t, 7, "other"),
+		},
+		{
+			name: "not bearer",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), "authorization", "Basic abc"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := client.GetBalance(tt.ctx, &pb.GetBalanceRequest{Exchange: consts.Binance})
+
+			assert.Equal(t, codes.Unauthenticated, status.Code(err))
+		})
+	}
+}
+
+func TestNewGrpc_EmptySecret(t *testing.T) {
+	srv, err := server.NewGrpc(":0", "", nil, nil, nil, nil, *log.NewLogger())
+
+	assert.ErrorIs(t, err, pkg.ErrEmptySecret)
+	assert.Nil(t, srv)
+}
+
+func TestTradeBotServer_AuthEmptySecret(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newSecretTestClient(t, "", mocks.NewMockOrderService(ctrl), mocks.NewMockAccountService(ctrl), nil,
+		eventbus.New(log.NewLogger()))
+	forged, err := jwt.NewWithClaims(jwt.SigningMethodHS256, &pkg.Jwt{UserID: 7}).SignedString([]byte(""))
+	require.NoError(t, err)
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+forged)
+
+	_, err = client.GetBalance(ctx, &pb.GetBalanceRequest{Exchange: consts.Binance})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	stream, err := client.WatchOrders(ctx, &pb.WatchOrdersRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	_, err = pkg.NewTokenString(7, time.Minute, "")
+	assert.ErrorIs(t, err, pkg.ErrEmptySecret)
+}
+
+func TestTradeBotServer_CreateOrder(t *testing.T) {
+	validRequest := &pb.CreateOrderRequest{
+		Exchange:  "Binance",
+		Symbol:    "btcusdt",
+		Side:      "buy",
+		OrderType: "market",
+		Quantity:  "0.1",
+		TpPercent: "5",
+		SlPercent: "2",
+	}
+
+	tests := []struct {
+		name         string
+		request      *pb.CreateOrderRequest
+		prepare      func(orderSrv *mocks.MockOrderService)
+		expectedCode codes.Code
+	}{
+		{
+			name:    "success",
+			request: validRequest,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), &dto.Order{
+					Exchange:  consts.Binance,
+					Symbol:    "BTCUSDT",
+					Side:      consts.OrderSideBuy,
+					OrderType: consts.OrderTypeMarket,
+					Quantity:  "0.1",
+					TpPercent: "5",
+					SlPercent: "2",
+				}, int64(7)).Return(&domain.Order{Id: 3, ExecOrderId: 11, Symbol: "BTCUSDT",
+					Status: consts.OrderStatusFilled}, nil)
+			},
+			expectedCode: codes.OK,
+		},
+		{
+			name:         "validation error",
+			request:      &pb.CreateOrderRequest{Exchange: consts.Binance, Symbol: "BTCUSDT"},
+			prepare:      func(orderSrv *mocks.MockOrderService) {},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:    "bad request from service",
+			request: validRequest,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+					Return(nil, errors.BadRequestError("apiKeysNotFound"))
+			},
+			expectedCode: codes.InvalidArgument,
+		},
+		{
+			name:    "internal error from service",
+			request: validRequest,
+			prepare: func(orderSrv *mocks.MockOrderService) {
+				orderSrv.EXPECT().CreateOrder(gomock.Any(), gomock.Any(), int64(7)).
+					Return(nil, errors.InternalServerError(assert.AnError))
+			},
+			expectedCode: codes.Internal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+
+			orderSrv := mocks.NewMockOrderService(ctrl)
+			tt.prepare(orderSrv)
+			client := newTestClient(t, orderSrv, mocks.NewMockAccountService(ctrl), nil, nil)
+
+			order, err := client.CreateOrder(authContext(t, 7, testSecret), tt.request)
+
+			require.Equal(t, tt.expectedCode, status.Code(err))
+			if tt.expectedCode == codes.OK {
+				assert.Equal(t, int64(3), order.GetId())
+				assert.Equal(t, int64(11), order.GetExecOrderId())
+				assert.Equal(t, consts.OrderStatusFilled, order.GetStatus())
+			}
+			if tt.expectedCode == codes.Internal {
+				assert.Equal(t, consts.ErrServer, status.Convert(err).Message())
+			}
+		})
+	}
+}
+
+func TestTradeBotServer_OrdersAndAccount(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	orderSrv := mocks.NewMockOrderService(ctrl)
+	accountSrv := mocks.NewMockAccountService(ctrl)
+	pnlSrv := mocks.NewMockPnlService(ctrl)
+	client := newTestClient(t, orderSrv, accountSrv, pnlSrv, nil)
+	ctx := authContext(t, 7, testSecret)
+
+	orderSrv.EXPECT().CancelOrder(gomock.Any(), dto.NewCancelOrder(3, "BTCUSDT", consts.Binance), int64(7)).Return(nil)
+	_, err := client.CancelOrder(ctx, &pb.CancelOrderRequest{Id: 3, Exchange: consts.Binance, Symbol: "btcusdt"})
+	assert.NoError(t, err)
+
+	orderSrv.EXPECT().UpdateTpslOrder(gomock.Any(), &dto.UpdateTpSl{Id: 3, Symbol: "BTCUSDT",
+		Exchange: consts.Binance, TpPrice: "110", SlPrice: "90"}, int64(7)).Return(nil)
+	_, err = client.UpdateTpSl(ctx, &pb.UpdateTpSlRequest{Id: 3, Exchange: consts.Binance, Symbol: "BTCUSDT",
+		TpPrice: "110", SlPrice: "90"})
+	assert.NoError(t, err)
+
+	orderSrv.EXPECT().GetOrder(gomock.Any(), int64(3), int64(7), "BTCUSDT", consts.Binance, false).
+		Return(nil, errors.NotFoundError("orderNotFound"))
+	_, err = client.GetOrder(ctx, &pb.GetOrderRequest{Id: 3, Exchange: consts.Binance, Symbol: "BTCUSDT"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	orderSrv.EXPECT().GetUserActiveOrders(gomock.Any(), int64(7), consts.Binance).
+		Return([]domain.Order{{Id: 1, Symbol: "BTCUSDT"}, {Id: 2, Symbol: "ETHUSDT"}}, nil)
+	orders, err := client.ListOrders(ctx, &pb.ListOrdersRequest{Exchange: consts.Binance})
+	require.NoError(t, err)
+	require.Len(t, orders.GetOrders(), 2)
+	assert.Equal(t, "ETHUSDT", orders.GetOrders()[1].GetSymbol())
+
+	accountSrv.EXPECT().GetBalance(gomock.Any(), int64(7), consts.Binance).
+		Return(domain.Balance{domain.NewBalanceSymbol("USDT", "100", "")}, nil)
+	balance, err := client.GetBalance(ctx, &pb.GetBalanceRequest{Exchange: consts.Binance})
+	require.NoError(t, err)
+	require.Len(t, balance.GetBalances(), 1)
+	assert.Equal(t, "100", balance.GetBalances()[0].GetQuantity())
+
+	accountSrv.EXPECT().GetPortfolio(gomock.Any(), int64(7), consts.Okx).
+		Return(&domain.Portfolio{Currency: domain.UsdtAsset, Total: "250.00", Exchanges: []string{consts.Okx},
+			Assets: []*domain.PortfolioAsset{{Exchange: consts.Okx, Asset: "BTC", Total: "0.01", Value: "250.00"}}}, nil)
+	portfolio, err := client.GetPortfolio(ctx, &pb.GetPortfolioRequest{Exchange: "OKX"})
+	require.NoError(t, err)
+	assert.Equal(t, "250.00", portfolio.GetTotal())
+	assert.Equal(t, []string{consts.Okx}, portfolio.GetExchanges())
+	require.Len(t, portfolio.GetAssets(), 1)
+	assert.Equal(t, "BTC", portfolio.GetAssets()[0].GetAsset())
+
+	accountSrv.EXPECT().GetSymbols(gomock.Any(), int64(7), consts.Binance).Return([]string{"BTCUSDT"}, nil)
+	symbols, err := client.ListSymbols(ctx, &pb.ListSymbolsRequest{Exchange: consts.Binance})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"BTCUSDT"}, symbols.GetSymbols())
+
+	pnlSrv.EXPECT().GetPnl(gomock.Any(), int64(7), consts.PnlPeriodWeek).
+		Return(&domain.PnlReport{Period: consts.PnlPeriodWeek, Currency: domain.UsdtAsset, TotalPnl: "15.5",
+			Symbols: []*domain.PnlSymbol{{Exchange: consts.Binance, Symbol: "BTCUSDT", TotalPnl: "15.5"}}}, nil)
+	pnl, err := client.GetPnl(ctx, &pb.GetPnlRequest{Period: consts.PnlPeriodWeek})
+	require.NoError(t, err)
+	assert.Equal(t, "15.5", pnl.GetTotalPnl())
+	require.Len(t, pnl.GetSymbols(), 1)
+	assert.Equal(t, "BTCUSDT", pnl.GetSymbols()[0].GetSymbol())
+
+	pnlSrv.EXPECT().GetPnl(gomock.Any(), int64(7), "year").Return(nil, errors.BadRequestError("invalidPnlPeriod"))
+	_, err = client.GetPnl(ctx, &pb.GetPnlRequest{Period: "year"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestTradeBotServer_WatchOrders(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	bus := eventbus.New(log.NewLogger())
+	client := newTestClient(t, mocks.NewMockOrderService(ctrl), mocks.NewMockAccountService(ctrl), nil, bus)
+
+	ctx, cancel := context.WithTimeout(authContext(t, 7, testSecret), 5*time.Second)
+	defer cancel()
+	stream, err := client.WatchOrders(ctx, &pb.WatchOrdersRequest{Exchange: consts.Binance})
+	require.NoError(t, err)
+	require.Eventually(t, func() bool { return bus.Len() == 1 }, time.Second, 10*time.Millisecond)
+
+	bus.Publish(domain.OrderEvent{Type: consts.OrderEventCreated, UserId: 8, OrderId: 1, Exchange: consts.Binance})
+	bus.Publish(domain.OrderEvent{Type: consts.OrderEventCreated, UserId: 7, OrderId: 2, Exchange: consts.Okx})
+	bus.Publish(domain.NewOrderEvent(consts.OrderEventFilled, &domain.Order{Id: 3, UserId: 7,
+		Exchange: consts.Binance, Symbol: "BTCUSDT", Status: consts.OrderStatusFilled}))
+
+	event, err := stream.Recv()
+	require.NoError(t, err)
+	assert.Equal(t, consts.OrderEventFilled, event.GetType())
+	assert.Equal(t, int64(3), event.GetOrder().GetId())
+	assert.Equal(t, consts.OrderStatusFilled, event.GetOrder().GetStatus())
+	assert.NotNil(t, event.GetCreatedAt())
+
+	cancel()
+	require.Eventually(t, func() bool { return bus.Len() == 0 }, time.Second, 10*time.Millisecond)
+}
+
+func TestTradeBotServer_WatchOrdersUnauthenticated(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	client := newTestClient(t, mocks.NewMockOrderService(ctrl), mocks.NewMockAccountService(ctrl), nil,
+		eventbus.New(log.NewLogger()))
+
+	stream, err := client.WatchOrders(context.Background(), &pb.WatchOrdersRequest{})
+	require.NoError(t, err)
+	_, err = stream.Recv()
+
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
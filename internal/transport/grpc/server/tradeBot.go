@@ -2,18 +2,377 @@ package server
 
 import (
 	"context"
+	"strings"
+
+	"github.com/linnoxlewis/trade-bot/internal/domain"
+	"github.com/linnoxlewis/trade-bot/internal/domain/dto"
+	"github.com/linnoxlewis/trade-bot/internal/errors"
 	pb "github.com/linnoxlewis/trade-bot/internal/transport/grpc/pb/trade-bot"
 	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+const watchOrdersBufferSize = 100
+
+type OrderService interface {
+	CreateOrder(ctx context.Context, order *dto.Order, tgUserId int64) (*domain.Order, error)
+	CancelOrder(ctx context.Context, order *dto.CancelOrder, tgUserId int64) error
+	UpdateTpslOrder(ctx context.Context, orderDto *dto.UpdateTpSl, tgUserId int64) error
+	GetUserActiveOrders(ctx context.Context, userId int64, exchange string) ([]domain.Order, error)
+	GetOrder(ctx context.Context, orderId int64, tgUserId int64, symbol, exchange string, inExchange bool) (*domain.Order, error)
+}
+
+type AccountService interface {
+	GetBalance(ctx context.Context, userId int64, exchange string) (domain.Balance, error)
+	GetPortfolio(ctx context.Context, userId int64, exchange string) (*domain.Portfolio, error)
+	GetSymbols(ctx context.Context, userId int64, exchange string) ([]string, error)
+}
+
+type PnlService interface {
+	GetPnl(ctx context.Context, userId int64, period string) (*domain.PnlReport, error)
+}
+
+type OrderEventSubscriber interface {
+	Subscribe(bufferSize int) (<-chan domain.OrderEvent, func())
+}
+
 type TradeBotServer struct {
-	pb.UnimplementedPaySystemServiceServer
+	pb.UnimplementedTradeBotServiceServer
+	orderSrv   OrderService
+	accountSrv AccountService
+	pnlSrv     PnlService
+	events     OrderEventSubscriber
+}
+
+func NewTradeBotServer(orderSrv OrderService,
+	accountSrv AccountService,
+	pnlSrv PnlService,
+	events OrderEventSubscriber) *TradeBotServer {
+	return &TradeBotServer{
+		orderSrv:   orderSrv,
+		accountSrv: accountSrv,
+		pnlSrv:     pnlSrv,
+		events:     events,
+	}
+}
+
+func (t *TradeBotServer) Ping(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+	return &emptypb.Empty{}, nil
+}
+
+func (t *TradeBotServer) CreateOrder(ctx context.Context, rqt *pb.CreateOrderRequest) (*pb.Order, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orderDto := &dto.Order{
+		Exchange:    strings.ToLower(rqt.GetExchange()),
+		Symbol:      strings.TrimSpace(strings.ToUpper(rqt.GetSymbol())),
+		OrderType:   strings.ToUpper(rqt.GetOrderType()),
+		Side:        strings.ToUpper(rqt.GetSide()),
+		Quantity:    rqt.GetQuantity(),
+		RiskPercent: rqt.GetRiskPercent(),
+		Price:       rqt.GetPrice(),
+		TimeInForce: strings.ToUpper(rqt.GetTimeInForce()),
+		StopPercent: rqt.GetStopPercent(),
+		StopPrice:   rqt.GetStopPrice(),
+		TpPercent:   rqt.GetTpPercent(),
+		SlPercent:   rqt.GetSlPercent(),
+		TpPrice:     rqt.GetTpPrice(),
+		SlPrice:     rqt.GetSlPrice(),
+		TpType:      strings.ToUpper(rqt.GetTpType()),
+		SlType:      strings.ToUpper(rqt.GetSlType()),
+		Ts:          rqt.GetTs(),
+		Protection:  strings.ToLower(rqt.GetProtection()),
+		SlBreakeven: rqt.GetSlBreakeven(),
+	}
+	for _, v := range rqt.GetTpLevels() {
+		orderDto.TpLevels = append(orderDto.TpLevels, dto.TpLevel{
+			Percent: v.GetPercent(),
+			Price:   v.GetPrice(),
+			Share:   v.GetShare(),
+		})
+	}
+	if err := orderDto.Validate(); err != nil {
+		return nil, toStatusError(errors.ValidationError(err))
+	}
+
+	order, err := t.orderSrv.CreateOrder(ctx, orderDto, userId)
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toPbOrder(order), nil
 }
 
-func NewTradeBotServer() *TradeBotServer {
-	return &TradeBotServer{}
+func (t *TradeBotServer) CancelOrder(ctx context.Context, rqt *pb.CancelOrderRequest) (*emptypb.Empty, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	cancelDto := dto.NewCancelOrder(rqt.GetId(), rqt.GetSymbol(), strings.ToLower(rqt.GetExchange()))
+	if err := cancelDto.Validate(); err != nil {
+		return nil, toStatusError(errors.ValidationError(err))
+	}
+
+	if err := t.orderSrv.CancelOrder(ctx, cancelDto, userId); err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &emptypb.Empty{}, nil
 }
 
-func (t TradeBotServer) Ping(ctx context.Context, _ *emptypb.Empty) (*emptypb.Empty, error) {
+func (t *TradeBotServer) UpdateTpSl(ctx context.Context, rqt *pb.UpdateTpSlRequest) (*emptypb.Empty, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	updateDto := &dto.UpdateTpSl{
+		Id:        rqt.GetId(),
+		Symbol:    strings.TrimSpace(strings.ToUpper(rqt.GetSymbol())),
+		Exchange:  strings.ToLower(rqt.GetExchange()),
+		TpPercent: rqt.GetTpPercent(),
+		SlPercent: rqt.GetSlPercent(),
+		TpPrice:   rqt.GetTpPrice(),
+		SlPrice:   rqt.GetSlPrice(),
+	}
+	if err := updateDto.Validate(); err != nil {
+		return nil, toStatusError(errors.ValidationError(err))
+	}
+
+	if err := t.orderSrv.UpdateTpslOrder(ctx, updateDto, userId); err != nil {
+		return nil, toStatusError(err)
+	}
+
 	return &emptypb.Empty{}, nil
 }
+
+func (t *TradeBotServer) GetOrder(ctx context.Context, rqt *pb.GetOrderRequest) (*pb.Order, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := t.orderSrv.GetOrder(ctx,
+		rqt.GetId(),
+		userId,
+		strings.TrimSpace(strings.ToUpper(rqt.GetSymbol())),
+		strings.ToLower(rqt.GetExchange()),
+		rqt.GetInExchange())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return toPbOrder(order), nil
+}
+
+func (t *TradeBotServer) ListOrders(ctx context.Context, rqt *pb.ListOrdersRequest) (*pb.ListOrdersResponse, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	orders, err := t.orderSrv.GetUserActiveOrders(ctx, userId, strings.ToLower(rqt.GetExchange()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := &pb.ListOrdersResponse{Orders: make([]*pb.Order, 0, len(orders))}
+	for i := range orders {
+		result.Orders = append(result.Orders, toPbOrder(&orders[i]))
+	}
+
+	return result, nil
+}
+
+func (t *TradeBotServer) WatchOrders(rqt *pb.WatchOrdersRequest, stream pb.TradeBotService_WatchOrdersServer) error {
+	ctx := stream.Context()
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	exchange := strings.ToLower(rqt.GetExchange())
+
+	events, unsubscribe := t.events.Subscribe(watchOrdersBufferSize)
+	defer unsubscribe()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case event, ok := <-events:
+			if !ok {
+				return nil
+			}
+			if event.UserId != userId || (exchange != "" && event.Exchange != exchange) {
+				continue
+			}
+			if err := stream.Send(toPbOrderEvent(event)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (t *TradeBotServer) GetBalance(ctx context.Context, rqt *pb.GetBalanceRequest) (*pb.GetBalanceResponse, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := t.accountSrv.GetBalance(ctx, userId, strings.ToLower(rqt.GetExchange()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := &pb.GetBalanceResponse{Balances: make([]*pb.Balance, 0, len(balance))}
+	for _, v := range balance {
+		result.Balances = append(result.Balances, &pb.Balance{Symbol: v.Symbol, Quantity: v.Quantity, Locked: v.Locked})
+	}
+
+	return result, nil
+}
+
+func (t *TradeBotServer) GetPortfolio(ctx context.Context, rqt *pb.GetPortfolioRequest) (*pb.Portfolio, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	portfolio, err := t.accountSrv.GetPortfolio(ctx, userId, strings.ToLower(rqt.GetExchange()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := &pb.Portfolio{
+		Currency:  portfolio.Currency,
+		Total:     portfolio.Total,
+		Exchanges: portfolio.Exchanges,
+		Assets:    make([]*pb.PortfolioAsset, 0, len(portfolio.Assets)),
+	}
+	for _, v := range portfolio.Assets {
+		result.Assets = append(result.Assets, &pb.PortfolioAsset{
+			Exchange: v.Exchange,
+			Asset:    v.Asset,
+			Free:     v.Free,
+			Locked:   v.Locked,
+			Total:    v.Total,
+			Price:    v.Price,
+			Value:    v.Value,
+			Share:    v.Share,
+		})
+	}
+
+	return result, nil
+}
+
+func (t *TradeBotServer) ListSymbols(ctx context.Context, rqt *pb.ListSymbolsRequest) (*pb.ListSymbolsResponse, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	symbols, err := t.accountSrv.GetSymbols(ctx, userId, strings.ToLower(rqt.GetExchange()))
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	return &pb.ListSymbolsResponse{Symbols: symbols}, nil
+}
+
+func toPbOrder(order *domain.Order) *pb.Order {
+	return &pb.Order{
+		Id:          order.Id,
+		ExecOrderId: order.ExecOrderId,
+		Exchange:    order.Exchange,
+		Symbol:      order.Symbol,
+		Side:        order.Side,
+		OrderType:   order.OrderType,
+		Quantity:    order.Quantity,
+		Price:       order.Price,
+		StopPrice:   order.StopPrice,
+		TimeInForce: order.TimeInForce,
+		Status:      order.Status,
+		TpSl:        order.TpSl,
+		Ts:          order.Ts,
+		TsPrice:     order.TsPrice,
+		Protection:  order.Protection,
+		OcoListId:   order.OcoListId,
+		ExecutedQty: order.ExecutedQty,
+		AvgPrice:    order.AvgPrice,
+	}
+}
+
+func toPbOrderEvent(event domain.OrderEvent) *pb.OrderEvent {
+	return &pb.OrderEvent{
+		Type: event.Type,
+		Order: &pb.Order{
+			Id:          event.OrderId,
+			ExecOrderId: event.ExecOrderId,
+			Exchange:    event.Exchange,
+			Symbol:      event.Symbol,
+			Side:        event.Side,
+			OrderType:   event.OrderType,
+			Quantity:    event.Quantity,
+			Price:       event.Price,
+			Status:      event.Status,
+			TpSl:        event.TpSl,
+			Ts:          event.Ts,
+			TsPrice:     event.TsPrice,
+			ExecutedQty: event.ExecutedQty,
+			AvgPrice:    event.AvgPrice,
+		},
+		Error:     event.Error,
+		CreatedAt: timestamppb.New(event.CreatedAt),
+	}
+}
+
+func (t *TradeBotServer) GetPnl(ctx context.Context, rqt *pb.GetPnlRequest) (*pb.PnlReport, error) {
+	userId, err := userFromContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	report, err := t.pnlSrv.GetPnl(ctx, userId, rqt.GetPeriod())
+	if err != nil {
+		return nil, toStatusError(err)
+	}
+
+	result := &pb.PnlReport{
+		Period:        report.Period,
+		From:          timestamppb.New(report.From),
+		To:            timestamppb.New(report.To),
+		Currency:      report.Currency,
+		RealizedPnl:   report.RealizedPnl,
+		UnrealizedPnl: report.UnrealizedPnl,
+		Fees:          report.Fees,
+		TotalPnl:      report.TotalPnl,
+		ClosedDeals:   int64(report.ClosedDeals),
+		OpenDeals:     int64(report.OpenDeals),
+		Wins:          int64(report.Wins),
+		WinRate:       report.WinRate,
+		AvgR:          report.AvgR,
+		Symbols:       make([]*pb.PnlSymbol, 0, len(report.Symbols)),
+	}
+	for _, v := range report.Symbols {
+		result.Symbols = append(result.Symbols, &pb.PnlSymbol{
+			Exchange:      v.Exchange,
+			Symbol:        v.Symbol,
+			Currency:      v.Currency,
+			RealizedPnl:   v.RealizedPnl,
+			UnrealizedPnl: v.UnrealizedPnl,
+			Fees:          v.Fees,
+			TotalPnl:      v.TotalPnl,
+			ClosedDeals:   int64(v.ClosedDeals),
+			OpenDeals:     int64(v.OpenDeals),
+			Wins:          int64(v.Wins),
+			WinRate:       v.WinRate,
+			AvgR:          v.AvgR,
+		})
+	}
+
+	return result, nil
+}
@@ -3,10 +3,11 @@ package pkg
 import (
 	"errors"
 	"github.com/dgrijalva/jwt-go"
-	"github.com/google/uuid"
 	"time"
 )
 
+var ErrEmptySecret = errors.New("err jwt secret is empty")
+
 var (
 	errNullClaims   = errors.New("err token or claims is null")
 	errParseToken   = errors.New("err can't decode token")
@@ -14,11 +15,11 @@ var (
 )
 
 type Jwt struct {
-	UserID uuid.UUID `json:"uid"`
+	UserID int64 `json:"uid"`
 	jwt.StandardClaims
 }
 
-func NewJwt(userID uuid.UUID, ttl time.Duration) (*Jwt, error) {
+func NewJwt(userID int64, ttl time.Duration) (*Jwt, error) {
 	now := time.Now()
 	return &Jwt{
 		UserID: userID,
@@ -28,7 +29,10 @@ func NewJwt(userID uuid.UUID, ttl time.Duration) (*Jwt, error) {
 		}}, nil
 }
 
-func NewTokenString(userID uuid.UUID, ttl time.Duration, secret string) (string, error) {
+func NewTokenString(userID int64, ttl time.Duration, secret string) (string, error) {
+	if secret == "" {
+		return "", ErrEmptySecret
+	}
 	auth, err := NewJwt(userID, ttl)
 	if err != nil {
 		return "", err
@@ -42,7 +46,11 @@ func NewTokenString(userID uuid.UUID, ttl time.Duration, secret string) (string,
 	return tokenString, nil
 }
 
+// ParseToken refuses every token without a secret, anyone can sign a token with an empty key.
 func ParseToken(token, secret string) (*Jwt, error) {
+	if secret == "" {
+		return nil, ErrEmptySecret
+	}
 	jwtToken, err := jwt.ParseWithClaims(token, &Jwt{}, func(token *jwt.Token) (interface{}, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, errParseToken
@@ -9,6 +9,7 @@ import (
 	"net/url"
 	"path"
 	"strconv"
+	"time"
 )
 
 type Client struct {
@@ -141,7 +142,8 @@ func (c *Client) formatResponse(data []byte) ([]Update, error) {
 				Text:      v.Message.Data,
 				From:      v.Message.From,
 				MessageID: v.Message.Message.MessageID,
-				Date:      v.Message.Message.Date,
+				// a callback query has no date of its own, the message date is the time the keyboard was sent
+				Date: int(time.Now().Unix()),
 			}}
 			upds = append(upds, upd)
 		}
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ts VARCHAR(255) DEFAULT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS ts_price VARCHAR(255) DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS ts_price;
+ALTER TABLE orders DROP COLUMN IF EXISTS ts;
@@ -0,0 +1,38 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS paper_balances
+(
+    user_id         BIGINT NOT NULL,
+    asset           VARCHAR(255) NOT NULL,
+    free            NUMERIC NOT NULL DEFAULT 0,
+    locked          NUMERIC NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (user_id, asset)
+    );
+
+CREATE TABLE IF NOT EXISTS paper_orders
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    symbol          VARCHAR(255) NOT NULL,
+    side            VARCHAR(255) NOT NULL,
+    order_type      VARCHAR(255) NOT NULL,
+    status          VARCHAR(255) NOT NULL,
+    quantity        NUMERIC NOT NULL,
+    price           NUMERIC NOT NULL,
+    stop_price      NUMERIC DEFAULT NULL,
+    time_in_force   VARCHAR(255),
+    reserve_asset   VARCHAR(255) NOT NULL,
+    reserve_amount  NUMERIC NOT NULL,
+    receive_asset   VARCHAR(255) NOT NULL,
+    receive_amount  NUMERIC NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE
+    );
+
+CREATE INDEX IF NOT EXISTS "paper_orders_user_status_index" ON "paper_orders"("user_id", "status");
+
+-- +goose Down
+DROP INDEX IF EXISTS "paper_orders_user_status_index";
+DROP TABLE IF EXISTS paper_orders;
+DROP TABLE IF EXISTS paper_balances;
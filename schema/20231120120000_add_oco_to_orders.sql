@@ -0,0 +1,12 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS protection VARCHAR(255) NOT NULL DEFAULT 'virtual';
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS oco_list_id BIGINT DEFAULT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS oco_order_id BIGINT DEFAULT NULL;
+
+CREATE INDEX IF NOT EXISTS "oco_list_id_index" ON "orders"("oco_list_id");
+
+-- +goose Down
+DROP INDEX IF EXISTS "oco_list_id_index";
+ALTER TABLE orders DROP COLUMN IF EXISTS oco_order_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS oco_list_id;
+ALTER TABLE orders DROP COLUMN IF EXISTS protection;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS executed_qty VARCHAR(255) DEFAULT NULL;
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS avg_price VARCHAR(255) DEFAULT NULL;
+
+-- +goose Down
+ALTER TABLE orders DROP COLUMN IF EXISTS avg_price;
+ALTER TABLE orders DROP COLUMN IF EXISTS executed_qty;
@@ -0,0 +1,7 @@
+-- +goose Up
+ALTER TABLE order_settings ADD COLUMN IF NOT EXISTS tp_levels TEXT DEFAULT NULL;
+ALTER TABLE order_settings ADD COLUMN IF NOT EXISTS sl_breakeven BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- +goose Down
+ALTER TABLE order_settings DROP COLUMN IF EXISTS sl_breakeven;
+ALTER TABLE order_settings DROP COLUMN IF EXISTS tp_levels;
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS user_risk_limits
+(
+    user_id             BIGINT NOT NULL PRIMARY KEY,
+    max_order_notional  NUMERIC DEFAULT NULL,
+    max_open_deals      INTEGER NOT NULL DEFAULT 0,
+    max_daily_loss      NUMERIC DEFAULT NULL,
+    allowed_symbols     TEXT NOT NULL DEFAULT '',
+    paused_until        TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at          TIMESTAMP WITH TIME ZONE,
+    updated_at          TIMESTAMP WITH TIME ZONE
+    );
+
+-- +goose Down
+DROP TABLE IF EXISTS user_risk_limits;
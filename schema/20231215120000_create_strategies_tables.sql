@@ -0,0 +1,37 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS strategies
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    type            VARCHAR(255) NOT NULL,
+    exchange        VARCHAR(255) NOT NULL,
+    symbol          VARCHAR(255) NOT NULL,
+    status          VARCHAR(255) NOT NULL,
+    params          TEXT NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE
+    );
+
+CREATE TABLE IF NOT EXISTS strategy_orders
+(
+    strategy_id     BIGINT NOT NULL REFERENCES strategies (id) ON DELETE CASCADE,
+    order_id        BIGINT NOT NULL,
+    role            VARCHAR(255) NOT NULL,
+    level           INTEGER NOT NULL DEFAULT 0,
+    side            VARCHAR(255) NOT NULL,
+    price           NUMERIC NOT NULL,
+    quantity        NUMERIC NOT NULL,
+    status          VARCHAR(255) NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE,
+    PRIMARY KEY (strategy_id, order_id)
+    );
+
+CREATE INDEX IF NOT EXISTS "strategies_user_status_index" ON "strategies"("user_id", "status");
+CREATE INDEX IF NOT EXISTS "strategy_orders_order_index" ON "strategy_orders"("order_id");
+
+-- +goose Down
+DROP INDEX IF EXISTS "strategy_orders_order_index";
+DROP INDEX IF EXISTS "strategies_user_status_index";
+DROP TABLE IF EXISTS strategy_orders;
+DROP TABLE IF EXISTS strategies;
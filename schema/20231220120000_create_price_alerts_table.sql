@@ -0,0 +1,25 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS price_alerts
+(
+    id              BIGSERIAL PRIMARY KEY,
+    user_id         BIGINT NOT NULL,
+    exchange        VARCHAR(255) NOT NULL,
+    symbol          VARCHAR(255) NOT NULL,
+    condition       VARCHAR(255) NOT NULL,
+    value           NUMERIC NOT NULL,
+    base_price      NUMERIC DEFAULT NULL,
+    recurring       BOOLEAN NOT NULL DEFAULT FALSE,
+    armed           BOOLEAN NOT NULL DEFAULT TRUE,
+    status          VARCHAR(255) NOT NULL,
+    triggered_at    TIMESTAMP WITH TIME ZONE DEFAULT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE,
+    updated_at      TIMESTAMP WITH TIME ZONE
+    );
+
+CREATE INDEX IF NOT EXISTS "price_alerts_status_index" ON "price_alerts"("status");
+CREATE INDEX IF NOT EXISTS "price_alerts_user_status_index" ON "price_alerts"("user_id", "status");
+
+-- +goose Down
+DROP INDEX IF EXISTS "price_alerts_user_status_index";
+DROP INDEX IF EXISTS "price_alerts_status_index";
+DROP TABLE IF EXISTS price_alerts;
@@ -1,3 +1,3 @@
 protoc -I ../internal/transport/grpc/proto/ \
- trade.proto \
+ trade_bot.proto \
  --go-grpc_out=../internal/transport --go_out=../internal/transport
\ No newline at end of file
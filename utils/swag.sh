@@ -0,0 +1 @@
+cd .. && swag init -g internal/transport/api/server/rest.go -o docs --parseDependency